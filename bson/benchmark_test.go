@@ -132,3 +132,27 @@ func BenchmarkEncodingNested(b *testing.B) {
 		_, _ = Marshal(nestedInstance)
 	}
 }
+
+func BenchmarkDecoding(b *testing.B) {
+	data, err := Marshal(encodetestInstance)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var out encodetest
+		_ = Unmarshal(data, &out)
+	}
+}
+
+func BenchmarkDecodingNested(b *testing.B) {
+	data, err := Marshal(nestedInstance)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var out nestedtest1
+		_ = Unmarshal(data, &out)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/bsonoptions"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Decimal128Codec is the Codec used to store a decimal type that is not defined by this package, such as one from a
+// third-party arbitrary precision library, as a BSON decimal128. Values are converted to and from a
+// primitive.Decimal128 by the functions given to NewDecimal128Codec.
+//
+// Register the codec for the decimal type with RegistryBuilder.RegisterCodec. To also decode BSON decimal128 values
+// into the decimal type when the destination is an empty interface, such as the values of a primitive.M, register the
+// type with RegistryBuilder.RegisterTypeMapEntry for bsontype.Decimal128.
+type Decimal128Codec struct {
+	DecodeNumbers bool
+
+	t    reflect.Type
+	to   func(interface{}) (primitive.Decimal128, error)
+	from func(primitive.Decimal128) (interface{}, error)
+}
+
+var _ ValueCodec = &Decimal128Codec{}
+
+// NewDecimal128Codec returns a Decimal128Codec for the decimal type t with options opts. The to function converts a
+// value of type t into a primitive.Decimal128 and the from function converts a primitive.Decimal128 into a value
+// that is assignable to t.
+func NewDecimal128Codec(t reflect.Type, to func(interface{}) (primitive.Decimal128, error),
+	from func(primitive.Decimal128) (interface{}, error), opts ...*bsonoptions.Decimal128CodecOptions) *Decimal128Codec {
+
+	decimalOpt := bsonoptions.MergeDecimal128CodecOptions(opts...)
+
+	codec := Decimal128Codec{t: t, to: to, from: from}
+	if decimalOpt.DecodeNumbers != nil {
+		codec.DecodeNumbers = *decimalOpt.DecodeNumbers
+	}
+	return &codec
+}
+
+// EncodeValue is the ValueEncoder for the decimal type.
+func (dc *Decimal128Codec) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != dc.t {
+		return ValueEncoderError{Name: "Decimal128CodecEncodeValue", Types: []reflect.Type{dc.t}, Received: val}
+	}
+
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		if val.IsNil() {
+			return vw.WriteNull()
+		}
+	}
+
+	d128, err := dc.to(val.Interface())
+	if err != nil {
+		return err
+	}
+	return vw.WriteDecimal128(d128)
+}
+
+// DecodeValue is the ValueDecoder for the decimal type.
+func (dc *Decimal128Codec) DecodeValue(dctx DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != dc.t {
+		return ValueDecoderError{Name: "Decimal128CodecDecodeValue", Types: []reflect.Type{dc.t}, Received: val}
+	}
+
+	var d128 primitive.Decimal128
+	var err error
+	switch vrType := vr.Type(); {
+	case vrType == bsontype.Decimal128:
+		d128, err = vr.ReadDecimal128()
+	case vrType == bsontype.Null:
+		val.Set(reflect.Zero(val.Type()))
+		return vr.ReadNull()
+	case vrType == bsontype.Int32 && dc.DecodeNumbers:
+		var i32 int32
+		i32, err = vr.ReadInt32()
+		d128 = primitive.NewDecimal128FromInt64(int64(i32))
+	case vrType == bsontype.Int64 && dc.DecodeNumbers:
+		var i64 int64
+		i64, err = vr.ReadInt64()
+		d128 = primitive.NewDecimal128FromInt64(i64)
+	case vrType == bsontype.Double && dc.DecodeNumbers:
+		var f64 float64
+		f64, err = vr.ReadDouble()
+		d128 = primitive.NewDecimal128FromFloat64(f64)
+	default:
+		return fmt.Errorf("cannot decode %v into a %s", vrType, dc.t)
+	}
+	if err != nil {
+		return err
+	}
+
+	decoded, err := dc.from(d128)
+	if err != nil {
+		return err
+	}
+	rv := reflect.ValueOf(decoded)
+	if !rv.IsValid() || !rv.Type().AssignableTo(dc.t) {
+		return fmt.Errorf("cannot decode %v into a %s: conversion returned %T", d128, dc.t, decoded)
+	}
+	val.Set(rv)
+	return nil
+}
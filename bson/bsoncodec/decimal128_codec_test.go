@@ -0,0 +1,142 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsonoptions"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+var tRat = reflect.TypeOf((*big.Rat)(nil))
+
+type testInvoice struct {
+	Total    *big.Rat
+	Discount *big.Rat
+	Extra    interface{}
+}
+
+func newRatCodec(opts ...*bsonoptions.Decimal128CodecOptions) *Decimal128Codec {
+	return NewDecimal128Codec(tRat,
+		func(v interface{}) (primitive.Decimal128, error) {
+			return primitive.NewDecimal128FromBigRat(v.(*big.Rat)), nil
+		},
+		func(d primitive.Decimal128) (interface{}, error) {
+			return d.BigRat()
+		},
+		opts...,
+	)
+}
+
+func TestDecimal128Codec(t *testing.T) {
+	newRegistry := func(codec *Decimal128Codec) *Registry {
+		rb := NewRegistryBuilder()
+		defaultValueEncoders.RegisterDefaultEncoders(rb)
+		defaultValueDecoders.RegisterDefaultDecoders(rb)
+		rb.RegisterCodec(tRat, codec).RegisterTypeMapEntry(bsontype.Decimal128, tRat)
+		return rb.Build()
+	}
+	decode := func(t *testing.T, reg *Registry, b []byte, v interface{}) error {
+		t.Helper()
+
+		val := reflect.ValueOf(v).Elem()
+		dec, err := reg.LookupDecoder(val.Type())
+		assert.Nil(t, err, "LookupDecoder error: %v", err)
+		return dec.DecodeValue(DecodeContext{Registry: reg}, bsonrw.NewBSONDocumentReader(b), val)
+	}
+	d128 := func(s string) primitive.Decimal128 {
+		d, err := primitive.ParseDecimal128(s)
+		assert.Nil(t, err, "ParseDecimal128 error: %v", err)
+		return d
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		reg := newRegistry(newRatCodec())
+		in := testInvoice{Total: big.NewRat(1999, 100)}
+
+		var sw bsonrw.SliceWriter
+		vw, err := bsonrw.NewBSONValueWriter(&sw)
+		assert.Nil(t, err, "NewBSONValueWriter error: %v", err)
+		enc, err := reg.LookupEncoder(reflect.TypeOf(in))
+		assert.Nil(t, err, "LookupEncoder error: %v", err)
+		err = enc.EncodeValue(EncodeContext{Registry: reg}, vw, reflect.ValueOf(in))
+		assert.Nil(t, err, "EncodeValue error: %v", err)
+
+		want := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendDecimal128Element(nil, "total", d128("19.99")),
+			bsoncore.AppendNullElement(nil, "discount"),
+			bsoncore.AppendNullElement(nil, "extra"),
+		)
+		assert.Equal(t, bsoncore.Document(want), bsoncore.Document(sw), "expected document %v, got %v",
+			bsoncore.Document(want), bsoncore.Document(sw))
+
+		var out testInvoice
+		err = decode(t, reg, sw, &out)
+		assert.Nil(t, err, "DecodeValue error: %v", err)
+		assert.Equal(t, "1999/100", out.Total.String(), "expected total 1999/100, got %v", out.Total)
+		assert.Nil(t, out.Discount, "expected nil discount, got %v", out.Discount)
+	})
+	t.Run("empty interface", func(t *testing.T) {
+		reg := newRegistry(newRatCodec())
+		doc := bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendDecimal128Element(nil, "extra", d128("-0.5")))
+
+		var out testInvoice
+		err := decode(t, reg, doc, &out)
+		assert.Nil(t, err, "DecodeValue error: %v", err)
+		r, ok := out.Extra.(*big.Rat)
+		assert.True(t, ok, "expected Extra to be a *big.Rat, got %T", out.Extra)
+		assert.Equal(t, "-1/2", r.String(), "expected -1/2, got %v", r)
+	})
+	t.Run("numbers", func(t *testing.T) {
+		decodeValue := func(codec *Decimal128Codec, vr bsonrw.ValueReader) (*big.Rat, error) {
+			var r *big.Rat
+			err := codec.DecodeValue(DecodeContext{}, vr, reflect.ValueOf(&r).Elem())
+			return r, err
+		}
+		i64 := bsonrw.NewBSONValueReader(bsontype.Int64, bsoncore.AppendInt64(nil, 42))
+		_, err := decodeValue(newRatCodec(), i64)
+		assert.NotNil(t, err, "expected an error decoding an int64 without DecodeNumbers")
+
+		codec := newRatCodec(bsonoptions.Decimal128Codec().SetDecodeNumbers(true))
+		r, err := decodeValue(codec, bsonrw.NewBSONValueReader(bsontype.Int64, bsoncore.AppendInt64(nil, 42)))
+		assert.Nil(t, err, "DecodeValue error: %v", err)
+		assert.Equal(t, "42/1", r.String(), "expected 42/1, got %v", r)
+		r, err = decodeValue(codec, bsonrw.NewBSONValueReader(bsontype.Int32, bsoncore.AppendInt32(nil, -7)))
+		assert.Nil(t, err, "DecodeValue error: %v", err)
+		assert.Equal(t, "-7/1", r.String(), "expected -7/1, got %v", r)
+		r, err = decodeValue(codec, bsonrw.NewBSONValueReader(bsontype.Double, bsoncore.AppendDouble(nil, 0.1)))
+		assert.Nil(t, err, "DecodeValue error: %v", err)
+		assert.Equal(t, "1/10", r.String(), "expected 1/10, got %v", r)
+		_, err = decodeValue(codec, bsonrw.NewBSONValueReader(bsontype.String, bsoncore.AppendString(nil, "1")))
+		assert.NotNil(t, err, "expected an error decoding a string")
+	})
+	t.Run("conversion errors", func(t *testing.T) {
+		convErr := errors.New("conversion error")
+		codec := NewDecimal128Codec(tRat,
+			func(interface{}) (primitive.Decimal128, error) { return primitive.Decimal128{}, convErr },
+			func(primitive.Decimal128) (interface{}, error) { return "not a rat", nil },
+		)
+
+		vw, err := bsonrw.NewBSONValueWriter(&bsonrw.SliceWriter{})
+		assert.Nil(t, err, "NewBSONValueWriter error: %v", err)
+		err = codec.EncodeValue(EncodeContext{}, vw, reflect.ValueOf(big.NewRat(1, 2)))
+		assert.Equal(t, convErr, err, "expected error %v, got %v", convErr, err)
+
+		var r *big.Rat
+		vr := bsonrw.NewBSONValueReader(bsontype.Decimal128, bsoncore.AppendDecimal128(nil, d128("1")))
+		err = codec.DecodeValue(DecodeContext{}, vr, reflect.ValueOf(&r).Elem())
+		assert.NotNil(t, err, "expected an error for a conversion that returns the wrong type")
+	})
+}
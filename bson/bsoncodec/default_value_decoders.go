@@ -62,19 +62,19 @@ func (dvd DefaultValueDecoders) RegisterDefaultDecoders(rb *RegistryBuilder) {
 		RegisterDecoder(tUnmarshaler, ValueDecoderFunc(dvd.UnmarshalerDecodeValue)).
 		RegisterDecoder(tCoreDocument, ValueDecoderFunc(dvd.CoreDocumentDecodeValue)).
 		RegisterDecoder(tCodeWithScope, ValueDecoderFunc(dvd.CodeWithScopeDecodeValue)).
-		RegisterDefaultDecoder(reflect.Bool, ValueDecoderFunc(dvd.BooleanDecodeValue)).
-		RegisterDefaultDecoder(reflect.Int, ValueDecoderFunc(dvd.IntDecodeValue)).
-		RegisterDefaultDecoder(reflect.Int8, ValueDecoderFunc(dvd.IntDecodeValue)).
-		RegisterDefaultDecoder(reflect.Int16, ValueDecoderFunc(dvd.IntDecodeValue)).
-		RegisterDefaultDecoder(reflect.Int32, ValueDecoderFunc(dvd.IntDecodeValue)).
-		RegisterDefaultDecoder(reflect.Int64, ValueDecoderFunc(dvd.IntDecodeValue)).
-		RegisterDefaultDecoder(reflect.Uint, ValueDecoderFunc(dvd.UintDecodeValue)).
-		RegisterDefaultDecoder(reflect.Uint8, ValueDecoderFunc(dvd.UintDecodeValue)).
-		RegisterDefaultDecoder(reflect.Uint16, ValueDecoderFunc(dvd.UintDecodeValue)).
-		RegisterDefaultDecoder(reflect.Uint32, ValueDecoderFunc(dvd.UintDecodeValue)).
-		RegisterDefaultDecoder(reflect.Uint64, ValueDecoderFunc(dvd.UintDecodeValue)).
-		RegisterDefaultDecoder(reflect.Float32, ValueDecoderFunc(dvd.FloatDecodeValue)).
-		RegisterDefaultDecoder(reflect.Float64, ValueDecoderFunc(dvd.FloatDecodeValue)).
+		RegisterDefaultDecoder(reflect.Bool, settingDecoder{ValueDecoderFunc(dvd.BooleanDecodeValue), setBooleanValue}).
+		RegisterDefaultDecoder(reflect.Int, settingDecoder{ValueDecoderFunc(dvd.IntDecodeValue), setIntValue}).
+		RegisterDefaultDecoder(reflect.Int8, settingDecoder{ValueDecoderFunc(dvd.IntDecodeValue), setIntValue}).
+		RegisterDefaultDecoder(reflect.Int16, settingDecoder{ValueDecoderFunc(dvd.IntDecodeValue), setIntValue}).
+		RegisterDefaultDecoder(reflect.Int32, settingDecoder{ValueDecoderFunc(dvd.IntDecodeValue), setIntValue}).
+		RegisterDefaultDecoder(reflect.Int64, settingDecoder{ValueDecoderFunc(dvd.IntDecodeValue), setIntValue}).
+		RegisterDefaultDecoder(reflect.Uint, settingDecoder{ValueDecoderFunc(dvd.UintDecodeValue), setUintValue}).
+		RegisterDefaultDecoder(reflect.Uint8, settingDecoder{ValueDecoderFunc(dvd.UintDecodeValue), setUintValue}).
+		RegisterDefaultDecoder(reflect.Uint16, settingDecoder{ValueDecoderFunc(dvd.UintDecodeValue), setUintValue}).
+		RegisterDefaultDecoder(reflect.Uint32, settingDecoder{ValueDecoderFunc(dvd.UintDecodeValue), setUintValue}).
+		RegisterDefaultDecoder(reflect.Uint64, settingDecoder{ValueDecoderFunc(dvd.UintDecodeValue), setUintValue}).
+		RegisterDefaultDecoder(reflect.Float32, settingDecoder{ValueDecoderFunc(dvd.FloatDecodeValue), setFloatValue}).
+		RegisterDefaultDecoder(reflect.Float64, settingDecoder{ValueDecoderFunc(dvd.FloatDecodeValue), setFloatValue}).
 		RegisterDefaultDecoder(reflect.Array, ValueDecoderFunc(dvd.ArrayDecodeValue)).
 		RegisterDefaultDecoder(reflect.Map, defaultMapCodec).
 		RegisterDefaultDecoder(reflect.Slice, defaultSliceCodec).
@@ -182,42 +182,7 @@ func (dvd DefaultValueDecoders) IntDecodeValue(dc DecodeContext, vr bsonrw.Value
 		return fmt.Errorf("cannot decode %v into an integer type", vr.Type())
 	}
 
-	if !val.CanSet() {
-		return ValueDecoderError{
-			Name:     "IntDecodeValue",
-			Kinds:    []reflect.Kind{reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int},
-			Received: val,
-		}
-	}
-
-	switch val.Kind() {
-	case reflect.Int8:
-		if i64 < math.MinInt8 || i64 > math.MaxInt8 {
-			return fmt.Errorf("%d overflows int8", i64)
-		}
-	case reflect.Int16:
-		if i64 < math.MinInt16 || i64 > math.MaxInt16 {
-			return fmt.Errorf("%d overflows int16", i64)
-		}
-	case reflect.Int32:
-		if i64 < math.MinInt32 || i64 > math.MaxInt32 {
-			return fmt.Errorf("%d overflows int32", i64)
-		}
-	case reflect.Int64:
-	case reflect.Int:
-		if int64(int(i64)) != i64 { // Can we fit this inside of an int
-			return fmt.Errorf("%d overflows int", i64)
-		}
-	default:
-		return ValueDecoderError{
-			Name:     "IntDecodeValue",
-			Kinds:    []reflect.Kind{reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int},
-			Received: val,
-		}
-	}
-
-	val.SetInt(i64)
-	return nil
+	return setInt(i64, val)
 }
 
 // UintDecodeValue is the ValueDecoderFunc for uint types.
@@ -260,45 +225,7 @@ func (dvd DefaultValueDecoders) UintDecodeValue(dc DecodeContext, vr bsonrw.Valu
 		return fmt.Errorf("cannot decode %v into an integer type", vr.Type())
 	}
 
-	if !val.CanSet() {
-		return ValueDecoderError{
-			Name:     "UintDecodeValue",
-			Kinds:    []reflect.Kind{reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint},
-			Received: val,
-		}
-	}
-
-	switch val.Kind() {
-	case reflect.Uint8:
-		if i64 < 0 || i64 > math.MaxUint8 {
-			return fmt.Errorf("%d overflows uint8", i64)
-		}
-	case reflect.Uint16:
-		if i64 < 0 || i64 > math.MaxUint16 {
-			return fmt.Errorf("%d overflows uint16", i64)
-		}
-	case reflect.Uint32:
-		if i64 < 0 || i64 > math.MaxUint32 {
-			return fmt.Errorf("%d overflows uint32", i64)
-		}
-	case reflect.Uint64:
-		if i64 < 0 {
-			return fmt.Errorf("%d overflows uint64", i64)
-		}
-	case reflect.Uint:
-		if i64 < 0 || int64(uint(i64)) != i64 { // Can we fit this inside of an uint
-			return fmt.Errorf("%d overflows uint", i64)
-		}
-	default:
-		return ValueDecoderError{
-			Name:     "UintDecodeValue",
-			Kinds:    []reflect.Kind{reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint},
-			Received: val,
-		}
-	}
-
-	val.SetUint(uint64(i64))
-	return nil
+	return setUint(i64, val)
 }
 
 // FloatDecodeValue is the ValueDecoderFunc for float types.
@@ -335,22 +262,7 @@ func (dvd DefaultValueDecoders) FloatDecodeValue(ec DecodeContext, vr bsonrw.Val
 		return fmt.Errorf("cannot decode %v into a float32 or float64 type", vr.Type())
 	}
 
-	if !val.CanSet() {
-		return ValueDecoderError{Name: "FloatDecodeValue", Kinds: []reflect.Kind{reflect.Float32, reflect.Float64}, Received: val}
-	}
-
-	switch val.Kind() {
-	case reflect.Float32:
-		if !ec.Truncate && float64(float32(f)) != f {
-			return errors.New("FloatDecodeValue can only convert float64 to float32 when truncation is allowed")
-		}
-	case reflect.Float64:
-	default:
-		return ValueDecoderError{Name: "FloatDecodeValue", Kinds: []reflect.Kind{reflect.Float32, reflect.Float64}, Received: val}
-	}
-
-	val.SetFloat(f)
-	return nil
+	return setFloat(ec, f, val)
 }
 
 // StringDecodeValue is the ValueDecoderFunc for string types.
@@ -923,6 +835,12 @@ func (dvd DefaultValueDecoders) UnmarshalerDecodeValue(dc DecodeContext, vr bson
 		return ValueDecoderError{Name: "UnmarshalerDecodeValue", Types: []reflect.Type{tUnmarshaler}, Received: val}
 	}
 
+	if val.Kind() == reflect.Ptr && vr.Type() == bsontype.Null && val.CanSet() {
+		// Null is decoded into a nil pointer, the same way the PointerCodec handles it.
+		val.Set(reflect.Zero(val.Type()))
+		return vr.ReadNull()
+	}
+
 	if val.Kind() == reflect.Ptr && val.IsNil() {
 		if !val.CanSet() {
 			return ValueDecoderError{Name: "UnmarshalerDecodeValue", Types: []reflect.Type{tUnmarshaler}, Received: val}
@@ -90,19 +90,19 @@ func (dve DefaultValueEncoders) RegisterDefaultEncoders(rb *RegistryBuilder) {
 		RegisterEncoder(tMaxKey, ValueEncoderFunc(dve.MaxKeyEncodeValue)).
 		RegisterEncoder(tCoreDocument, ValueEncoderFunc(dve.CoreDocumentEncodeValue)).
 		RegisterEncoder(tCodeWithScope, ValueEncoderFunc(dve.CodeWithScopeEncodeValue)).
-		RegisterDefaultEncoder(reflect.Bool, ValueEncoderFunc(dve.BooleanEncodeValue)).
-		RegisterDefaultEncoder(reflect.Int, ValueEncoderFunc(dve.IntEncodeValue)).
-		RegisterDefaultEncoder(reflect.Int8, ValueEncoderFunc(dve.IntEncodeValue)).
-		RegisterDefaultEncoder(reflect.Int16, ValueEncoderFunc(dve.IntEncodeValue)).
-		RegisterDefaultEncoder(reflect.Int32, ValueEncoderFunc(dve.IntEncodeValue)).
-		RegisterDefaultEncoder(reflect.Int64, ValueEncoderFunc(dve.IntEncodeValue)).
-		RegisterDefaultEncoder(reflect.Uint, ValueEncoderFunc(dve.UintEncodeValue)).
-		RegisterDefaultEncoder(reflect.Uint8, ValueEncoderFunc(dve.UintEncodeValue)).
-		RegisterDefaultEncoder(reflect.Uint16, ValueEncoderFunc(dve.UintEncodeValue)).
-		RegisterDefaultEncoder(reflect.Uint32, ValueEncoderFunc(dve.UintEncodeValue)).
-		RegisterDefaultEncoder(reflect.Uint64, ValueEncoderFunc(dve.UintEncodeValue)).
-		RegisterDefaultEncoder(reflect.Float32, ValueEncoderFunc(dve.FloatEncodeValue)).
-		RegisterDefaultEncoder(reflect.Float64, ValueEncoderFunc(dve.FloatEncodeValue)).
+		RegisterDefaultEncoder(reflect.Bool, appendingEncoder{ValueEncoderFunc(dve.BooleanEncodeValue), appendBooleanValue}).
+		RegisterDefaultEncoder(reflect.Int, appendingEncoder{ValueEncoderFunc(dve.IntEncodeValue), appendIntValue}).
+		RegisterDefaultEncoder(reflect.Int8, appendingEncoder{ValueEncoderFunc(dve.IntEncodeValue), appendIntValue}).
+		RegisterDefaultEncoder(reflect.Int16, appendingEncoder{ValueEncoderFunc(dve.IntEncodeValue), appendIntValue}).
+		RegisterDefaultEncoder(reflect.Int32, appendingEncoder{ValueEncoderFunc(dve.IntEncodeValue), appendIntValue}).
+		RegisterDefaultEncoder(reflect.Int64, appendingEncoder{ValueEncoderFunc(dve.IntEncodeValue), appendIntValue}).
+		RegisterDefaultEncoder(reflect.Uint, appendingEncoder{ValueEncoderFunc(dve.UintEncodeValue), appendUintValue}).
+		RegisterDefaultEncoder(reflect.Uint8, appendingEncoder{ValueEncoderFunc(dve.UintEncodeValue), appendUintValue}).
+		RegisterDefaultEncoder(reflect.Uint16, appendingEncoder{ValueEncoderFunc(dve.UintEncodeValue), appendUintValue}).
+		RegisterDefaultEncoder(reflect.Uint32, appendingEncoder{ValueEncoderFunc(dve.UintEncodeValue), appendUintValue}).
+		RegisterDefaultEncoder(reflect.Uint64, appendingEncoder{ValueEncoderFunc(dve.UintEncodeValue), appendUintValue}).
+		RegisterDefaultEncoder(reflect.Float32, appendingEncoder{ValueEncoderFunc(dve.FloatEncodeValue), appendFloatValue}).
+		RegisterDefaultEncoder(reflect.Float64, appendingEncoder{ValueEncoderFunc(dve.FloatEncodeValue), appendFloatValue}).
 		RegisterDefaultEncoder(reflect.Array, ValueEncoderFunc(dve.ArrayEncodeValue)).
 		RegisterDefaultEncoder(reflect.Map, defaultMapCodec).
 		RegisterDefaultEncoder(reflect.Slice, defaultSliceCodec).
@@ -507,7 +507,19 @@ func (dve DefaultValueEncoders) EmptyInterfaceEncodeValue(ec EncodeContext, vw b
 
 // ValueMarshalerEncodeValue is the ValueEncoderFunc for ValueMarshaler implementations.
 func (dve DefaultValueEncoders) ValueMarshalerEncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
-	if !val.IsValid() || !val.Type().Implements(tValueMarshaler) {
+	// Either val or a pointer to val must implement ValueMarshaler
+	switch {
+	case !val.IsValid():
+		return ValueEncoderError{Name: "ValueMarshalerEncodeValue", Types: []reflect.Type{tValueMarshaler}, Received: val}
+	case val.Type().Implements(tValueMarshaler):
+		// Calling a value receiver method on a nil pointer panics, so nil pointers are encoded as null. Pointer
+		// receiver methods are called with the nil pointer.
+		if val.Kind() == reflect.Ptr && val.IsNil() && val.Type().Elem().Implements(tValueMarshaler) {
+			return vw.WriteNull()
+		}
+	case reflect.PtrTo(val.Type()).Implements(tValueMarshaler) && val.CanAddr():
+		val = val.Addr()
+	default:
 		return ValueEncoderError{Name: "ValueMarshalerEncodeValue", Types: []reflect.Type{tValueMarshaler}, Received: val}
 	}
 
@@ -522,7 +534,17 @@ func (dve DefaultValueEncoders) ValueMarshalerEncodeValue(ec EncodeContext, vw b
 
 // MarshalerEncodeValue is the ValueEncoderFunc for Marshaler implementations.
 func (dve DefaultValueEncoders) MarshalerEncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
-	if !val.IsValid() || !val.Type().Implements(tMarshaler) {
+	// Either val or a pointer to val must implement Marshaler
+	switch {
+	case !val.IsValid():
+		return ValueEncoderError{Name: "MarshalerEncodeValue", Types: []reflect.Type{tMarshaler}, Received: val}
+	case val.Type().Implements(tMarshaler):
+		if val.Kind() == reflect.Ptr && val.IsNil() && val.Type().Elem().Implements(tMarshaler) {
+			return vw.WriteNull()
+		}
+	case reflect.PtrTo(val.Type()).Implements(tMarshaler) && val.CanAddr():
+		val = val.Addr()
+	default:
 		return ValueEncoderError{Name: "MarshalerEncodeValue", Types: []reflect.Type{tMarshaler}, Received: val}
 	}
 
@@ -537,7 +559,17 @@ func (dve DefaultValueEncoders) MarshalerEncodeValue(ec EncodeContext, vw bsonrw
 
 // ProxyEncodeValue is the ValueEncoderFunc for Proxy implementations.
 func (dve DefaultValueEncoders) ProxyEncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
-	if !val.IsValid() || !val.Type().Implements(tProxy) {
+	// Either val or a pointer to val must implement Proxy
+	switch {
+	case !val.IsValid():
+		return ValueEncoderError{Name: "ProxyEncodeValue", Types: []reflect.Type{tProxy}, Received: val}
+	case val.Type().Implements(tProxy):
+		if val.Kind() == reflect.Ptr && val.IsNil() && val.Type().Elem().Implements(tProxy) {
+			return vw.WriteNull()
+		}
+	case reflect.PtrTo(val.Type()).Implements(tProxy) && val.CanAddr():
+		val = val.Addr()
+	default:
 		return ValueEncoderError{Name: "ProxyEncodeValue", Types: []reflect.Type{tProxy}, Received: val}
 	}
 
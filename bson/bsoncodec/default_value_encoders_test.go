@@ -607,6 +607,22 @@ func TestDefaultValueEncoders(t *testing.T) {
 					bsonrwtest.WriteString,
 					nil,
 				},
+				{
+					"nil pointer",
+					(*testValueMarshaler)(nil),
+					nil,
+					nil,
+					bsonrwtest.WriteNull,
+					nil,
+				},
+				{
+					"nil pointer with pointer receiver",
+					(*testPtrValueMarshaler)(nil),
+					nil,
+					nil,
+					bsonrwtest.WriteString,
+					nil,
+				},
 			},
 		},
 		{
@@ -637,6 +653,22 @@ func TestDefaultValueEncoders(t *testing.T) {
 					bsonrwtest.WriteDocumentEnd,
 					nil,
 				},
+				{
+					"nil pointer",
+					(*testMarshaler)(nil),
+					nil,
+					nil,
+					bsonrwtest.WriteNull,
+					nil,
+				},
+				{
+					"nil pointer with pointer receiver",
+					(*testPtrMarshaler)(nil),
+					nil,
+					nil,
+					bsonrwtest.WriteDocumentEnd,
+					nil,
+				},
 			},
 		},
 		{
@@ -675,6 +707,22 @@ func TestDefaultValueEncoders(t *testing.T) {
 					bsonrwtest.WriteInt64,
 					nil,
 				},
+				{
+					"nil pointer",
+					(*testProxy)(nil),
+					nil,
+					nil,
+					bsonrwtest.WriteNull,
+					nil,
+				},
+				{
+					"nil pointer with pointer receiver",
+					(*testPtrProxy)(nil),
+					&EncodeContext{Registry: buildDefaultRegistry()},
+					nil,
+					bsonrwtest.WriteInt64,
+					nil,
+				},
 			},
 		},
 		{
@@ -1586,3 +1634,21 @@ type testProxy struct {
 }
 
 func (tp testProxy) ProxyBSON() (interface{}, error) { return tp.ret, tp.err }
+
+// testPtrValueMarshaler, testPtrMarshaler and testPtrProxy implement their interfaces with pointer receivers that
+// handle a nil receiver.
+type testPtrValueMarshaler struct{}
+
+func (*testPtrValueMarshaler) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return bsontype.String, []byte{0x04, 0x00, 0x00, 0x00, 'n', 'i', 'l', 0x00}, nil
+}
+
+type testPtrMarshaler struct{}
+
+func (*testPtrMarshaler) MarshalBSON() ([]byte, error) {
+	return bsoncore.BuildDocument(nil, bsoncore.AppendNullElement(nil, "nil")), nil
+}
+
+type testPtrProxy struct{}
+
+func (*testPtrProxy) ProxyBSON() (interface{}, error) { return int64(0), nil }
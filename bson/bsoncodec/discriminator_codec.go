@@ -0,0 +1,181 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// ErrNoDiscriminatorEntry is returned when a discriminator value or concrete type has not been registered for an
+// interface type.
+type ErrNoDiscriminatorEntry struct {
+	Interface reflect.Type
+	Value     string
+	Type      reflect.Type
+}
+
+func (ende ErrNoDiscriminatorEntry) Error() string {
+	if ende.Type != nil {
+		return "no discriminator value registered for " + ende.Type.String() + " as " + ende.Interface.String()
+	}
+	return "no type registered for discriminator value " + ende.Value + " of " + ende.Interface.String()
+}
+
+// discriminatorCodec is the codec used for interface types registered with RegisterTypeDiscriminator. Concrete values
+// are written as documents whose first element holds the discriminator value for their type, and documents are
+// decoded into the concrete type registered for their discriminator value.
+type discriminatorCodec struct {
+	iface  reflect.Type
+	key    string
+	types  map[string]reflect.Type
+	values map[reflect.Type]string
+}
+
+var _ ValueCodec = (*discriminatorCodec)(nil)
+
+// newDiscriminatorCodec returns a discriminatorCodec for the interface type iface.
+func newDiscriminatorCodec(iface reflect.Type, key string, types map[string]reflect.Type) *discriminatorCodec {
+	codec := discriminatorCodec{
+		iface:  iface,
+		key:    key,
+		types:  make(map[string]reflect.Type, len(types)),
+		values: make(map[reflect.Type]string, len(types)),
+	}
+	for value, t := range types {
+		codec.types[value] = t
+		codec.values[t] = value
+	}
+	return &codec
+}
+
+// EncodeValue is the ValueEncoderFunc for an interface type with a registered discriminator.
+func (dc *discriminatorCodec) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != dc.iface {
+		return ValueEncoderError{Name: "DiscriminatorEncodeValue", Types: []reflect.Type{dc.iface}, Received: val}
+	}
+
+	if val.IsNil() {
+		return vw.WriteNull()
+	}
+	val = val.Elem()
+
+	value, ok := dc.values[val.Type()]
+	if !ok {
+		return ErrNoDiscriminatorEntry{Interface: dc.iface, Type: val.Type()}
+	}
+
+	encoder, err := ec.LookupEncoder(val.Type())
+	if err != nil {
+		return err
+	}
+
+	sw := sliceWriterPool.Get().(*bsonrw.SliceWriter)
+	defer sliceWriterPool.Put(sw)
+	*sw = (*sw)[:0]
+
+	docVW := bvwPool.Get(sw)
+	defer bvwPool.Put(docVW)
+
+	err = encoder.EncodeValue(ec, docVW, val)
+	if err != nil {
+		return err
+	}
+
+	elems, err := bsoncore.Document(*sw).Elements()
+	if err != nil {
+		return fmt.Errorf("cannot encode %s as a document with a discriminator: %v", val.Type(), err)
+	}
+
+	dw, err := vw.WriteDocument()
+	if err != nil {
+		return err
+	}
+	evw, err := dw.WriteDocumentElement(dc.key)
+	if err != nil {
+		return err
+	}
+	err = evw.WriteString(value)
+	if err != nil {
+		return err
+	}
+
+	for _, elem := range elems {
+		if elem.Key() == dc.key {
+			continue
+		}
+		evw, err = dw.WriteDocumentElement(elem.Key())
+		if err != nil {
+			return err
+		}
+		ev := elem.Value()
+		err = bsonrw.Copier{}.CopyValueFromBytes(evw, ev.Type, ev.Data)
+		if err != nil {
+			return err
+		}
+	}
+	return dw.WriteDocumentEnd()
+}
+
+// DecodeValue is the ValueDecoderFunc for an interface type with a registered discriminator.
+func (dc *discriminatorCodec) DecodeValue(dctx DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != dc.iface {
+		return ValueDecoderError{Name: "DiscriminatorDecodeValue", Types: []reflect.Type{dc.iface}, Received: val}
+	}
+
+	switch vr.Type() {
+	case bsontype.Null:
+		val.Set(reflect.Zero(val.Type()))
+		return vr.ReadNull()
+	case bsontype.Undefined:
+		val.Set(reflect.Zero(val.Type()))
+		return vr.ReadUndefined()
+	case bsontype.Type(0), bsontype.EmbeddedDocument:
+	default:
+		return fmt.Errorf("cannot decode %v into a %s", vr.Type(), val.Type())
+	}
+
+	doc, err := bsonrw.Copier{}.CopyDocumentToBytes(vr)
+	if err != nil {
+		return err
+	}
+
+	rv, err := bsoncore.Document(doc).LookupErr(dc.key)
+	if err != nil {
+		return fmt.Errorf("cannot decode document into %s: missing discriminator key %q", val.Type(), dc.key)
+	}
+	value, ok := rv.StringValueOK()
+	if !ok {
+		return fmt.Errorf("cannot decode document into %s: discriminator %q must be a string, got %v", val.Type(), dc.key, rv.Type)
+	}
+
+	t, ok := dc.types[value]
+	if !ok {
+		return ErrNoDiscriminatorEntry{Interface: dc.iface, Value: value}
+	}
+	if !t.Implements(dc.iface) {
+		return fmt.Errorf("cannot decode discriminator value %s: %s does not implement %s", value, t, dc.iface)
+	}
+
+	decoder, err := dctx.LookupDecoder(t)
+	if err != nil {
+		return err
+	}
+
+	elem := reflect.New(t).Elem()
+	err = decoder.DecodeValue(dctx, bsonrw.NewBSONDocumentReader(doc), elem)
+	if err != nil {
+		return err
+	}
+
+	val.Set(elem)
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+type testShape interface {
+	area() float64
+}
+
+type testCircle struct {
+	Radius float64
+}
+
+func (c testCircle) area() float64 { return 3 * c.Radius * c.Radius }
+
+type testSquare struct {
+	Side float64
+}
+
+func (s *testSquare) area() float64 { return s.Side * s.Side }
+
+type testAggregate struct {
+	Primary testShape
+	Events  []testShape
+	ByName  map[string]testShape
+	Missing testShape
+}
+
+var tShape = reflect.TypeOf((*testShape)(nil)).Elem()
+
+func newDiscriminatorRegistry() *Registry {
+	rb := NewRegistryBuilder()
+	defaultValueEncoders.RegisterDefaultEncoders(rb)
+	defaultValueDecoders.RegisterDefaultDecoders(rb)
+	rb.RegisterTypeDiscriminator(tShape, "_t", map[string]reflect.Type{
+		"circle": reflect.TypeOf(testCircle{}),
+		"square": reflect.TypeOf(&testSquare{}),
+	})
+	return rb.Build()
+}
+
+func TestDiscriminatorCodec(t *testing.T) {
+	reg := newDiscriminatorRegistry()
+
+	encode := func(t *testing.T, v interface{}) []byte {
+		t.Helper()
+
+		var sw bsonrw.SliceWriter
+		vw, err := bsonrw.NewBSONValueWriter(&sw)
+		assert.Nil(t, err, "NewBSONValueWriter error: %v", err)
+		enc, err := reg.LookupEncoder(reflect.TypeOf(v))
+		assert.Nil(t, err, "LookupEncoder error: %v", err)
+		err = enc.EncodeValue(EncodeContext{Registry: reg}, vw, reflect.ValueOf(v))
+		assert.Nil(t, err, "EncodeValue error: %v", err)
+		return sw
+	}
+	decode := func(t *testing.T, b []byte, v interface{}) error {
+		t.Helper()
+
+		val := reflect.ValueOf(v).Elem()
+		dec, err := reg.LookupDecoder(val.Type())
+		assert.Nil(t, err, "LookupDecoder error: %v", err)
+		return dec.DecodeValue(DecodeContext{Registry: reg}, bsonrw.NewBSONDocumentReader(b), val)
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		agg := testAggregate{
+			Primary: testCircle{Radius: 2},
+			Events:  []testShape{&testSquare{Side: 3}, testCircle{Radius: 1}, nil},
+			ByName:  map[string]testShape{"sq": &testSquare{Side: 4}},
+		}
+		b := encode(t, agg)
+
+		primary, err := bsoncore.Document(b).LookupErr("primary")
+		assert.Nil(t, err, "LookupErr error: %v", err)
+		first := primary.Document().Index(0)
+		assert.Equal(t, "_t", first.Key(), "expected discriminator first, got %v", first.Key())
+		assert.Equal(t, "circle", first.Value().StringValue(), "expected circle, got %v", first.Value())
+		event, err := bsoncore.Document(b).LookupErr("events", "0", "_t")
+		assert.Nil(t, err, "LookupErr error: %v", err)
+		assert.Equal(t, "square", event.StringValue(), "expected square, got %v", event)
+
+		var got testAggregate
+		err = decode(t, b, &got)
+		assert.Nil(t, err, "DecodeValue error: %v", err)
+		assert.True(t, reflect.DeepEqual(agg, got), "expected %v, got %v", agg, got)
+	})
+	t.Run("existing discriminator element is replaced", func(t *testing.T) {
+		type withKey struct {
+			T      string `bson:"_t"`
+			Radius float64
+		}
+		rb := NewRegistryBuilder()
+		defaultValueEncoders.RegisterDefaultEncoders(rb)
+		defaultValueDecoders.RegisterDefaultDecoders(rb)
+		reg := rb.RegisterTypeDiscriminator(tEmpty, "_t", map[string]reflect.Type{"k": reflect.TypeOf(withKey{})}).Build()
+
+		var sw bsonrw.SliceWriter
+		vw, err := bsonrw.NewBSONValueWriter(&sw)
+		assert.Nil(t, err, "NewBSONValueWriter error: %v", err)
+		err = reg.typeEncoders[tEmpty].EncodeValue(
+			EncodeContext{Registry: reg}, vw, reflect.ValueOf(&struct{ V interface{} }{withKey{"other", 1}}).Elem().Field(0))
+		assert.Nil(t, err, "EncodeValue error: %v", err)
+		elems, err := bsoncore.Document(sw).Elements()
+		assert.Nil(t, err, "Elements error: %v", err)
+		assert.Equal(t, 2, len(elems), "expected 2 elements, got %d", len(elems))
+		assert.Equal(t, "k", elems[0].Value().StringValue(), "expected k, got %v", elems[0].Value())
+	})
+	t.Run("errors", func(t *testing.T) {
+		type other struct{ testCircle }
+
+		var sw bsonrw.SliceWriter
+		vw, err := bsonrw.NewBSONValueWriter(&sw)
+		assert.Nil(t, err, "NewBSONValueWriter error: %v", err)
+		enc, err := reg.LookupEncoder(reflect.TypeOf(testAggregate{}))
+		assert.Nil(t, err, "LookupEncoder error: %v", err)
+		err = enc.EncodeValue(EncodeContext{Registry: reg}, vw, reflect.ValueOf(testAggregate{Primary: other{}}))
+		want := ErrNoDiscriminatorEntry{Interface: tShape, Type: reflect.TypeOf(other{})}
+		assert.Equal(t, want, err, "expected error %v, got %v", want, err)
+
+		unknown := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendDocumentElement(nil, "primary", bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendStringElement(nil, "_t", "triangle"))))
+		var got testAggregate
+		err = decode(t, unknown, &got)
+		want = ErrNoDiscriminatorEntry{Interface: tShape, Value: "triangle"}
+		assert.Equal(t, want, err, "expected error %v, got %v", want, err)
+
+		missing := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendDocumentElement(nil, "primary", bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendDoubleElement(nil, "radius", 1))))
+		err = decode(t, missing, &got)
+		assert.NotNil(t, err, "expected error for missing discriminator, got nil")
+	})
+}
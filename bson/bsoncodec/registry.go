@@ -202,6 +202,21 @@ func (rb *RegistryBuilder) RegisterTypeMapEntry(bt bsontype.Type, rt reflect.Typ
 	return rb
 }
 
+// RegisterTypeDiscriminator registers a discriminator for the interface type iface. Values stored in a field,
+// slice element, or map value of type iface are encoded as documents whose first element is key, holding the
+// discriminator value registered for the value's concrete type. When decoding into iface, the document's key element
+// selects the concrete type to decode into from types. Each type in types must implement iface; pointer types may be
+// registered when only the pointer implements the interface.
+//
+// The discriminator is registered as the type encoder and decoder for iface, replacing any previously registered
+// for that type.
+func (rb *RegistryBuilder) RegisterTypeDiscriminator(iface reflect.Type, key string, types map[string]reflect.Type) *RegistryBuilder {
+	codec := newDiscriminatorCodec(iface, key, types)
+	rb.typeEncoders[iface] = codec
+	rb.typeDecoders[iface] = codec
+	return rb
+}
+
 // Build creates a Registry from the current state of this RegistryBuilder.
 func (rb *RegistryBuilder) Build() *Registry {
 	registry := new(Registry)
@@ -244,7 +259,12 @@ func (rb *RegistryBuilder) Build() *Registry {
 // type provided. An encoder registered for a specific type will take
 // precedence over an encoder registered for an interface the type satisfies,
 // which takes precedence over an encoder for the reflect.Kind of the value. If
-// no encoder can be found, an error is returned.
+// only a pointer to the type satisfies an interface, the encoder registered for
+// that interface is used for addressable values, such as the fields of a struct
+// that is encoded through a pointer, and other values use the encoder the type
+// would otherwise have. This applies to every registry, so a type whose
+// MarshalBSON method has a pointer receiver is marshaled with that method when
+// it is addressable. If no encoder can be found, an error is returned.
 func (r *Registry) LookupEncoder(t reflect.Type) (ValueEncoder, error) {
 	encodererr := ErrNoEncoder{Type: t}
 	r.mu.RLock()
@@ -257,7 +277,7 @@ func (r *Registry) LookupEncoder(t reflect.Type) (ValueEncoder, error) {
 		return enc, nil
 	}
 
-	enc, found = r.lookupInterfaceEncoder(t)
+	enc, found = r.lookupInterfaceEncoder(t, true)
 	if found {
 		r.mu.Lock()
 		r.typeEncoders[t] = enc
@@ -291,16 +311,25 @@ func (r *Registry) lookupTypeEncoder(t reflect.Type) (ValueEncoder, bool) {
 	return enc, found
 }
 
-func (r *Registry) lookupInterfaceEncoder(t reflect.Type) (ValueEncoder, bool) {
+// lookupInterfaceEncoder returns the encoder registered for the first interface t implements. If allowAddr is true
+// and only a pointer to t implements the interface, the returned encoder uses the interface encoder for addressable
+// values and the encoder t would otherwise use for values that cannot be addressed.
+func (r *Registry) lookupInterfaceEncoder(t reflect.Type, allowAddr bool) (ValueEncoder, bool) {
 	if t == nil {
 		return nil, false
 	}
 	for _, ienc := range r.interfaceEncoders {
-		if !t.Implements(ienc.i) {
-			continue
+		if t.Implements(ienc.i) {
+			return ienc.ve, true
+		}
+		if allowAddr && reflect.PtrTo(t).Implements(ienc.i) {
+			// t itself may still implement an interface that was registered later.
+			defaultEnc, found := r.lookupInterfaceEncoder(t, false)
+			if !found {
+				defaultEnc = r.kindEncoders[t.Kind()]
+			}
+			return newCondAddrEncoder(ienc.ve, defaultEnc), true
 		}
-
-		return ienc.ve, true
 	}
 	return nil, false
 }
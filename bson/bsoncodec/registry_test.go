@@ -298,6 +298,34 @@ func TestRegistry(t *testing.T) {
 				})
 			}
 		})
+		t.Run("Lookup pointer receiver interface", func(t *testing.T) {
+			ti3 := reflect.TypeOf((*testInterface3)(nil)).Elem()
+			fc3, fsc := fakeCodec{num: 3}, new(fakeStructCodec)
+			reg := NewRegistryBuilder().
+				RegisterEncoder(ti3, fc3).
+				RegisterDefaultEncoder(reflect.Struct, fsc).
+				Build()
+
+			ft6 := reflect.TypeOf(fakeType6{})
+			gotcodec, err := reg.LookupEncoder(ft6)
+			noerr(t, err)
+			cae, ok := gotcodec.(*condAddrEncoder)
+			if !ok {
+				t.Fatalf("expected a *condAddrEncoder, got %T", gotcodec)
+			}
+			if cae.canAddrEnc != ValueEncoder(fc3) {
+				t.Errorf("Addressable encoder did not match. got %v; want %v", cae.canAddrEnc, fc3)
+			}
+			if cae.elseEnc != ValueEncoder(fsc) {
+				t.Errorf("Non-addressable encoder did not match. got %v; want %v", cae.elseEnc, fsc)
+			}
+
+			gotcodec, err = reg.LookupEncoder(reflect.PtrTo(ft6))
+			noerr(t, err)
+			if gotcodec != ValueEncoder(fc3) {
+				t.Errorf("Codecs did not match. got %v; want %v", gotcodec, fc3)
+			}
+		})
 	})
 	t.Run("Type Map", func(t *testing.T) {
 		reg := NewRegistryBuilder().
@@ -338,6 +366,7 @@ type fakeType2 struct{ b bool }
 type fakeType3 struct{ b bool }
 type fakeType4 struct{ b bool }
 type fakeType5 func(string, string) string
+type fakeType6 struct{ b bool }
 type fakeStructCodec struct{ fakeCodec }
 type fakeSliceCodec struct{ fakeCodec }
 type fakeMapCodec struct{ fakeCodec }
@@ -356,4 +385,6 @@ type testInterface2 interface{ test2() }
 type testInterface3 interface{ test3() }
 type testInterface4 interface{ test4() }
 
+func (*fakeType6) test3() {}
+
 func typeComparer(i1, i2 reflect.Type) bool { return i1 == i2 }
@@ -13,6 +13,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/bsonoptions"
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
 var defaultStringCodec = NewStringCodec()
@@ -43,6 +44,18 @@ func (sc *StringCodec) EncodeValue(ectx EncodeContext, vw bsonrw.ValueWriter, va
 	return vw.WriteString(val.String())
 }
 
+func (sc *StringCodec) appendValue(_ EncodeContext, dst []byte, val reflect.Value) (bsontype.Type, []byte, error) {
+	if val.Kind() != reflect.String {
+		return 0, dst, ValueEncoderError{
+			Name:     "StringEncodeValue",
+			Kinds:    []reflect.Kind{reflect.String},
+			Received: val,
+		}
+	}
+
+	return bsontype.String, bsoncore.AppendString(dst, val.String()), nil
+}
+
 // DecodeValue is the ValueDecoder for string types.
 func (sc *StringCodec) DecodeValue(dctx DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
 	if !val.CanSet() || val.Kind() != reflect.String {
@@ -88,3 +101,15 @@ func (sc *StringCodec) DecodeValue(dctx DecodeContext, vr bsonrw.ValueReader, va
 	val.SetString(str)
 	return nil
 }
+
+func (sc *StringCodec) setValue(_ DecodeContext, t bsontype.Type, data []byte, val reflect.Value) (bool, error) {
+	if !val.CanSet() || val.Kind() != reflect.String {
+		return false, nil
+	}
+	str, ok := readStringValue(t, data)
+	if !ok {
+		return false, nil
+	}
+	val.SetString(str)
+	return true, nil
+}
@@ -17,6 +17,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/bsonoptions"
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
 var defaultStructCodec = &StructCodec{
@@ -85,10 +86,22 @@ func (sc *StructCodec) EncodeValue(r EncodeContext, vw bsonrw.ValueWriter, val r
 		return err
 	}
 
+	if bw, ok := vw.(bsonrw.BytesWriter); ok && sd.appendable {
+		scratch := sliceWriterPool.Get().(*bsonrw.SliceWriter)
+		defer sliceWriterPool.Put(scratch)
+
+		*scratch, err = sc.appendDocument(r, (*scratch)[:0], sd, val)
+		if err != nil {
+			return err
+		}
+		return bw.WriteValueBytes(bsontype.EmbeddedDocument, *scratch)
+	}
+
 	dw, err := vw.WriteDocument()
 	if err != nil {
 		return err
 	}
+
 	var rv reflect.Value
 	for _, desc := range sd.fl {
 		if desc.inline == nil {
@@ -127,17 +140,7 @@ func (sc *StructCodec) EncodeValue(r EncodeContext, vw bsonrw.ValueWriter, val r
 
 		encoder := desc.encoder
 
-		var isZero bool
-		rvInterface := rv.Interface()
-		if cz, ok := encoder.(CodecZeroer); ok {
-			isZero = cz.IsTypeZero(rvInterface)
-		} else if rv.Kind() == reflect.Interface {
-			// sc.isZero will not treat an interface rv as an interface, so we need to check for the zero interface separately.
-			isZero = rv.IsNil()
-		} else {
-			isZero = sc.isZero(rvInterface)
-		}
-		if desc.omitEmpty && isZero {
+		if desc.omitEmpty && sc.isFieldZero(encoder, rv) {
 			continue
 		}
 
@@ -169,6 +172,7 @@ func (sc *StructCodec) EncodeValue(r EncodeContext, vw bsonrw.ValueWriter, val r
 // DecodeValue implements the Codec interface.
 // By default, map types in val will not be cleared. If a map has existing key/value pairs, it will be extended with the new ones from vr.
 // For slices, the decoder will set the length of the slice to zero and append all elements. The underlying array will not be cleared.
+// A BSON null or undefined value sets val to its zero value.
 func (sc *StructCodec) DecodeValue(r DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
 	if !val.CanSet() || val.Kind() != reflect.Struct {
 		return ValueDecoderError{Name: "StructCodec.DecodeValue", Kinds: []reflect.Kind{reflect.Struct}, Received: val}
@@ -176,6 +180,18 @@ func (sc *StructCodec) DecodeValue(r DecodeContext, vr bsonrw.ValueReader, val r
 
 	switch vr.Type() {
 	case bsontype.Type(0), bsontype.EmbeddedDocument:
+	case bsontype.Null:
+		if err := vr.ReadNull(); err != nil {
+			return err
+		}
+		val.Set(reflect.Zero(val.Type()))
+		return nil
+	case bsontype.Undefined:
+		if err := vr.ReadUndefined(); err != nil {
+			return err
+		}
+		val.Set(reflect.Zero(val.Type()))
+		return nil
 	default:
 		return fmt.Errorf("cannot decode %v into a %s", vr.Type(), val.Type())
 	}
@@ -185,6 +201,19 @@ func (sc *StructCodec) DecodeValue(r DecodeContext, vr bsonrw.ValueReader, val r
 		return err
 	}
 
+	if br, ok := vr.(bsonrw.BytesReader); ok && sd.decodable {
+		// The document is copied because decoders may keep references to the bytes they decode.
+		_, doc, err := br.ReadValueBytes(nil)
+		if err != nil {
+			return err
+		}
+		return sc.decodeDocument(r, sd, doc, val)
+	}
+	return sc.decodeElements(r, sd, vr, val)
+}
+
+// decodeElements decodes the document read from vr into val one element at a time.
+func (sc *StructCodec) decodeElements(r DecodeContext, sd *structDescription, vr bsonrw.ValueReader, val reflect.Value) error {
 	if sc.DecodeZeroStruct {
 		val.Set(reflect.Zero(val.Type()))
 	}
@@ -192,6 +221,7 @@ func (sc *StructCodec) DecodeValue(r DecodeContext, vr bsonrw.ValueReader, val r
 		val.Set(deepZero(val.Type()))
 	}
 
+	var err error
 	var decoder ValueDecoder
 	var inlineMap reflect.Value
 	if sd.inlineMap >= 0 {
@@ -207,6 +237,7 @@ func (sc *StructCodec) DecodeValue(r DecodeContext, vr bsonrw.ValueReader, val r
 		return err
 	}
 
+	var next int
 	for {
 		name, vr, err := dr.ReadElement()
 		if err == bsonrw.ErrEOD {
@@ -216,7 +247,15 @@ func (sc *StructCodec) DecodeValue(r DecodeContext, vr bsonrw.ValueReader, val r
 			return err
 		}
 
-		fd, exists := sd.fm[name]
+		// Documents are usually decoded into the struct they were encoded from, so check the field that follows the
+		// previous one before looking the name up.
+		var fd fieldDescription
+		exists := next < len(sd.fl) && sd.fl[next].name == name
+		if exists {
+			fd = sd.fl[next]
+		} else {
+			fd, exists = sd.fm[name]
+		}
 		if !exists {
 			// if the original name isn't found in the struct description, try again with the name in lowercase
 			// this could match if a BSON tag isn't specified because by default, describeStruct lowercases all field
@@ -248,6 +287,7 @@ func (sc *StructCodec) DecodeValue(r DecodeContext, vr bsonrw.ValueReader, val r
 			inlineMap.SetMapIndex(reflect.ValueOf(name), elem)
 			continue
 		}
+		next = fd.pos + 1
 
 		var field reflect.Value
 		if fd.inline == nil {
@@ -335,11 +375,182 @@ func (sc *StructCodec) isZero(i interface{}) bool {
 	return false
 }
 
+// isFieldZero reports whether rv, which is encoded with encoder, should be omitted from a field tagged omitempty.
+func (sc *StructCodec) isFieldZero(encoder ValueEncoder, rv reflect.Value) bool {
+	if cz, ok := encoder.(CodecZeroer); ok {
+		return cz.IsTypeZero(rv.Interface())
+	}
+	if rv.Kind() == reflect.Interface {
+		// sc.isZero will not treat an interface rv as an interface, so we need to check for the zero interface separately.
+		return rv.IsNil()
+	}
+	return sc.isZero(rv.Interface())
+}
+
+// appendDocument appends val as a BSON document to dst using the precompiled field appenders in sd. It must only be
+// called with descriptions where appendable is true.
+func (sc *StructCodec) appendDocument(ec EncodeContext, dst []byte, sd *structDescription, val reflect.Value) ([]byte, error) {
+	idx, dst := bsoncore.AppendDocumentStart(dst)
+	for _, desc := range sd.fl {
+		rv := val.Field(desc.idx)
+		if desc.omitEmpty && sc.isFieldZero(desc.encoder, rv) {
+			continue
+		}
+
+		var tpos int
+		var t bsontype.Type
+		var err error
+		tpos, dst = appendElementHeader(dst, desc.name)
+		t, dst, err = desc.appendFn(EncodeContext{Registry: ec.Registry, MinSize: desc.minSize}, dst, rv)
+		if err != nil {
+			return dst, err
+		}
+		dst[tpos] = byte(t)
+	}
+	return bsoncore.AppendDocumentEnd(dst, idx)
+}
+
+// appendFuncFor returns the function used to append values of type t directly to a byte buffer, or nil if values of
+// type t must be written through encoder. Scalar types handled by the default encoders and structs made up entirely
+// of such types can be appended.
+func (sc *StructCodec) appendFuncFor(r *Registry, t reflect.Type, encoder ValueEncoder) appendFunc {
+	switch enc := encoder.(type) {
+	case valueAppender:
+		return enc.appendValue
+	case *StructCodec:
+		if t.Kind() != reflect.Struct {
+			return nil
+		}
+		sd, err := enc.describeStruct(r, t)
+		if err != nil || !sd.appendable {
+			return nil
+		}
+		return func(ec EncodeContext, dst []byte, val reflect.Value) (bsontype.Type, []byte, error) {
+			if !val.IsValid() || val.Kind() != reflect.Struct {
+				return 0, dst, ValueEncoderError{Name: "StructCodec.EncodeValue", Kinds: []reflect.Kind{reflect.Struct}, Received: val}
+			}
+			dst, err := enc.appendDocument(ec, dst, sd, val)
+			return bsontype.EmbeddedDocument, dst, err
+		}
+	}
+	return nil
+}
+
+// decodeDocument decodes doc into val using the precompiled field setters in sd. It must only be called with
+// descriptions where decodable is true. A document that is not well formed is decoded with decodeElements instead,
+// so that the error returned is the one a ValueReader reports.
+func (sc *StructCodec) decodeDocument(dc DecodeContext, sd *structDescription, doc []byte, val reflect.Value) error {
+	length, rem, ok := bsoncore.ReadLength(doc)
+	if !ok || int(length) != len(doc) || len(rem) == 0 || rem[len(rem)-1] != 0x00 {
+		return sc.decodeElements(dc, sd, bsonrw.NewBSONDocumentReader(doc), val)
+	}
+	rem = rem[:len(rem)-1]
+
+	if sc.DecodeZeroStruct {
+		val.Set(reflect.Zero(val.Type()))
+	}
+
+	var next int
+	for len(rem) > 0 {
+		t, key, data, elemRem, ok := readElementBytes(rem)
+		if !ok {
+			return sc.decodeElements(dc, sd, bsonrw.NewBSONDocumentReader(doc), val)
+		}
+		rem = elemRem
+
+		// The conversions of key to string in the comparison and the map index expressions do not allocate.
+		var fd fieldDescription
+		exists := next < len(sd.fl) && sd.fl[next].name == string(key)
+		if exists {
+			fd = sd.fl[next]
+		} else {
+			fd, exists = sd.fm[string(key)]
+		}
+		if !exists {
+			fd, exists = sd.fm[strings.ToLower(string(key))]
+		}
+		if !exists {
+			continue
+		}
+		next = fd.pos + 1
+
+		field := val.Field(fd.idx)
+		if !field.CanSet() {
+			return fmt.Errorf("cannot decode element '%s' into field %v; it is not settable", key, field)
+		}
+
+		dctx := DecodeContext{Registry: dc.Registry, Truncate: fd.truncate || dc.Truncate}
+		handled, err := fd.decodeFn(dctx, t, data, field)
+		if err != nil {
+			return err
+		}
+		if handled {
+			continue
+		}
+		err = fd.decoder.DecodeValue(dctx, bsonrw.NewBSONValueReader(t, data), field)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readElementBytes reads the type, key and value bytes of the element at the start of src.
+func readElementBytes(src []byte) (bsontype.Type, []byte, []byte, []byte, bool) {
+	t, rem, ok := bsoncore.ReadType(src)
+	if !ok {
+		return 0, nil, nil, src, false
+	}
+	key, rem, ok := bsoncore.ReadKeyBytes(rem)
+	if !ok {
+		return 0, nil, nil, src, false
+	}
+	v, rem, ok := bsoncore.ReadValue(rem, t)
+	if !ok {
+		return 0, nil, nil, src, false
+	}
+	return t, key, v.Data, rem, true
+}
+
+// decodeFuncFor returns the function used to decode values of type t directly from their bytes, or nil if values of
+// type t must be read through decoder. Scalar types handled by the default decoders and structs made up entirely of
+// such types can be decoded from bytes.
+func (sc *StructCodec) decodeFuncFor(r *Registry, t reflect.Type, decoder ValueDecoder) decodeFunc {
+	switch dec := decoder.(type) {
+	case valueSetter:
+		return dec.setValue
+	case *StructCodec:
+		if t.Kind() != reflect.Struct {
+			return nil
+		}
+		sd, err := dec.describeStruct(r, t)
+		if err != nil || !sd.decodable {
+			return nil
+		}
+		return func(dc DecodeContext, bt bsontype.Type, data []byte, val reflect.Value) (bool, error) {
+			if bt != bsontype.EmbeddedDocument || !val.CanSet() || val.Type() != t {
+				return false, nil
+			}
+			return true, dec.decodeDocument(dc, sd, data, val)
+		}
+	}
+	return nil
+}
+
 type structDescription struct {
 	fm        map[string]fieldDescription
 	fl        []fieldDescription
 	inlineMap int
 	inline    bool
+
+	// appendable is true if every field can be appended directly to a byte buffer, which lets the description be
+	// used by appendDocument.
+	appendable bool
+
+	// decodable is true if every field can be set directly from the bytes of a document, which lets the description
+	// be used by decodeDocument.
+	decodable bool
 }
 
 type fieldDescription struct {
@@ -351,6 +562,9 @@ type fieldDescription struct {
 	inline    []int
 	encoder   ValueEncoder
 	decoder   ValueDecoder
+	appendFn  appendFunc
+	decodeFn  decodeFunc
+	pos       int // position in structDescription.fl
 }
 
 func (sc *StructCodec) describeStruct(r *Registry, t reflect.Type) (*structDescription, error) {
@@ -388,6 +602,12 @@ func (sc *StructCodec) describeStruct(r *Registry, t reflect.Type) (*structDescr
 		}
 
 		description := fieldDescription{idx: i, encoder: encoder, decoder: decoder}
+		if encoder != nil {
+			description.appendFn = sc.appendFuncFor(r, sfType, encoder)
+		}
+		if decoder != nil {
+			description.decodeFn = sc.decodeFuncFor(r, sfType, decoder)
+		}
 
 		stags, err := sc.parser.ParseStructTags(sf)
 		if err != nil {
@@ -432,6 +652,7 @@ func (sc *StructCodec) describeStruct(r *Registry, t reflect.Type) (*structDescr
 					} else {
 						fd.inline = append([]int{i}, fd.inline...)
 					}
+					fd.pos = len(sd.fl)
 					sd.fm[fd.name] = fd
 					sd.fl = append(sd.fl, fd)
 				}
@@ -445,10 +666,26 @@ func (sc *StructCodec) describeStruct(r *Registry, t reflect.Type) (*structDescr
 			return nil, fmt.Errorf("struct %s) duplicated key %s", t.String(), description.name)
 		}
 
+		description.pos = len(sd.fl)
 		sd.fm[description.name] = description
 		sd.fl = append(sd.fl, description)
 	}
 
+	sd.appendable = sd.inlineMap < 0
+	for _, fd := range sd.fl {
+		if fd.inline != nil || fd.appendFn == nil {
+			sd.appendable = false
+			break
+		}
+	}
+	sd.decodable = !sd.inline
+	for _, fd := range sd.fl {
+		if fd.inline != nil || fd.decodeFn == nil {
+			sd.decodable = false
+			break
+		}
+	}
+
 	sc.l.Lock()
 	sc.cache[t] = sd
 	sc.l.Unlock()
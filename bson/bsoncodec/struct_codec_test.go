@@ -7,10 +7,15 @@
 package bsoncodec
 
 import (
+	"math"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
 func TestZeoerInterfaceUsedByDecoder(t *testing.T) {
@@ -45,3 +50,279 @@ func TestZeoerInterfaceUsedByDecoder(t *testing.T) {
 	var zp *zeroTest
 	assert.True(t, enc.isZero(zp))
 }
+
+type appendInner struct {
+	S string
+	T time.Time
+}
+
+type appendCustomInt int64
+
+// appendFlat has only fields that can be appended, so it is encoded as a whole by appendDocument.
+type appendFlat struct {
+	B      bool
+	I      int
+	I8     int8
+	I64Min int64 `bson:",minsize"`
+	U64    uint64
+	F32    float32
+	S      string
+	Empty  string `bson:",omitempty"`
+	T      time.Time
+	Inner  appendInner
+}
+
+type appendTest struct {
+	B       bool
+	I       int
+	I8      int8
+	I64     int64
+	I64Min  int64 `bson:",minsize"`
+	U16     uint16
+	U64     uint64
+	U64Min  uint64 `bson:",minsize"`
+	F32     float32
+	F64     float64
+	S       string
+	Empty   string `bson:",omitempty"`
+	T       time.Time
+	Inner   appendInner
+	Custom  appendCustomInt
+	Pointer *appendInner
+}
+
+func TestStructCodecAppend(t *testing.T) {
+	customEncoder := ValueEncoderFunc(func(_ EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+		return vw.WriteString("custom")
+	})
+	// A slow registry uses encoders that don't implement valueAppender, so every field is written through its encoder.
+	// Each registry gets its own StructCodec so cached descriptions aren't shared between them.
+	newRegistry := func(slow bool) *Registry {
+		codec, err := NewStructCodec(DefaultStructTagParser)
+		assert.NoError(t, err)
+		rb := NewRegistryBuilder()
+		defaultValueEncoders.RegisterDefaultEncoders(rb)
+		defaultValueDecoders.RegisterDefaultDecoders(rb)
+		rb.RegisterDefaultEncoder(reflect.Struct, codec)
+		rb.RegisterEncoder(reflect.TypeOf(appendCustomInt(0)), customEncoder)
+		if slow {
+			for _, kind := range []reflect.Kind{reflect.Int, reflect.Int8, reflect.Int64} {
+				rb.RegisterDefaultEncoder(kind, ValueEncoderFunc(defaultValueEncoders.IntEncodeValue))
+			}
+			for _, kind := range []reflect.Kind{reflect.Uint16, reflect.Uint64} {
+				rb.RegisterDefaultEncoder(kind, ValueEncoderFunc(defaultValueEncoders.UintEncodeValue))
+			}
+			for _, kind := range []reflect.Kind{reflect.Float32, reflect.Float64} {
+				rb.RegisterDefaultEncoder(kind, ValueEncoderFunc(defaultValueEncoders.FloatEncodeValue))
+			}
+			rb.RegisterDefaultEncoder(reflect.Bool, ValueEncoderFunc(defaultValueEncoders.BooleanEncodeValue))
+			rb.RegisterDefaultEncoder(reflect.String, ValueEncoderFunc(defaultValueEncoders.StringEncodeValue))
+			rb.RegisterEncoder(tTime, ValueEncoderFunc(defaultTimeCodec.EncodeValue))
+		}
+		return rb.Build()
+	}
+	encode := func(t *testing.T, reg *Registry, v interface{}) ([]byte, error) {
+		t.Helper()
+
+		enc, err := reg.LookupEncoder(reflect.TypeOf(v))
+		assert.NoError(t, err)
+		var sw bsonrw.SliceWriter
+		vw, err := bsonrw.NewBSONValueWriter(&sw)
+		assert.NoError(t, err)
+		err = enc.EncodeValue(EncodeContext{Registry: reg}, vw, reflect.ValueOf(v))
+		return sw, err
+	}
+
+	t.Run("matches encoders", func(t *testing.T) {
+		v := appendTest{
+			B: true, I: math.MaxInt32 + 1, I8: -8, I64: 64, I64Min: 32, U16: 16, U64: 64, U64Min: 32, F32: 1.5, F64: 2.5,
+			S: "foo", T: time.Unix(1577836800, 123e6), Inner: appendInner{S: "bar", T: time.Unix(1, 0)}, Custom: 42,
+			Pointer: &appendInner{S: "baz"},
+		}
+		want, err := encode(t, newRegistry(true), v)
+		assert.NoError(t, err)
+		got, err := encode(t, newRegistry(false), v)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+	t.Run("matches encoders for appendable structs", func(t *testing.T) {
+		v := appendFlat{
+			B: true, I: math.MaxInt32 + 1, I8: -8, I64Min: 32, U64: 64, F32: 1.5, S: "foo", T: time.Unix(1577836800, 123e6),
+			Inner: appendInner{S: "bar", T: time.Unix(1, 0)},
+		}
+		want, err := encode(t, newRegistry(true), v)
+		assert.NoError(t, err)
+		got, err := encode(t, newRegistry(false), v)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+	t.Run("uint overflow", func(t *testing.T) {
+		_, err := encode(t, newRegistry(false), appendTest{U64: math.MaxUint64})
+		assert.Error(t, err)
+		_, err = encode(t, newRegistry(false), appendFlat{U64: math.MaxUint64})
+		assert.Error(t, err)
+	})
+	t.Run("appendable descriptions", func(t *testing.T) {
+		reg := newRegistry(false)
+		codec := reg.kindEncoders[reflect.Struct].(*StructCodec)
+		sd, err := codec.describeStruct(reg, reflect.TypeOf(appendInner{}))
+		assert.NoError(t, err)
+		assert.True(t, sd.appendable)
+		sd, err = codec.describeStruct(reg, reflect.TypeOf(appendFlat{}))
+		assert.NoError(t, err)
+		assert.True(t, sd.appendable)
+		sd, err = codec.describeStruct(reg, reflect.TypeOf(appendTest{}))
+		assert.NoError(t, err)
+		assert.False(t, sd.appendable)
+		assert.NotNil(t, sd.fm["inner"].appendFn)
+		assert.Nil(t, sd.fm["custom"].appendFn)
+	})
+}
+
+type decodeInner struct {
+	S string
+	T time.Time
+}
+
+type decodeTest struct {
+	B     bool
+	I     int
+	I8    int8
+	I64   int64
+	U16   uint16
+	U64   uint64
+	F32   float32
+	F64   float64
+	S     string
+	T     time.Time
+	Inner decodeInner
+}
+
+func TestStructCodecDecodeBytes(t *testing.T) {
+	// A slow registry uses decoders that don't implement valueSetter, so every field is read through its decoder.
+	// Each registry gets its own StructCodec so cached descriptions aren't shared between them.
+	newRegistry := func(slow bool) *Registry {
+		codec, err := NewStructCodec(DefaultStructTagParser)
+		assert.NoError(t, err)
+		rb := NewRegistryBuilder()
+		defaultValueEncoders.RegisterDefaultEncoders(rb)
+		defaultValueDecoders.RegisterDefaultDecoders(rb)
+		rb.RegisterDefaultDecoder(reflect.Struct, codec)
+		if slow {
+			for _, kind := range []reflect.Kind{reflect.Int, reflect.Int8, reflect.Int64} {
+				rb.RegisterDefaultDecoder(kind, ValueDecoderFunc(defaultValueDecoders.IntDecodeValue))
+			}
+			for _, kind := range []reflect.Kind{reflect.Uint16, reflect.Uint64} {
+				rb.RegisterDefaultDecoder(kind, ValueDecoderFunc(defaultValueDecoders.UintDecodeValue))
+			}
+			for _, kind := range []reflect.Kind{reflect.Float32, reflect.Float64} {
+				rb.RegisterDefaultDecoder(kind, ValueDecoderFunc(defaultValueDecoders.FloatDecodeValue))
+			}
+			rb.RegisterDefaultDecoder(reflect.Bool, ValueDecoderFunc(defaultValueDecoders.BooleanDecodeValue))
+			rb.RegisterDefaultDecoder(reflect.String, ValueDecoderFunc(defaultStringCodec.DecodeValue))
+			rb.RegisterDecoder(tTime, ValueDecoderFunc(defaultTimeCodec.DecodeValue))
+		}
+		return rb.Build()
+	}
+	decode := func(t *testing.T, reg *Registry, doc []byte) (decodeTest, error) {
+		t.Helper()
+
+		var v decodeTest
+		dec, err := reg.LookupDecoder(reflect.TypeOf(v))
+		assert.NoError(t, err)
+		err = dec.DecodeValue(DecodeContext{Registry: reg}, bsonrw.NewBSONDocumentReader(doc), reflect.ValueOf(&v).Elem())
+		return v, err
+	}
+	inner := bsoncore.BuildDocument(nil,
+		bsoncore.AppendStringElement(nil, "s", "bar"),
+		bsoncore.AppendDateTimeElement(nil, "t", 1000),
+	)
+
+	testCases := []struct {
+		name string
+		doc  []byte
+	}{
+		{
+			"all fields",
+			bsoncore.BuildDocument(nil,
+				bsoncore.AppendBooleanElement(nil, "b", true),
+				bsoncore.AppendInt64Element(nil, "i", math.MaxInt32+1),
+				bsoncore.AppendInt32Element(nil, "i8", -8),
+				bsoncore.AppendInt64Element(nil, "i64", 64),
+				bsoncore.AppendInt32Element(nil, "u16", 16),
+				bsoncore.AppendInt64Element(nil, "u64", 64),
+				bsoncore.AppendDoubleElement(nil, "f32", 1.5),
+				bsoncore.AppendDoubleElement(nil, "f64", 2.5),
+				bsoncore.AppendStringElement(nil, "s", "foo"),
+				bsoncore.AppendDateTimeElement(nil, "t", 1577836800123),
+				bsoncore.AppendDocumentElement(nil, "inner", inner),
+			),
+		},
+		{
+			"out of order, unknown and uppercase keys",
+			bsoncore.BuildDocument(nil,
+				bsoncore.AppendDocumentElement(nil, "inner", inner),
+				bsoncore.AppendStringElement(nil, "unknown", "skipped"),
+				bsoncore.AppendStringElement(nil, "S", "foo"),
+				bsoncore.AppendBooleanElement(nil, "b", true),
+			),
+		},
+		{
+			"conversions handled by the decoders",
+			bsoncore.BuildDocument(nil,
+				bsoncore.AppendInt32Element(nil, "b", 1),
+				bsoncore.AppendDoubleElement(nil, "i", 3),
+				bsoncore.AppendBooleanElement(nil, "u64", true),
+				bsoncore.AppendInt32Element(nil, "f64", 7),
+				bsoncore.AppendSymbolElement(nil, "s", "sym"),
+				bsoncore.AppendInt64Element(nil, "t", 1000),
+			),
+		},
+		{"int overflow", bsoncore.BuildDocument(nil, bsoncore.AppendInt32Element(nil, "i8", 300))},
+		{"uint overflow", bsoncore.BuildDocument(nil, bsoncore.AppendInt32Element(nil, "u16", -1))},
+		{"float32 truncation", bsoncore.BuildDocument(nil, bsoncore.AppendDoubleElement(nil, "f32", 0.1))},
+		{"double truncation", bsoncore.BuildDocument(nil, bsoncore.AppendDoubleElement(nil, "i", 1.5))},
+		{"wrong type", bsoncore.BuildDocument(nil, bsoncore.AppendNullElement(nil, "s"))},
+		{"wrong type for nested struct", bsoncore.BuildDocument(nil, bsoncore.AppendStringElement(nil, "inner", "foo"))},
+		{"null nested struct", bsoncore.BuildDocument(nil, bsoncore.AppendNullElement(nil, "inner"))},
+		{"undefined nested struct", bsoncore.BuildDocument(nil, bsoncore.AppendUndefinedElement(nil, "inner"))},
+		{"invalid boolean", bsoncore.BuildDocument(nil, []byte{byte(bsontype.Boolean), 'b', 0x00, 0x02})},
+		{"invalid nested document", bsoncore.BuildDocument(nil, bsoncore.AppendDocumentElement(nil, "inner", inner[:10]))},
+		{"invalid string", bsoncore.BuildDocument(nil, []byte{byte(bsontype.String), 's', 0x00, 0x02, 0x00, 0x00, 0x00, 'a', 'b'})},
+		{"trailing bytes", append(bsoncore.BuildDocument(nil, bsoncore.AppendStringElement(nil, "s", "foo")), 0x00)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			want, wantErr := decode(t, newRegistry(true), tc.doc)
+			got, gotErr := decode(t, newRegistry(false), tc.doc)
+			assert.Equal(t, wantErr, gotErr)
+			assert.Equal(t, want, got)
+		})
+	}
+
+	t.Run("null zeroes nested struct", func(t *testing.T) {
+		doc := bsoncore.BuildDocument(nil, bsoncore.AppendNullElement(nil, "inner"))
+		for _, slow := range []bool{true, false} {
+			reg := newRegistry(slow)
+			v := decodeTest{}
+			v.Inner.S = "foo"
+			dec, err := reg.LookupDecoder(reflect.TypeOf(v))
+			assert.NoError(t, err)
+			err = dec.DecodeValue(DecodeContext{Registry: reg}, bsonrw.NewBSONDocumentReader(doc), reflect.ValueOf(&v).Elem())
+			assert.NoError(t, err)
+			assert.Equal(t, decodeTest{}, v)
+		}
+	})
+	t.Run("decodable descriptions", func(t *testing.T) {
+		reg := newRegistry(false)
+		codec := reg.kindDecoders[reflect.Struct].(*StructCodec)
+		sd, err := codec.describeStruct(reg, reflect.TypeOf(decodeTest{}))
+		assert.NoError(t, err)
+		assert.True(t, sd.decodable)
+		sd, err = codec.describeStruct(reg, reflect.TypeOf(appendTest{}))
+		assert.NoError(t, err)
+		assert.False(t, sd.decodable)
+		assert.NotNil(t, sd.fm["inner"].decodeFn)
+		assert.Nil(t, sd.fm["pointer"].decodeFn)
+	})
+}
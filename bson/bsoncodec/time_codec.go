@@ -14,6 +14,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/bsonoptions"
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
 const (
@@ -88,6 +89,21 @@ func (tc *TimeCodec) DecodeValue(dc DecodeContext, vr bsonrw.ValueReader, val re
 	return nil
 }
 
+func (tc *TimeCodec) setValue(_ DecodeContext, t bsontype.Type, data []byte, val reflect.Value) (bool, error) {
+	if !val.CanSet() || val.Type() != tTime {
+		return false, nil
+	}
+	timeVal, ok := readDateTimeValue(t, data)
+	if !ok {
+		return false, nil
+	}
+	if !tc.UseLocalTimeZone {
+		timeVal = timeVal.UTC()
+	}
+	val.Set(reflect.ValueOf(timeVal))
+	return true, nil
+}
+
 // EncodeValue is the ValueEncoderFunc for time.TIme.
 func (tc *TimeCodec) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
 	if !val.IsValid() || val.Type() != tTime {
@@ -96,3 +112,11 @@ func (tc *TimeCodec) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val re
 	tt := val.Interface().(time.Time)
 	return vw.WriteDateTime(tt.Unix()*1000 + int64(tt.Nanosecond()/1e6))
 }
+
+func (tc *TimeCodec) appendValue(_ EncodeContext, dst []byte, val reflect.Value) (bsontype.Type, []byte, error) {
+	if !val.IsValid() || val.Type() != tTime {
+		return 0, dst, ValueEncoderError{Name: "TimeEncodeValue", Types: []reflect.Type{tTime}, Received: val}
+	}
+	tt := val.Interface().(time.Time)
+	return bsontype.DateTime, bsoncore.AppendDateTime(dst, tt.Unix()*1000+int64(tt.Nanosecond()/1e6)), nil
+}
@@ -0,0 +1,112 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// appendFunc appends the BSON encoding of val to dst and returns the BSON type of the appended value. It produces
+// exactly the bytes the corresponding ValueEncoder would write, without going through a ValueWriter.
+type appendFunc func(ec EncodeContext, dst []byte, val reflect.Value) (bsontype.Type, []byte, error)
+
+// valueAppender is implemented by the default encoders for common scalar types. The StructCodec uses it to write
+// fields of those types directly into a byte buffer. Encoders registered by users never implement it, so they are
+// always called through EncodeValue.
+type valueAppender interface {
+	appendValue(ec EncodeContext, dst []byte, val reflect.Value) (bsontype.Type, []byte, error)
+}
+
+// appendingEncoder is a ValueEncoderFunc that also implements valueAppender.
+type appendingEncoder struct {
+	ValueEncoderFunc
+	appendFn appendFunc
+}
+
+var _ ValueEncoder = appendingEncoder{}
+var _ valueAppender = appendingEncoder{}
+
+func (ae appendingEncoder) appendValue(ec EncodeContext, dst []byte, val reflect.Value) (bsontype.Type, []byte, error) {
+	return ae.appendFn(ec, dst, val)
+}
+
+func appendBooleanValue(_ EncodeContext, dst []byte, val reflect.Value) (bsontype.Type, []byte, error) {
+	if !val.IsValid() || val.Kind() != reflect.Bool {
+		return 0, dst, ValueEncoderError{Name: "BooleanEncodeValue", Kinds: []reflect.Kind{reflect.Bool}, Received: val}
+	}
+	return bsontype.Boolean, bsoncore.AppendBoolean(dst, val.Bool()), nil
+}
+
+func appendIntValue(ec EncodeContext, dst []byte, val reflect.Value) (bsontype.Type, []byte, error) {
+	switch val.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return bsontype.Int32, bsoncore.AppendInt32(dst, int32(val.Int())), nil
+	case reflect.Int:
+		i64 := val.Int()
+		if fitsIn32Bits(i64) {
+			return bsontype.Int32, bsoncore.AppendInt32(dst, int32(i64)), nil
+		}
+		return bsontype.Int64, bsoncore.AppendInt64(dst, i64), nil
+	case reflect.Int64:
+		i64 := val.Int()
+		if ec.MinSize && fitsIn32Bits(i64) {
+			return bsontype.Int32, bsoncore.AppendInt32(dst, int32(i64)), nil
+		}
+		return bsontype.Int64, bsoncore.AppendInt64(dst, i64), nil
+	}
+
+	return 0, dst, ValueEncoderError{
+		Name:     "IntEncodeValue",
+		Kinds:    []reflect.Kind{reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int},
+		Received: val,
+	}
+}
+
+func appendUintValue(ec EncodeContext, dst []byte, val reflect.Value) (bsontype.Type, []byte, error) {
+	switch val.Kind() {
+	case reflect.Uint8, reflect.Uint16:
+		return bsontype.Int32, bsoncore.AppendInt32(dst, int32(val.Uint())), nil
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		u64 := val.Uint()
+		if ec.MinSize && u64 <= math.MaxInt32 {
+			return bsontype.Int32, bsoncore.AppendInt32(dst, int32(u64)), nil
+		}
+		if u64 > math.MaxInt64 {
+			return 0, dst, fmt.Errorf("%d overflows int64", u64)
+		}
+		return bsontype.Int64, bsoncore.AppendInt64(dst, int64(u64)), nil
+	}
+
+	return 0, dst, ValueEncoderError{
+		Name:     "UintEncodeValue",
+		Kinds:    []reflect.Kind{reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint},
+		Received: val,
+	}
+}
+
+func appendFloatValue(_ EncodeContext, dst []byte, val reflect.Value) (bsontype.Type, []byte, error) {
+	switch val.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return bsontype.Double, bsoncore.AppendDouble(dst, val.Float()), nil
+	}
+
+	return 0, dst, ValueEncoderError{Name: "FloatEncodeValue", Kinds: []reflect.Kind{reflect.Float32, reflect.Float64}, Received: val}
+}
+
+// appendElementHeader appends a placeholder type byte and key to dst. The type byte at the returned index must be
+// set once the type of the element's value is known.
+func appendElementHeader(dst []byte, key string) (int, []byte) {
+	idx := len(dst)
+	dst = append(dst, 0x00)
+	dst = append(dst, key...)
+	return idx, append(dst, 0x00)
+}
@@ -0,0 +1,220 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+	"unicode"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// decodeFunc sets val from data, the bytes of a BSON value of type t. It returns false without modifying val if it
+// does not handle that combination of type and bytes, in which case the caller must decode the value through the
+// ValueDecoder instead. Values it does handle are decoded exactly as the corresponding ValueDecoder would decode them.
+type decodeFunc func(dc DecodeContext, t bsontype.Type, data []byte, val reflect.Value) (bool, error)
+
+// valueSetter is implemented by the default decoders for common scalar types. The StructCodec uses it to set fields
+// of those types directly from the bytes of a document. Decoders registered by users never implement it, so they are
+// always called through DecodeValue.
+type valueSetter interface {
+	setValue(dc DecodeContext, t bsontype.Type, data []byte, val reflect.Value) (bool, error)
+}
+
+// settingDecoder is a ValueDecoderFunc that also implements valueSetter.
+type settingDecoder struct {
+	ValueDecoderFunc
+	setFn decodeFunc
+}
+
+var _ ValueDecoder = settingDecoder{}
+var _ valueSetter = settingDecoder{}
+
+func (sd settingDecoder) setValue(dc DecodeContext, t bsontype.Type, data []byte, val reflect.Value) (bool, error) {
+	return sd.setFn(dc, t, data, val)
+}
+
+func setBooleanValue(_ DecodeContext, t bsontype.Type, data []byte, val reflect.Value) (bool, error) {
+	if t != bsontype.Boolean || len(data) != 1 || data[0] > 1 || !val.CanSet() || val.Kind() != reflect.Bool {
+		return false, nil
+	}
+	val.SetBool(data[0] == 1)
+	return true, nil
+}
+
+func setIntValue(_ DecodeContext, t bsontype.Type, data []byte, val reflect.Value) (bool, error) {
+	i64, ok := readIntegerValue(t, data)
+	if !ok {
+		return false, nil
+	}
+	return true, setInt(i64, val)
+}
+
+func setUintValue(_ DecodeContext, t bsontype.Type, data []byte, val reflect.Value) (bool, error) {
+	i64, ok := readIntegerValue(t, data)
+	if !ok {
+		return false, nil
+	}
+	return true, setUint(i64, val)
+}
+
+func setFloatValue(dc DecodeContext, t bsontype.Type, data []byte, val reflect.Value) (bool, error) {
+	var f float64
+	switch {
+	case t == bsontype.Double && len(data) == 8:
+		f, _, _ = bsoncore.ReadDouble(data)
+	default:
+		i64, ok := readIntegerValue(t, data)
+		if !ok {
+			return false, nil
+		}
+		f = float64(i64)
+	}
+	return true, setFloat(dc, f, val)
+}
+
+// readIntegerValue reads data as an int32 or int64 value.
+func readIntegerValue(t bsontype.Type, data []byte) (int64, bool) {
+	switch {
+	case t == bsontype.Int32 && len(data) == 4:
+		i32, _, _ := bsoncore.ReadInt32(data)
+		return int64(i32), true
+	case t == bsontype.Int64 && len(data) == 8:
+		i64, _, _ := bsoncore.ReadInt64(data)
+		return i64, true
+	}
+	return 0, false
+}
+
+// readStringValue reads data as a string value. Strings that the ValueReader would reject are not handled.
+func readStringValue(t bsontype.Type, data []byte) (string, bool) {
+	if t != bsontype.String || len(data) < 5 {
+		return "", false
+	}
+	length, rem, _ := bsoncore.ReadLength(data)
+	if int(length) != len(rem) || rem[length-1] != 0x00 || (length == 2 && rem[0] > unicode.MaxASCII) {
+		return "", false
+	}
+	return string(rem[:length-1]), true
+}
+
+// readDateTimeValue reads data as a datetime value.
+func readDateTimeValue(t bsontype.Type, data []byte) (time.Time, bool) {
+	if t != bsontype.DateTime || len(data) != 8 {
+		return time.Time{}, false
+	}
+	dt, _, _ := bsoncore.ReadDateTime(data)
+	return time.Unix(dt/1000, dt%1000*1000000), true
+}
+
+// setInt sets val, which must be a settable int value, to i64 if it fits.
+func setInt(i64 int64, val reflect.Value) error {
+	if !val.CanSet() {
+		return ValueDecoderError{
+			Name:     "IntDecodeValue",
+			Kinds:    []reflect.Kind{reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int},
+			Received: val,
+		}
+	}
+
+	switch val.Kind() {
+	case reflect.Int8:
+		if i64 < math.MinInt8 || i64 > math.MaxInt8 {
+			return fmt.Errorf("%d overflows int8", i64)
+		}
+	case reflect.Int16:
+		if i64 < math.MinInt16 || i64 > math.MaxInt16 {
+			return fmt.Errorf("%d overflows int16", i64)
+		}
+	case reflect.Int32:
+		if i64 < math.MinInt32 || i64 > math.MaxInt32 {
+			return fmt.Errorf("%d overflows int32", i64)
+		}
+	case reflect.Int64:
+	case reflect.Int:
+		if int64(int(i64)) != i64 { // Can we fit this inside of an int
+			return fmt.Errorf("%d overflows int", i64)
+		}
+	default:
+		return ValueDecoderError{
+			Name:     "IntDecodeValue",
+			Kinds:    []reflect.Kind{reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int},
+			Received: val,
+		}
+	}
+
+	val.SetInt(i64)
+	return nil
+}
+
+// setUint sets val, which must be a settable uint value, to i64 if it fits.
+func setUint(i64 int64, val reflect.Value) error {
+	if !val.CanSet() {
+		return ValueDecoderError{
+			Name:     "UintDecodeValue",
+			Kinds:    []reflect.Kind{reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint},
+			Received: val,
+		}
+	}
+
+	switch val.Kind() {
+	case reflect.Uint8:
+		if i64 < 0 || i64 > math.MaxUint8 {
+			return fmt.Errorf("%d overflows uint8", i64)
+		}
+	case reflect.Uint16:
+		if i64 < 0 || i64 > math.MaxUint16 {
+			return fmt.Errorf("%d overflows uint16", i64)
+		}
+	case reflect.Uint32:
+		if i64 < 0 || i64 > math.MaxUint32 {
+			return fmt.Errorf("%d overflows uint32", i64)
+		}
+	case reflect.Uint64:
+		if i64 < 0 {
+			return fmt.Errorf("%d overflows uint64", i64)
+		}
+	case reflect.Uint:
+		if i64 < 0 || int64(uint(i64)) != i64 { // Can we fit this inside of an uint
+			return fmt.Errorf("%d overflows uint", i64)
+		}
+	default:
+		return ValueDecoderError{
+			Name:     "UintDecodeValue",
+			Kinds:    []reflect.Kind{reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint},
+			Received: val,
+		}
+	}
+
+	val.SetUint(uint64(i64))
+	return nil
+}
+
+// setFloat sets val, which must be a settable float value, to f if it can be represented.
+func setFloat(dc DecodeContext, f float64, val reflect.Value) error {
+	if !val.CanSet() {
+		return ValueDecoderError{Name: "FloatDecodeValue", Kinds: []reflect.Kind{reflect.Float32, reflect.Float64}, Received: val}
+	}
+
+	switch val.Kind() {
+	case reflect.Float32:
+		if !dc.Truncate && float64(float32(f)) != f {
+			return errors.New("FloatDecodeValue can only convert float64 to float32 when truncation is allowed")
+		}
+	case reflect.Float64:
+	default:
+		return ValueDecoderError{Name: "FloatDecodeValue", Kinds: []reflect.Kind{reflect.Float32, reflect.Float64}, Received: val}
+	}
+
+	val.SetFloat(f)
+	return nil
+}
@@ -0,0 +1,41 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsonoptions
+
+// Decimal128CodecOptions represents all possible options for encoding and decoding a decimal type as a BSON
+// decimal128.
+type Decimal128CodecOptions struct {
+	DecodeNumbers *bool // Specifies if BSON int32, int64 and double values can be decoded. Defaults to false.
+}
+
+// Decimal128Codec creates a new *Decimal128CodecOptions
+func Decimal128Codec() *Decimal128CodecOptions {
+	return &Decimal128CodecOptions{}
+}
+
+// SetDecodeNumbers specifies if BSON int32, int64 and double values can be decoded into the decimal type. A double is
+// converted to the decimal with the fewest digits that has the same float64 value. Defaults to false.
+func (d *Decimal128CodecOptions) SetDecodeNumbers(b bool) *Decimal128CodecOptions {
+	d.DecodeNumbers = &b
+	return d
+}
+
+// MergeDecimal128CodecOptions combines the given *Decimal128CodecOptions into a single *Decimal128CodecOptions in a
+// last one wins fashion.
+func MergeDecimal128CodecOptions(opts ...*Decimal128CodecOptions) *Decimal128CodecOptions {
+	d := Decimal128Codec()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.DecodeNumbers != nil {
+			d.DecodeNumbers = opt.DecodeNumbers
+		}
+	}
+
+	return d
+}
@@ -0,0 +1,100 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsonoptions
+
+// ExtJSONDateFormat specifies how BSON datetimes are rendered in Extended JSON.
+type ExtJSONDateFormat uint8
+
+// These constants are the valid ExtJSONDateFormat values.
+const (
+	// ExtJSONDateFormatDefault renders datetimes as the Extended JSON specification requires: as a $numberLong in
+	// canonical mode, and as an ISO-8601 string in relaxed mode when the year is between 1970 and 9999.
+	ExtJSONDateFormatDefault ExtJSONDateFormat = iota
+	// ExtJSONDateFormatISO renders datetimes as ISO-8601 strings whenever the year is between 0 and 9999, including
+	// years before 1970 in relaxed mode. Datetimes outside of that range, and all datetimes in canonical mode, are
+	// rendered as a $numberLong because canonical Extended JSON does not allow the string form.
+	ExtJSONDateFormatISO
+	// ExtJSONDateFormatNumberLong always renders datetimes as the number of milliseconds since the Unix epoch.
+	ExtJSONDateFormatNumberLong
+)
+
+// ExtJSONOptions represents all possible options for writing Extended JSON.
+type ExtJSONOptions struct {
+	Canonical  *bool              // Specifies if canonical Extended JSON is written. Defaults to false.
+	EscapeHTML *bool              // Specifies if <, > and & are escaped in strings. Defaults to false.
+	Indent     *string            // Specifies the string used for each level of indentation. Defaults to "".
+	ShellMode  *bool              // Specifies if mongo shell syntax is written. Defaults to false.
+	DateFormat *ExtJSONDateFormat // Specifies how datetimes are rendered. Defaults to ExtJSONDateFormatDefault.
+}
+
+// ExtJSON creates a new *ExtJSONOptions
+func ExtJSON() *ExtJSONOptions {
+	return &ExtJSONOptions{}
+}
+
+// SetCanonical specifies if canonical Extended JSON is written. If false, relaxed Extended JSON is written. Defaults
+// to false.
+func (e *ExtJSONOptions) SetCanonical(b bool) *ExtJSONOptions {
+	e.Canonical = &b
+	return e
+}
+
+// SetEscapeHTML specifies if <, > and & are escaped in strings so the output is safe to embed in HTML. Defaults to
+// false.
+func (e *ExtJSONOptions) SetEscapeHTML(b bool) *ExtJSONOptions {
+	e.EscapeHTML = &b
+	return e
+}
+
+// SetIndent specifies the string used for each level of indentation. If it is not empty, every element of a
+// document or array is written on its own line. Defaults to "", which writes everything on a single line.
+func (e *ExtJSONOptions) SetIndent(indent string) *ExtJSONOptions {
+	e.Indent = &indent
+	return e
+}
+
+// SetShellMode specifies if values are written using the constructors of the legacy mongo shell, such as
+// ObjectId("..."), ISODate("...") and NumberLong(...), instead of Extended JSON wrapper objects. Types without a
+// shell constructor are written as relaxed Extended JSON. The output is not valid JSON, but it can be read by the
+// relaxed Extended JSON ValueReader. Defaults to false.
+func (e *ExtJSONOptions) SetShellMode(b bool) *ExtJSONOptions {
+	e.ShellMode = &b
+	return e
+}
+
+// SetDateFormat specifies how datetimes are rendered. Defaults to ExtJSONDateFormatDefault.
+func (e *ExtJSONOptions) SetDateFormat(f ExtJSONDateFormat) *ExtJSONOptions {
+	e.DateFormat = &f
+	return e
+}
+
+// MergeExtJSONOptions combines the given *ExtJSONOptions into a single *ExtJSONOptions in a last one wins fashion.
+func MergeExtJSONOptions(opts ...*ExtJSONOptions) *ExtJSONOptions {
+	e := ExtJSON()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Canonical != nil {
+			e.Canonical = opt.Canonical
+		}
+		if opt.EscapeHTML != nil {
+			e.EscapeHTML = opt.EscapeHTML
+		}
+		if opt.Indent != nil {
+			e.Indent = opt.Indent
+		}
+		if opt.ShellMode != nil {
+			e.ShellMode = opt.ShellMode
+		}
+		if opt.DateFormat != nil {
+			e.DateFormat = opt.DateFormat
+		}
+	}
+
+	return e
+}
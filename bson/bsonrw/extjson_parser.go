@@ -214,6 +214,12 @@ func (ejp *extJSONParser) readValue(t bsontype.Type) (*extJSONValue, error) {
 		return nil, ejp.err
 	}
 
+	if ejp.s == jpsSawValue {
+		if sv, ok := ejp.v.v.(*shellValue); ok {
+			return sv.v, nil
+		}
+	}
+
 	var v *extJSONValue
 
 	switch t {
@@ -465,6 +471,12 @@ func (ejp *extJSONParser) advanceState() {
 		return
 	}
 
+	if jt.t == jttShellValue && ejp.canonical {
+		ejp.err = fmt.Errorf("invalid JSON input; mongo shell value at position %d is not canonical extended JSON", jt.p)
+		ejp.s = jpsInvalidState
+		return
+	}
+
 	valid := ejp.validateToken(jt.t)
 	if !valid {
 		ejp.err = unexpectedTokenError(jt)
@@ -541,6 +553,7 @@ var jpsValidTransitionTokens = map[jsonParseState]map[jsonTokenType]bool{
 		jttString:      true,
 		jttBool:        true,
 		jttNull:        true,
+		jttShellValue:  true,
 		jttEOF:         true,
 	},
 	jpsSawBeginObject: {
@@ -563,6 +576,7 @@ var jpsValidTransitionTokens = map[jsonParseState]map[jsonTokenType]bool{
 		jttString:      true,
 		jttBool:        true,
 		jttNull:        true,
+		jttShellValue:  true,
 	},
 	jpsSawEndArray: {
 		jttEndObject: true,
@@ -579,6 +593,7 @@ var jpsValidTransitionTokens = map[jsonParseState]map[jsonTokenType]bool{
 		jttString:      true,
 		jttBool:        true,
 		jttNull:        true,
+		jttShellValue:  true,
 	},
 	jpsSawComma: {
 		jttBeginObject: true,
@@ -589,6 +604,7 @@ var jpsValidTransitionTokens = map[jsonParseState]map[jsonTokenType]bool{
 		jttString:      true,
 		jttBool:        true,
 		jttNull:        true,
+		jttShellValue:  true,
 	},
 	jpsSawKey: {
 		jttColon: true,
@@ -682,6 +698,9 @@ func extendJSONToken(jt *jsonToken) *extJSONValue {
 		t = bsontype.Boolean
 	case jttNull:
 		t = bsontype.Null
+	case jttShellValue:
+		sv := jt.v.(*shellValue)
+		return &extJSONValue{t: sv.t, v: sv}
 	default:
 		return nil
 	}
@@ -721,6 +740,8 @@ func unexpectedTokenError(jt *jsonToken) error {
 		return fmt.Errorf("invalid JSON input; unexpected null literal at position %d", jt.p)
 	case jttEOF:
 		return fmt.Errorf("invalid JSON input; unexpected end of input at position %d", jt.p)
+	case jttShellValue:
+		return fmt.Errorf("invalid JSON input; unexpected %s value at position %d", jt.v.(*shellValue).t, jt.p)
 	default:
 		return fmt.Errorf("invalid JSON input; unexpected %c at position %d", jt.v.(byte), jt.p)
 	}
@@ -7,13 +7,18 @@
 package bsonrw
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"math"
 	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"go.mongodb.org/mongo-driver/bson/bsonoptions"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
 func TestExtJSONReader(t *testing.T) {
@@ -166,3 +171,82 @@ func readAllDocuments(vr ValueReader) ([][]byte, error) {
 
 	return actual, nil
 }
+
+func TestExtJSONReaderShellMode(t *testing.T) {
+	oid := primitive.ObjectID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C}
+	doc := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendObjectIDElement(nil, "oid", oid),
+		bsoncore.AppendDateTimeElement(nil, "date", 1577934245006),
+		bsoncore.AppendDateTimeElement(nil, "old", -62198755200000),
+		bsoncore.AppendInt64Element(nil, "small", 42),
+		bsoncore.AppendInt64Element(nil, "large", math.MaxInt64),
+		bsoncore.AppendInt32Element(nil, "int", -7),
+		bsoncore.AppendDoubleElement(nil, "double", 1.5),
+		bsoncore.AppendDoubleElement(nil, "inf", math.Inf(-1)),
+		bsoncore.AppendDecimal128Element(nil, "dec", primitive.NewDecimal128(0x3040000000000000, 12345)),
+		bsoncore.AppendBinaryElement(nil, "bin", 0x04, []byte{0x01, 0x02, 0x03}),
+		bsoncore.AppendTimestampElement(nil, "ts", 12, 34),
+		bsoncore.AppendRegexElement(nil, "regex", `a/b[/]\d`, "im"),
+		bsoncore.AppendDBPointerElement(nil, "dbp", "db.coll", oid),
+		bsoncore.AppendUndefinedElement(nil, "undef"),
+		bsoncore.AppendMinKeyElement(nil, "min"),
+		bsoncore.AppendMaxKeyElement(nil, "max"),
+		bsoncore.AppendSymbolElement(nil, "sym", "symbol"),
+		bsoncore.AppendArrayElement(nil, "arr", bsoncore.BuildArray(nil,
+			bsoncore.Value{Type: bsontype.ObjectID, Data: bsoncore.AppendObjectID(nil, oid)},
+			bsoncore.Value{Type: bsontype.Int64, Data: bsoncore.AppendInt64(nil, -1)},
+		)),
+	)
+
+	for _, indent := range []string{"", "  "} {
+		var sw SliceWriter
+		vw, err := NewExtJSONValueWriterWithOptions(&sw, bsonoptions.ExtJSON().SetShellMode(true).SetIndent(indent))
+		noerr(t, err)
+		noerr(t, Copier{}.CopyDocumentFromBytes(vw, doc))
+
+		vr, err := NewExtJSONValueReader(bytes.NewReader(sw), false)
+		noerr(t, err)
+		got, err := Copier{}.CopyDocumentToBytes(vr)
+		noerr(t, err)
+		if !bytes.Equal(got, doc) {
+			t.Errorf("Documents do not match for %s. got %v; want %v", sw, bsoncore.Document(got), bsoncore.Document(doc))
+		}
+	}
+
+	t.Run("handwritten", func(t *testing.T) {
+		input := `{ "a" : NumberInt("5"), "b" : new Date("2020-01-02T03:04:05Z"), "c" : MinKey, ` +
+			`"d" : NumberDecimal(1.5), "e" : NaN, "f" : new NumberLong(3) }`
+		vr, err := NewExtJSONValueReader(strings.NewReader(input), false)
+		noerr(t, err)
+		got, err := Copier{}.CopyDocumentToBytes(vr)
+		noerr(t, err)
+
+		want := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "a", 5),
+			bsoncore.AppendDateTimeElement(nil, "b", 1577934245000),
+			bsoncore.AppendMinKeyElement(nil, "c"),
+			bsoncore.AppendDecimal128Element(nil, "d", mustParseDecimal128(t, "1.5")),
+			bsoncore.AppendDoubleElement(nil, "e", math.NaN()),
+			bsoncore.AppendInt64Element(nil, "f", 3),
+		)
+		if !bytes.Equal(got, want) {
+			t.Errorf("Documents do not match. got %v; want %v", bsoncore.Document(got), bsoncore.Document(want))
+		}
+	})
+	t.Run("canonical mode rejects shell values", func(t *testing.T) {
+		vr, err := NewExtJSONValueReader(strings.NewReader(`{"a": ObjectId("0102030405060708090a0b0c")}`), true)
+		noerr(t, err)
+		_, err = Copier{}.CopyDocumentToBytes(vr)
+		if err == nil {
+			t.Errorf("Expected an error reading a shell value in canonical mode, got nil")
+		}
+	})
+}
+
+func mustParseDecimal128(t *testing.T, s string) primitive.Decimal128 {
+	t.Helper()
+
+	d, err := primitive.ParseDecimal128(s)
+	noerr(t, err)
+	return d
+}
@@ -10,7 +10,6 @@ import (
 	"bytes"
 	"encoding/base64"
 	"fmt"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"io"
 	"math"
 	"sort"
@@ -19,8 +18,17 @@ import (
 	"sync"
 	"time"
 	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson/bsonoptions"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// shellISODate is the format the mongo shell uses for the argument of ISODate.
+const shellISODate = "2006-01-02T15:04:05.000Z07:00"
+
+// maxShellSafeInteger is the largest integer that can be written as a JavaScript number without losing precision.
+const maxShellSafeInteger = 1<<53 - 1
+
 var ejvwPool = sync.Pool{
 	New: func() interface{} {
 		return new(extJSONValueWriter)
@@ -51,11 +59,19 @@ func (bvwp *ExtJSONValueWriterPool) Get(w io.Writer, canonical, escapeHTML bool)
 		vw.w = writer
 		return vw
 	}
-	vw.buf = vw.buf[:0]
+	vw.reset(vw.buf[:0], canonical, escapeHTML)
 	vw.w = w
 	return vw
 }
 
+// GetWithOptions retrieves a ExtJSON ValueWriter from the pool, resets it to use w as the destination and configures
+// it with the given options.
+func (bvwp *ExtJSONValueWriterPool) GetWithOptions(w io.Writer, opts ...*bsonoptions.ExtJSONOptions) ValueWriter {
+	vw := bvwp.Get(w, false, false).(*extJSONValueWriter)
+	vw.setOptions(bsonoptions.MergeExtJSONOptions(opts...))
+	return vw
+}
+
 // Put inserts a ValueWriter into the pool. If the ValueWriter is not a ExtJSON ValueWriter, nothing
 // happens and ok will be false.
 func (bvwp *ExtJSONValueWriterPool) Put(vw ValueWriter) (ok bool) {
@@ -85,6 +101,12 @@ type extJSONValueWriter struct {
 	frame      int64
 	canonical  bool
 	escapeHTML bool
+	indent     string
+	shell      bool
+	dateFormat bsonoptions.ExtJSONDateFormat
+
+	// depth is the number of documents and arrays currently open, used for indentation.
+	depth int
 }
 
 // NewExtJSONValueWriter creates a ValueWriter that writes Extended JSON to w.
@@ -96,6 +118,17 @@ func NewExtJSONValueWriter(w io.Writer, canonical, escapeHTML bool) (ValueWriter
 	return newExtJSONWriter(w, canonical, escapeHTML), nil
 }
 
+// NewExtJSONValueWriterWithOptions creates a ValueWriter that writes Extended JSON to w using the given options.
+func NewExtJSONValueWriterWithOptions(w io.Writer, opts ...*bsonoptions.ExtJSONOptions) (ValueWriter, error) {
+	if w == nil {
+		return nil, errNilWriter
+	}
+
+	ejvw := newExtJSONWriter(w, false, false)
+	ejvw.setOptions(bsonoptions.MergeExtJSONOptions(opts...))
+	return ejvw, nil
+}
+
 func newExtJSONWriter(w io.Writer, canonical, escapeHTML bool) *extJSONValueWriter {
 	stack := make([]ejvwState, 1, 5)
 	stack[0] = ejvwState{mode: mTopLevel}
@@ -130,11 +163,55 @@ func (ejvw *extJSONValueWriter) reset(buf []byte, canonical, escapeHTML bool) {
 	ejvw.stack[0] = ejvwState{mode: mTopLevel}
 	ejvw.canonical = canonical
 	ejvw.escapeHTML = escapeHTML
+	ejvw.indent = ""
+	ejvw.shell = false
+	ejvw.dateFormat = bsonoptions.ExtJSONDateFormatDefault
 	ejvw.frame = 0
+	ejvw.depth = 0
 	ejvw.buf = buf
 	ejvw.w = nil
 }
 
+func (ejvw *extJSONValueWriter) setOptions(opts *bsonoptions.ExtJSONOptions) {
+	if opts.Canonical != nil {
+		ejvw.canonical = *opts.Canonical
+	}
+	if opts.EscapeHTML != nil {
+		ejvw.escapeHTML = *opts.EscapeHTML
+	}
+	if opts.Indent != nil {
+		ejvw.indent = *opts.Indent
+	}
+	if opts.ShellMode != nil {
+		ejvw.shell = *opts.ShellMode
+	}
+	if opts.DateFormat != nil {
+		ejvw.dateFormat = *opts.DateFormat
+	}
+}
+
+// writeNewline starts a new line indented to the given depth. It does nothing unless an indent is set.
+func (ejvw *extJSONValueWriter) writeNewline(depth int) {
+	if ejvw.indent == "" {
+		return
+	}
+
+	ejvw.buf = append(ejvw.buf, '\n')
+	for i := 0; i < depth; i++ {
+		ejvw.buf = append(ejvw.buf, ejvw.indent...)
+	}
+}
+
+// closeContainer removes the comma after the last element of a document or array and writes the closing character.
+func (ejvw *extJSONValueWriter) closeContainer(c byte) {
+	ejvw.depth--
+	if ejvw.buf[len(ejvw.buf)-1] == ',' {
+		ejvw.buf = ejvw.buf[:len(ejvw.buf)-1]
+		ejvw.writeNewline(ejvw.depth)
+	}
+	ejvw.buf = append(ejvw.buf, c)
+}
+
 func (ejvw *extJSONValueWriter) advanceFrame() {
 	if ejvw.frame+1 >= int64(len(ejvw.stack)) { // We need to grow the stack
 		length := len(ejvw.stack)
@@ -209,6 +286,7 @@ func (ejvw *extJSONValueWriter) WriteArray() (ArrayWriter, error) {
 	}
 
 	ejvw.buf = append(ejvw.buf, '[')
+	ejvw.depth++
 
 	ejvw.push(mArray)
 	return ejvw, nil
@@ -224,9 +302,15 @@ func (ejvw *extJSONValueWriter) WriteBinaryWithSubtype(b []byte, btype byte) err
 	}
 
 	var buf bytes.Buffer
-	buf.WriteString(`{"$binary":{"base64":"`)
-	buf.WriteString(base64.StdEncoding.EncodeToString(b))
-	buf.WriteString(fmt.Sprintf(`","subType":"%02x"}},`, btype))
+	if ejvw.shell {
+		buf.WriteString(fmt.Sprintf(`BinData(%d,"`, btype))
+		buf.WriteString(base64.StdEncoding.EncodeToString(b))
+		buf.WriteString(`"),`)
+	} else {
+		buf.WriteString(`{"$binary":{"base64":"`)
+		buf.WriteString(base64.StdEncoding.EncodeToString(b))
+		buf.WriteString(fmt.Sprintf(`","subType":"%02x"}},`, btype))
+	}
 
 	ejvw.buf = append(ejvw.buf, buf.Bytes()...)
 
@@ -257,6 +341,7 @@ func (ejvw *extJSONValueWriter) WriteCodeWithScope(code string) (DocumentWriter,
 	buf.WriteString(`,"$scope":{`)
 
 	ejvw.buf = append(ejvw.buf, buf.Bytes()...)
+	ejvw.depth++
 
 	ejvw.push(mCodeWithScope)
 	return ejvw, nil
@@ -268,11 +353,19 @@ func (ejvw *extJSONValueWriter) WriteDBPointer(ns string, oid primitive.ObjectID
 	}
 
 	var buf bytes.Buffer
-	buf.WriteString(`{"$dbPointer":{"$ref":"`)
-	buf.WriteString(ns)
-	buf.WriteString(`","$id":{"$oid":"`)
-	buf.WriteString(oid.Hex())
-	buf.WriteString(`"}}},`)
+	if ejvw.shell {
+		buf.WriteString(`DBPointer("`)
+		buf.WriteString(ns)
+		buf.WriteString(`",ObjectId("`)
+		buf.WriteString(oid.Hex())
+		buf.WriteString(`")),`)
+	} else {
+		buf.WriteString(`{"$dbPointer":{"$ref":"`)
+		buf.WriteString(ns)
+		buf.WriteString(`","$id":{"$oid":"`)
+		buf.WriteString(oid.Hex())
+		buf.WriteString(`"}}},`)
+	}
 
 	ejvw.buf = append(ejvw.buf, buf.Bytes()...)
 
@@ -287,11 +380,30 @@ func (ejvw *extJSONValueWriter) WriteDateTime(dt int64) error {
 
 	t := time.Unix(dt/1e3, dt%1e3*1e6).UTC()
 
-	if ejvw.canonical || t.Year() < 1970 || t.Year() > 9999 {
+	var iso bool
+	switch ejvw.dateFormat {
+	case bsonoptions.ExtJSONDateFormatISO:
+		// Canonical Extended JSON only allows the $numberLong form.
+		iso = (ejvw.shell || !ejvw.canonical) && t.Year() >= 0 && t.Year() <= 9999
+	case bsonoptions.ExtJSONDateFormatNumberLong:
+	default:
+		if ejvw.shell {
+			iso = t.Year() >= 0 && t.Year() <= 9999
+		} else {
+			iso = !ejvw.canonical && t.Year() >= 1970 && t.Year() <= 9999
+		}
+	}
+
+	switch {
+	case ejvw.shell && iso:
+		ejvw.buf = append(ejvw.buf, `ISODate("`+t.Format(shellISODate)+`")`...)
+	case ejvw.shell:
+		ejvw.buf = append(ejvw.buf, "new Date("+strconv.FormatInt(dt, 10)+")"...)
+	case iso:
+		ejvw.writeExtendedSingleValue("date", t.Format(rfc3339Milli), true)
+	default:
 		s := fmt.Sprintf(`{"$numberLong":"%d"}`, dt)
 		ejvw.writeExtendedSingleValue("date", s, false)
-	} else {
-		ejvw.writeExtendedSingleValue("date", t.Format(rfc3339Milli), true)
 	}
 
 	ejvw.buf = append(ejvw.buf, ',')
@@ -305,7 +417,11 @@ func (ejvw *extJSONValueWriter) WriteDecimal128(d primitive.Decimal128) error {
 		return err
 	}
 
-	ejvw.writeExtendedSingleValue("numberDecimal", d.String(), true)
+	if ejvw.shell {
+		ejvw.buf = append(ejvw.buf, `NumberDecimal("`+d.String()+`")`...)
+	} else {
+		ejvw.writeExtendedSingleValue("numberDecimal", d.String(), true)
+	}
 	ejvw.buf = append(ejvw.buf, ',')
 
 	ejvw.pop()
@@ -315,6 +431,7 @@ func (ejvw *extJSONValueWriter) WriteDecimal128(d primitive.Decimal128) error {
 func (ejvw *extJSONValueWriter) WriteDocument() (DocumentWriter, error) {
 	if ejvw.stack[ejvw.frame].mode == mTopLevel {
 		ejvw.buf = append(ejvw.buf, '{')
+		ejvw.depth++
 		return ejvw, nil
 	}
 
@@ -323,6 +440,7 @@ func (ejvw *extJSONValueWriter) WriteDocument() (DocumentWriter, error) {
 	}
 
 	ejvw.buf = append(ejvw.buf, '{')
+	ejvw.depth++
 	ejvw.push(mDocument)
 	return ejvw, nil
 }
@@ -334,9 +452,12 @@ func (ejvw *extJSONValueWriter) WriteDouble(f float64) error {
 
 	s := formatDouble(f)
 
-	if ejvw.canonical {
+	switch {
+	case ejvw.shell:
+		ejvw.buf = append(ejvw.buf, s...)
+	case ejvw.canonical:
 		ejvw.writeExtendedSingleValue("numberDouble", s, true)
-	} else {
+	default:
 		switch s {
 		case "Infinity":
 			fallthrough
@@ -361,7 +482,7 @@ func (ejvw *extJSONValueWriter) WriteInt32(i int32) error {
 
 	s := strconv.FormatInt(int64(i), 10)
 
-	if ejvw.canonical {
+	if ejvw.canonical && !ejvw.shell {
 		ejvw.writeExtendedSingleValue("numberInt", s, true)
 	} else {
 		ejvw.buf = append(ejvw.buf, []byte(s)...)
@@ -380,9 +501,15 @@ func (ejvw *extJSONValueWriter) WriteInt64(i int64) error {
 
 	s := strconv.FormatInt(i, 10)
 
-	if ejvw.canonical {
+	switch {
+	case ejvw.shell && (i > maxShellSafeInteger || i < -maxShellSafeInteger):
+		// The shell parses numbers as doubles, so large values must be quoted.
+		ejvw.buf = append(ejvw.buf, `NumberLong("`+s+`")`...)
+	case ejvw.shell:
+		ejvw.buf = append(ejvw.buf, "NumberLong("+s+")"...)
+	case ejvw.canonical:
 		ejvw.writeExtendedSingleValue("numberLong", s, true)
-	} else {
+	default:
 		ejvw.buf = append(ejvw.buf, []byte(s)...)
 	}
 
@@ -412,7 +539,11 @@ func (ejvw *extJSONValueWriter) WriteMaxKey() error {
 		return err
 	}
 
-	ejvw.writeExtendedSingleValue("maxKey", "1", false)
+	if ejvw.shell {
+		ejvw.buf = append(ejvw.buf, "MaxKey()"...)
+	} else {
+		ejvw.writeExtendedSingleValue("maxKey", "1", false)
+	}
 	ejvw.buf = append(ejvw.buf, ',')
 
 	ejvw.pop()
@@ -424,7 +555,11 @@ func (ejvw *extJSONValueWriter) WriteMinKey() error {
 		return err
 	}
 
-	ejvw.writeExtendedSingleValue("minKey", "1", false)
+	if ejvw.shell {
+		ejvw.buf = append(ejvw.buf, "MinKey()"...)
+	} else {
+		ejvw.writeExtendedSingleValue("minKey", "1", false)
+	}
 	ejvw.buf = append(ejvw.buf, ',')
 
 	ejvw.pop()
@@ -448,7 +583,11 @@ func (ejvw *extJSONValueWriter) WriteObjectID(oid primitive.ObjectID) error {
 		return err
 	}
 
-	ejvw.writeExtendedSingleValue("oid", oid.Hex(), true)
+	if ejvw.shell {
+		ejvw.buf = append(ejvw.buf, `ObjectId("`+oid.Hex()+`")`...)
+	} else {
+		ejvw.writeExtendedSingleValue("oid", oid.Hex(), true)
+	}
 	ejvw.buf = append(ejvw.buf, ',')
 
 	ejvw.pop()
@@ -461,11 +600,16 @@ func (ejvw *extJSONValueWriter) WriteRegex(pattern string, options string) error
 	}
 
 	var buf bytes.Buffer
-	buf.WriteString(`{"$regularExpression":{"pattern":`)
-	writeStringWithEscapes(pattern, &buf, ejvw.escapeHTML)
-	buf.WriteString(`,"options":"`)
-	buf.WriteString(sortStringAlphebeticAscending(options))
-	buf.WriteString(`"}},`)
+	if ejvw.shell {
+		writeShellRegex(pattern, sortStringAlphebeticAscending(options), &buf)
+		buf.WriteByte(',')
+	} else {
+		buf.WriteString(`{"$regularExpression":{"pattern":`)
+		writeStringWithEscapes(pattern, &buf, ejvw.escapeHTML)
+		buf.WriteString(`,"options":"`)
+		buf.WriteString(sortStringAlphebeticAscending(options))
+		buf.WriteString(`"}},`)
+	}
 
 	ejvw.buf = append(ejvw.buf, buf.Bytes()...)
 
@@ -509,11 +653,19 @@ func (ejvw *extJSONValueWriter) WriteTimestamp(t uint32, i uint32) error {
 	}
 
 	var buf bytes.Buffer
-	buf.WriteString(`{"$timestamp":{"t":`)
-	buf.WriteString(strconv.FormatUint(uint64(t), 10))
-	buf.WriteString(`,"i":`)
-	buf.WriteString(strconv.FormatUint(uint64(i), 10))
-	buf.WriteString(`}},`)
+	if ejvw.shell {
+		buf.WriteString(`Timestamp(`)
+		buf.WriteString(strconv.FormatUint(uint64(t), 10))
+		buf.WriteString(`,`)
+		buf.WriteString(strconv.FormatUint(uint64(i), 10))
+		buf.WriteString(`),`)
+	} else {
+		buf.WriteString(`{"$timestamp":{"t":`)
+		buf.WriteString(strconv.FormatUint(uint64(t), 10))
+		buf.WriteString(`,"i":`)
+		buf.WriteString(strconv.FormatUint(uint64(i), 10))
+		buf.WriteString(`}},`)
+	}
 
 	ejvw.buf = append(ejvw.buf, buf.Bytes()...)
 
@@ -526,7 +678,11 @@ func (ejvw *extJSONValueWriter) WriteUndefined() error {
 		return err
 	}
 
-	ejvw.writeExtendedSingleValue("undefined", "true", false)
+	if ejvw.shell {
+		ejvw.buf = append(ejvw.buf, "undefined"...)
+	} else {
+		ejvw.writeExtendedSingleValue("undefined", "true", false)
+	}
 	ejvw.buf = append(ejvw.buf, ',')
 
 	ejvw.pop()
@@ -536,7 +692,11 @@ func (ejvw *extJSONValueWriter) WriteUndefined() error {
 func (ejvw *extJSONValueWriter) WriteDocumentElement(key string) (ValueWriter, error) {
 	switch ejvw.stack[ejvw.frame].mode {
 	case mDocument, mTopLevel, mCodeWithScope:
+		ejvw.writeNewline(ejvw.depth)
 		ejvw.buf = append(ejvw.buf, []byte(fmt.Sprintf(`"%s":`, key))...)
+		if ejvw.indent != "" {
+			ejvw.buf = append(ejvw.buf, ' ')
+		}
 		ejvw.push(mElement)
 	default:
 		return nil, ejvw.invalidTransitionErr(mElement, "WriteDocumentElement", []mode{mDocument, mTopLevel, mCodeWithScope})
@@ -552,12 +712,7 @@ func (ejvw *extJSONValueWriter) WriteDocumentEnd() error {
 		return fmt.Errorf("incorrect mode to end document: %s", ejvw.stack[ejvw.frame].mode)
 	}
 
-	// close the document
-	if ejvw.buf[len(ejvw.buf)-1] == ',' {
-		ejvw.buf[len(ejvw.buf)-1] = '}'
-	} else {
-		ejvw.buf = append(ejvw.buf, '}')
-	}
+	ejvw.closeContainer('}')
 
 	switch ejvw.stack[ejvw.frame].mode {
 	case mCodeWithScope:
@@ -581,6 +736,7 @@ func (ejvw *extJSONValueWriter) WriteDocumentEnd() error {
 func (ejvw *extJSONValueWriter) WriteArrayElement() (ValueWriter, error) {
 	switch ejvw.stack[ejvw.frame].mode {
 	case mArray:
+		ejvw.writeNewline(ejvw.depth)
 		ejvw.push(mValue)
 	default:
 		return nil, ejvw.invalidTransitionErr(mValue, "WriteArrayElement", []mode{mArray})
@@ -592,13 +748,7 @@ func (ejvw *extJSONValueWriter) WriteArrayElement() (ValueWriter, error) {
 func (ejvw *extJSONValueWriter) WriteArrayEnd() error {
 	switch ejvw.stack[ejvw.frame].mode {
 	case mArray:
-		// close the array
-		if ejvw.buf[len(ejvw.buf)-1] == ',' {
-			ejvw.buf[len(ejvw.buf)-1] = ']'
-		} else {
-			ejvw.buf = append(ejvw.buf, ']')
-		}
-
+		ejvw.closeContainer(']')
 		ejvw.buf = append(ejvw.buf, ',')
 
 		ejvw.pop()
@@ -629,6 +779,28 @@ func formatDouble(f float64) string {
 	return s
 }
 
+// writeShellRegex writes a JavaScript regular expression literal. Slashes in the pattern that are not already escaped
+// are escaped so they do not terminate the literal.
+func writeShellRegex(pattern, options string, buf *bytes.Buffer) {
+	buf.WriteByte('/')
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '\\':
+			buf.WriteByte(c)
+			if i+1 < len(pattern) {
+				i++
+				buf.WriteByte(pattern[i])
+			}
+		case '/':
+			buf.WriteString(`\/`)
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	buf.WriteByte('/')
+	buf.WriteString(options)
+}
+
 var hexChars = "0123456789abcdef"
 
 func writeStringWithEscapes(s string, buf *bytes.Buffer, escapeHTML bool) {
@@ -12,9 +12,12 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"go.mongodb.org/mongo-driver/bson/bsonoptions"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
 func TestExtJSONValueWriter(t *testing.T) {
@@ -258,3 +261,89 @@ func TestExtJSONValueWriter(t *testing.T) {
 		}
 	})
 }
+
+func TestExtJSONValueWriterOptions(t *testing.T) {
+	oid := primitive.ObjectID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C}
+	dt := time.Date(2020, 1, 2, 3, 4, 5, 6000000, time.UTC).UnixNano() / 1e6
+	doc := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendObjectIDElement(nil, "_id", oid),
+		bsoncore.AppendDateTimeElement(nil, "date", dt),
+		bsoncore.AppendInt64Element(nil, "long", 42),
+		bsoncore.AppendArrayElement(nil, "arr", bsoncore.BuildArray(nil,
+			bsoncore.Value{Type: bsontype.Int32, Data: bsoncore.AppendInt32(nil, 1)},
+			bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: bsoncore.BuildDocument(nil, nil)},
+		)),
+	)
+
+	testCases := []struct {
+		name string
+		opts *bsonoptions.ExtJSONOptions
+		want string
+	}{
+		{
+			"relaxed",
+			bsonoptions.ExtJSON(),
+			`{"_id":{"$oid":"0102030405060708090a0b0c"},"date":{"$date":"2020-01-02T03:04:05.006Z"},"long":42,"arr":[1,{}]}`,
+		},
+		{
+			"canonical ISO dates",
+			bsonoptions.ExtJSON().SetCanonical(true).SetDateFormat(bsonoptions.ExtJSONDateFormatISO),
+			`{"_id":{"$oid":"0102030405060708090a0b0c"},"date":{"$date":{"$numberLong":"1577934245006"}},` +
+				`"long":{"$numberLong":"42"},"arr":[{"$numberInt":"1"},{}]}`,
+		},
+		{
+			"relaxed numberLong dates",
+			bsonoptions.ExtJSON().SetDateFormat(bsonoptions.ExtJSONDateFormatNumberLong),
+			`{"_id":{"$oid":"0102030405060708090a0b0c"},"date":{"$date":{"$numberLong":"1577934245006"}},"long":42,"arr":[1,{}]}`,
+		},
+		{
+			"indented",
+			bsonoptions.ExtJSON().SetIndent("  "),
+			"{\n" +
+				`  "_id": {"$oid":"0102030405060708090a0b0c"},` + "\n" +
+				`  "date": {"$date":"2020-01-02T03:04:05.006Z"},` + "\n" +
+				`  "long": 42,` + "\n" +
+				`  "arr": [` + "\n" +
+				`    1,` + "\n" +
+				`    {}` + "\n" +
+				`  ]` + "\n" +
+				"}",
+		},
+		{
+			"shell",
+			bsonoptions.ExtJSON().SetShellMode(true),
+			`{"_id":ObjectId("0102030405060708090a0b0c"),"date":ISODate("2020-01-02T03:04:05.006Z"),"long":NumberLong(42),"arr":[1,{}]}`,
+		},
+		{
+			"shell numberLong dates",
+			bsonoptions.ExtJSON().SetShellMode(true).SetDateFormat(bsonoptions.ExtJSONDateFormatNumberLong),
+			`{"_id":ObjectId("0102030405060708090a0b0c"),"date":new Date(1577934245006),"long":NumberLong(42),"arr":[1,{}]}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var sw SliceWriter
+			vw, err := NewExtJSONValueWriterWithOptions(&sw, tc.opts)
+			noerr(t, err)
+			err = Copier{}.CopyDocumentFromBytes(vw, doc)
+			noerr(t, err)
+			if got := string(sw); got != tc.want {
+				t.Errorf("Extended JSON does not match. got %s; want %s", got, tc.want)
+			}
+		})
+	}
+	t.Run("pool resets options", func(t *testing.T) {
+		pool := NewExtJSONValueWriterPool()
+		var sw SliceWriter
+		vw := pool.GetWithOptions(&sw, bsonoptions.ExtJSON().SetShellMode(true).SetIndent("\t"))
+		noerr(t, Copier{}.CopyDocumentFromBytes(vw, doc))
+		pool.Put(vw)
+
+		sw = sw[:0]
+		vw = pool.Get(&sw, false, false)
+		noerr(t, Copier{}.CopyDocumentFromBytes(vw, doc))
+		if want := testCases[0].want; string(sw) != want {
+			t.Errorf("Extended JSON does not match. got %s; want %s", sw, want)
+		}
+	})
+}
@@ -33,6 +33,7 @@ const (
 	jttBool
 	jttNull
 	jttEOF
+	jttShellValue
 )
 
 type jsonToken struct {
@@ -80,12 +81,23 @@ func (js *jsonScanner) nextToken() (*jsonToken, error) {
 		return &jsonToken{t: jttComma, v: byte(','), p: js.pos - 1}, nil
 	case '"': // RFC-8259 only allows for double quotes (") not single (')
 		return js.scanString()
+	case '/':
+		return js.scanShellRegex()
 	default:
 		// check if it's a number
+		if c == '-' {
+			// -Infinity is the only identifier that can follow a minus sign
+			if next, err := js.readNextByte(); err == nil {
+				if next == 'I' {
+					return js.scanShellIdentifier(next, true)
+				}
+				js.pos--
+			}
+		}
 		if c == '-' || isDigit(c) {
 			return js.scanNumber(c)
-		} else if c == 't' || c == 'f' || c == 'n' {
-			// maybe a literal
+		} else if isIdentifierStart(c) {
+			// maybe a literal or a mongo shell value
 			return js.scanLiteral(c)
 		} else {
 			return nil, fmt.Errorf("invalid JSON input. Position: %d. Character: %c", js.pos-1, c)
@@ -159,7 +171,7 @@ func isDigit(c byte) bool {
 }
 
 func isValueTerminator(c byte) bool {
-	return c == ',' || c == '}' || c == ']' || isWhiteSpace(c)
+	return c == ',' || c == '}' || c == ']' || c == ')' || isWhiteSpace(c)
 }
 
 // scanString reads from an opening '"' to a closing '"' and handles escaped characters
@@ -222,38 +234,39 @@ func (js *jsonScanner) scanString() (*jsonToken, error) {
 
 // scanLiteral reads an unquoted sequence of characters and determines if it is one of
 // three valid JSON literals (true, false, null); if so, it returns the appropriate
-// jsonToken; otherwise, it returns an error
+// jsonToken. Any other identifier is scanned as a mongo shell value.
 func (js *jsonScanner) scanLiteral(first byte) (*jsonToken, error) {
 	p := js.pos - 1
 
-	lit := make([]byte, 4)
-	lit[0] = first
-
-	err := js.readNNextBytes(lit, 3, 1)
+	lit, err := js.readIdentifier(first)
 	if err != nil {
 		return nil, err
 	}
 
-	c5, err := js.readNextByte()
-
-	if bytes.Equal([]byte("true"), lit) && (isValueTerminator(c5) || err == io.EOF) {
-		js.pos = int(math.Max(0, float64(js.pos-1)))
-		return &jsonToken{t: jttBool, v: true, p: p}, nil
-	} else if bytes.Equal([]byte("null"), lit) && (isValueTerminator(c5) || err == io.EOF) {
-		js.pos = int(math.Max(0, float64(js.pos-1)))
-		return &jsonToken{t: jttNull, v: nil, p: p}, nil
-	} else if bytes.Equal([]byte("fals"), lit) {
-		if c5 == 'e' {
-			c5, err = js.readNextByte()
-
-			if isValueTerminator(c5) || err == io.EOF {
-				js.pos = int(math.Max(0, float64(js.pos-1)))
-				return &jsonToken{t: jttBool, v: false, p: p}, nil
-			}
-		}
+	var t *jsonToken
+	switch lit {
+	case "true":
+		t = &jsonToken{t: jttBool, v: true, p: p}
+	case "false":
+		t = &jsonToken{t: jttBool, v: false, p: p}
+	case "null":
+		t = &jsonToken{t: jttNull, v: nil, p: p}
+	default:
+		return js.scanShellValue(lit, p, false)
 	}
 
-	return nil, fmt.Errorf("invalid JSON literal. Position: %d, literal: %s", p, lit)
+	c, err := js.readNextByte()
+	if err == io.EOF {
+		return t, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !isValueTerminator(c) {
+		return nil, fmt.Errorf("invalid JSON literal. Position: %d, literal: %s", p, lit)
+	}
+	js.pos--
+	return t, nil
 }
 
 type numberScanState byte
@@ -321,7 +334,7 @@ func (js *jsonScanner) scanNumber(first byte) (*jsonToken, error) {
 			case 'e', 'E':
 				s = nssSawExponentLetter
 				b.WriteByte(c)
-			case '}', ']', ',':
+			case '}', ']', ',', ')':
 				s = nssDone
 			default:
 				if isWhiteSpace(c) || err == io.EOF {
@@ -338,7 +351,7 @@ func (js *jsonScanner) scanNumber(first byte) (*jsonToken, error) {
 			case 'e', 'E':
 				s = nssSawExponentLetter
 				b.WriteByte(c)
-			case '}', ']', ',':
+			case '}', ']', ',', ')':
 				s = nssDone
 			default:
 				if isWhiteSpace(c) || err == io.EOF {
@@ -363,7 +376,7 @@ func (js *jsonScanner) scanNumber(first byte) (*jsonToken, error) {
 			case 'e', 'E':
 				s = nssSawExponentLetter
 				b.WriteByte(c)
-			case '}', ']', ',':
+			case '}', ']', ',', ')':
 				s = nssDone
 			default:
 				if isWhiteSpace(c) || err == io.EOF {
@@ -398,7 +411,7 @@ func (js *jsonScanner) scanNumber(first byte) (*jsonToken, error) {
 			}
 		case nssSawExponentDigits:
 			switch c {
-			case '}', ']', ',':
+			case '}', ']', ',', ')':
 				s = nssDone
 			default:
 				if isWhiteSpace(c) || err == io.EOF {
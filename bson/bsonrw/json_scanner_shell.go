@@ -0,0 +1,321 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsonrw
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// shellValue is the value of a jttShellValue token. t is the BSON type of the value and v holds it in the same form
+// readValue returns for the equivalent Extended JSON wrapper object, so the parse methods of extJSONValue can be used
+// for both.
+type shellValue struct {
+	t bsontype.Type
+	v *extJSONValue
+}
+
+func isIdentifierStart(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_' || c == '$'
+}
+
+func isIdentifierPart(c byte) bool {
+	return isIdentifierStart(c) || isDigit(c)
+}
+
+// readIdentifier reads a JavaScript identifier starting with first.
+func (js *jsonScanner) readIdentifier(first byte) (string, error) {
+	var b bytes.Buffer
+	b.WriteByte(first)
+
+	for {
+		c, err := js.readNextByte()
+		if err == io.EOF {
+			return b.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if !isIdentifierPart(c) {
+			js.pos--
+			return b.String(), nil
+		}
+		b.WriteByte(c)
+	}
+}
+
+// skipWhiteSpace returns the next byte that is not white space.
+func (js *jsonScanner) skipWhiteSpace() (byte, error) {
+	c, err := js.readNextByte()
+	for isWhiteSpace(c) && err == nil {
+		c, err = js.readNextByte()
+	}
+	return c, err
+}
+
+// scanShellIdentifier reads an identifier that follows a minus sign, which must be Infinity.
+func (js *jsonScanner) scanShellIdentifier(first byte, negative bool) (*jsonToken, error) {
+	p := js.pos - 2
+
+	lit, err := js.readIdentifier(first)
+	if err != nil {
+		return nil, err
+	}
+	if lit != "Infinity" {
+		return nil, fmt.Errorf("invalid JSON number. Position: %d", p)
+	}
+	return js.scanShellValue(lit, p, negative)
+}
+
+// scanShellValue reads the remainder of a value written in mongo shell syntax, such as ObjectId("..."), after its
+// leading identifier name.
+func (js *jsonScanner) scanShellValue(name string, p int, negative bool) (*jsonToken, error) {
+	invalid := func() (*jsonToken, error) {
+		return nil, fmt.Errorf("invalid mongo shell value. Position: %d, value: %s", p, name)
+	}
+	token := func(t bsontype.Type, v *extJSONValue) (*jsonToken, error) {
+		return &jsonToken{t: jttShellValue, v: &shellValue{t: t, v: v}, p: p}, nil
+	}
+
+	switch name {
+	case "Infinity":
+		if negative {
+			return token(bsontype.Double, &extJSONValue{t: bsontype.Double, v: math.Inf(-1)})
+		}
+		return token(bsontype.Double, &extJSONValue{t: bsontype.Double, v: math.Inf(1)})
+	case "NaN":
+		return token(bsontype.Double, &extJSONValue{t: bsontype.Double, v: math.NaN()})
+	case "undefined":
+		return token(bsontype.Undefined, &extJSONValue{t: bsontype.Boolean, v: true})
+	case "MinKey", "MaxKey":
+		// The parentheses are optional.
+		c, err := js.skipWhiteSpace()
+		if err == nil {
+			js.pos--
+			if c == '(' {
+				if args, err := js.readShellArgs(name); err != nil || len(args) != 0 {
+					return invalid()
+				}
+			}
+		}
+		if name == "MinKey" {
+			return token(bsontype.MinKey, &extJSONValue{t: bsontype.Int32, v: int32(1)})
+		}
+		return token(bsontype.MaxKey, &extJSONValue{t: bsontype.Int32, v: int32(1)})
+	case "new":
+		c, err := js.skipWhiteSpace()
+		if err != nil || !isIdentifierStart(c) {
+			return invalid()
+		}
+		name, err = js.readIdentifier(c)
+		if err != nil {
+			return nil, err
+		}
+		if name != "Date" {
+			return js.scanShellValue(name, p, false)
+		}
+	}
+
+	args, err := js.readShellArgs(name)
+	if err != nil {
+		return nil, err
+	}
+	vals := make([]*extJSONValue, len(args))
+	for i, arg := range args {
+		if arg.t == jttShellValue {
+			vals[i] = arg.v.(*shellValue).v
+			continue
+		}
+		vals[i] = extendJSONToken(arg)
+	}
+	argTypes := func(types ...bsontype.Type) bool {
+		if len(vals) != len(types) {
+			return false
+		}
+		for i, t := range types {
+			if vals[i].t != t && !(t == bsontype.Int64 && vals[i].t == bsontype.Int32) {
+				return false
+			}
+		}
+		return true
+	}
+
+	switch {
+	case name == "ObjectId" && argTypes(bsontype.String):
+		return token(bsontype.ObjectID, vals[0])
+	case name == "ISODate" && argTypes(bsontype.String), name == "Date" && argTypes(bsontype.String):
+		return token(bsontype.DateTime, vals[0])
+	case name == "Date" && argTypes(bsontype.Int64):
+		return token(bsontype.DateTime, vals[0])
+	case name == "NumberLong" && argTypes(bsontype.Int64):
+		i := toInt64(vals[0])
+		return token(bsontype.Int64, &extJSONValue{t: bsontype.Int64, v: i})
+	case name == "NumberLong" && argTypes(bsontype.String):
+		i, err := strconv.ParseInt(vals[0].v.(string), 10, 64)
+		if err != nil {
+			return invalid()
+		}
+		return token(bsontype.Int64, &extJSONValue{t: bsontype.Int64, v: i})
+	case name == "NumberInt" && argTypes(bsontype.Int32):
+		return token(bsontype.Int32, vals[0])
+	case name == "NumberInt" && argTypes(bsontype.String):
+		i, err := strconv.ParseInt(vals[0].v.(string), 10, 32)
+		if err != nil {
+			return invalid()
+		}
+		return token(bsontype.Int32, &extJSONValue{t: bsontype.Int32, v: int32(i)})
+	case name == "NumberDecimal" && argTypes(bsontype.String):
+		return token(bsontype.Decimal128, vals[0])
+	case name == "NumberDecimal" && argTypes(bsontype.Int64):
+		i := toInt64(vals[0])
+		return token(bsontype.Decimal128, &extJSONValue{t: bsontype.String, v: strconv.FormatInt(i, 10)})
+	case name == "NumberDecimal" && argTypes(bsontype.Double):
+		s := strconv.FormatFloat(vals[0].v.(float64), 'g', -1, 64)
+		return token(bsontype.Decimal128, &extJSONValue{t: bsontype.String, v: s})
+	case name == "BinData" && argTypes(bsontype.Int64, bsontype.String):
+		subType := toInt64(vals[0])
+		if subType < 0 || subType > math.MaxUint8 {
+			return invalid()
+		}
+		st := &extJSONValue{t: bsontype.String, v: fmt.Sprintf("%02x", subType)}
+		return token(bsontype.Binary, shellObject([]string{"base64", "subType"}, vals[1], st))
+	case name == "Timestamp" && argTypes(bsontype.Int64, bsontype.Int64):
+		return token(bsontype.Timestamp, shellObject([]string{"t", "i"}, vals[0], vals[1]))
+	case name == "DBPointer" && len(args) == 2 && args[1].t == jttShellValue &&
+		args[1].v.(*shellValue).t == bsontype.ObjectID && vals[0].t == bsontype.String:
+		return token(bsontype.DBPointer, shellObject([]string{"$ref", "$id"}, vals[0], vals[1]))
+	}
+	return invalid()
+}
+
+// readShellArgs reads the parenthesized arguments of a mongo shell constructor. Arguments must be strings, numbers
+// or other mongo shell values.
+func (js *jsonScanner) readShellArgs(name string) ([]*jsonToken, error) {
+	p := js.pos
+	c, err := js.skipWhiteSpace()
+	if err != nil || c != '(' {
+		return nil, fmt.Errorf("invalid mongo shell value %s; expected ( at position %d", name, p)
+	}
+
+	var args []*jsonToken
+	c, err = js.skipWhiteSpace()
+	if err == nil && c == ')' {
+		return args, nil
+	}
+	if err == nil {
+		js.pos--
+	}
+
+	for {
+		arg, err := js.nextToken()
+		if err != nil {
+			return nil, err
+		}
+		switch arg.t {
+		case jttString, jttInt32, jttInt64, jttDouble, jttShellValue:
+		default:
+			return nil, fmt.Errorf("invalid argument to mongo shell value %s at position %d", name, arg.p)
+		}
+		args = append(args, arg)
+
+		p = js.pos
+		c, err = js.skipWhiteSpace()
+		if err == io.EOF {
+			return nil, fmt.Errorf("end of input in mongo shell value %s", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch c {
+		case ')':
+			return args, nil
+		case ',':
+		default:
+			return nil, fmt.Errorf("invalid mongo shell value %s; expected , or ) at position %d", name, p)
+		}
+	}
+}
+
+// scanShellRegex reads a JavaScript regular expression literal after its opening slash.
+func (js *jsonScanner) scanShellRegex() (*jsonToken, error) {
+	p := js.pos - 1
+
+	var pattern bytes.Buffer
+	inClass := false
+	for done := false; !done; {
+		c, err := js.readNextByte()
+		if err == io.EOF {
+			return nil, errors.New("end of input in regular expression")
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case c == '\n' || c == '\r':
+			return nil, fmt.Errorf("invalid regular expression. Position: %d", p)
+		case c == '\\':
+			c, err = js.readNextByte()
+			if err != nil {
+				return nil, errors.New("end of input in regular expression")
+			}
+			// An escaped slash only exists to keep the literal from ending, so it is unescaped.
+			if c != '/' {
+				pattern.WriteByte('\\')
+			}
+			pattern.WriteByte(c)
+		case c == '/' && !inClass:
+			done = true
+		default:
+			if c == '[' {
+				inClass = true
+			} else if c == ']' {
+				inClass = false
+			}
+			pattern.WriteByte(c)
+		}
+	}
+
+	var options bytes.Buffer
+	for {
+		c, err := js.readNextByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !isIdentifierPart(c) {
+			js.pos--
+			break
+		}
+		options.WriteByte(c)
+	}
+
+	v := shellObject([]string{"pattern", "options"},
+		&extJSONValue{t: bsontype.String, v: pattern.String()},
+		&extJSONValue{t: bsontype.String, v: options.String()})
+	return &jsonToken{t: jttShellValue, v: &shellValue{t: bsontype.Regex, v: v}, p: p}, nil
+}
+
+func shellObject(keys []string, values ...*extJSONValue) *extJSONValue {
+	return &extJSONValue{t: bsontype.EmbeddedDocument, v: &extJSONObject{keys: keys, values: values}}
+}
+
+// toInt64 returns the value of an Int32 or Int64 extJSONValue as an int64.
+func toInt64(v *extJSONValue) int64 {
+	if i, ok := v.v.(int32); ok {
+		return int64(i)
+	}
+	return v.v.(int64)
+}
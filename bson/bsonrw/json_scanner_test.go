@@ -316,6 +316,13 @@ func TestJsonScannerInvalidInputs(t *testing.T) {
 		{desc: "invalid literal--falsee", input: "falsee"},
 		{desc: "invalid literal--fake", input: "fake"},
 		{desc: "invalid literal--bad", input: "bad"},
+		{desc: "invalid shell value--unknown constructor", input: `Foo("bar")`},
+		{desc: "invalid shell value--wrong argument type", input: "ObjectId(1)"},
+		{desc: "invalid shell value--missing paren", input: `ObjectId("5e1a"`},
+		{desc: "invalid shell value--NumberLong overflow", input: `NumberLong("9223372036854775808")`},
+		{desc: "invalid shell value--BinData subtype", input: `BinData(256, "AQI=")`},
+		{desc: "invalid shell value--unterminated regex", input: "/abc"},
+		{desc: "invalid number: -Inf", input: "-Inf"},
 		{desc: "invalid number: -", input: "-"},
 		{desc: "invalid number: --0", input: "--0"},
 		{desc: "invalid number: -a", input: "-a"},
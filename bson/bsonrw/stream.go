@@ -0,0 +1,134 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsonrw
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// DefaultMaxDocumentSize is the largest document a stream ValueReader created by NewBSONStreamReader will read. It
+// is the maximum BSON document size used by the server, including the headroom allowed for internal documents.
+const DefaultMaxDocumentSize = 16*1024*1024 + 16*1024
+
+var errNilReader = errors.New("cannot create a ValueReader from a nil io.Reader")
+
+// streamValueReader is a ValueReader that reads consecutive BSON documents from an io.Reader. Each call to
+// ReadDocument or ReadValueBytes on it reads the next document from r and resets the embedded valueReader to it, so
+// only a single document is held in memory at a time.
+type streamValueReader struct {
+	*valueReader
+	r       io.Reader
+	maxSize int32
+}
+
+// NewBSONStreamReader returns a ValueReader that reads a stream of consecutive BSON documents, such as a
+// mongodump .bson file, from r. Each top level read, such as a call to bson.Decoder.Decode, consumes the next
+// document. Once every document has been read, the top level read methods return io.EOF.
+//
+// Documents larger than DefaultMaxDocumentSize are rejected. Use NewBSONStreamReaderSize to choose a different
+// limit.
+func NewBSONStreamReader(r io.Reader) (ValueReader, error) {
+	return NewBSONStreamReaderSize(r, DefaultMaxDocumentSize)
+}
+
+// NewBSONStreamReaderSize returns a ValueReader that reads a stream of consecutive BSON documents from r and returns
+// an error for any document whose length is greater than maxDocumentSize.
+//
+// A new buffer is allocated for each document, because values such as binary data may be decoded by reference to
+// it, but never for more than maxDocumentSize bytes. Wrap r in a bufio.Reader if it is not already buffered.
+func NewBSONStreamReaderSize(r io.Reader, maxDocumentSize int32) (ValueReader, error) {
+	if r == nil {
+		return nil, errNilReader
+	}
+	if maxDocumentSize < 5 {
+		return nil, fmt.Errorf("invalid maximum document size: %d", maxDocumentSize)
+	}
+	return &streamValueReader{
+		valueReader: newValueReader(nil),
+		r:           r,
+		maxSize:     maxDocumentSize,
+	}, nil
+}
+
+// next reads the next document from the stream and resets the embedded valueReader to it.
+func (svr *streamValueReader) next() error {
+	var lb [4]byte
+	if _, err := io.ReadFull(svr.r, lb[:]); err != nil {
+		// A clean io.EOF means the stream ended between documents. A partial length is reported as
+		// io.ErrUnexpectedEOF by io.ReadFull.
+		svr.reset(nil)
+		return err
+	}
+
+	length := int32(binary.LittleEndian.Uint32(lb[:]))
+	if length < 5 {
+		svr.reset(nil)
+		return fmt.Errorf("invalid document length: %d", length)
+	}
+	if length > svr.maxSize {
+		svr.reset(nil)
+		return fmt.Errorf("document length %d exceeds the maximum document size of %d", length, svr.maxSize)
+	}
+
+	doc := make([]byte, length)
+	copy(doc, lb[:])
+	if _, err := io.ReadFull(svr.r, doc[4:]); err != nil {
+		svr.reset(nil)
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	svr.reset(doc)
+	return nil
+}
+
+// ReadDocument reads the next document from the stream and returns a DocumentReader for it.
+func (svr *streamValueReader) ReadDocument() (DocumentReader, error) {
+	if err := svr.next(); err != nil {
+		return nil, err
+	}
+	return svr.valueReader.ReadDocument()
+}
+
+// ReadValueBytes reads the next document from the stream and appends its bytes to dst.
+func (svr *streamValueReader) ReadValueBytes(dst []byte) (bsontype.Type, []byte, error) {
+	if err := svr.next(); err != nil {
+		return 0, dst, err
+	}
+	return svr.valueReader.ReadValueBytes(dst)
+}
+
+// streamValueWriter is a ValueWriter that writes consecutive BSON documents to an io.Writer.
+type streamValueWriter struct {
+	*valueWriter
+}
+
+// NewBSONStreamWriter returns a ValueWriter that writes a stream of consecutive BSON documents to w. Each document
+// is buffered while it is built and written to w once it is complete, so a document that fails to encode is never
+// written and does not affect the documents that follow it. The output can be read with NewBSONStreamReader.
+func NewBSONStreamWriter(w io.Writer) (ValueWriter, error) {
+	if w == nil {
+		return nil, errNilWriter
+	}
+	return &streamValueWriter{valueWriter: newValueWriter(w)}, nil
+}
+
+// WriteDocument starts a new document, discarding anything left buffered by a previous document that was not
+// completed.
+func (svw *streamValueWriter) WriteDocument() (DocumentWriter, error) {
+	w := svw.w
+	svw.reset(svw.buf[:0])
+	svw.w = w
+	return svw.valueWriter.WriteDocument()
+}
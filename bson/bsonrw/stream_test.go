@@ -0,0 +1,154 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsonrw
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func TestBSONStreamReader(t *testing.T) {
+	docs := []bsoncore.Document{
+		bsoncore.BuildDocument(nil, bsoncore.AppendInt32Element(nil, "a", 1)),
+		bsoncore.BuildDocument(nil, bsoncore.AppendStringElement(nil, "b", "hello")),
+		bsoncore.BuildDocument(nil, bsoncore.AppendDocumentElement(nil, "c",
+			bsoncore.BuildDocument(nil, bsoncore.AppendBinaryElement(nil, "d", 0x00, []byte{0x01, 0x02})))),
+	}
+	var stream []byte
+	for _, doc := range docs {
+		stream = append(stream, doc...)
+	}
+
+	t.Run("nil reader", func(t *testing.T) {
+		_, err := NewBSONStreamReader(nil)
+		if err != errNilReader {
+			t.Errorf("Expected error %v, got %v", errNilReader, err)
+		}
+	})
+	t.Run("invalid maximum size", func(t *testing.T) {
+		_, err := NewBSONStreamReaderSize(bytes.NewReader(stream), 4)
+		if err == nil {
+			t.Error("Expected an error for a maximum document size of 4")
+		}
+	})
+	t.Run("ReadDocument", func(t *testing.T) {
+		vr, err := NewBSONStreamReader(bytes.NewReader(stream))
+		noerr(t, err)
+		for i, want := range docs {
+			got, err := Copier{}.CopyDocumentToBytes(vr)
+			noerr(t, err)
+			if !bytes.Equal(got, want) {
+				t.Errorf("Document %d does not match. got %v; want %v", i, bsoncore.Document(got), want)
+			}
+		}
+		if _, err = vr.ReadDocument(); err != io.EOF {
+			t.Errorf("Expected io.EOF after the last document, got %v", err)
+		}
+	})
+	t.Run("partially read document", func(t *testing.T) {
+		vr, err := NewBSONStreamReader(bytes.NewReader(stream))
+		noerr(t, err)
+		dr, err := vr.ReadDocument()
+		noerr(t, err)
+		_, evr, err := dr.ReadElement()
+		noerr(t, err)
+		if evr.Type() != bsontype.Int32 {
+			t.Fatalf("Expected first element to be an int32, got %v", evr.Type())
+		}
+
+		// The rest of the first document is skipped by the next top level read.
+		_, got, err := vr.(BytesReader).ReadValueBytes(nil)
+		noerr(t, err)
+		if !bytes.Equal(got, docs[1]) {
+			t.Errorf("Document does not match. got %v; want %v", bsoncore.Document(got), docs[1])
+		}
+	})
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			stream []byte
+			max    int32
+			err    string
+		}{
+			{"truncated length", stream[:len(docs[0])+2], DefaultMaxDocumentSize, io.ErrUnexpectedEOF.Error()},
+			{"truncated document", stream[:len(docs[0])+6], DefaultMaxDocumentSize, io.ErrUnexpectedEOF.Error()},
+			{"length only", stream[:len(docs[0])+4], DefaultMaxDocumentSize, io.ErrUnexpectedEOF.Error()},
+			{"invalid length", append(append([]byte{}, docs[0]...), 0x04, 0x00, 0x00, 0x00), DefaultMaxDocumentSize, "invalid document length: 4"},
+			{"too large", stream, int32(len(docs[0])), "exceeds the maximum document size"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				vr, err := NewBSONStreamReaderSize(bytes.NewReader(tc.stream), tc.max)
+				noerr(t, err)
+				_, err = Copier{}.CopyDocumentToBytes(vr)
+				noerr(t, err)
+				_, err = vr.ReadDocument()
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Errorf("Expected error containing %q, got %v", tc.err, err)
+				}
+			})
+		}
+	})
+}
+
+func TestBSONStreamWriter(t *testing.T) {
+	docs := []bsoncore.Document{
+		bsoncore.BuildDocument(nil, bsoncore.AppendInt32Element(nil, "a", 1)),
+		bsoncore.BuildDocument(nil, bsoncore.AppendStringElement(nil, "b", "hello")),
+	}
+
+	t.Run("nil writer", func(t *testing.T) {
+		_, err := NewBSONStreamWriter(nil)
+		if err != errNilWriter {
+			t.Errorf("Expected error %v, got %v", errNilWriter, err)
+		}
+	})
+	t.Run("round trip", func(t *testing.T) {
+		var buf bytes.Buffer
+		vw, err := NewBSONStreamWriter(&buf)
+		noerr(t, err)
+		for _, doc := range docs {
+			noerr(t, Copier{}.CopyDocumentFromBytes(vw, doc))
+		}
+
+		vr, err := NewBSONStreamReader(&buf)
+		noerr(t, err)
+		for i, want := range docs {
+			got, err := Copier{}.CopyDocumentToBytes(vr)
+			noerr(t, err)
+			if !bytes.Equal(got, want) {
+				t.Errorf("Document %d does not match. got %v; want %v", i, bsoncore.Document(got), want)
+			}
+		}
+		if _, err = vr.ReadDocument(); err != io.EOF {
+			t.Errorf("Expected io.EOF after the last document, got %v", err)
+		}
+	})
+	t.Run("incomplete document is discarded", func(t *testing.T) {
+		var buf bytes.Buffer
+		vw, err := NewBSONStreamWriter(&buf)
+		noerr(t, err)
+		dw, err := vw.WriteDocument()
+		noerr(t, err)
+		evw, err := dw.WriteDocumentElement("a")
+		noerr(t, err)
+		noerr(t, evw.WriteInt32(1))
+		if buf.Len() != 0 {
+			t.Fatalf("Expected nothing to be written before the document is complete, got %d bytes", buf.Len())
+		}
+
+		noerr(t, Copier{}.CopyDocumentFromBytes(vw, docs[1]))
+		if !bytes.Equal(buf.Bytes(), docs[1]) {
+			t.Errorf("Stream does not match. got %v; want %v", buf.Bytes(), []byte(docs[1]))
+		}
+	})
+}
@@ -308,6 +308,10 @@ func (vr *valueReader) ReadValueBytes(dst []byte) (bsontype.Type, []byte, error)
 		if err != nil {
 			return bsontype.Type(0), nil, err
 		}
+		// Match ReadDocument, which rejects a top level document followed by extra bytes.
+		if int64(length) < int64(len(vr.d))-vr.offset {
+			return bsontype.Type(0), nil, fmt.Errorf("invalid document length")
+		}
 		dst, err = vr.appendBytes(dst, length)
 		if err != nil {
 			return bsontype.Type(0), nil, err
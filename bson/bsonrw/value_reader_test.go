@@ -1482,6 +1482,24 @@ func TestValueReader(t *testing.T) {
 				})
 			}
 		})
+		t.Run("ReadValueBytes/Top Level Doc With Extra Bytes", func(t *testing.T) {
+			doc := bsoncore.BuildDocument(nil, bsoncore.AppendDoubleElement(nil, "pi", 3.14159))
+			doc = append(doc, 0x00)
+			want := fmt.Errorf("invalid document length")
+
+			vr := &valueReader{d: doc, stack: []vrState{{mode: mTopLevel}}}
+			_, _, err := vr.ReadValueBytes(nil)
+			if !compareErrors(err, want) {
+				t.Errorf("Did not receive expected error. got %v; want %v", err, want)
+			}
+
+			// ReadDocument rejects the same document.
+			vr = &valueReader{d: doc, stack: []vrState{{mode: mTopLevel}}}
+			_, err = vr.ReadDocument()
+			if !compareErrors(err, want) {
+				t.Errorf("Did not receive expected error from ReadDocument. got %v; want %v", err, want)
+			}
+		})
 	})
 
 	t.Run("invalid transition", func(t *testing.T) {
@@ -263,6 +263,11 @@ func (vw *valueWriter) writeElementHeader(t bsontype.Type, destination mode, cal
 }
 
 func (vw *valueWriter) WriteValueBytes(t bsontype.Type, b []byte) error {
+	// A top level document is written as is, the same way WriteDocument and WriteDocumentEnd would write it.
+	if vw.stack[vw.frame].mode == mTopLevel && t == bsontype.EmbeddedDocument {
+		vw.buf = append(vw.buf, b...)
+		return vw.Flush()
+	}
 	if err := vw.writeElementHeader(t, mode(0), "WriteValueBytes"); err != nil {
 		return err
 	}
@@ -328,11 +328,23 @@ func TestValueWriter(t *testing.T) {
 			vw := newValueWriterFromSlice(nil)
 			want := TransitionError{current: mTopLevel, destination: mode(0),
 				name: "WriteValueBytes", modes: []mode{mElement, mValue}, action: "write"}
-			got := vw.WriteValueBytes(bsontype.EmbeddedDocument, nil)
+			got := vw.WriteValueBytes(bsontype.String, nil)
 			if !compareErrors(got, want) {
 				t.Errorf("Did not received expected error. got %v; want %v", got, want)
 			}
 		})
+		t.Run("top level document", func(t *testing.T) {
+			want := bsoncore.BuildDocument(nil, bsoncore.AppendStringElement(nil, "hello", "world"))
+
+			var sw SliceWriter
+			vw, err := NewBSONValueWriter(&sw)
+			noerr(t, err)
+			err = vw.(BytesWriter).WriteValueBytes(bsontype.EmbeddedDocument, want)
+			noerr(t, err)
+			if !bytes.Equal(sw, want) {
+				t.Errorf("Bytes are not equal. got %v; want %v", sw, want)
+			}
+		})
 		t.Run("success", func(t *testing.T) {
 			index, doc := bsoncore.ReserveLength(nil)
 			doc = bsoncore.AppendStringElement(doc, "hello", "world")
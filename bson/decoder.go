@@ -14,6 +14,7 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
 // ErrDecodeToNil is the error returned when trying to decode to a nil value
@@ -33,6 +34,8 @@ var decPool = sync.Pool{
 type Decoder struct {
 	dc bsoncodec.DecodeContext
 	vr bsonrw.ValueReader
+
+	validator *bsoncore.Validator
 }
 
 // NewDecoder returns a new decoder that uses the DefaultRegistry to read from vr.
@@ -68,9 +71,21 @@ func NewDecoderWithContext(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader) (*
 // The documentation for Unmarshal contains details about of BSON into a Go
 // value.
 func (d *Decoder) Decode(val interface{}) error {
+	vr := d.vr
+	if d.validator != nil {
+		buf, err := bsonrw.Copier{}.CopyDocumentToBytes(d.vr)
+		if err != nil {
+			return err
+		}
+		if err = d.validator.Validate(buf); err != nil {
+			return err
+		}
+		vr = bsonrw.NewBSONDocumentReader(buf)
+	}
+
 	if unmarshaler, ok := val.(Unmarshaler); ok {
 		// TODO(skriptble): Reuse a []byte here and use the AppendDocumentBytes method.
-		buf, err := bsonrw.Copier{}.CopyDocumentToBytes(d.vr)
+		buf, err := bsonrw.Copier{}.CopyDocumentToBytes(vr)
 		if err != nil {
 			return err
 		}
@@ -95,7 +110,7 @@ func (d *Decoder) Decode(val interface{}) error {
 	if err != nil {
 		return err
 	}
-	return decoder.DecodeValue(d.dc, d.vr, rval)
+	return decoder.DecodeValue(d.dc, vr, rval)
 }
 
 // Reset will reset the state of the decoder, using the same *DecodeContext used in
@@ -111,6 +126,14 @@ func (d *Decoder) SetRegistry(r *bsoncodec.Registry) error {
 	return nil
 }
 
+// SetValidator makes the decoder check each document with v before decoding it. Documents that fail validation are
+// not decoded, and Decode returns the bsoncore.ValidationError. Each document is copied into a new buffer to be
+// validated, and is then decoded from that copy. A nil v turns validation off.
+func (d *Decoder) SetValidator(v *bsoncore.Validator) error {
+	d.validator = v
+	return nil
+}
+
 // SetContext replaces the current registry of the decoder with dc.
 func (d *Decoder) SetContext(dc bsoncodec.DecodeContext) error {
 	d.dc = dc
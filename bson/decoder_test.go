@@ -9,6 +9,7 @@ package bson
 import (
 	"bytes"
 	"errors"
+	"io"
 	"reflect"
 	"testing"
 
@@ -247,6 +248,71 @@ func TestDecoderv2(t *testing.T) {
 			t.Fatalf("Decode error mismatch; expected %v, got %v", ErrDecodeToNil, err)
 		}
 	})
+	t.Run("Stream", func(t *testing.T) {
+		type item struct {
+			Name string
+			Data []byte
+		}
+		want := []item{{"a", []byte{0x01}}, {"b", []byte{0x02, 0x03}}, {"c", nil}}
+
+		var buf bytes.Buffer
+		vw, err := bsonrw.NewBSONStreamWriter(&buf)
+		noerr(t, err)
+		enc, err := NewEncoder(vw)
+		noerr(t, err)
+		for _, it := range want {
+			noerr(t, enc.Encode(it))
+		}
+		noerr(t, enc.Encode(D{{"item", "canvas"}}))
+
+		vr, err := bsonrw.NewBSONStreamReader(&buf)
+		noerr(t, err)
+		dec, err := NewDecoder(vr)
+		noerr(t, err)
+		var got []item
+		for range want {
+			var it item
+			noerr(t, dec.Decode(&it))
+			got = append(got, it)
+		}
+		if !cmp.Equal(got, want) {
+			t.Errorf("Decoded documents do not match. got %v; want %v", got, want)
+		}
+		unmarshaler := &testUnmarshaler{}
+		noerr(t, dec.Decode(unmarshaler))
+		if !bytes.Equal(unmarshaler.data, docToBytes(D{{"item", "canvas"}})) {
+			t.Errorf("Unmarshaled bytes do not match. got %v; want %v", unmarshaler.data, docToBytes(D{{"item", "canvas"}}))
+		}
+		if err = dec.Decode(&D{}); err != io.EOF {
+			t.Errorf("Expected io.EOF after the last document, got %v", err)
+		}
+	})
+	t.Run("SetValidator", func(t *testing.T) {
+		var buf bytes.Buffer
+		buf.Write(docToBytes(D{{"a", "ok"}}))
+		buf.Write(docToBytes(D{{"a", "x"}, {"a", "y"}}))
+		buf.Write(docToBytes(D{{"a", "x"}, {"a", "y"}}))
+		vr, err := bsonrw.NewBSONStreamReader(&buf)
+		noerr(t, err)
+		dec, err := NewDecoder(vr)
+		noerr(t, err)
+		noerr(t, dec.SetValidator(&bsoncore.Validator{CheckDuplicateKeys: true}))
+
+		var got struct{ A string }
+		noerr(t, dec.Decode(&got))
+		if got.A != "ok" {
+			t.Errorf("Decoded value does not match. got %q; want %q", got.A, "ok")
+		}
+		err = dec.Decode(&got)
+		if _, ok := err.(bsoncore.ValidationError); !ok {
+			t.Errorf("Expected a ValidationError, got %v", err)
+		}
+		noerr(t, dec.SetValidator(nil))
+		noerr(t, dec.Decode(&got))
+		if got.A != "y" {
+			t.Errorf("Decoded value does not match. got %q; want %q", got.A, "y")
+		}
+	})
 }
 
 type testDecoderCodec struct {
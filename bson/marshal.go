@@ -8,6 +8,7 @@ package bson
 
 import (
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonoptions"
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
 )
@@ -197,9 +198,24 @@ func MarshalExtJSONAppendWithRegistry(r *bsoncodec.Registry, dst []byte, val int
 // val to dst using Registry r. If dst is not large enough to hold the BSON
 // encoding of val, dst will be grown.
 func MarshalExtJSONAppendWithContext(ec bsoncodec.EncodeContext, dst []byte, val interface{}, canonical, escapeHTML bool) ([]byte, error) {
+	opts := bsonoptions.ExtJSON().SetCanonical(canonical).SetEscapeHTML(escapeHTML)
+	return MarshalExtJSONAppendWithOptions(ec, dst, val, opts)
+}
+
+// MarshalExtJSONWithOptions returns the extended JSON encoding of val using the given options. The options allow
+// the output to be indented, to be written in mongo shell syntax and to control how datetimes are rendered.
+func MarshalExtJSONWithOptions(val interface{}, opts ...*bsonoptions.ExtJSONOptions) ([]byte, error) {
+	dst := make([]byte, 0, defaultDstCap)
+	return MarshalExtJSONAppendWithOptions(bsoncodec.EncodeContext{Registry: DefaultRegistry}, dst, val, opts...)
+}
+
+// MarshalExtJSONAppendWithOptions will append the extended JSON encoding of
+// val to dst using EncodeContext ec and the given options. If dst is not large
+// enough to hold the extended JSON encoding of val, dst will be grown.
+func MarshalExtJSONAppendWithOptions(ec bsoncodec.EncodeContext, dst []byte, val interface{}, opts ...*bsonoptions.ExtJSONOptions) ([]byte, error) {
 	sw := new(bsonrw.SliceWriter)
 	*sw = dst
-	ejvw := extjPool.Get(sw, canonical, escapeHTML)
+	ejvw := extjPool.GetWithOptions(sw, opts...)
 	defer extjPool.Put(ejvw)
 
 	enc := encPool.Get().(*Encoder)
@@ -9,10 +9,12 @@ package bson
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonoptions"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -149,6 +151,43 @@ func TestMarshalExtJSONWithContext(t *testing.T) {
 	})
 }
 
+func TestMarshalExtJSONWithOptions(t *testing.T) {
+	t.Run("MarshalExtJSONWithOptions", func(t *testing.T) {
+		oid := primitive.ObjectID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C}
+		val := D{{"_id", oid}, {"n", int64(1)}}
+		opts := bsonoptions.ExtJSON().SetShellMode(true).SetIndent("\t")
+		got, err := MarshalExtJSONWithOptions(val, opts)
+		noerr(t, err)
+		want := []byte("{\n\t\"_id\": ObjectId(\"0102030405060708090a0b0c\"),\n\t\"n\": NumberLong(1)\n}")
+		if !bytes.Equal(got, want) {
+			t.Errorf("Bytes:\n%s\n%s", got, want)
+		}
+
+		var doc D
+		err = UnmarshalExtJSON(got, false, &doc)
+		noerr(t, err)
+		if !cmp.Equal(doc, val) {
+			t.Errorf("Documents do not match. got %v; want %v", doc, val)
+		}
+	})
+	t.Run("ISO dates round trip", func(t *testing.T) {
+		dt := primitive.NewDateTimeFromTime(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+		val := D{{"d", dt}}
+		for _, canonical := range []bool{true, false} {
+			opts := bsonoptions.ExtJSON().SetCanonical(canonical).SetDateFormat(bsonoptions.ExtJSONDateFormatISO)
+			got, err := MarshalExtJSONWithOptions(val, opts)
+			noerr(t, err)
+
+			var doc D
+			err = UnmarshalExtJSON(got, canonical, &doc)
+			noerr(t, err)
+			if !cmp.Equal(doc, val) {
+				t.Errorf("Documents do not match for canonical=%v. got %v; want %v", canonical, doc, val)
+			}
+		}
+	})
+}
+
 func TestMarshal_roundtripFromBytes(t *testing.T) {
 	before := []byte{
 		// length
@@ -207,3 +246,25 @@ func TestMarshal_roundtripFromDoc(t *testing.T) {
 		t.Errorf("Documents to not match. got %v; want %v", after, before)
 	}
 }
+
+type addrMarshaler struct{ V int32 }
+
+func (am *addrMarshaler) MarshalBSON() ([]byte, error) { return Marshal(D{{"marshaled", true}}) }
+
+func TestMarshalPointerReceiverMarshaler(t *testing.T) {
+	type doc struct{ M addrMarshaler }
+
+	// The fields of a struct encoded through a pointer are addressable, so MarshalBSON is used.
+	got, err := Marshal(&doc{})
+	require.NoError(t, err)
+	want, err := Marshal(D{{"m", D{{"marshaled", true}}}})
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+
+	// The fields of a struct encoded by value are not, so the struct is encoded as it would be without MarshalBSON.
+	got, err = Marshal(doc{})
+	require.NoError(t, err)
+	want, err = Marshal(D{{"m", D{{"v", int32(0)}}}})
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+}
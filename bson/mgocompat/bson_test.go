@@ -55,7 +55,7 @@ func makeZeroDoc(value interface{}) (zero interface{}) {
 
 func testUnmarshal(t *testing.T, data string, obj interface{}) {
 	zero := makeZeroDoc(obj)
-	err := bson.UnmarshalWithRegistry(mgoRegistry, []byte(data), zero)
+	err := bson.UnmarshalWithRegistry(Registry, []byte(data), zero)
 	assert.Nil(t, err, "expected nil error, got: %v", err)
 	assert.True(t, reflect.DeepEqual(zero, obj), "expected: %v, got: %v", obj, zero)
 }
@@ -83,7 +83,7 @@ var sampleItems = []testItemType{
 func TestMarshalSampleItems(t *testing.T) {
 	for i, item := range sampleItems {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
-			data, err := bson.MarshalWithRegistry(mgoRegistry, item.obj)
+			data, err := bson.MarshalWithRegistry(Registry, item.obj)
 			assert.Nil(t, err, "expected nil error, got: %v", err)
 			assert.Equal(t, string(data), item.data, "expected: %v, got: %v", item.data, string(data))
 		})
@@ -94,7 +94,7 @@ func TestUnmarshalSampleItems(t *testing.T) {
 	for i, item := range sampleItems {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
 			value := bson.M{}
-			err := bson.UnmarshalWithRegistry(mgoRegistry, []byte(item.data), &value)
+			err := bson.UnmarshalWithRegistry(Registry, []byte(item.data), &value)
 			assert.Nil(t, err, "expected nil error, got: %v", err)
 			assert.True(t, reflect.DeepEqual(value, item.obj), "expected: %v, got: %v", item.obj, value)
 		})
@@ -161,7 +161,7 @@ var allItems = []testItemType{
 func TestMarshalAllItems(t *testing.T) {
 	for i, item := range allItems {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
-			data, err := bson.MarshalWithRegistry(mgoRegistry, item.obj)
+			data, err := bson.MarshalWithRegistry(Registry, item.obj)
 			assert.Nil(t, err, "expected nil error, got: %v", err)
 			assert.Equal(t, string(data), wrapInDoc(item.data), "expected: %v, got: %v", wrapInDoc(item.data), string(data))
 		})
@@ -172,7 +172,7 @@ func TestUnmarshalAllItems(t *testing.T) {
 	for i, item := range allItems {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
 			value := bson.M{}
-			err := bson.UnmarshalWithRegistry(mgoRegistry, []byte(wrapInDoc(item.data)), &value)
+			err := bson.UnmarshalWithRegistry(Registry, []byte(wrapInDoc(item.data)), &value)
 			assert.Nil(t, err, "expected nil error, got: %v", err)
 			assert.True(t, reflect.DeepEqual(value, item.obj), "expected: %v, got: %v", item.obj, value)
 		})
@@ -191,7 +191,7 @@ func TestUnmarshalRawAllItems(t *testing.T) {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
 			pv := reflect.New(reflect.ValueOf(value).Type())
 			raw := bson.RawValue{Type: bsontype.Type(item.data[0]), Value: []byte(item.data[3:])}
-			err := raw.UnmarshalWithRegistry(mgoRegistry, pv.Interface())
+			err := raw.UnmarshalWithRegistry(Registry, pv.Interface())
 			assert.Nil(t, err, "expected nil error, got: %v", err)
 			assert.True(t, reflect.DeepEqual(value, pv.Elem().Interface()), "expected: %v, got: %v", value, pv.Elem().Interface())
 		})
@@ -200,26 +200,26 @@ func TestUnmarshalRawAllItems(t *testing.T) {
 
 func TestUnmarshalRawIncompatible(t *testing.T) {
 	raw := bson.RawValue{Type: 0x08, Value: []byte{0x01}} // true
-	err := raw.UnmarshalWithRegistry(mgoRegistry, &struct{}{})
+	err := raw.UnmarshalWithRegistry(Registry, &struct{}{})
 	assert.NotNil(t, err, "expected an error")
 }
 
 func TestUnmarshalZeroesStruct(t *testing.T) {
-	data, err := bson.MarshalWithRegistry(mgoRegistry, bson.M{"b": 2})
+	data, err := bson.MarshalWithRegistry(Registry, bson.M{"b": 2})
 	assert.Nil(t, err, "expected nil error, got: %v", err)
 	type T struct{ A, B int }
 	v := T{A: 1}
-	err = bson.UnmarshalWithRegistry(mgoRegistry, data, &v)
+	err = bson.UnmarshalWithRegistry(Registry, data, &v)
 	assert.Nil(t, err, "expected nil error, got: %v", err)
 	assert.Equal(t, 0, v.A, "expected: 0, got: %v", v.A)
 	assert.Equal(t, 2, v.B, "expected: 2, got: %v", v.B)
 }
 
 func TestUnmarshalZeroesMap(t *testing.T) {
-	data, err := bson.MarshalWithRegistry(mgoRegistry, bson.M{"b": 2})
+	data, err := bson.MarshalWithRegistry(Registry, bson.M{"b": 2})
 	assert.Nil(t, err, "expected nil error, got: %v", err)
 	m := bson.M{"a": 1}
-	err = bson.UnmarshalWithRegistry(mgoRegistry, data, &m)
+	err = bson.UnmarshalWithRegistry(Registry, data, &m)
 	assert.Nil(t, err, "expected nil error, got: %v", err)
 
 	want := bson.M{"b": 2}
@@ -227,12 +227,12 @@ func TestUnmarshalZeroesMap(t *testing.T) {
 }
 
 func TestUnmarshalNonNilInterface(t *testing.T) {
-	data, err := bson.MarshalWithRegistry(mgoRegistry, bson.M{"b": 2})
+	data, err := bson.MarshalWithRegistry(Registry, bson.M{"b": 2})
 	assert.Nil(t, err, "expected nil error, got: %v", err)
 	m := bson.M{"a": 1}
 	var i interface{}
 	i = m
-	err = bson.UnmarshalWithRegistry(mgoRegistry, data, &i)
+	err = bson.UnmarshalWithRegistry(Registry, data, &i)
 	assert.Nil(t, err, "expected nil error, got: %v", err)
 	assert.True(t, reflect.DeepEqual(bson.M{"b": 2}, i), "expected: %v, got: %v", bson.M{"b": 2}, i)
 	assert.True(t, reflect.DeepEqual(bson.M{"a": 1}, m), "expected: %v, got: %v", bson.M{"a": 1}, m)
@@ -265,10 +265,10 @@ func TestPtrInline(t *testing.T) {
 
 	for i, cs := range cases {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
-			data, err := bson.MarshalWithRegistry(mgoRegistry, cs.In)
+			data, err := bson.MarshalWithRegistry(Registry, cs.In)
 			assert.Nil(t, err, "expected nil error, got: %v", err)
 			var dataBSON bson.M
-			err = bson.UnmarshalWithRegistry(mgoRegistry, data, &dataBSON)
+			err = bson.UnmarshalWithRegistry(Registry, data, &dataBSON)
 			assert.Nil(t, err, "expected nil error, got: %v", err)
 
 			assert.True(t, reflect.DeepEqual(cs.Out, dataBSON), "expected: %v, got: %v", cs.Out, dataBSON)
@@ -326,8 +326,8 @@ var oneWayMarshalItems = []testItemType{
 		"\x10\x00\x08\x00\x00\x00"},
 
 	// There are no unsigned types in BSON.  Will unmarshal as int32 or int64.
-	//{bson.M{"": uint32(258)},
-	//	"\x10\x00\x02\x01\x00\x00"},
+	{bson.M{"": uint32(258)},
+		"\x10\x00\x02\x01\x00\x00"},
 	{bson.M{"": uint64(258)},
 		"\x12\x00\x02\x01\x00\x00\x00\x00\x00\x00"},
 	{bson.M{"": uint64(258 << 32)},
@@ -348,7 +348,7 @@ var oneWayMarshalItems = []testItemType{
 func TestOneWayMarshalItems(t *testing.T) {
 	for i, item := range oneWayMarshalItems {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
-			data, err := bson.MarshalWithRegistry(mgoRegistry, item.obj)
+			data, err := bson.MarshalWithRegistry(Registry, item.obj)
 			assert.Nil(t, err, "expected nil error, got: %v", err)
 
 			assert.Equal(t, wrapInDoc(item.data), string(data), "expected: %v, got: %v", wrapInDoc(item.data), string(data))
@@ -371,7 +371,7 @@ var arrayOpsMarshalItems = []testItemType{
 func TestArrayOpsMarshalItems(t *testing.T) {
 	for i, item := range arrayOpsMarshalItems {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
-			data, err := bson.MarshalWithRegistry(mgoRegistry, item.obj)
+			data, err := bson.MarshalWithRegistry(Registry, item.obj)
 			assert.Nil(t, err, "expected nil error, got: %v", err)
 			assert.Equal(t, wrapInDoc(item.data), string(data), "expected: %v, got: %v", wrapInDoc(item.data), string(data))
 		})
@@ -401,7 +401,7 @@ var structSampleItems = []testItemType{
 func TestMarshalStructSampleItems(t *testing.T) {
 	for i, item := range structSampleItems {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
-			data, err := bson.MarshalWithRegistry(mgoRegistry, item.obj)
+			data, err := bson.MarshalWithRegistry(Registry, item.obj)
 			assert.Nil(t, err, "expected nil error, got: %v", err)
 			assert.Equal(t, item.data, string(data), "expected: %v, got: %v", item.data, string(data))
 		})
@@ -419,13 +419,13 @@ func TestUnmarshalStructSampleItems(t *testing.T) {
 func Test64bitInt(t *testing.T) {
 	var i int64 = (1 << 31)
 	if int(i) > 0 {
-		data, err := bson.MarshalWithRegistry(mgoRegistry, bson.M{"i": int(i)})
+		data, err := bson.MarshalWithRegistry(Registry, bson.M{"i": int(i)})
 		assert.Nil(t, err, "expected nil error, got: %v", err)
 		want := wrapInDoc("\x12i\x00\x00\x00\x00\x80\x00\x00\x00\x00")
 		assert.Equal(t, want, string(data), "expected: %v, got: %v", want, string(data))
 
 		var result struct{ I int }
-		err = bson.UnmarshalWithRegistry(mgoRegistry, data, &result)
+		err = bson.UnmarshalWithRegistry(Registry, data, &result)
 		assert.Nil(t, err, "expected nil error, got: %v", err)
 		assert.Equal(t, i, int64(result.I), "expected: %v, got: %v", i, int64(result.I))
 	}
@@ -444,12 +444,12 @@ func (t *prefixPtr) GetBSON() (interface{}, error) {
 	return "foo-" + string(*t), nil
 }
 
-func (t *prefixPtr) SetBSON(raw bson.Raw) error {
+func (t *prefixPtr) SetBSON(raw bson.RawValue) error {
 	var s string
-	// if raw.Kind == 0x0A {
-	// 	return bson.ErrSetZero
-	// }
-	if err := bson.UnmarshalWithRegistry(mgoRegistry, raw, &s); err != nil {
+	if raw.Type == 0x0A {
+		return ErrSetZero
+	}
+	if err := raw.UnmarshalWithRegistry(Registry, &s); err != nil {
 		return err
 	}
 	if !strings.HasPrefix(s, "foo-") {
@@ -463,12 +463,12 @@ func (t prefixVal) GetBSON() (interface{}, error) {
 	return "foo-" + string(t), nil
 }
 
-func (t *prefixVal) SetBSON(raw bson.Raw) error {
+func (t *prefixVal) SetBSON(raw bson.RawValue) error {
 	var s string
-	// if raw.Kind == 0x0A {
-	// 	return bson.ErrSetZero
-	// }
-	if err := bson.UnmarshalWithRegistry(mgoRegistry, raw, &s); err != nil {
+	if raw.Type == 0x0A {
+		return ErrSetZero
+	}
+	if err := raw.UnmarshalWithRegistry(Registry, &s); err != nil {
 		return err
 	}
 	if !strings.HasPrefix(s, "foo-") {
@@ -519,20 +519,20 @@ var structItems = []testItemType{
 	{&struct{ V [2]byte }{[2]byte{'y', 'o'}},
 		"\x05v\x00\x02\x00\x00\x00\x00yo"},
 
-	// {&struct{ V prefixPtr }{prefixPtr("buzz")},
-	// 	"\x02v\x00\x09\x00\x00\x00foo-buzz\x00"},
+	{&struct{ V prefixPtr }{prefixPtr("buzz")},
+		"\x02v\x00\x09\x00\x00\x00foo-buzz\x00"},
 
-	// {&struct{ V *prefixPtr }{&prefixptr},
-	// 	"\x02v\x00\x08\x00\x00\x00foo-bar\x00"},
+	{&struct{ V *prefixPtr }{&prefixptr},
+		"\x02v\x00\x08\x00\x00\x00foo-bar\x00"},
 
 	{&struct{ V *prefixPtr }{nil},
 		"\x0Av\x00"},
 
-	// {&struct{ V prefixVal }{prefixVal("buzz")},
-	// 	"\x02v\x00\x09\x00\x00\x00foo-buzz\x00"},
+	{&struct{ V prefixVal }{prefixVal("buzz")},
+		"\x02v\x00\x09\x00\x00\x00foo-buzz\x00"},
 
-	// {&struct{ V *prefixVal }{&prefixval},
-	// 	"\x02v\x00\x08\x00\x00\x00foo-bar\x00"},
+	{&struct{ V *prefixVal }{&prefixval},
+		"\x02v\x00\x08\x00\x00\x00foo-bar\x00"},
 
 	{&struct{ V *prefixVal }{nil},
 		"\x0Av\x00"},
@@ -541,7 +541,7 @@ var structItems = []testItemType{
 func TestMarshalStructItems(t *testing.T) {
 	for i, item := range structItems {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
-			data, err := bson.MarshalWithRegistry(mgoRegistry, item.obj)
+			data, err := bson.MarshalWithRegistry(Registry, item.obj)
 			assert.Nil(t, err, "expected nil error, got: %v", err)
 			assert.Equal(t, wrapInDoc(item.data), string(data), "expected: %v, got: %v", wrapInDoc(item.data), string(data))
 		})
@@ -561,7 +561,7 @@ func TestUnmarshalRawStructItems(t *testing.T) {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
 			raw := bson.Raw(wrapInDoc(item.data))
 			zero := makeZeroDoc(item.obj)
-			err := bson.UnmarshalWithRegistry(mgoRegistry, raw, zero)
+			err := bson.UnmarshalWithRegistry(Registry, raw, zero)
 			assert.Nil(t, err, "expected nil error, got: %v", err)
 			assert.True(t, reflect.DeepEqual(item.obj, zero), "expected: %v, got: %v", item.obj, zero)
 		})
@@ -572,7 +572,7 @@ func TestUnmarshalRawStructItems(t *testing.T) {
 // 	// Regression test: shouldn't try to nil out the pointer itself,
 // 	// as it's not settable.
 // 	raw := bson.Raw{}
-// 	err := bson.UnmarshalWithRegistry(mgoRegistry, raw, &struct{}{})
+// 	err := bson.UnmarshalWithRegistry(Registry, raw, &struct{}{})
 // 	assert.Nil(t, err, "expected nil error, got: %v", err)
 // }
 
@@ -609,7 +609,7 @@ var marshalItems = []testItemType{
 func TestMarshalOneWayItems(t *testing.T) {
 	for i, item := range marshalItems {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
-			data, err := bson.MarshalWithRegistry(mgoRegistry, item.obj)
+			data, err := bson.MarshalWithRegistry(Registry, item.obj)
 			assert.Nil(t, err, "expected nil error, got: %v", err)
 			assert.Equal(t, wrapInDoc(item.data), string(data), "expected: %v, got: %v", wrapInDoc(item.data), string(data))
 		})
@@ -693,7 +693,7 @@ func TestUnmarshalNilInStruct(t *testing.T) {
 	// Nil is the default value, so we need to ensure it's indeed being set.
 	b := byte(1)
 	v := &struct{ Ptr *byte }{&b}
-	err := bson.UnmarshalWithRegistry(mgoRegistry, []byte(wrapInDoc("\x0Aptr\x00")), v)
+	err := bson.UnmarshalWithRegistry(Registry, []byte(wrapInDoc("\x0Aptr\x00")), v)
 	assert.Nil(t, err, "expected nil error, got: %v", err)
 
 	want := &struct{ Ptr *byte }{nil}
@@ -736,7 +736,7 @@ var marshalErrorItems = []testItemType{
 func TestMarshalErrorItems(t *testing.T) {
 	for i, item := range marshalErrorItems {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
-			data, err := bson.MarshalWithRegistry(mgoRegistry, item.obj)
+			data, err := bson.MarshalWithRegistry(Registry, item.obj)
 
 			assert.NotNil(t, err, "expected error")
 			assert.Nil(t, data, " expected nil data, got: %v", data)
@@ -794,7 +794,7 @@ func TestUnmarshalErrorItems(t *testing.T) {
 			default:
 				value = item.obj
 			}
-			err := bson.UnmarshalWithRegistry(mgoRegistry, data, value)
+			err := bson.UnmarshalWithRegistry(Registry, data, value)
 			assert.NotNil(t, err, "expected error")
 		})
 	}
@@ -828,7 +828,7 @@ var unmarshalRawErrorItems = []unmarshalRawErrorType{
 func TestUnmarshalRawErrorItems(t *testing.T) {
 	for i, item := range unmarshalRawErrorItems {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
-			err := item.raw.UnmarshalWithRegistry(mgoRegistry, item.obj)
+			err := item.raw.UnmarshalWithRegistry(Registry, item.obj)
 			assert.NotNil(t, err, "expected error")
 		})
 	}
@@ -866,10 +866,10 @@ var corruptedData = []string{
 func TestUnmarshalMapDocumentTooShort(t *testing.T) {
 	for i, data := range corruptedData {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
-			err := bson.UnmarshalWithRegistry(mgoRegistry, []byte(data), bson.M{})
+			err := bson.UnmarshalWithRegistry(Registry, []byte(data), bson.M{})
 			assert.NotNil(t, err, "expected error, got nil")
 
-			err = bson.UnmarshalWithRegistry(mgoRegistry, []byte(data), &struct{}{})
+			err = bson.UnmarshalWithRegistry(Registry, []byte(data), &struct{}{})
 			assert.NotNil(t, err, "expected error, got nil")
 		})
 	}
@@ -884,8 +884,8 @@ type setterType struct {
 	received interface{}
 }
 
-func (o *setterType) SetBSON(raw bson.Raw) error {
-	err := bson.UnmarshalWithRegistry(mgoRegistry, raw, &o.received)
+func (o *setterType) SetBSON(raw bson.RawValue) error {
+	err := raw.UnmarshalWithRegistry(Registry, &o.received)
 	if err != nil {
 		panic("The panic:" + err.Error())
 	}
@@ -905,43 +905,43 @@ type valSetterDoc struct {
 	Field setterType `bson:"_"`
 }
 
-// func TestUnmarshalAllItemsWithPtrSetter(t *testing.T) {
-// 	for _, item := range allItems {
-// 		for i := 0; i != 2; i++ {
-// 			var field *setterType
-// 			if i == 0 {
-// 				obj := &ptrSetterDoc{}
-// 				err := bson.UnmarshalWithRegistry(mgoRegistry, []byte(wrapInDoc(item.data)), obj)
-// 				assert.Nil(t, err, "expected nil error, got: %v", err)
-// 				field = obj.Field
-// 			} else {
-// 				obj := &valSetterDoc{}
-// 				err := bson.UnmarshalWithRegistry(mgoRegistry, []byte(wrapInDoc(item.data)), obj)
-// 				assert.Nil(t, err, "expected nil error, got: %v", err)
-// 				field = &obj.Field
-// 			}
-// 			if item.data == "" {
-// 				// Nothing to unmarshal. Should be untouched.
-// 				if i == 0 {
-// 					assert.Nil(t, field, "expected field to be nil, got: %v", field)
-// 				} else {
-// 					assert.Nil(t, field.received, "expected field.recieved to be nil, got: %v", field.received)
-// 				}
-// 			} else {
-// 				expected := item.obj.(bson.M)["_"]
-// 				assert.NotNil(t, field, "Pointer not initialized (%#v)", expected)
-// 				assert.True(t, reflect.DeepEqual(expected, field.received), "expected field.recieved to be: %v, got: %v", expected, field.received)
-// 			}
-// 		}
-// 	}
-// }
+func TestUnmarshalAllItemsWithPtrSetter(t *testing.T) {
+	for _, item := range allItems {
+		for i := 0; i != 2; i++ {
+			var field *setterType
+			if i == 0 {
+				obj := &ptrSetterDoc{}
+				err := bson.UnmarshalWithRegistry(Registry, []byte(wrapInDoc(item.data)), obj)
+				assert.Nil(t, err, "expected nil error, got: %v", err)
+				field = obj.Field
+			} else {
+				obj := &valSetterDoc{}
+				err := bson.UnmarshalWithRegistry(Registry, []byte(wrapInDoc(item.data)), obj)
+				assert.Nil(t, err, "expected nil error, got: %v", err)
+				field = &obj.Field
+			}
+			if item.data == "" {
+				// Nothing to unmarshal. Should be untouched.
+				if i == 0 {
+					assert.Nil(t, field, "expected field to be nil, got: %v", field)
+				} else {
+					assert.Nil(t, field.received, "expected field.recieved to be nil, got: %v", field.received)
+				}
+			} else {
+				expected := item.obj.(bson.M)["_"]
+				assert.NotNil(t, field, "Pointer not initialized (%#v)", expected)
+				assert.True(t, reflect.DeepEqual(expected, field.received), "expected field.recieved to be: %v, got: %v", expected, field.received)
+			}
+		}
+	}
+}
 
-// func TestUnmarshalWholeDocumentWithSetter(t *testing.T) {
-// 	obj := &setterType{}
-// 	err := bson.UnmarshalWithRegistry(mgoRegistry, []byte(sampleItems[0].data), obj)
-// 	assert.Nil(t, err, "expected nil error, got: %v", err)
-// 	assert.True(t, reflect.DeepEqual(bson.M{"hello": "world"}, obj.received), "expected obj.recieved to be: %v, got: %v", bson.M{"hello": "world"}, obj.received)
-// }
+func TestUnmarshalWholeDocumentWithSetter(t *testing.T) {
+	obj := &setterType{}
+	err := bson.UnmarshalWithRegistry(Registry, []byte(sampleItems[0].data), obj)
+	assert.Nil(t, err, "expected nil error, got: %v", err)
+	assert.True(t, reflect.DeepEqual(bson.M{"hello": "world"}, obj.received), "expected obj.recieved to be: %v, got: %v", bson.M{"hello": "world"}, obj.received)
+}
 
 // func TestUnmarshalSetterOmits(t *testing.T) {
 // 	err := fmt.Errorf("incorrect type")
@@ -957,7 +957,7 @@ type valSetterDoc struct {
 // 		"\x02def\x00\x02\x00\x00\x002\x00" +
 // 		"\x02ghi\x00\x02\x00\x00\x003\x00" +
 // 		"\x02jkl\x00\x02\x00\x00\x004\x00")
-// 	err = bson.UnmarshalWithRegistry(mgoRegistry, []byte(data), m)
+// 	err = bson.UnmarshalWithRegistry(Registry, []byte(data), m)
 // 	assert.Nil(t, err, "expected nil error, got: %v", err)
 // 	assert.NotNil(t, m["abc"], "expected value not to be nil")
 // 	assert.Nil(t, m["def"], "expected value to be nil, got: %v", m["def"])
@@ -968,24 +968,24 @@ type valSetterDoc struct {
 // 	assert.Equal(t, "3", m["ghi"].received, "expected m[\"ghi\"].recieved to be: %v, got: %v", "3", m["ghi"].received)
 // }
 
-// func TestUnmarshalSetterErrors(t *testing.T) {
-// 	boom := errors.New("BOOM")
-// 	setterResult["2"] = boom
-// 	defer delete(setterResult, "2")
+func TestUnmarshalSetterErrors(t *testing.T) {
+	boom := errors.New("BOOM")
+	setterResult["2"] = boom
+	defer delete(setterResult, "2")
 
-// 	m := map[string]*setterType{}
-// 	data := wrapInDoc("\x02abc\x00\x02\x00\x00\x001\x00" +
-// 		"\x02def\x00\x02\x00\x00\x002\x00" +
-// 		"\x02ghi\x00\x02\x00\x00\x003\x00")
-// 	err := bson.UnmarshalWithRegistry(mgoRegistry, []byte(data), m)
-// 	assert.Equal(t, boom, err, "expected error to be: %v, got: %v", boom, err)
+	m := map[string]*setterType{}
+	data := wrapInDoc("\x02abc\x00\x02\x00\x00\x001\x00" +
+		"\x02def\x00\x02\x00\x00\x002\x00" +
+		"\x02ghi\x00\x02\x00\x00\x003\x00")
+	err := bson.UnmarshalWithRegistry(Registry, []byte(data), m)
+	assert.Equal(t, boom, err, "expected error to be: %v, got: %v", boom, err)
 
-// 	assert.NotNil(t, m["abc"], "expected value not to be nil")
-// 	assert.Nil(t, m["def"], "expected value to be nil, got: %v", m["def"])
-// 	assert.Nil(t, m["ghi"], "expected value to be nil, got: %v", m["ghi"])
+	assert.NotNil(t, m["abc"], "expected value not to be nil")
+	assert.Nil(t, m["def"], "expected value to be nil, got: %v", m["def"])
+	assert.Nil(t, m["ghi"], "expected value to be nil, got: %v", m["ghi"])
 
-// 	assert.Equal(t, "1", m["abc"].received, "expected m[\"abc\"].recieved to be: %v, got: %v", "1", m["abc"].received)
-// }
+	assert.Equal(t, "1", m["abc"].received, "expected m[\"abc\"].recieved to be: %v, got: %v", "1", m["abc"].received)
+}
 
 func TestDMap(t *testing.T) {
 	d := bson.D{{"a", 1}, {"b", 2}}
@@ -993,21 +993,21 @@ func TestDMap(t *testing.T) {
 	assert.True(t, reflect.DeepEqual(want, d.Map()), "expected: %v, got: %v", want, d.Map())
 }
 
-// func TestUnmarshalSetterErrSetZero(t *testing.T) {
-// 	//setterResult["foo"] = bson.ErrSetZero
-// 	defer delete(setterResult, "field")
+func TestUnmarshalSetterErrSetZero(t *testing.T) {
+	setterResult["foo"] = ErrSetZero
+	defer delete(setterResult, "foo")
 
-// 	data, err := bson.MarshalWithRegistry(mgoRegistry, bson.M{"field": "foo"})
-// 	assert.Nil(t, err, "expected nil error, got: %v", err)
+	data, err := bson.MarshalWithRegistry(Registry, bson.M{"field": "foo"})
+	assert.Nil(t, err, "expected nil error, got: %v", err)
 
-// 	m := map[string]*setterType{}
-// 	err = bson.UnmarshalWithRegistry(mgoRegistry, []byte(data), m)
-// 	assert.Nil(t, err, "expected nil error, got: %v", err)
+	m := map[string]*setterType{}
+	err = bson.UnmarshalWithRegistry(Registry, []byte(data), m)
+	assert.Nil(t, err, "expected nil error, got: %v", err)
 
-// 	value, ok := m["field"]
-// 	assert.True(t, reflect.DeepEqual(true, ok), "expected ok to be: %v, got: %v", true, ok)
-// 	assert.Nil(t, value, "expected nil value, got: %v", value)
-// }
+	value, ok := m["field"]
+	assert.True(t, reflect.DeepEqual(true, ok), "expected ok to be: %v, got: %v", true, ok)
+	assert.Nil(t, value, "expected nil value, got: %v", value)
+}
 
 // --------------------------------------------------------------------------
 // Getter test cases.
@@ -1028,42 +1028,42 @@ type docWithGetterField struct {
 	Field *typeWithGetter `bson:"_"`
 }
 
-// func TestMarshalAllItemsWithGetter(t *testing.T) {
-// 	for i, item := range allItems {
-// 		if item.data == "" {
-// 			continue
-// 		}
-// 		obj := &docWithGetterField{}
-// 		obj.Field = &typeWithGetter{result: item.obj.(bson.M)["_"]}
-// 		data, err := bson.MarshalWithRegistry(mgoRegistry, obj)
-// 		assert.Nil(t, err, "expected nil error, got: %v", err)
-// 		assert.Equal(t, wrapInDoc(item.data), string(data),
-// 			"expected value at %v to be: %v, got: %v", i, wrapInDoc(item.data), string(data))
-// 	}
-// }
+func TestMarshalAllItemsWithGetter(t *testing.T) {
+	for i, item := range allItems {
+		if item.data == "" {
+			continue
+		}
+		obj := &docWithGetterField{}
+		obj.Field = &typeWithGetter{result: item.obj.(bson.M)["_"]}
+		data, err := bson.MarshalWithRegistry(Registry, obj)
+		assert.Nil(t, err, "expected nil error, got: %v", err)
+		assert.Equal(t, wrapInDoc(item.data), string(data),
+			"expected value at %v to be: %v, got: %v", i, wrapInDoc(item.data), string(data))
+	}
+}
 
-// func TestMarshalWholeDocumentWithGetter(t *testing.T) {
-// 	obj := &typeWithGetter{result: sampleItems[0].obj}
-// 	data, err := bson.MarshalWithRegistry(mgoRegistry, obj)
-// 	assert.Nil(t, err, "expected nil error, got: %v", err)
-// 	assert.Equal(t, sampleItems[0].data, string(data),
-// 		"expected: %v, got: %v", sampleItems[0].data, string(data))
-// }
+func TestMarshalWholeDocumentWithGetter(t *testing.T) {
+	obj := &typeWithGetter{result: sampleItems[0].obj}
+	data, err := bson.MarshalWithRegistry(Registry, obj)
+	assert.Nil(t, err, "expected nil error, got: %v", err)
+	assert.Equal(t, sampleItems[0].data, string(data),
+		"expected: %v, got: %v", sampleItems[0].data, string(data))
+}
 
-// func TestGetterErrors(t *testing.T) {
-// 	e := errors.New("oops")
+func TestGetterErrors(t *testing.T) {
+	e := errors.New("oops")
 
-// 	obj1 := &docWithGetterField{}
-// 	obj1.Field = &typeWithGetter{sampleItems[0].obj, e}
-// 	data, err := bson.MarshalWithRegistry(mgoRegistry, obj1)
-// 	assert.Equal(t, e, err, "expected error: %v, got: %v", e, err)
-// 	assert.Nil(t, data, "expected nil data, got: %v", data)
+	obj1 := &docWithGetterField{}
+	obj1.Field = &typeWithGetter{sampleItems[0].obj, e}
+	data, err := bson.MarshalWithRegistry(Registry, obj1)
+	assert.Equal(t, e, err, "expected error: %v, got: %v", e, err)
+	assert.Nil(t, data, "expected nil data, got: %v", data)
 
-// 	obj2 := &typeWithGetter{sampleItems[0].obj, e}
-// 	data, err = bson.MarshalWithRegistry(mgoRegistry, obj2)
-// 	assert.Equal(t, e, err, "expected error: %v, got: %v", e, err)
-// 	assert.Nil(t, data, "expected nil data, got: %v", data)
-// }
+	obj2 := &typeWithGetter{sampleItems[0].obj, e}
+	data, err = bson.MarshalWithRegistry(Registry, obj2)
+	assert.Equal(t, e, err, "expected error: %v, got: %v", e, err)
+	assert.Nil(t, data, "expected nil data, got: %v", data)
+}
 
 type intGetter int64
 
@@ -1077,24 +1077,24 @@ type typeWithIntGetter struct {
 
 func TestMarshalShortWithGetter(t *testing.T) {
 	obj := typeWithIntGetter{42}
-	data, err := bson.MarshalWithRegistry(mgoRegistry, obj)
+	data, err := bson.MarshalWithRegistry(Registry, obj)
 	assert.Nil(t, err, "expected nil error, got: %v", err)
 	m := bson.M{}
-	err = bson.UnmarshalWithRegistry(mgoRegistry, data, &m)
+	err = bson.UnmarshalWithRegistry(Registry, data, &m)
 	assert.Nil(t, err, "expected nil error, got: %v", err)
 	assert.Equal(t, 42, m["v"], "expected m[\"v\"] to be: %v, got: %v", 42, m["v"])
 }
 
-// func TestMarshalWithGetterNil(t *testing.T) {
-// 	obj := docWithGetterField{}
-// 	data, err := bson.MarshalWithRegistry(mgoRegistry, obj)
-// 	assert.Nil(t, err, "expected nil error, got: %v", err)
-// 	m := bson.M{}
-// 	err = bson.UnmarshalWithRegistry(mgoRegistry, data, &m)
-// 	assert.Nil(t, err, "expected nil error, got: %v", err)
-// 	want := bson.M{"_": "<value is nil>"}
-// 	assert.Equal(t, want, m, "expected m[\"v\"] to be: %v, got: %v", want, m)
-// }
+func TestMarshalWithGetterNil(t *testing.T) {
+	obj := docWithGetterField{}
+	data, err := bson.MarshalWithRegistry(Registry, obj)
+	assert.Nil(t, err, "expected nil error, got: %v", err)
+	m := bson.M{}
+	err = bson.UnmarshalWithRegistry(Registry, data, &m)
+	assert.Nil(t, err, "expected nil error, got: %v", err)
+	want := bson.M{"_": "<value is nil>"}
+	assert.Equal(t, want, m, "expected m[\"v\"] to be: %v, got: %v", want, m)
+}
 
 // --------------------------------------------------------------------------
 // Cross-type conversion tests.
@@ -1220,9 +1220,9 @@ func (s getterSetterD) GetBSON() (interface{}, error) {
 	return bson.D(s[:len(s)-1]), nil
 }
 
-func (s *getterSetterD) SetBSON(raw bson.Raw) error {
+func (s *getterSetterD) SetBSON(raw bson.RawValue) error {
 	var doc bson.D
-	err := bson.UnmarshalWithRegistry(mgoRegistry, raw, &doc)
+	err := raw.UnmarshalWithRegistry(Registry, &doc)
 	doc = append(doc, bson.E{"suffix", true})
 	*s = getterSetterD(doc)
 	return err
@@ -1234,9 +1234,9 @@ func (i getterSetterInt) GetBSON() (interface{}, error) {
 	return bson.D{{"a", int(i)}}, nil
 }
 
-func (i *getterSetterInt) SetBSON(raw bson.Raw) error {
+func (i *getterSetterInt) SetBSON(raw bson.RawValue) error {
 	var doc struct{ A int }
-	err := bson.UnmarshalWithRegistry(mgoRegistry, raw, &doc)
+	err := raw.UnmarshalWithRegistry(Registry, &doc)
 	*i = getterSetterInt(doc.A)
 	return err
 }
@@ -1247,9 +1247,9 @@ type ifaceType interface {
 
 type ifaceSlice []ifaceType
 
-func (s *ifaceSlice) SetBSON(raw bson.Raw) error {
+func (s *ifaceSlice) SetBSON(raw bson.RawValue) error {
 	var ns []int
-	if err := bson.UnmarshalWithRegistry(mgoRegistry, raw, &ns); err != nil {
+	if err := raw.UnmarshalWithRegistry(Registry, &ns); err != nil {
 		return err
 	}
 	*s = make(ifaceSlice, ns[0])
@@ -1490,11 +1490,11 @@ var twoWayCrossItems = []crossTypeItem{
 	{&struct{ N json.Number }{"9223372036854776000"}, map[string]interface{}{"n": float64(1 << 63)}},
 
 	// bson.D <=> non-struct getter/setter
-	// {&bson.D{{"a", 1}}, &getterSetterD{{"a", 1}, {"suffix", true}}},
-	// {&bson.D{{"a", 42}}, &gsintvar},
+	{&bson.D{{"a", 1}}, &getterSetterD{{"a", 1}, {"suffix", true}}},
+	{&bson.D{{"a", 42}}, &gsintvar},
 
 	// Interface slice setter.
-	// {&struct{ V ifaceSlice }{ifaceSlice{nil, nil, nil}}, bson.M{"v": []interface{}{3}}},
+	{&struct{ V ifaceSlice }{ifaceSlice{nil, nil, nil}}, bson.M{"v": []interface{}{3}}},
 }
 
 // Same thing, but only one way (obj1 => obj2).
@@ -1522,9 +1522,9 @@ var oneWayCrossItems = []crossTypeItem{
 
 func testCrossPair(t *testing.T, dump interface{}, load interface{}) {
 	zero := makeZeroDoc(load)
-	data, err := bson.MarshalWithRegistry(mgoRegistry, dump)
+	data, err := bson.MarshalWithRegistry(Registry, dump)
 	assert.Nil(t, err, "expected nil error, got: %v", err)
-	err = bson.UnmarshalWithRegistry(mgoRegistry, data, zero)
+	err = bson.UnmarshalWithRegistry(Registry, data, zero)
 	assert.Nil(t, err, "expected nil error, got: %v", err)
 
 	assert.True(t, reflect.DeepEqual(load, zero), "expected: %v, got: %v", load, zero)
@@ -1632,11 +1632,11 @@ func TestMarshalNotRespectNil(t *testing.T) {
 	assert.Nil(t, testStruct1.BSlice, "expected nil byte slice, got: %v", testStruct1.BSlice)
 	assert.Nil(t, testStruct1.Map, "expected nil map, got: %v", testStruct1.Map)
 
-	b, _ := bson.MarshalWithRegistry(mgoRegistry, testStruct1)
+	b, _ := bson.MarshalWithRegistry(Registry, testStruct1)
 
 	testStruct2 := T{}
 
-	_ = bson.UnmarshalWithRegistry(mgoRegistry, b, &testStruct2)
+	_ = bson.UnmarshalWithRegistry(Registry, b, &testStruct2)
 
 	assert.NotNil(t, testStruct2.Slice, "expected non-nil slice")
 	assert.NotNil(t, testStruct2.BSlice, "expected non-nil byte slice")
@@ -1663,11 +1663,11 @@ func TestMarshalNotRespectNil(t *testing.T) {
 // 	assert.Nil(t, testStruct1.MapPtr, "expected nil map ptr, got: %v", testStruct1.MapPtr)
 // 	assert.Nil(t, testStruct1.Ptr, "expected nil ptr, got: %v", testStruct1.Ptr)
 
-// 	b, _ := bson.MarshalWithRegistry(mgoRegistry, testStruct1)
+// 	b, _ := bson.MarshalWithRegistry(Registry, testStruct1)
 
 // 	testStruct2 := T{}
 
-// 	_ = bson.UnmarshalWithRegistry(mgoRegistry, b, &testStruct2)
+// 	_ = bson.UnmarshalWithRegistry(Registry, b, &testStruct2)
 
 // 	assert.Nil(t, testStruct2.Slice, "expected nil slice, got: %v", testStruct2.Slice)
 // 	assert.Nil(t, testStruct2.SlicePtr, "expected nil slice ptr, got: %v", testStruct2.SlicePtr)
@@ -1687,11 +1687,11 @@ func TestMarshalNotRespectNil(t *testing.T) {
 // 	assert.NotNil(t, testStruct1.Map, "expected non-nil map")
 // 	assert.NotNil(t, testStruct1.MapPtr, "expected non-nil map ptr")
 
-// 	b, _ = bson.MarshalWithRegistry(mgoRegistry, testStruct1)
+// 	b, _ = bson.MarshalWithRegistry(Registry, testStruct1)
 
 // 	testStruct2 = T{}
 
-// 	_ = bson.UnmarshalWithRegistry(mgoRegistry, b, &testStruct2)
+// 	_ = bson.UnmarshalWithRegistry(Registry, b, &testStruct2)
 
 // 	assert.NotNil(t, testStruct2.Slice, "expected non-nil slice")
 // 	assert.NotNil(t, testStruct2.SlicePtr, "expected non-nil slice ptr")
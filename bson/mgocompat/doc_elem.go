@@ -0,0 +1,203 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mgocompat
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// DocElem is an element of a D document.
+type DocElem struct {
+	Name  string
+	Value interface{}
+}
+
+// D represents a BSON document as an ordered slice of name/value pairs. It is the mgo equivalent of bson.D and is
+// provided so that models written against mgo continue to work unchanged. Embedded documents in a D are decoded as
+// a D as well.
+type D []DocElem
+
+// Map returns a map out of the ordered element name/value pairs in d.
+func (d D) Map() bson.M {
+	m := make(bson.M, len(d))
+	for _, item := range d {
+		m[item.Name] = item.Value
+	}
+	return m
+}
+
+// RawDocElem is an element of a RawD document.
+type RawDocElem struct {
+	Name  string
+	Value bson.RawValue
+}
+
+// RawD represents a BSON document as an ordered slice of name/value pairs whose values are kept in their raw,
+// undecoded form. It is the mgo equivalent of a document decoded into []bson.RawDocElem.
+type RawD []RawDocElem
+
+var (
+	tD    = reflect.TypeOf(D{})
+	tRawD = reflect.TypeOf(RawD{})
+)
+
+// dEncodeValue is the ValueEncoderFunc for D.
+func dEncodeValue(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != tD {
+		return bsoncodec.ValueEncoderError{Name: "DEncodeValue", Types: []reflect.Type{tD}, Received: val}
+	}
+	if val.IsNil() {
+		return vw.WriteNull()
+	}
+
+	dw, err := vw.WriteDocument()
+	if err != nil {
+		return err
+	}
+	for _, e := range val.Interface().(D) {
+		evw, err := dw.WriteDocumentElement(e.Name)
+		if err != nil {
+			return err
+		}
+		if e.Value == nil {
+			if err = evw.WriteNull(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		ev := reflect.ValueOf(e.Value)
+		encoder, err := ec.LookupEncoder(ev.Type())
+		if err != nil {
+			return err
+		}
+		if err = encoder.EncodeValue(ec, evw, ev); err != nil {
+			return err
+		}
+	}
+	return dw.WriteDocumentEnd()
+}
+
+// dDecodeValue is the ValueDecoderFunc for D.
+func dDecodeValue(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != tD {
+		return bsoncodec.ValueDecoderError{Name: "DDecodeValue", Types: []reflect.Type{tD}, Received: val}
+	}
+
+	switch vr.Type() {
+	case bsontype.Type(0), bsontype.EmbeddedDocument:
+	case bsontype.Null:
+		val.Set(reflect.Zero(val.Type()))
+		return vr.ReadNull()
+	default:
+		return fmt.Errorf("cannot decode %v into a %s", vr.Type(), tD)
+	}
+
+	dr, err := vr.ReadDocument()
+	if err != nil {
+		return err
+	}
+
+	decoder, err := dc.LookupDecoder(tEmpty)
+	if err != nil {
+		return err
+	}
+	// Embedded documents are decoded as D to preserve their order.
+	dc.Ancestor = tD
+
+	d := make(D, 0)
+	for {
+		key, evr, err := dr.ReadElement()
+		if err == bsonrw.ErrEOD {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		elem := reflect.New(tEmpty).Elem()
+		if err = decoder.DecodeValue(dc, evr, elem); err != nil {
+			return err
+		}
+		d = append(d, DocElem{Name: key, Value: elem.Interface()})
+	}
+
+	val.Set(reflect.ValueOf(d))
+	return nil
+}
+
+// rawDEncodeValue is the ValueEncoderFunc for RawD.
+func rawDEncodeValue(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != tRawD {
+		return bsoncodec.ValueEncoderError{Name: "RawDEncodeValue", Types: []reflect.Type{tRawD}, Received: val}
+	}
+	if val.IsNil() {
+		return vw.WriteNull()
+	}
+
+	dw, err := vw.WriteDocument()
+	if err != nil {
+		return err
+	}
+	for _, e := range val.Interface().(RawD) {
+		evw, err := dw.WriteDocumentElement(e.Name)
+		if err != nil {
+			return err
+		}
+		if err = (bsonrw.Copier{}).CopyValueFromBytes(evw, e.Value.Type, e.Value.Value); err != nil {
+			return err
+		}
+	}
+	return dw.WriteDocumentEnd()
+}
+
+// rawDDecodeValue is the ValueDecoderFunc for RawD.
+func rawDDecodeValue(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != tRawD {
+		return bsoncodec.ValueDecoderError{Name: "RawDDecodeValue", Types: []reflect.Type{tRawD}, Received: val}
+	}
+
+	switch vr.Type() {
+	case bsontype.Type(0), bsontype.EmbeddedDocument:
+	case bsontype.Null:
+		val.Set(reflect.Zero(val.Type()))
+		return vr.ReadNull()
+	default:
+		return fmt.Errorf("cannot decode %v into a %s", vr.Type(), tRawD)
+	}
+
+	dr, err := vr.ReadDocument()
+	if err != nil {
+		return err
+	}
+
+	d := make(RawD, 0)
+	for {
+		key, evr, err := dr.ReadElement()
+		if err == bsonrw.ErrEOD {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		t, data, err := bsonrw.Copier{}.CopyValueToBytes(evr)
+		if err != nil {
+			return err
+		}
+		d = append(d, RawDocElem{Name: key, Value: bson.RawValue{Type: t, Value: data}})
+	}
+
+	val.Set(reflect.ValueOf(d))
+	return nil
+}
@@ -7,12 +7,15 @@
 package mgocompat
 
 import (
+	"errors"
+	"math"
 	"reflect"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/bsonoptions"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
 )
 
@@ -26,14 +29,21 @@ var (
 	tEmpty          = reflect.TypeOf((*interface{})(nil)).Elem()
 )
 
-// mgoRegistry is the default bsoncodec.Registry. It contains the default codecs and the
-// primitive codecs.
-var mgoRegistry = newRegistryBuilder().Build()
+// Registry is the mgo compatible bsoncodec.Registry. It contains the default and primitive codecs
+// with mgo compatible options as well as codecs for the Getter and Setter interfaces and the D and
+// RawD types.
+var Registry = NewRegistryBuilder().Build()
 
-// newRegistryBuilder creates a new RegistryBuilder configured with the default encoders and
+var defaultTimeCodec = bsoncodec.NewTimeCodec()
+
+// zeroTimeMillis is the BSON datetime that mgo uses for the zero time.Time, which is the number of milliseconds from
+// the Unix epoch to January 1, year 1, 00:00:00 UTC.
+const zeroTimeMillis = -62135596800000
+
+// NewRegistryBuilder creates a new RegistryBuilder configured with the default encoders and
 // deocders from the bsoncodec.DefaultValueEncoders and bsoncodec.DefaultValueDecoders types and the
 // PrimitiveCodecs type in this package.
-func newRegistryBuilder() *bsoncodec.RegistryBuilder {
+func NewRegistryBuilder() *bsoncodec.RegistryBuilder {
 	rb := bsoncodec.NewRegistryBuilder()
 	bsoncodec.DefaultValueEncoders{}.RegisterDefaultEncoders(rb)
 	bsoncodec.DefaultValueDecoders{}.RegisterDefaultDecoders(rb)
@@ -65,7 +75,85 @@ func newRegistryBuilder() *bsoncodec.RegistryBuilder {
 		RegisterTypeMapEntry(bsontype.Int32, tInt).
 		RegisterTypeMapEntry(bsontype.Type(0), tM).
 		RegisterTypeMapEntry(bsontype.DateTime, tTime).
-		RegisterTypeMapEntry(bsontype.Array, tInterfaceSlice)
+		RegisterTypeMapEntry(bsontype.Array, tInterfaceSlice).
+		RegisterDefaultEncoder(reflect.Uint, bsoncodec.ValueEncoderFunc(uintEncodeValue)).
+		RegisterDefaultEncoder(reflect.Uint8, bsoncodec.ValueEncoderFunc(uintEncodeValue)).
+		RegisterDefaultEncoder(reflect.Uint16, bsoncodec.ValueEncoderFunc(uintEncodeValue)).
+		RegisterDefaultEncoder(reflect.Uint32, bsoncodec.ValueEncoderFunc(uintEncodeValue)).
+		RegisterDefaultEncoder(reflect.Uint64, bsoncodec.ValueEncoderFunc(uintEncodeValue)).
+		RegisterEncoder(tTime, bsoncodec.ValueEncoderFunc(timeEncodeValue)).
+		RegisterDecoder(tTime, bsoncodec.ValueDecoderFunc(timeDecodeValue)).
+		RegisterEncoder(tD, bsoncodec.ValueEncoderFunc(dEncodeValue)).
+		RegisterDecoder(tD, bsoncodec.ValueDecoderFunc(dDecodeValue)).
+		RegisterEncoder(tRawD, bsoncodec.ValueEncoderFunc(rawDEncodeValue)).
+		RegisterDecoder(tRawD, bsoncodec.ValueDecoderFunc(rawDDecodeValue)).
+		RegisterEncoder(tGetter, bsoncodec.ValueEncoderFunc(getterEncodeValue)).
+		RegisterDecoder(tSetter, bsoncodec.ValueDecoderFunc(setterDecodeValue))
 
 	return rb
 }
+
+var errUintOverflow = errors.New("BSON has no uint64 type, and value is too large to fit correctly in an int64")
+
+// uintEncodeValue is the ValueEncoderFunc for unsigned integers. Like mgo, it encodes uint, uint8, uint16 and uint32
+// values as an int32 when they fit and uint64 values as an int64 unless the minsize struct tag is used.
+func uintEncodeValue(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	switch val.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	default:
+		return bsoncodec.ValueEncoderError{
+			Name:     "UintEncodeValue",
+			Kinds:    []reflect.Kind{reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint},
+			Received: val,
+		}
+	}
+
+	u64 := val.Uint()
+	if u64 > math.MaxInt64 {
+		return errUintOverflow
+	}
+	if u64 <= math.MaxInt32 && (val.Kind() != reflect.Uint64 || ec.MinSize) {
+		return vw.WriteInt32(int32(u64))
+	}
+	return vw.WriteInt64(int64(u64))
+}
+
+// timeDecodeValue is the ValueDecoderFunc for time.Time. Like mgo, it decodes a BSON null and the datetime
+// zeroTimeMillis as the zero time.
+func timeDecodeValue(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	switch vr.Type() {
+	case bsontype.Null, bsontype.DateTime:
+	default:
+		return defaultTimeCodec.DecodeValue(dc, vr, val)
+	}
+	if !val.CanSet() || val.Type() != tTime {
+		return bsoncodec.ValueDecoderError{Name: "TimeDecodeValue", Types: []reflect.Type{tTime}, Received: val}
+	}
+
+	if vr.Type() == bsontype.Null {
+		val.Set(reflect.Zero(tTime))
+		return vr.ReadNull()
+	}
+	dt, err := vr.ReadDateTime()
+	if err != nil {
+		return err
+	}
+	if dt == zeroTimeMillis {
+		val.Set(reflect.Zero(tTime))
+		return nil
+	}
+	val.Set(reflect.ValueOf(time.Unix(dt/1000, dt%1000*1000000).UTC()))
+	return nil
+}
+
+// timeEncodeValue is the ValueEncoderFunc for time.Time. Like mgo, it encodes the zero time as the datetime
+// zeroTimeMillis.
+func timeEncodeValue(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != tTime {
+		return bsoncodec.ValueEncoderError{Name: "TimeEncodeValue", Types: []reflect.Type{tTime}, Received: val}
+	}
+	if val.Interface().(time.Time).IsZero() {
+		return vw.WriteDateTime(zeroTimeMillis)
+	}
+	return defaultTimeCodec.EncodeValue(ec, vw, val)
+}
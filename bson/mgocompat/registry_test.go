@@ -0,0 +1,127 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mgocompat
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+type corpusModel struct {
+	ID      primitive.ObjectID `bson:"_id"`
+	Name    prefixVal          `bson:"name"`
+	Count   uint               `bson:"count"`
+	Total   uint64             `bson:"total"`
+	Created time.Time          `bson:"created"`
+	Meta    D                  `bson:"meta"`
+	Extra   RawD               `bson:"extra"`
+}
+
+type corpusGetterModel struct {
+	GS    getterSetterInt `bson:"gs"`
+	Ptr   *prefixPtr      `bson:"ptr"`
+	Small uint32          `bson:"small"`
+	Big   uint            `bson:"big"`
+}
+
+// mgoCorpus holds documents as they are written by mgo along with the values they were written from.
+var mgoCorpus = []struct {
+	name string
+	obj  interface{}
+	data string
+}{
+	{
+		"model",
+		&corpusModel{
+			ID:      primitive.ObjectID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C},
+			Name:    prefixVal("bar"),
+			Count:   42,
+			Total:   42,
+			Created: time.Unix(1577836800, 123e6).UTC(),
+			Meta:    D{{"b", 1}, {"a", D{{"x", "y"}}}},
+			Extra: RawD{
+				{"k", bson.RawValue{Type: bsontype.String, Value: []byte("\x02\x00\x00\x00v\x00")}},
+				{"n", bson.RawValue{Type: bsontype.Int64, Value: []byte("\x07\x00\x00\x00\x00\x00\x00\x00")}},
+			},
+		},
+		wrapInDoc("\x07_id\x00\x01\x02\x03\x04\x05\x06\x07\x08\x09\x0A\x0B\x0C" +
+			"\x02name\x00\x08\x00\x00\x00foo-bar\x00" +
+			"\x10count\x00\x2A\x00\x00\x00" +
+			"\x12total\x00\x2A\x00\x00\x00\x00\x00\x00\x00" +
+			"\x09created\x00\x7B\xE8\x66\x5E\x6F\x01\x00\x00" +
+			"\x03meta\x00" + wrapInDoc("\x10b\x00\x01\x00\x00\x00"+"\x03a\x00"+wrapInDoc("\x02x\x00\x02\x00\x00\x00y\x00")) +
+			"\x03extra\x00" + wrapInDoc("\x02k\x00\x02\x00\x00\x00v\x00"+"\x12n\x00\x07\x00\x00\x00\x00\x00\x00\x00")),
+	},
+	{
+		"getters and unsigned integers",
+		&corpusGetterModel{GS: getterSetterInt(42), Small: 7, Big: 1 << 40},
+		wrapInDoc("\x03gs\x00" + wrapInDoc("\x10a\x00\x2A\x00\x00\x00") +
+			"\x0Aptr\x00" +
+			"\x10small\x00\x07\x00\x00\x00" +
+			"\x12big\x00\x00\x00\x00\x00\x00\x01\x00\x00"),
+	},
+	{
+		"whole document getter",
+		&getterSetterD{{"a", 1}, {"suffix", true}},
+		wrapInDoc("\x10a\x00\x01\x00\x00\x00"),
+	},
+}
+
+func TestMgoCorpus(t *testing.T) {
+	for _, tc := range mgoCorpus {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := bson.MarshalWithRegistry(Registry, tc.obj)
+			assert.Nil(t, err, "Marshal error: %v", err)
+			assert.Equal(t, tc.data, string(data), "expected: %q, got: %q", tc.data, string(data))
+
+			got := reflect.New(reflect.TypeOf(tc.obj).Elem()).Interface()
+			err = bson.UnmarshalWithRegistry(Registry, []byte(tc.data), got)
+			assert.Nil(t, err, "Unmarshal error: %v", err)
+			assert.True(t, reflect.DeepEqual(tc.obj, got), "expected: %v, got: %v", tc.obj, got)
+		})
+	}
+}
+
+func TestUnmarshalNullTime(t *testing.T) {
+	v := struct{ Created time.Time }{time.Now()}
+	err := bson.UnmarshalWithRegistry(Registry, []byte(wrapInDoc("\x0Acreated\x00")), &v)
+	assert.Nil(t, err, "Unmarshal error: %v", err)
+	assert.True(t, v.Created.IsZero(), "expected zero time, got: %v", v.Created)
+}
+
+func TestZeroTimeRoundTrip(t *testing.T) {
+	data := wrapInDoc("\x09created\x00\x00\x28\xd3\xed\x7c\xc7\xff\xff")
+
+	got, err := bson.MarshalWithRegistry(Registry, struct{ Created time.Time }{})
+	assert.Nil(t, err, "Marshal error: %v", err)
+	assert.Equal(t, data, string(got), "expected: %q, got: %q", data, string(got))
+
+	v := struct{ Created time.Time }{time.Now()}
+	err = bson.UnmarshalWithRegistry(Registry, []byte(data), &v)
+	assert.Nil(t, err, "Unmarshal error: %v", err)
+	assert.Equal(t, time.Time{}, v.Created, "expected the zero time, got: %v", v.Created)
+}
+
+func TestMarshalRawDElementOrder(t *testing.T) {
+	var d RawD
+	data := wrapInDoc("\x10z\x00\x01\x00\x00\x00" + "\x10a\x00\x02\x00\x00\x00")
+	err := bson.UnmarshalWithRegistry(Registry, []byte(data), &d)
+	assert.Nil(t, err, "Unmarshal error: %v", err)
+	assert.Equal(t, 2, len(d), "expected 2 elements, got %d", len(d))
+	assert.Equal(t, "z", d[0].Name, "expected first element z, got %v", d[0].Name)
+	assert.Equal(t, int32(1), d[0].Value.Int32(), "expected 1, got %v", d[0].Value)
+
+	got, err := bson.MarshalWithRegistry(Registry, d)
+	assert.Nil(t, err, "Marshal error: %v", err)
+	assert.Equal(t, data, string(got), "expected: %q, got: %q", data, string(got))
+}
@@ -0,0 +1,644 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package primitive
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// decimal128Digits is the number of decimal digits in the significand of a decimal128 value.
+const decimal128Digits = 34
+
+// decimal128FloatPrec is the precision of the *big.Float values returned by Decimal128.BigFloat. It is large enough
+// that converting the *big.Float back to a Decimal128 gives the original value.
+const decimal128FloatPrec = 128
+
+// RoundingMode specifies how Decimal128 arithmetic rounds a result that cannot be represented exactly. The modes are
+// the rounding-direction attributes defined by IEEE 754-2008.
+type RoundingMode uint8
+
+// These constants are the valid RoundingMode values.
+const (
+	// RoundTiesToEven rounds to the nearest value, and to the value with an even least significant digit when the
+	// result is exactly halfway between two values. This is the default.
+	RoundTiesToEven RoundingMode = iota
+	// RoundTiesToAway rounds to the nearest value, and away from zero when the result is exactly halfway between two
+	// values.
+	RoundTiesToAway
+	// RoundTowardZero truncates the result.
+	RoundTowardZero
+	// RoundTowardPositive rounds toward positive infinity.
+	RoundTowardPositive
+	// RoundTowardNegative rounds toward negative infinity.
+	RoundTowardNegative
+)
+
+func (rm RoundingMode) String() string {
+	switch rm {
+	case RoundTiesToEven:
+		return "RoundTiesToEven"
+	case RoundTiesToAway:
+		return "RoundTiesToAway"
+	case RoundTowardZero:
+		return "RoundTowardZero"
+	case RoundTowardPositive:
+		return "RoundTowardPositive"
+	case RoundTowardNegative:
+		return "RoundTowardNegative"
+	default:
+		return "RoundingMode(" + strconv.Itoa(int(rm)) + ")"
+	}
+}
+
+// DecimalContext performs Decimal128 arithmetic and conversions that round their results. The zero value rounds
+// with RoundTiesToEven.
+//
+// Operations never return an error. As in IEEE 754-2008, invalid operations such as 0/0 or Infinity-Infinity return
+// NaN, any operation with a NaN operand returns NaN, dividing a non-zero value by zero returns an infinity, and a
+// result too large to be represented returns an infinity or the largest finite value, depending on the rounding mode.
+type DecimalContext struct {
+	Rounding RoundingMode
+}
+
+// Add returns x+y.
+func (c DecimalContext) Add(x, y Decimal128) Decimal128 {
+	return c.add(x.parts(), y.parts())
+}
+
+// Sub returns x-y.
+func (c DecimalContext) Sub(x, y Decimal128) Decimal128 {
+	yp := y.parts()
+	yp.neg = !yp.neg
+	return c.add(x.parts(), yp)
+}
+
+// Mul returns x*y.
+func (c DecimalContext) Mul(x, y Decimal128) Decimal128 {
+	xp, yp := x.parts(), y.parts()
+	neg := xp.neg != yp.neg
+	switch {
+	case xp.form == nanForm || yp.form == nanForm:
+		return dNaN
+	case xp.form == infForm || yp.form == infForm:
+		if xp.isZero() || yp.isZero() {
+			return dNaN
+		}
+		return decimal128Inf(neg)
+	}
+	return c.round(neg, new(big.Int).Mul(xp.coef, yp.coef), xp.exp+yp.exp, false)
+}
+
+// Quo returns x/y.
+func (c DecimalContext) Quo(x, y Decimal128) Decimal128 {
+	xp, yp := x.parts(), y.parts()
+	neg := xp.neg != yp.neg
+	switch {
+	case xp.form == nanForm || yp.form == nanForm:
+		return dNaN
+	case xp.form == infForm && yp.form == infForm:
+		return dNaN
+	case xp.form == infForm:
+		return decimal128Inf(neg)
+	case yp.form == infForm:
+		return newDecimal128(neg, new(big.Int), MinDecimal128Exp)
+	case yp.isZero():
+		if xp.isZero() {
+			return dNaN
+		}
+		return decimal128Inf(neg)
+	case xp.isZero():
+		return c.round(neg, new(big.Int), xp.exp-yp.exp, false)
+	}
+	return c.quo(neg, xp.coef, xp.exp, yp.coef, yp.exp)
+}
+
+// Quantize returns d rounded to have the exponent exp, such as -2 to round an amount of money to cents. It returns NaN
+// if d is not finite, if exp is outside of the range of decimal128 exponents, or if the result would need more than
+// 34 digits.
+func (c DecimalContext) Quantize(d Decimal128, exp int) Decimal128 {
+	p := d.parts()
+	if p.form != finiteForm || exp < MinDecimal128Exp || exp > MaxDecimal128Exp {
+		return dNaN
+	}
+
+	coef := p.coef
+	if p.exp >= exp {
+		coef = new(big.Int).Mul(coef, pow10(p.exp-exp))
+	} else {
+		pow := pow10(exp - p.exp)
+		r := new(big.Int)
+		coef, r = new(big.Int).QuoRem(coef, pow, r)
+		if c.roundUp(p.neg, coef, r, pow, false) {
+			coef.Add(coef, big.NewInt(1))
+		}
+	}
+	if numDigits(coef) > decimal128Digits {
+		return dNaN
+	}
+	return newDecimal128(p.neg, coef, exp)
+}
+
+// FromBigRat returns the Decimal128 closest to r in the direction of c's rounding mode.
+func (c DecimalContext) FromBigRat(r *big.Rat) Decimal128 {
+	if r.Sign() == 0 {
+		return newDecimal128(false, new(big.Int), 0)
+	}
+	num := new(big.Int).Abs(r.Num())
+	return c.quo(r.Sign() < 0, num, 0, r.Denom(), 0)
+}
+
+// FromBigFloat returns the Decimal128 closest to f in the direction of c's rounding mode.
+func (c DecimalContext) FromBigFloat(f *big.Float) Decimal128 {
+	neg := f.Signbit()
+	switch {
+	case f.IsInf():
+		return decimal128Inf(neg)
+	case f.Sign() == 0:
+		return newDecimal128(neg, new(big.Int), 0)
+	}
+
+	// |f| is at least 2^(exp-1) and less than 2^exp. Values far outside of the decimal128 range are handled without
+	// converting them to a *big.Rat, whose numerator or denominator would otherwise have exp bits.
+	exp := f.MantExp(nil)
+	switch {
+	case exp > 20500:
+		return c.overflow(neg)
+	case exp < -20600:
+		// Smaller than half of the smallest subnormal decimal128 value, so the result only depends on the sign and the
+		// rounding mode.
+		return c.round(neg, new(big.Int), MinDecimal128Exp-1, true)
+	}
+	r, _ := f.Rat(nil)
+	return c.FromBigRat(r)
+}
+
+// Add returns d+e, rounded with RoundTiesToEven.
+func (d Decimal128) Add(e Decimal128) Decimal128 {
+	return DecimalContext{}.Add(d, e)
+}
+
+// Sub returns d-e, rounded with RoundTiesToEven.
+func (d Decimal128) Sub(e Decimal128) Decimal128 {
+	return DecimalContext{}.Sub(d, e)
+}
+
+// Mul returns d*e, rounded with RoundTiesToEven.
+func (d Decimal128) Mul(e Decimal128) Decimal128 {
+	return DecimalContext{}.Mul(d, e)
+}
+
+// Quo returns d/e, rounded with RoundTiesToEven.
+func (d Decimal128) Quo(e Decimal128) Decimal128 {
+	return DecimalContext{}.Quo(d, e)
+}
+
+// Neg returns d with its sign reversed.
+func (d Decimal128) Neg() Decimal128 {
+	return Decimal128{h: d.h ^ 1<<63, l: d.l}
+}
+
+// Abs returns the absolute value of d.
+func (d Decimal128) Abs() Decimal128 {
+	return Decimal128{h: d.h &^ (1 << 63), l: d.l}
+}
+
+// Cmp compares d and e numerically. It returns -1 if d is less than e, 0 if they are equal, and +1 if d is greater
+// than e.
+//
+// Values that differ only in their exponent, such as 1.0 and 1.00, and positive and negative zero, are equal. NaN
+// values are equal to each other and less than any other value, so that Cmp can be used to sort Decimal128 values.
+func (d Decimal128) Cmp(e Decimal128) int {
+	dp, ep := d.parts(), e.parts()
+	switch {
+	case dp.form == nanForm && ep.form == nanForm:
+		return 0
+	case dp.form == nanForm:
+		return -1
+	case ep.form == nanForm:
+		return 1
+	}
+
+	ds, es := dp.sign(), ep.sign()
+	if ds != es {
+		if ds < es {
+			return -1
+		}
+		return 1
+	}
+	if ds == 0 {
+		return 0
+	}
+
+	// The operands have the same sign, so compare their magnitudes and reverse the result for negative values.
+	var c int
+	switch {
+	case dp.form == infForm && ep.form == infForm:
+		c = 0
+	case dp.form == infForm:
+		c = 1
+	case ep.form == infForm:
+		c = -1
+	default:
+		dAdj, eAdj := dp.exp+numDigits(dp.coef), ep.exp+numDigits(ep.coef)
+		switch {
+		case dAdj < eAdj:
+			c = -1
+		case dAdj > eAdj:
+			c = 1
+		case dp.exp < ep.exp:
+			c = dp.coef.Cmp(new(big.Int).Mul(ep.coef, pow10(ep.exp-dp.exp)))
+		default:
+			c = new(big.Int).Mul(dp.coef, pow10(dp.exp-ep.exp)).Cmp(ep.coef)
+		}
+	}
+	return c * ds
+}
+
+// NewDecimal128FromInt64 returns the Decimal128 equal to i.
+func NewDecimal128FromInt64(i int64) Decimal128 {
+	bi := big.NewInt(i)
+	return newDecimal128(i < 0, bi.Abs(bi), 0)
+}
+
+// NewDecimal128FromFloat64 returns the Decimal128 with the fewest digits that converts back to f. NaN and the
+// infinities are converted to the corresponding Decimal128 values.
+func NewDecimal128FromFloat64(f float64) Decimal128 {
+	// The shortest representation of a float64 has at most 17 digits, so it is always a valid decimal128 string.
+	d, err := ParseDecimal128(strconv.FormatFloat(f, 'e', -1, 64))
+	if err != nil {
+		return dNaN
+	}
+	return d
+}
+
+// NewDecimal128FromBigRat returns the Decimal128 closest to r, rounded with RoundTiesToEven.
+func NewDecimal128FromBigRat(r *big.Rat) Decimal128 {
+	return DecimalContext{}.FromBigRat(r)
+}
+
+// NewDecimal128FromBigFloat returns the Decimal128 closest to f, rounded with RoundTiesToEven.
+func NewDecimal128FromBigFloat(f *big.Float) Decimal128 {
+	return DecimalContext{}.FromBigFloat(f)
+}
+
+// Float64 returns the float64 closest to d. Values too large to be represented are converted to an infinity.
+func (d Decimal128) Float64() float64 {
+	// ParseFloat returns the correctly rounded value, or an infinity or zero along with an ErrRange error when d is
+	// outside of the float64 range.
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// Int64 returns d as an int64. It returns an error if d is NaN, an infinity, has a fractional part, or does not fit
+// in an int64. Use DecimalContext.Quantize with an exponent of 0 to round d to an integer first.
+func (d Decimal128) Int64() (int64, error) {
+	r, err := d.BigRat()
+	if err != nil {
+		return 0, err
+	}
+	if !r.IsInt() {
+		return 0, fmt.Errorf("cannot convert %s to an int64: value has a fractional part", d)
+	}
+	if !r.Num().IsInt64() {
+		return 0, fmt.Errorf("cannot convert %s to an int64: value out of range", d)
+	}
+	return r.Num().Int64(), nil
+}
+
+// BigRat returns d as a *big.Rat. It returns an error if d is NaN or an infinity.
+func (d Decimal128) BigRat() (*big.Rat, error) {
+	p := d.parts()
+	if p.form != finiteForm {
+		return nil, fmt.Errorf("cannot convert %s to a *big.Rat", d)
+	}
+
+	r := new(big.Rat)
+	if p.exp >= 0 {
+		r.SetInt(new(big.Int).Mul(p.coef, pow10(p.exp)))
+	} else {
+		r.SetFrac(p.coef, pow10(-p.exp))
+	}
+	if p.neg {
+		r.Neg(r)
+	}
+	return r, nil
+}
+
+// BigFloat returns d as a *big.Float with 128 bits of precision, which is enough for NewDecimal128FromBigFloat to
+// convert it back to a value equal to d. It returns an error if d is NaN.
+func (d Decimal128) BigFloat() (*big.Float, error) {
+	p := d.parts()
+	switch p.form {
+	case nanForm:
+		return nil, errors.New("cannot convert NaN to a *big.Float")
+	case infForm:
+		return new(big.Float).SetInf(p.neg), nil
+	}
+
+	r, err := d.BigRat()
+	if err != nil {
+		return nil, err
+	}
+	f := new(big.Float).SetPrec(decimal128FloatPrec).SetRat(r)
+	if p.neg && f.Sign() == 0 {
+		f.Neg(f)
+	}
+	return f, nil
+}
+
+// MarshalJSON returns the Decimal128 as a string.
+func (d Decimal128) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON creates a Decimal128 from a JSON string, a JSON number, or an Extended JSON $numberDecimal object.
+// A JSON null leaves d unchanged.
+func (d *Decimal128) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+
+	var res interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&res); err != nil {
+		return err
+	}
+
+	var str string
+	switch v := res.(type) {
+	case string:
+		str = v
+	case json.Number:
+		str = v.String()
+	case map[string]interface{}:
+		s, ok := v["$numberDecimal"].(string)
+		if !ok || len(v) != 1 {
+			return errors.New("not an extended JSON Decimal128")
+		}
+		str = s
+	default:
+		return fmt.Errorf("cannot unmarshal %s into a Decimal128", b)
+	}
+
+	parsed, err := ParseDecimal128(str)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+type decimalForm uint8
+
+const (
+	finiteForm decimalForm = iota
+	infForm
+	nanForm
+)
+
+// decimalParts is a decoded decimal128 value. A finite value is equal to coef * 10^exp, negated if neg is set.
+type decimalParts struct {
+	form decimalForm
+	neg  bool
+	coef *big.Int
+	exp  int
+}
+
+func (p decimalParts) isZero() bool {
+	return p.form == finiteForm && p.coef.Sign() == 0
+}
+
+// sign returns -1, 0 or +1 for a value that is not NaN. Both zeros have a sign of 0.
+func (p decimalParts) sign() int {
+	switch {
+	case p.isZero():
+		return 0
+	case p.neg:
+		return -1
+	default:
+		return 1
+	}
+}
+
+func (d Decimal128) parts() decimalParts {
+	p := decimalParts{neg: d.h>>63&1 == 1}
+	switch d.h >> 58 & (1<<5 - 1) {
+	case 0x1F:
+		p.form = nanForm
+		return p
+	case 0x1E:
+		p.form = infForm
+		return p
+	}
+
+	var high, low uint64
+	if d.h>>61&3 == 3 {
+		// The significand of this form is always larger than the maximum, so the value is zero.
+		p.exp = int(d.h >> 47 & (1<<14 - 1))
+	} else {
+		p.exp = int(d.h >> 49 & (1<<14 - 1))
+		high, low = d.h&(1<<49-1), d.l
+	}
+	p.exp += MinDecimal128Exp
+
+	p.coef = new(big.Int).SetUint64(high)
+	p.coef.Lsh(p.coef, 64).Or(p.coef, new(big.Int).SetUint64(low))
+	if p.coef.Cmp(maxS) > 0 {
+		// Non-canonical significands are treated as zero.
+		p.coef.SetUint64(0)
+	}
+	return p
+}
+
+// newDecimal128 returns the Decimal128 for a coefficient of at most 34 digits and an exponent in range.
+func newDecimal128(neg bool, coef *big.Int, exp int) Decimal128 {
+	low := new(big.Int).And(coef, new(big.Int).SetUint64(math.MaxUint64)).Uint64()
+	high := new(big.Int).Rsh(coef, 64).Uint64()
+
+	high |= uint64(exp-MinDecimal128Exp) & uint64(1<<14-1) << 49
+	if neg {
+		high |= 1 << 63
+	}
+	return Decimal128{h: high, l: low}
+}
+
+func decimal128Inf(neg bool) Decimal128 {
+	if neg {
+		return dNegInf
+	}
+	return dPosInf
+}
+
+func (c DecimalContext) add(x, y decimalParts) Decimal128 {
+	switch {
+	case x.form == nanForm || y.form == nanForm:
+		return dNaN
+	case x.form == infForm && y.form == infForm:
+		if x.neg != y.neg {
+			return dNaN
+		}
+		return decimal128Inf(x.neg)
+	case x.form == infForm:
+		return decimal128Inf(x.neg)
+	case y.form == infForm:
+		return decimal128Inf(y.neg)
+	}
+
+	exp := x.exp
+	if y.exp < exp {
+		exp = y.exp
+	}
+	xc := new(big.Int).Mul(x.coef, pow10(x.exp-exp))
+	if x.neg {
+		xc.Neg(xc)
+	}
+	yc := new(big.Int).Mul(y.coef, pow10(y.exp-exp))
+	if y.neg {
+		yc.Neg(yc)
+	}
+
+	sum := xc.Add(xc, yc)
+	neg := sum.Sign() < 0
+	if sum.Sign() == 0 {
+		// An exact zero sum is positive unless both operands are negative or the result is rounded toward negative
+		// infinity.
+		neg = x.neg && y.neg || x.neg != y.neg && c.Rounding == RoundTowardNegative
+	}
+	return c.round(neg, sum.Abs(sum), exp, false)
+}
+
+// quo returns the rounded quotient of two finite, non-zero values.
+func (c DecimalContext) quo(neg bool, xc *big.Int, xe int, yc *big.Int, ye int) Decimal128 {
+	// Scale the dividend so the quotient has at least one more digit than a decimal128 significand. The remainder then
+	// only needs to be known to be zero or non-zero to round correctly.
+	shift := decimal128Digits + 1 + numDigits(yc) - numDigits(xc)
+	if shift < 0 {
+		shift = 0
+	}
+	ideal := xe - ye
+	exp := ideal - shift
+
+	q, r := new(big.Int).QuoRem(new(big.Int).Mul(xc, pow10(shift)), yc, new(big.Int))
+	if r.Sign() != 0 {
+		return c.round(neg, q, exp, true)
+	}
+
+	// The quotient is exact, so remove trailing zeros added by the scaling, up to the ideal exponent.
+	digit := new(big.Int)
+	for exp < ideal {
+		next, m := new(big.Int).QuoRem(q, ten, digit)
+		if m.Sign() != 0 {
+			break
+		}
+		q = next
+		exp++
+	}
+	return c.round(neg, q, exp, false)
+}
+
+// round returns the Decimal128 for the magnitude coef * 10^exp rounded to 34 digits and to the range of decimal128
+// exponents. If sticky is set, the exact magnitude is slightly greater than coef * 10^exp, by less than 10^exp.
+func (c DecimalContext) round(neg bool, coef *big.Int, exp int, sticky bool) Decimal128 {
+	drop := numDigits(coef) - decimal128Digits
+	if sub := MinDecimal128Exp - exp; sub > drop {
+		drop = sub
+	}
+	if sticky && drop <= 0 {
+		coef = new(big.Int).Mul(coef, ten)
+		exp--
+		drop = 1
+	}
+
+	if drop > 0 {
+		pow := pow10(drop)
+		q, r := new(big.Int).QuoRem(coef, pow, new(big.Int))
+		exp += drop
+		if c.roundUp(neg, q, r, pow, sticky) {
+			q.Add(q, big.NewInt(1))
+			if numDigits(q) > decimal128Digits {
+				q.Quo(q, ten)
+				exp++
+			}
+		}
+		coef = q
+	}
+
+	if exp > MaxDecimal128Exp {
+		if coef.Sign() == 0 {
+			exp = MaxDecimal128Exp
+		} else if numDigits(coef)+exp-MaxDecimal128Exp <= decimal128Digits {
+			// Clamp the exponent by adding trailing zeros to the significand.
+			coef = new(big.Int).Mul(coef, pow10(exp-MaxDecimal128Exp))
+			exp = MaxDecimal128Exp
+		} else {
+			return c.overflow(neg)
+		}
+	}
+	return newDecimal128(neg, coef, exp)
+}
+
+// roundUp reports whether the magnitude q, truncated from q*pow + r, must be incremented. If sticky is set, the
+// exact magnitude is slightly greater than q*pow + r.
+func (c DecimalContext) roundUp(neg bool, q, r, pow *big.Int, sticky bool) bool {
+	if r.Sign() == 0 && !sticky {
+		return false
+	}
+
+	switch c.Rounding {
+	case RoundTowardZero:
+		return false
+	case RoundTowardPositive:
+		return !neg
+	case RoundTowardNegative:
+		return neg
+	}
+
+	half := new(big.Int).Lsh(r, 1).Cmp(pow)
+	if half == 0 && sticky {
+		half = 1
+	}
+	if c.Rounding == RoundTiesToAway {
+		return half >= 0
+	}
+	return half > 0 || half == 0 && q.Bit(0) == 1
+}
+
+// overflow returns the result of rounding a value too large to be represented.
+func (c DecimalContext) overflow(neg bool) Decimal128 {
+	switch {
+	case c.Rounding == RoundTowardZero,
+		c.Rounding == RoundTowardPositive && neg,
+		c.Rounding == RoundTowardNegative && !neg:
+		return newDecimal128(neg, maxS, MaxDecimal128Exp)
+	}
+	return decimal128Inf(neg)
+}
+
+// pow10 returns 10^n for n >= 0.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(ten, big.NewInt(int64(n)), nil)
+}
+
+// numDigits returns the number of decimal digits in the non-negative x. Zero has one digit.
+func numDigits(x *big.Int) int {
+	if x.Sign() == 0 {
+		return 1
+	}
+	// x is at least 2^(BitLen-1), so it has at least n digits and at most n+1.
+	n := int(float64(x.BitLen()-1)*math.Log10(2)) + 1
+	if x.Cmp(pow10(n)) >= 0 {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,274 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package primitive
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustParseDecimal128(t *testing.T, s string) Decimal128 {
+	t.Helper()
+	d, err := ParseDecimal128(s)
+	require.NoError(t, err, "parsing %q", s)
+	return d
+}
+
+func TestDecimalContext_Arithmetic(t *testing.T) {
+	const maxFinite = "9.999999999999999999999999999999999E+6144"
+
+	testCases := []struct {
+		op       string
+		x, y     string
+		rounding RoundingMode
+		want     string
+	}{
+		{"add", "1.1", "2.2", RoundTiesToEven, "3.3"},
+		{"add", "1", "1.00", RoundTiesToEven, "2.00"},
+		{"add", "-5", "5", RoundTiesToEven, "0"},
+		{"add", "-5", "5", RoundTowardNegative, "-0"},
+		{"add", "-0", "-0", RoundTiesToEven, "-0"},
+		{"add", "9999999999999999999999999999999999", "1", RoundTiesToEven, "1.000000000000000000000000000000000E+34"},
+		{"add", "1234567890123456789012345678901234", "0.5", RoundTiesToEven, "1234567890123456789012345678901234"},
+		{"add", "1234567890123456789012345678901235", "0.5", RoundTiesToEven, "1234567890123456789012345678901236"},
+		{"add", "1234567890123456789012345678901234", "0.5", RoundTiesToAway, "1234567890123456789012345678901235"},
+		{"add", "1234567890123456789012345678901234", "0.5000001", RoundTiesToEven, "1234567890123456789012345678901235"},
+		{"add", "1234567890123456789012345678901234", "0.1", RoundTowardPositive, "1234567890123456789012345678901235"},
+		{"add", "-1234567890123456789012345678901234", "-0.1", RoundTowardPositive, "-1234567890123456789012345678901234"},
+		{"add", "-1234567890123456789012345678901234", "-0.1", RoundTowardNegative, "-1234567890123456789012345678901235"},
+		{"add", "1234567890123456789012345678901234", "0.9", RoundTowardZero, "1234567890123456789012345678901234"},
+		{"add", "1E+6144", maxFinite, RoundTiesToEven, "Infinity"},
+		{"add", "1E+6144", maxFinite, RoundTowardZero, maxFinite},
+		{"add", "-1E+6144", "-" + maxFinite, RoundTowardPositive, "-" + maxFinite},
+		{"add", "1E+6111", "1E-6176", RoundTiesToEven, "1.000000000000000000000000000000000E+6111"},
+		{"add", "Infinity", "1", RoundTiesToEven, "Infinity"},
+		{"add", "Infinity", "-Infinity", RoundTiesToEven, "NaN"},
+		{"add", "NaN", "1", RoundTiesToEven, "NaN"},
+		{"sub", "10.00", "0.01", RoundTiesToEven, "9.99"},
+		{"sub", "1", "1", RoundTiesToEven, "0"},
+		{"sub", "-Infinity", "-Infinity", RoundTiesToEven, "NaN"},
+		{"mul", "1.10", "3", RoundTiesToEven, "3.30"},
+		{"mul", "-2", "0", RoundTiesToEven, "-0"},
+		{"mul", "1.5E+6000", "1E+200", RoundTiesToEven, "Infinity"},
+		{"mul", "1.5E+6000", "-1E+200", RoundTowardPositive, "-" + maxFinite},
+		{"mul", "Infinity", "0", RoundTiesToEven, "NaN"},
+		{"mul", "-Infinity", "2", RoundTiesToEven, "-Infinity"},
+		{"quo", "1", "3", RoundTiesToEven, "0.3333333333333333333333333333333333"},
+		{"quo", "2", "3", RoundTiesToEven, "0.6666666666666666666666666666666667"},
+		{"quo", "2", "3", RoundTowardZero, "0.6666666666666666666666666666666666"},
+		{"quo", "-2", "3", RoundTowardNegative, "-0.6666666666666666666666666666666667"},
+		{"quo", "1", "4", RoundTiesToEven, "0.25"},
+		{"quo", "10", "2", RoundTiesToEven, "5"},
+		{"quo", "1.00", "2", RoundTiesToEven, "0.50"},
+		{"quo", "100", "1", RoundTiesToEven, "100"},
+		{"quo", "2E+3", "1", RoundTiesToEven, "2E+3"},
+		{"quo", "1E-6176", "2", RoundTiesToEven, "0E-6176"},
+		{"quo", "1E-6176", "2", RoundTiesToAway, "1E-6176"},
+		{"quo", "3E-6176", "2", RoundTiesToEven, "2E-6176"},
+		{"quo", "1", "0", RoundTiesToEven, "Infinity"},
+		{"quo", "-1", "0", RoundTiesToEven, "-Infinity"},
+		{"quo", "0", "0", RoundTiesToEven, "NaN"},
+		{"quo", "0", "5", RoundTiesToEven, "0"},
+		{"quo", "1", "Infinity", RoundTiesToEven, "0E-6176"},
+		{"quo", "Infinity", "-Infinity", RoundTiesToEven, "NaN"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.op+" "+tc.x+" "+tc.y+" "+tc.rounding.String(), func(t *testing.T) {
+			x, y := mustParseDecimal128(t, tc.x), mustParseDecimal128(t, tc.y)
+			c := DecimalContext{Rounding: tc.rounding}
+
+			var got Decimal128
+			switch tc.op {
+			case "add":
+				got = c.Add(x, y)
+			case "sub":
+				got = c.Sub(x, y)
+			case "mul":
+				got = c.Mul(x, y)
+			case "quo":
+				got = c.Quo(x, y)
+			}
+			require.Equal(t, tc.want, got.String())
+		})
+	}
+
+	t.Run("methods", func(t *testing.T) {
+		x, y := mustParseDecimal128(t, "19.99"), mustParseDecimal128(t, "3")
+		require.Equal(t, "22.99", x.Add(y).String())
+		require.Equal(t, "16.99", x.Sub(y).String())
+		require.Equal(t, "59.97", x.Mul(y).String())
+		require.Equal(t, "6.663333333333333333333333333333333", x.Quo(y).String())
+		require.Equal(t, "-19.99", x.Neg().String())
+		require.Equal(t, "19.99", x.Neg().Abs().String())
+	})
+}
+
+func TestDecimalContext_Quantize(t *testing.T) {
+	testCases := []struct {
+		d        string
+		exp      int
+		rounding RoundingMode
+		want     string
+	}{
+		{"2.345", -2, RoundTiesToEven, "2.34"},
+		{"2.355", -2, RoundTiesToEven, "2.36"},
+		{"2.345", -2, RoundTiesToAway, "2.35"},
+		{"-2.345", -2, RoundTowardPositive, "-2.34"},
+		{"-2.341", -2, RoundTowardNegative, "-2.35"},
+		{"1", -2, RoundTiesToEven, "1.00"},
+		{"0.004", -2, RoundTiesToEven, "0.00"},
+		{"1E+3", 0, RoundTiesToEven, "1000"},
+		{"1", -34, RoundTiesToEven, "NaN"},
+		{"Infinity", 0, RoundTiesToEven, "NaN"},
+		{"1", MaxDecimal128Exp + 1, RoundTiesToEven, "NaN"},
+	}
+
+	for _, tc := range testCases {
+		got := DecimalContext{Rounding: tc.rounding}.Quantize(mustParseDecimal128(t, tc.d), tc.exp)
+		require.Equal(t, tc.want, got.String(), "quantizing %s to %d with %s", tc.d, tc.exp, tc.rounding)
+	}
+}
+
+func TestDecimal128_Cmp(t *testing.T) {
+	testCases := []struct {
+		x, y string
+		want int
+	}{
+		{"1.0", "1.00", 0},
+		{"-0", "0", 0},
+		{"0E+10", "0E-10", 0},
+		{"1", "2", -1},
+		{"-1", "-2", 1},
+		{"-1", "0", -1},
+		{"1E+10", "9.99E+9", 1},
+		{"123.45", "123.450000001", -1},
+		{"NaN", "1", -1},
+		{"1", "NaN", 1},
+		{"NaN", "NaN", 0},
+		{"Infinity", "9.999999999999999999999999999999999E+6144", 1},
+		{"-Infinity", "-1", -1},
+		{"-Infinity", "-Infinity", 0},
+	}
+
+	for _, tc := range testCases {
+		got := mustParseDecimal128(t, tc.x).Cmp(mustParseDecimal128(t, tc.y))
+		require.Equal(t, tc.want, got, "comparing %s and %s", tc.x, tc.y)
+	}
+}
+
+func TestDecimal128_Conversions(t *testing.T) {
+	t.Run("Float64", func(t *testing.T) {
+		require.Equal(t, 0.1, mustParseDecimal128(t, "0.1").Float64())
+		require.Equal(t, -1234.5, mustParseDecimal128(t, "-1.2345E+3").Float64())
+		require.True(t, math.IsInf(mustParseDecimal128(t, "1E+400").Float64(), 1))
+		require.True(t, math.IsInf(mustParseDecimal128(t, "-Infinity").Float64(), -1))
+		require.True(t, math.IsNaN(mustParseDecimal128(t, "NaN").Float64()))
+	})
+	t.Run("NewDecimal128FromFloat64", func(t *testing.T) {
+		testCases := []struct {
+			f    float64
+			want string
+		}{
+			{0.1, "0.1"},
+			{123.456, "123.456"},
+			{1e21, "1E+21"},
+			{5e-324, "5E-324"},
+			{math.Copysign(0, -1), "-0"},
+			{math.Inf(1), "Infinity"},
+			{math.Inf(-1), "-Infinity"},
+			{math.NaN(), "NaN"},
+		}
+		for _, tc := range testCases {
+			require.Equal(t, tc.want, NewDecimal128FromFloat64(tc.f).String())
+		}
+	})
+	t.Run("Int64", func(t *testing.T) {
+		require.Equal(t, "-9223372036854775808", NewDecimal128FromInt64(math.MinInt64).String())
+
+		i, err := mustParseDecimal128(t, "4.20E+1").Int64()
+		require.NoError(t, err)
+		require.Equal(t, int64(42), i)
+		i, err = mustParseDecimal128(t, "-9223372036854775808").Int64()
+		require.NoError(t, err)
+		require.Equal(t, int64(math.MinInt64), i)
+
+		for _, s := range []string{"1.5", "9223372036854775808", "NaN", "-Infinity"} {
+			_, err = mustParseDecimal128(t, s).Int64()
+			require.Error(t, err, "converting %s", s)
+		}
+	})
+	t.Run("BigRat", func(t *testing.T) {
+		r, err := mustParseDecimal128(t, "-1.25").BigRat()
+		require.NoError(t, err)
+		require.Equal(t, "-5/4", r.String())
+		r, err = mustParseDecimal128(t, "1.2E+3").BigRat()
+		require.NoError(t, err)
+		require.Equal(t, "1200/1", r.String())
+		_, err = mustParseDecimal128(t, "Infinity").BigRat()
+		require.Error(t, err)
+
+		require.Equal(t, "0.3333333333333333333333333333333333", NewDecimal128FromBigRat(big.NewRat(1, 3)).String())
+		require.Equal(t, "0.125", NewDecimal128FromBigRat(big.NewRat(1, 8)).String())
+		require.Equal(t, "-0.6666666666666666666666666666666666",
+			DecimalContext{Rounding: RoundTowardPositive}.FromBigRat(big.NewRat(-2, 3)).String())
+	})
+	t.Run("BigFloat", func(t *testing.T) {
+		for _, s := range []string{
+			"0.1",
+			"-1234567890.123456789012345678901234",
+			"9.999999999999999999999999999999999E+6144",
+			"1.234567890123456789012345678901234E-6000",
+			"1E-6176",
+			"-0",
+			"Infinity",
+		} {
+			d := mustParseDecimal128(t, s)
+			f, err := d.BigFloat()
+			require.NoError(t, err, "converting %s", s)
+			got := NewDecimal128FromBigFloat(f)
+			require.Equal(t, 0, got.Cmp(d), "round trip of %s produced %s", s, got)
+			require.Equal(t, d.h>>63, got.h>>63, "round trip of %s changed the sign", s)
+		}
+		_, err := mustParseDecimal128(t, "NaN").BigFloat()
+		require.Error(t, err)
+
+		huge := new(big.Float).SetMantExp(big.NewFloat(1), 100000)
+		require.Equal(t, "Infinity", NewDecimal128FromBigFloat(huge).String())
+		tiny := new(big.Float).SetMantExp(big.NewFloat(1), -100000)
+		require.Equal(t, "0E-6176", NewDecimal128FromBigFloat(tiny).String())
+		require.Equal(t, "1E-6176", DecimalContext{Rounding: RoundTowardPositive}.FromBigFloat(tiny).String())
+	})
+}
+
+func TestDecimal128_JSON(t *testing.T) {
+	b, err := json.Marshal(mustParseDecimal128(t, "1.50"))
+	require.NoError(t, err)
+	require.Equal(t, `"1.50"`, string(b))
+
+	for _, in := range []string{`"1.50"`, `1.50`, `{"$numberDecimal": "1.50"}`} {
+		var d Decimal128
+		require.NoError(t, json.Unmarshal([]byte(in), &d), "unmarshaling %s", in)
+		require.Equal(t, "1.50", d.String(), "unmarshaling %s", in)
+	}
+
+	var s struct {
+		Price Decimal128 `json:"price"`
+	}
+	s.Price = mustParseDecimal128(t, "2")
+	require.NoError(t, json.Unmarshal([]byte(`{"price": null}`), &s))
+	require.Equal(t, "2", s.Price.String())
+
+	for _, in := range []string{`true`, `"abc"`, `{"$numberDecimal": 1}`, `{"amount": "1"}`} {
+		var d Decimal128
+		require.Error(t, json.Unmarshal([]byte(in), &d), "unmarshaling %s", in)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import "go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+
+// RawPath is a parsed path for Raw.Query.
+type RawPath struct {
+	p bsoncore.QueryPath
+}
+
+// ParseRawPath parses a dotted path such as "a.b.2.c" or "items.$[].price". Each segment is either a key of an
+// embedded document, the index of an array element, or "$[]" to match every element of an array. A RawPath can be
+// parsed once and used to query any number of documents.
+func ParseRawPath(path string) (RawPath, error) {
+	p, err := bsoncore.ParseQueryPath(path)
+	if err != nil {
+		return RawPath{}, err
+	}
+	return RawPath{p: p}, nil
+}
+
+// NewRawPath returns a RawPath with the given segments. Unlike ParseRawPath, keys may contain dots.
+func NewRawPath(segments ...string) RawPath {
+	return RawPath{p: bsoncore.NewQueryPath(segments...)}
+}
+
+// String returns the path in dot notation.
+func (rp RawPath) String() string { return rp.p.String() }
+
+// Query returns an iterator over the values in r that match path, without decoding them. The returned values
+// reference r directly, so r must not be modified while they are in use.
+//
+// Unlike Lookup, a path can select every element of an array with "$[]", so a single path can match many values. A
+// key segment matches the first element of a document with that key. Missing elements, and intermediate values that
+// are not documents or arrays, do not match.
+func (r Raw) Query(path RawPath) *RawValueIterator {
+	return &RawValueIterator{qi: bsoncore.Document(r).Query(path.p)}
+}
+
+// RawValueIterator iterates over the values that match a RawPath. It is used like a Cursor:
+//
+//	it := doc.Query(path)
+//	for it.Next() {
+//		val := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type RawValueIterator struct {
+	qi *bsoncore.QueryIterator
+}
+
+// Next advances the iterator to the next matching value. It returns false when there are no more matches or an error
+// occurs, which can be checked with Err.
+func (rvi *RawValueIterator) Next() bool { return rvi.qi.Next() }
+
+// Value returns the current value.
+func (rvi *RawValueIterator) Value() RawValue { return convertFromCoreValue(rvi.qi.Value()) }
+
+// Path returns the path of the current value, with "$[]" segments replaced by the index of the matching array
+// element.
+func (rvi *RawValueIterator) Path() []string { return rvi.qi.Path() }
+
+// Err returns the error that stopped the iteration, if any.
+func (rvi *RawValueIterator) Err() error { return rvi.qi.Err() }
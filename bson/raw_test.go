@@ -344,4 +344,26 @@ func TestRaw(t *testing.T) {
 			})
 		}
 	})
+	t.Run("Query", func(t *testing.T) {
+		rdr, err := Marshal(D{
+			{"items", A{D{{"sku", "x"}, {"qty", 1}}, "skip", D{{"sku", "y"}, {"qty", 2}}}},
+		})
+		noerr(t, err)
+		path, err := ParseRawPath("items.$[].qty")
+		noerr(t, err)
+
+		var paths []string
+		var qtys []int32
+		it := Raw(rdr).Query(path)
+		for it.Next() {
+			paths = append(paths, NewRawPath(it.Path()...).String())
+			qtys = append(qtys, it.Value().Int32())
+		}
+		noerr(t, it.Err())
+		require.Equal(t, []string{"items.0.qty", "items.2.qty"}, paths)
+		require.Equal(t, []int32{1, 2}, qtys)
+
+		_, err = ParseRawPath("items..qty")
+		require.Error(t, err)
+	})
 }
@@ -12,6 +12,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
 // Unmarshaler is an interface implemented by types that can unmarshal a BSON
@@ -53,6 +54,18 @@ func UnmarshalWithContext(dc bsoncodec.DecodeContext, data []byte, val interface
 	return unmarshalFromReader(dc, vr, val)
 }
 
+// UnmarshalWithValidator checks the BSON-encoded data with Validator v and,
+// if it is valid, stores the result in the value pointed to by val. Use this
+// instead of Unmarshal to reject malformed or hostile documents, such as
+// those received from untrusted sources. If the data is invalid, the
+// bsoncore.ValidationError describing the problem is returned.
+func UnmarshalWithValidator(v bsoncore.Validator, data []byte, val interface{}) error {
+	if err := v.Validate(data); err != nil {
+		return err
+	}
+	return Unmarshal(data, val)
+}
+
 // UnmarshalExtJSON parses the extended JSON-encoded data and stores the result
 // in the value pointed to by val. If val is nil or not a pointer, Unmarshal
 // returns InvalidUnmarshalError.
@@ -13,6 +13,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
 func TestUnmarshal(t *testing.T) {
@@ -70,6 +71,36 @@ func TestUnmarshalWithContext(t *testing.T) {
 	}
 }
 
+func TestUnmarshalWithValidator(t *testing.T) {
+	v := bsoncore.Validator{CheckUTF8: true, CheckDuplicateKeys: true}
+	for _, tc := range unmarshalingTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.reg != nil {
+				t.Skip() // test requires custom registry
+			}
+			got := reflect.New(tc.sType).Interface()
+			err := UnmarshalWithValidator(v, tc.data, got)
+			noerr(t, err)
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("Did not unmarshal as expected. got %v; want %v", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		data := docToBytes(D{{"a", 1}, {"a", 2}})
+		var got D
+		err := UnmarshalWithValidator(v, data, &got)
+		want := bsoncore.ValidationError{Offset: 11, Path: []string{"a"}, Err: bsoncore.ErrDuplicateKey}
+		if !cmp.Equal(err, want) {
+			t.Errorf("Errors do not match. got %v; want %v", err, want)
+		}
+		if got != nil {
+			t.Errorf("Expected nothing to be unmarshaled, got %v", got)
+		}
+	})
+}
+
 func TestUnmarshalExtJSONWithRegistry(t *testing.T) {
 	t.Run("UnmarshalExtJSONWithContext", func(t *testing.T) {
 		type teststruct struct{ Foo int }
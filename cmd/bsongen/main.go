@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"go.mongodb.org/mongo-driver/x/bsonx/bsongen"
+)
+
+func main() {
+	fs := flag.NewFlagSet("", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "bsongen is used to generate MarshalBSON and UnmarshalBSON methods for struct types.")
+		fmt.Fprintln(fs.Output(), "usage: bsongen <package directory> <generated file name> <type>...")
+		fs.PrintDefaults()
+	}
+	var dryrun bool
+	fs.BoolVar(&dryrun, "dryrun", false, "prints the output to stdout instead of writing to a file.")
+	err := fs.Parse(os.Args[1:])
+	if err == flag.ErrHelp {
+		fs.Usage()
+		os.Exit(0)
+	}
+	if err != nil {
+		log.Fatalf("Could not parse flags: %v", err)
+	}
+	args := fs.Args()
+	if len(args) < 3 {
+		log.Println("Insufficient arguments specified.")
+		fs.Usage()
+		os.Exit(1)
+	}
+	dir := args[0]
+	filename := args[1]
+	types := args[2:]
+
+	pkg, err := bsongen.ParseDir(dir, types...)
+	if err != nil {
+		log.Fatalf("Could not parse package '%s': %v", dir, err)
+	}
+	var b bytes.Buffer
+	err = pkg.Generate(&b)
+	if err != nil {
+		log.Fatalf("Could not generate methods: %v", err)
+	}
+	if dryrun {
+		os.Stdout.Write(b.Bytes())
+		os.Exit(0)
+	}
+
+	err = ioutil.WriteFile(filename, b.Bytes(), 0644)
+	if err != nil {
+		log.Fatalf("Could not write to %s: %v", filename, err)
+	}
+}
@@ -8,8 +8,12 @@ package event // import "go.mongodb.org/mongo-driver/event"
 
 import (
 	"context"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/address"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/description"
 )
 
 // CommandStartedEvent represents an event generated when a command is sent to a server.
@@ -62,6 +66,8 @@ const (
 	ConnectionClosed   = "ConnectionClosed"
 	PoolCreated        = "ConnectionPoolCreated"
 	ConnectionCreated  = "ConnectionCreated"
+	ConnectionReady    = "ConnectionReady"
+	GetStarted         = "ConnectionCheckOutStarted"
 	GetFailed          = "ConnectionCheckOutFailed"
 	GetSucceeded       = "ConnectionCheckedOut"
 	ConnectionReturned = "ConnectionCheckedIn"
@@ -73,7 +79,8 @@ const (
 type MonitorPoolOptions struct {
 	MaxPoolSize        uint64 `json:"maxPoolSize"`
 	MinPoolSize        uint64 `json:"minPoolSize"`
-	WaitQueueTimeoutMS uint64 `json:"maxIdleTimeMS"`
+	MaxConnecting      uint64 `json:"maxConnecting"`
+	WaitQueueTimeoutMS uint64 `json:"waitQueueTimeoutMS"`
 }
 
 // PoolEvent contains all information summarizing a pool event
@@ -83,9 +90,98 @@ type PoolEvent struct {
 	ConnectionID uint64              `json:"connectionId"`
 	PoolOptions  *MonitorPoolOptions `json:"options"`
 	Reason       string              `json:"reason"`
+	// ServiceID is only set if the Type is PoolCleared and the server is deployed behind a load balancer. This field
+	// can be used to distinguish between individual servers in a load balanced deployment.
+	ServiceID *primitive.ObjectID `json:"serviceId"`
 }
 
 // PoolMonitor is a function that allows the user to gain access to events occurring in the pool
 type PoolMonitor struct {
 	Event func(*PoolEvent)
 }
+
+// ServerDescriptionChangedEvent represents a server description change.
+type ServerDescriptionChangedEvent struct {
+	Address             address.Address
+	TopologyID          primitive.ObjectID // A unique identifier for the topology this server is a part of
+	PreviousDescription description.Server
+	NewDescription      description.Server
+}
+
+// ServerOpeningEvent is an event generated when the server is initialized.
+type ServerOpeningEvent struct {
+	Address    address.Address
+	TopologyID primitive.ObjectID // A unique identifier for the topology this server is a part of
+}
+
+// ServerClosedEvent is an event generated when the server is closed.
+type ServerClosedEvent struct {
+	Address    address.Address
+	TopologyID primitive.ObjectID // A unique identifier for the topology this server is a part of
+}
+
+// TopologyDescriptionChangedEvent represents a topology description change.
+type TopologyDescriptionChangedEvent struct {
+	TopologyID          primitive.ObjectID // A unique identifier for the topology this server is a part of
+	PreviousDescription description.Topology
+	NewDescription      description.Topology
+}
+
+// TopologyOpeningEvent is an event generated when the topology is initialized.
+type TopologyOpeningEvent struct {
+	TopologyID primitive.ObjectID // A unique identifier for the topology this server is a part of
+}
+
+// TopologyClosedEvent is an event generated when the topology is closed.
+type TopologyClosedEvent struct {
+	TopologyID primitive.ObjectID // A unique identifier for the topology this server is a part of
+}
+
+// ServerHeartbeatStartedEvent is an event generated when the isMaster command is started.
+type ServerHeartbeatStartedEvent struct {
+	ConnectionID string // The address this heartbeat was sent to with a unique identifier
+	Awaited      bool   // If this heartbeat was awaitable
+}
+
+// ServerHeartbeatSucceededEvent is an event generated when the isMaster succeeds.
+type ServerHeartbeatSucceededEvent struct {
+	DurationNanos int64
+	Reply         description.Server
+	ConnectionID  string // The address this heartbeat was sent to with a unique identifier
+	Awaited       bool   // If this heartbeat was awaitable
+}
+
+// Duration returns the time taken by the heartbeat.
+func (e *ServerHeartbeatSucceededEvent) Duration() time.Duration {
+	return time.Duration(e.DurationNanos)
+}
+
+// ServerHeartbeatFailedEvent is an event generated when the isMaster fails.
+type ServerHeartbeatFailedEvent struct {
+	DurationNanos int64
+	Failure       error
+	ConnectionID  string // The address this heartbeat was sent to with a unique identifier
+	Awaited       bool   // If this heartbeat was awaitable
+}
+
+// Duration returns the time taken by the heartbeat.
+func (e *ServerHeartbeatFailedEvent) Duration() time.Duration {
+	return time.Duration(e.DurationNanos)
+}
+
+// ServerMonitor represents a monitor that is triggered for different server discovery and monitoring (SDAM) events.
+// Any of the functions may be nil, in which case the corresponding event is not published. The functions are called
+// synchronously from the driver's monitoring goroutines, so they should return quickly.
+type ServerMonitor struct {
+	ServerDescriptionChanged func(*ServerDescriptionChangedEvent)
+	ServerOpening            func(*ServerOpeningEvent)
+	ServerClosed             func(*ServerClosedEvent)
+	// TopologyDescriptionChanged is called when the topology is updated. When a server in the topology changes its
+	// description, ServerDescriptionChanged is called first, followed by TopologyDescriptionChanged.
+	TopologyDescriptionChanged func(*TopologyDescriptionChangedEvent)
+	TopologyOpening            func(*TopologyOpeningEvent)
+	TopologyClosed             func(*TopologyClosedEvent)
+	ServerHeartbeatStarted     func(*ServerHeartbeatStartedEvent)
+	ServerHeartbeatSucceeded   func(*ServerHeartbeatSucceededEvent)
+	ServerHeartbeatFailed      func(*ServerHeartbeatFailedEvent)
+}
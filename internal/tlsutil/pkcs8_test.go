@@ -0,0 +1,60 @@
+// Copyright (C) MongoDB, Inc. 2020-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package tlsutil
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestDecryptPKCS8PrivateKey(t *testing.T) {
+	expected := readPEMBlock(t, "key.pem", "PRIVATE KEY").Bytes
+
+	testCases := []struct {
+		name      string
+		file      string
+		password  string
+		expectErr bool
+	}{
+		{"AES-256-CBC with HMAC-SHA256", "key-aes256-sha256.pem", "passphrase", false},
+		{"AES-128-CBC with HMAC-SHA1", "key-aes128-sha1.pem", "passphrase", false},
+		{"DES-EDE3-CBC", "key-des3.pem", "passphrase", false},
+		{"incorrect password", "key-aes256-sha256.pem", "wrong", true},
+		{"unsupported PBES1 scheme", "key-pbes1.pem", "passphrase", true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			block := readPEMBlock(t, tc.file, "ENCRYPTED PRIVATE KEY")
+			got, err := DecryptPKCS8PrivateKey(block.Bytes, []byte(tc.password))
+			if tc.expectErr {
+				assert.NotNil(t, err, "expected error, got nil")
+				return
+			}
+			assert.Nil(t, err, "DecryptPKCS8PrivateKey error: %v", err)
+			assert.Equal(t, expected, got, "expected decrypted key %v, got %v", expected, got)
+		})
+	}
+	t.Run("malformed data", func(t *testing.T) {
+		_, err := DecryptPKCS8PrivateKey([]byte{1, 2, 3}, []byte("passphrase"))
+		assert.NotNil(t, err, "expected error, got nil")
+	})
+}
+
+func readPEMBlock(t *testing.T, file, blockType string) *pem.Block {
+	t.Helper()
+
+	data, err := ioutil.ReadFile(filepath.Join("testdata", file))
+	assert.Nil(t, err, "ReadFile error: %v", err)
+	block, _ := pem.Decode(data)
+	assert.NotNil(t, block, "no PEM block found in %v", file)
+	assert.Equal(t, blockType, block.Type, "expected block type %v, got %v", blockType, block.Type)
+	return block
+}
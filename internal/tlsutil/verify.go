@@ -0,0 +1,158 @@
+// Copyright (C) MongoDB, Inc. 2020-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+)
+
+// LoadCRLFile reads the certificate revocation lists in the given file. The file can contain a single DER encoded CRL
+// or any number of PEM encoded "X509 CRL" blocks.
+func LoadCRLFile(file string) ([]*pkix.CertificateList, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var crls []*pkix.CertificateList
+	for rest := data; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "X509 CRL" {
+			continue
+		}
+
+		crl, err := x509.ParseDERCRL(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing CRL: %v", err)
+		}
+		crls = append(crls, crl)
+	}
+	if len(crls) > 0 {
+		return crls, nil
+	}
+
+	crl, err := x509.ParseDERCRL(data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing CRL: %v", err)
+	}
+	return []*pkix.CertificateList{crl}, nil
+}
+
+// ConfigureVerification sets up server certificate verification for options that tls.Config does not support
+// directly. If allowInvalidHostnames is true, the certificate chain is still verified against cfg.RootCAs, but the
+// server's hostname is not checked. Because the tls package then reports no verified chains, VerifiedChains must be
+// used to get the chains for revocation checks after the handshake. If any CRLs are provided, connections to servers
+// whose certificate chain contains a certificate revoked by one of the CRLs will fail. This must be called after
+// cfg.RootCAs has been set and has no effect if cfg.InsecureSkipVerify is already true.
+func ConfigureVerification(cfg *tls.Config, allowInvalidHostnames bool, crls []*pkix.CertificateList) {
+	if cfg.InsecureSkipVerify || (!allowInvalidHostnames && len(crls) == 0) {
+		return
+	}
+
+	if !allowInvalidHostnames {
+		cfg.VerifyPeerCertificate = func(_ [][]byte, verifiedChains [][]*x509.Certificate) error {
+			return checkRevoked(verifiedChains, crls)
+		}
+		return
+	}
+
+	// There is no option to skip only hostname verification, so all verification is disabled and the chain is
+	// verified here instead.
+	roots := cfg.RootCAs
+	cfg.InsecureSkipVerify = true
+	cfg.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		chains, err := verifyChains(rawCerts, roots)
+		if err != nil {
+			return err
+		}
+		return checkRevoked(chains, crls)
+	}
+}
+
+// VerifiedChains returns the verified certificate chains for a completed handshake that used cfg. If cfg was set up
+// by ConfigureVerification to allow invalid hostnames, the tls package does not verify the chain, so it is verified
+// again here against cfg.RootCAs without checking the hostname. Nil is returned if the chain was not verified, which
+// is the case when certificate verification has been disabled.
+func VerifiedChains(cfg *tls.Config, state tls.ConnectionState) [][]*x509.Certificate {
+	if len(state.VerifiedChains) > 0 || !cfg.InsecureSkipVerify || cfg.VerifyPeerCertificate == nil {
+		return state.VerifiedChains
+	}
+
+	chains, err := verifyCertificates(state.PeerCertificates, cfg.RootCAs)
+	if err != nil {
+		return nil
+	}
+	return chains
+}
+
+// verifyChains verifies the certificates presented by a server without checking the hostname. A nil roots pool
+// uses the system roots.
+func verifyChains(rawCerts [][]byte, roots *x509.CertPool) ([][]*x509.Certificate, error) {
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing server certificate: %v", err)
+		}
+		certs[i] = cert
+	}
+	return verifyCertificates(certs, roots)
+}
+
+// verifyCertificates verifies parsed server certificates, leaf first, without checking the hostname.
+func verifyCertificates(certs []*x509.Certificate, roots *x509.CertPool) ([][]*x509.Certificate, error) {
+	if len(certs) == 0 {
+		return nil, errors.New("server did not present a certificate")
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	return certs[0].Verify(opts)
+}
+
+// checkRevoked returns an error if any certificate in the verified chains has been revoked by a CRL issued by the
+// next certificate in the chain, or if a CRL issued by the next certificate is past its next update time and can no
+// longer be relied on.
+func checkRevoked(chains [][]*x509.Certificate, crls []*pkix.CertificateList) error {
+	now := time.Now()
+	for _, chain := range chains {
+		for i := 0; i < len(chain)-1; i++ {
+			cert, issuer := chain[i], chain[i+1]
+			for _, crl := range crls {
+				if issuer.CheckCRLSignature(crl) != nil {
+					continue
+				}
+				if nextUpdate := crl.TBSCertList.NextUpdate; !nextUpdate.IsZero() && now.After(nextUpdate) {
+					return fmt.Errorf("CRL issued by %v is stale; its next update was due at %v", issuer.Subject,
+						nextUpdate)
+				}
+				for _, revoked := range crl.TBSCertList.RevokedCertificates {
+					if revoked.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+						return fmt.Errorf("certificate with serial number %v has been revoked", cert.SerialNumber)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
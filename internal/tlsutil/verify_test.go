@@ -0,0 +1,264 @@
+// Copyright (C) MongoDB, Inc. 2020-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package tlsutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestConfigureVerification(t *testing.T) {
+	caKey, caCert := newTestCertificate(t, nil, nil, 1)
+	_, leafCert := newTestCertificate(t, caCert, caKey, 2)
+	otherKey, otherCA := newTestCertificate(t, nil, nil, 3)
+	_, untrustedLeaf := newTestCertificate(t, otherCA, otherKey, 4)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+	revokesLeaf := newTestCRL(t, caCert, caKey, leafCert.SerialNumber)
+	revokesOther := newTestCRL(t, caCert, caKey, big.NewInt(100))
+	// A CRL for the leaf's serial number that was issued by a different CA should not be used.
+	wrongIssuer := newTestCRL(t, otherCA, otherKey, leafCert.SerialNumber)
+	staleDER, err := caCert.CreateCRL(rand.Reader, caKey, nil, time.Now().Add(-2*time.Hour), time.Now().Add(-time.Hour))
+	assert.Nil(t, err, "CreateCRL error: %v", err)
+	stale, err := x509.ParseDERCRL(staleDER)
+	assert.Nil(t, err, "ParseDERCRL error: %v", err)
+
+	t.Run("no changes needed", func(t *testing.T) {
+		cfg := &tls.Config{RootCAs: roots}
+		ConfigureVerification(cfg, false, nil)
+		assert.False(t, cfg.InsecureSkipVerify, "expected InsecureSkipVerify to be false")
+		assert.Nil(t, cfg.VerifyPeerCertificate, "expected VerifyPeerCertificate to be nil")
+
+		cfg = &tls.Config{RootCAs: roots, InsecureSkipVerify: true}
+		ConfigureVerification(cfg, true, []*pkix.CertificateList{revokesLeaf})
+		assert.Nil(t, cfg.VerifyPeerCertificate, "expected VerifyPeerCertificate to be nil")
+	})
+	t.Run("CRLs", func(t *testing.T) {
+		testCases := []struct {
+			name      string
+			crl       *pkix.CertificateList
+			expectErr bool
+		}{
+			{"certificate revoked", revokesLeaf, true},
+			{"certificate not revoked", revokesOther, false},
+			{"CRL from different issuer", wrongIssuer, false},
+			{"stale CRL", stale, true},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				cfg := &tls.Config{RootCAs: roots}
+				ConfigureVerification(cfg, false, []*pkix.CertificateList{tc.crl})
+				assert.False(t, cfg.InsecureSkipVerify, "expected InsecureSkipVerify to be false")
+
+				chains := [][]*x509.Certificate{{leafCert, caCert}}
+				err := cfg.VerifyPeerCertificate([][]byte{leafCert.Raw}, chains)
+				checkError(t, err, tc.expectErr)
+			})
+		}
+	})
+	t.Run("allow invalid hostnames", func(t *testing.T) {
+		testCases := []struct {
+			name      string
+			certs     []*x509.Certificate
+			crls      []*pkix.CertificateList
+			expectErr bool
+		}{
+			{"trusted certificate", []*x509.Certificate{leafCert}, nil, false},
+			{"trusted certificate with intermediates", []*x509.Certificate{leafCert, caCert}, nil, false},
+			{"untrusted certificate", []*x509.Certificate{untrustedLeaf}, nil, true},
+			{"no certificates", nil, nil, true},
+			{"revoked certificate", []*x509.Certificate{leafCert}, []*pkix.CertificateList{revokesLeaf}, true},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				cfg := &tls.Config{RootCAs: roots}
+				ConfigureVerification(cfg, true, tc.crls)
+				assert.True(t, cfg.InsecureSkipVerify, "expected InsecureSkipVerify to be true")
+
+				var rawCerts [][]byte
+				for _, cert := range tc.certs {
+					rawCerts = append(rawCerts, cert.Raw)
+				}
+				err := cfg.VerifyPeerCertificate(rawCerts, nil)
+				checkError(t, err, tc.expectErr)
+			})
+		}
+	})
+}
+
+func TestVerifiedChains(t *testing.T) {
+	caKey, caCert := newTestCertificate(t, nil, nil, 1)
+	_, leafCert := newTestCertificate(t, caCert, caKey, 2)
+	otherKey, otherCA := newTestCertificate(t, nil, nil, 3)
+	_, untrustedLeaf := newTestCertificate(t, otherCA, otherKey, 4)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+	allowInvalidHostnames := &tls.Config{RootCAs: roots}
+	ConfigureVerification(allowInvalidHostnames, true, nil)
+	verifiedChains := [][]*x509.Certificate{{leafCert, caCert}}
+
+	testCases := []struct {
+		name     string
+		cfg      *tls.Config
+		state    tls.ConnectionState
+		expected int
+	}{
+		{"chains verified by tls", &tls.Config{RootCAs: roots}, tls.ConnectionState{VerifiedChains: verifiedChains}, 1},
+		{
+			"allow invalid hostnames",
+			allowInvalidHostnames,
+			tls.ConnectionState{PeerCertificates: []*x509.Certificate{leafCert}},
+			1,
+		},
+		{
+			"allow invalid hostnames with untrusted certificate",
+			allowInvalidHostnames,
+			tls.ConnectionState{PeerCertificates: []*x509.Certificate{untrustedLeaf}},
+			0,
+		},
+		{
+			"verification disabled",
+			&tls.Config{RootCAs: roots, InsecureSkipVerify: true},
+			tls.ConnectionState{PeerCertificates: []*x509.Certificate{leafCert}},
+			0,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			chains := VerifiedChains(tc.cfg, tc.state)
+			assert.Equal(t, tc.expected, len(chains), "expected %v chains, got %v", tc.expected, len(chains))
+			if tc.expected > 0 {
+				assert.Equal(t, leafCert, chains[0][0], "expected chain to start with the leaf certificate")
+			}
+		})
+	}
+}
+
+func TestLoadCRLFile(t *testing.T) {
+	caKey, caCert := newTestCertificate(t, nil, nil, 1)
+	crl1 := newTestCRLBytes(t, caCert, caKey, big.NewInt(10))
+	crl2 := newTestCRLBytes(t, caCert, caKey, big.NewInt(20))
+
+	dir, err := ioutil.TempDir("", "crl")
+	assert.Nil(t, err, "TempDir error: %v", err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	writeFile := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		err := ioutil.WriteFile(path, data, 0600)
+		assert.Nil(t, err, "WriteFile error: %v", err)
+		return path
+	}
+	pemData := append(
+		pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crl1}),
+		pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crl2})...,
+	)
+
+	testCases := []struct {
+		name     string
+		file     string
+		expected int
+	}{
+		{"PEM", writeFile("crl.pem", pemData), 2},
+		{"DER", writeFile("crl.der", crl1), 1},
+		{"invalid", writeFile("invalid.pem", []byte("not a CRL")), 0},
+		{"missing file", filepath.Join(dir, "missing.pem"), 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			crls, err := LoadCRLFile(tc.file)
+			if tc.expected == 0 {
+				assert.NotNil(t, err, "expected error, got nil")
+				return
+			}
+			assert.Nil(t, err, "LoadCRLFile error: %v", err)
+			assert.Equal(t, tc.expected, len(crls), "expected %v CRLs, got %v", tc.expected, len(crls))
+		})
+	}
+}
+
+func checkError(t *testing.T, err error, expectErr bool) {
+	t.Helper()
+
+	if expectErr {
+		assert.NotNil(t, err, "expected error, got nil")
+		return
+	}
+	assert.Nil(t, err, "expected no error, got %v", err)
+}
+
+// newTestCertificate creates a certificate signed by the given issuer. The certificate's only DNS name is
+// "db.example.com". If the issuer is nil, a self-signed CA certificate is created.
+func newTestCertificate(t *testing.T, issuer *x509.Certificate, issuerKey *ecdsa.PrivateKey,
+	serial int64) (*ecdsa.PrivateKey, *x509.Certificate) {
+
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err, "GenerateKey error: %v", err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "db.example.com"},
+		DNSNames:     []string{"db.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	if issuer == nil {
+		template.Subject = pkix.Name{CommonName: "test CA"}
+		template.KeyUsage |= x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+		template.BasicConstraintsValid = true
+		template.IsCA = true
+		issuer, issuerKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, issuerKey)
+	assert.Nil(t, err, "CreateCertificate error: %v", err)
+	cert, err := x509.ParseCertificate(der)
+	assert.Nil(t, err, "ParseCertificate error: %v", err)
+	return key, cert
+}
+
+func newTestCRL(t *testing.T, issuer *x509.Certificate, issuerKey *ecdsa.PrivateKey,
+	revoked ...*big.Int) *pkix.CertificateList {
+
+	t.Helper()
+
+	crl, err := x509.ParseDERCRL(newTestCRLBytes(t, issuer, issuerKey, revoked...))
+	assert.Nil(t, err, "ParseDERCRL error: %v", err)
+	return crl
+}
+
+// newTestCRLBytes creates a DER encoded CRL that revokes the given serial numbers.
+func newTestCRLBytes(t *testing.T, issuer *x509.Certificate, issuerKey *ecdsa.PrivateKey, revoked ...*big.Int) []byte {
+	t.Helper()
+
+	var entries []pkix.RevokedCertificate
+	for _, serial := range revoked {
+		entries = append(entries, pkix.RevokedCertificate{SerialNumber: serial, RevocationTime: time.Now()})
+	}
+	der, err := issuer.CreateCRL(rand.Reader, issuerKey, entries, time.Now(), time.Now().Add(time.Hour))
+	assert.Nil(t, err, "CreateCRL error: %v", err)
+	return der
+}
@@ -66,6 +66,7 @@ type changeStreamConfig struct {
 	readPreference *readpref.ReadPref
 	client         *Client
 	registry       *bsoncodec.Registry
+	timeout        *time.Duration
 	streamType     StreamType
 	collectionName string
 	databaseName   string
@@ -100,7 +101,8 @@ func newChangeStream(ctx context.Context, config changeStreamConfig, pipeline in
 	cs.aggregate = operation.NewAggregate(nil).
 		ReadPreference(config.readPreference).ReadConcern(config.readConcern).
 		Deployment(cs.client.deployment).ClusterClock(cs.client.clock).
-		CommandMonitor(cs.client.monitor).Session(cs.sess).ServerSelector(cs.selector).Retry(driver.RetryNone)
+		CommandMonitor(cs.client.monitor).Session(cs.sess).ServerSelector(cs.selector).Retry(driver.RetryNone).
+		Timeout(config.timeout).ServerAPI(cs.client.serverAPI)
 
 	if cs.options.Collation != nil {
 		cs.aggregate.Collation(bsoncore.Document(cs.options.Collation.ToDocument()))
@@ -113,6 +115,8 @@ func newChangeStream(ctx context.Context, config changeStreamConfig, pipeline in
 		cs.cursorOptions.MaxTimeMS = int64(time.Duration(*cs.options.MaxAwaitTime) / time.Millisecond)
 	}
 	cs.cursorOptions.CommandMonitor = cs.client.monitor
+	cs.cursorOptions.Timeout = config.timeout
+	cs.cursorOptions.ServerAPI = cs.client.serverAPI
 
 	switch cs.streamType {
 	case ClientStream:
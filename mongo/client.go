@@ -26,6 +26,7 @@ import (
 	"go.mongodb.org/mongo-driver/x/mongo/driver/auth"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/description"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/ocsp"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
@@ -57,6 +58,8 @@ type Client struct {
 	readConcern     *readconcern.ReadConcern
 	writeConcern    *writeconcern.WriteConcern
 	registry        *bsoncodec.Registry
+	timeout         *time.Duration
+	serverAPI       *driver.ServerAPIOptions
 	marshaller      BSONAppender
 	monitor         *event.CommandMonitor
 	sessionPool     *session.Pool
@@ -297,7 +300,7 @@ func (c *Client) endSessions(ctx context.Context) {
 
 	op := operation.NewEndSessions(idArray).ClusterClock(c.clock).Deployment(c.deployment).
 		ServerSelector(description.ReadPrefSelector(readpref.PrimaryPreferred())).CommandMonitor(c.monitor).
-		Database("admin").Crypt(c.crypt)
+		Database("admin").Crypt(c.crypt).ServerAPI(c.serverAPI)
 
 	idx, idArray = bsoncore.AppendArrayStart(nil)
 	totalNumIDs := len(ids)
@@ -358,9 +361,24 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 			func(opts ...string) []string { return append(opts, comps...) },
 		))
 	}
+	// LoadBalanced
+	loadBalanced := opts.LoadBalanced != nil && *opts.LoadBalanced
+	// ServerAPIOptions
+	if opts.ServerAPIOptions != nil {
+		c.serverAPI = &driver.ServerAPIOptions{
+			ServerAPIVersion:  string(opts.ServerAPIOptions.ServerAPIVersion),
+			Strict:            opts.ServerAPIOptions.Strict,
+			DeprecationErrors: opts.ServerAPIOptions.DeprecationErrors,
+		}
+
+		serverOpts = append(serverOpts, topology.WithServerAPI(
+			func(*driver.ServerAPIOptions) *driver.ServerAPIOptions { return c.serverAPI },
+		))
+	}
 	// Handshaker
 	var handshaker = func(driver.Handshaker) driver.Handshaker {
-		return operation.NewIsMaster().AppName(appName).Compressors(comps)
+		return operation.NewIsMaster().AppName(appName).Compressors(comps).LoadBalanced(loadBalanced).
+			ServerAPI(c.serverAPI)
 	}
 	// Auth & Database & Password & Username
 	if opts.Auth != nil {
@@ -375,7 +393,7 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 
 		if len(cred.Source) == 0 {
 			switch strings.ToUpper(mechanism) {
-			case auth.MongoDBX509, auth.GSSAPI, auth.PLAIN:
+			case auth.MongoDBX509, auth.GSSAPI, auth.PLAIN, auth.MongoDBAWS:
 				cred.Source = "$external"
 			default:
 				cred.Source = "admin"
@@ -391,6 +409,8 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 			AppName:       appName,
 			Authenticator: authenticator,
 			Compressors:   comps,
+			LoadBalanced:  loadBalanced,
+			ServerAPI:     c.serverAPI,
 		}
 		if mechanism == "" {
 			// Required for SASL mechanism negotiation during handshake
@@ -429,6 +449,11 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 			func(topology.MonitorMode) topology.MonitorMode { return topology.SingleMode },
 		))
 	}
+	if loadBalanced {
+		topologyOpts = append(topologyOpts, topology.WithMode(
+			func(topology.MonitorMode) topology.MonitorMode { return topology.LoadBalancedMode },
+		))
+	}
 	// HeartbeatInterval
 	if opts.HeartbeatInterval != nil {
 		serverOpts = append(serverOpts, topology.WithHeartbeatInterval(
@@ -454,6 +479,13 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 			func(time.Duration) time.Duration { return *opts.MaxConnIdleTime },
 		))
 	}
+	// MaxConnecting
+	if opts.MaxConnecting != nil {
+		serverOpts = append(
+			serverOpts,
+			topology.WithMaxConnecting(func(uint64) uint64 { return *opts.MaxConnecting }),
+		)
+	}
 	// MaxPoolSize
 	if opts.MaxPoolSize != nil {
 		serverOpts = append(
@@ -475,6 +507,13 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 			topology.WithConnectionPoolMonitor(func(*event.PoolMonitor) *event.PoolMonitor { return opts.PoolMonitor }),
 		)
 	}
+	// ServerMonitor
+	if opts.ServerMonitor != nil {
+		serverOpts = append(
+			serverOpts,
+			topology.WithServerMonitor(func(*event.ServerMonitor) *event.ServerMonitor { return opts.ServerMonitor }),
+		)
+	}
 	// Monitor
 	if opts.Monitor != nil {
 		c.monitor = opts.Monitor
@@ -526,6 +565,8 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 			topology.WithWriteTimeout(func(time.Duration) time.Duration { return *opts.SocketTimeout }),
 		)
 	}
+	// Timeout
+	c.timeout = opts.Timeout
 	// TLSConfig
 	if opts.TLSConfig != nil {
 		connOpts = append(connOpts, topology.WithTLSConfig(
@@ -534,6 +575,28 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 			},
 		))
 	}
+	// OCSP cache
+	ocspCache := ocsp.NewCache()
+	connOpts = append(connOpts, topology.WithOCSPCache(func(ocsp.Cache) ocsp.Cache { return ocspCache }))
+	// DisableOCSPEndpointCheck
+	if opts.DisableOCSPEndpointCheck != nil {
+		connOpts = append(connOpts, topology.WithDisableOCSPEndpointCheck(
+			func(bool) bool { return *opts.DisableOCSPEndpointCheck },
+		))
+	}
+	// DisableCertificateRevocationCheck
+	if opts.DisableCertificateRevocationCheck != nil {
+		connOpts = append(connOpts, topology.WithDisableCertificateRevocationCheck(
+			func(bool) bool { return *opts.DisableCertificateRevocationCheck },
+		))
+	}
+	// WaitQueueTimeout
+	if opts.WaitQueueTimeout != nil {
+		serverOpts = append(
+			serverOpts,
+			topology.WithWaitQueueTimeout(func(time.Duration) time.Duration { return *opts.WaitQueueTimeout }),
+		)
+	}
 	// WriteConcern
 	if opts.WriteConcern != nil {
 		c.writeConcern = opts.WriteConcern
@@ -692,7 +755,8 @@ func (c *Client) ListDatabases(ctx context.Context, filter interface{}, opts ...
 	ldo := options.MergeListDatabasesOptions(opts...)
 	op := operation.NewListDatabases(filterDoc).
 		Session(sess).ReadPreference(c.readPreference).CommandMonitor(c.monitor).
-		ServerSelector(selector).ClusterClock(c.clock).Database("admin").Deployment(c.deployment).Crypt(c.crypt)
+		ServerSelector(selector).ClusterClock(c.clock).Database("admin").Deployment(c.deployment).Crypt(c.crypt).
+		Timeout(c.timeout).ServerAPI(c.serverAPI)
 	if ldo.NameOnly != nil {
 		op = op.NameOnly(*ldo.NameOnly)
 	}
@@ -801,6 +865,7 @@ func (c *Client) Watch(ctx context.Context, pipeline interface{},
 		readPreference: c.readPreference,
 		client:         c,
 		registry:       c.registry,
+		timeout:        c.timeout,
 		streamType:     ClientStream,
 	}
 
@@ -38,6 +38,7 @@ type Collection struct {
 	readSelector   description.ServerSelector
 	writeSelector  description.ServerSelector
 	registry       *bsoncodec.Registry
+	timeout        *time.Duration
 }
 
 // aggregateParams is used to store information to configure an Aggregate operation.
@@ -54,6 +55,7 @@ type aggregateParams struct {
 	readSelector   description.ServerSelector
 	writeSelector  description.ServerSelector
 	readPreference *readpref.ReadPref
+	timeout        *time.Duration
 	opts           []*options.AggregateOptions
 }
 
@@ -86,6 +88,11 @@ func newCollection(db *Database, name string, opts ...*options.CollectionOptions
 		reg = collOpt.Registry
 	}
 
+	timeout := db.timeout
+	if collOpt.Timeout != nil {
+		timeout = collOpt.Timeout
+	}
+
 	readSelector := description.CompositeSelector([]description.ServerSelector{
 		description.ReadPrefSelector(rp),
 		description.LatencySelector(db.client.localThreshold),
@@ -106,6 +113,7 @@ func newCollection(db *Database, name string, opts ...*options.CollectionOptions
 		readSelector:   readSelector,
 		writeSelector:  writeSelector,
 		registry:       reg,
+		timeout:        timeout,
 	}
 
 	return coll
@@ -122,6 +130,7 @@ func (coll *Collection) copy() *Collection {
 		readSelector:   coll.readSelector,
 		writeSelector:  coll.writeSelector,
 		registry:       coll.registry,
+		timeout:        coll.timeout,
 	}
 }
 
@@ -148,6 +157,10 @@ func (coll *Collection) Clone(opts ...*options.CollectionOptions) (*Collection,
 		copyColl.registry = optsColl.Registry
 	}
 
+	if optsColl.Timeout != nil {
+		copyColl.timeout = optsColl.Timeout
+	}
+
 	copyColl.readSelector = description.CompositeSelector([]description.ServerSelector{
 		description.ReadPrefSelector(copyColl.readPreference),
 		description.LatencySelector(copyColl.client.localThreshold),
@@ -279,7 +292,8 @@ func (coll *Collection) insert(ctx context.Context, documents []interface{},
 		Session(sess).WriteConcern(wc).CommandMonitor(coll.client.monitor).
 		ServerSelector(selector).ClusterClock(coll.client.clock).
 		Database(coll.db.name).Collection(coll.name).
-		Deployment(coll.client.deployment).Crypt(coll.client.crypt)
+		Deployment(coll.client.deployment).Crypt(coll.client.crypt).Timeout(coll.timeout).
+		ServerAPI(coll.client.serverAPI)
 	imo := options.MergeInsertManyOptions(opts...)
 	if imo.BypassDocumentValidation != nil && *imo.BypassDocumentValidation {
 		op = op.BypassDocumentValidation(*imo.BypassDocumentValidation)
@@ -409,6 +423,27 @@ func (coll *Collection) delete(ctx context.Context, filter interface{}, deleteOn
 
 	selector := makePinnedSelector(sess, coll.writeSelector)
 
+	op := coll.deleteOperation(f, deleteOne, opts...).
+		Session(sess).WriteConcern(wc).ServerSelector(selector)
+
+	// deleteMany cannot be retried
+	retryMode := driver.RetryNone
+	if deleteOne && coll.client.retryWrites {
+		retryMode = driver.RetryOncePerCommand
+	}
+	op = op.Retry(retryMode)
+	rr, err := processWriteError(op.Execute(ctx))
+	if rr&expectedRr == 0 {
+		return nil, err
+	}
+	return &DeleteResult{DeletedCount: int64(op.Result().N)}, err
+}
+
+// deleteOperation creates a Delete operation for the given filter and options. The caller is responsible for setting
+// the session, write concern, server selector, and retry mode.
+func (coll *Collection) deleteOperation(f bsoncore.Document, deleteOne bool,
+	opts ...*options.DeleteOptions) *operation.Delete {
+
 	var limit int32
 	if deleteOne {
 		limit = 1
@@ -422,23 +457,11 @@ func (coll *Collection) delete(ctx context.Context, filter interface{}, deleteOn
 	}
 	doc, _ = bsoncore.AppendDocumentEnd(doc, didx)
 
-	op := operation.NewDelete(doc).
-		Session(sess).WriteConcern(wc).CommandMonitor(coll.client.monitor).
-		ServerSelector(selector).ClusterClock(coll.client.clock).
+	return operation.NewDelete(doc).
+		CommandMonitor(coll.client.monitor).ClusterClock(coll.client.clock).
 		Database(coll.db.name).Collection(coll.name).
-		Deployment(coll.client.deployment).Crypt(coll.client.crypt)
-
-	// deleteMany cannot be retried
-	retryMode := driver.RetryNone
-	if deleteOne && coll.client.retryWrites {
-		retryMode = driver.RetryOncePerCommand
-	}
-	op = op.Retry(retryMode)
-	rr, err := processWriteError(op.Execute(ctx))
-	if rr&expectedRr == 0 {
-		return nil, err
-	}
-	return &DeleteResult{DeletedCount: int64(op.Result().N)}, err
+		Deployment(coll.client.deployment).Crypt(coll.client.crypt).Timeout(coll.timeout).
+		ServerAPI(coll.client.serverAPI)
 }
 
 // DeleteOne executes a delete command to delete at most one document from the collection.
@@ -480,35 +503,10 @@ func (coll *Collection) updateOrReplace(ctx context.Context, filter bsoncore.Doc
 		ctx = context.Background()
 	}
 
-	uo := options.MergeUpdateOptions(opts...)
-	uidx, updateDoc := bsoncore.AppendDocumentStart(nil)
-	updateDoc = bsoncore.AppendDocumentElement(updateDoc, "q", filter)
-
-	u, err := transformUpdateValue(coll.registry, update, checkDollarKey)
+	op, err := coll.updateOperation(filter, update, multi, checkDollarKey, opts...)
 	if err != nil {
 		return nil, err
 	}
-	updateDoc = bsoncore.AppendValueElement(updateDoc, "u", u)
-	if multi {
-		updateDoc = bsoncore.AppendBooleanElement(updateDoc, "multi", multi)
-	}
-
-	// collation, arrayFilters, and upsert are included on the individual update documents rather than as part of the
-	// command
-	if uo.Collation != nil {
-		updateDoc = bsoncore.AppendDocumentElement(updateDoc, "collation", bsoncore.Document(uo.Collation.ToDocument()))
-	}
-	if uo.ArrayFilters != nil {
-		arr, err := uo.ArrayFilters.ToArrayDocument()
-		if err != nil {
-			return nil, err
-		}
-		updateDoc = bsoncore.AppendArrayElement(updateDoc, "arrayFilters", arr)
-	}
-	if uo.Upsert != nil {
-		updateDoc = bsoncore.AppendBooleanElement(updateDoc, "upsert", *uo.Upsert)
-	}
-	updateDoc, _ = bsoncore.AppendDocumentEnd(updateDoc, uidx)
 
 	sess := sessionFromContext(ctx)
 	if sess == nil && coll.client.sessionPool != nil {
@@ -535,15 +533,7 @@ func (coll *Collection) updateOrReplace(ctx context.Context, filter bsoncore.Doc
 
 	selector := makePinnedSelector(sess, coll.writeSelector)
 
-	op := operation.NewUpdate(updateDoc).
-		Session(sess).WriteConcern(wc).CommandMonitor(coll.client.monitor).
-		ServerSelector(selector).ClusterClock(coll.client.clock).
-		Database(coll.db.name).Collection(coll.name).
-		Deployment(coll.client.deployment).Crypt(coll.client.crypt)
-
-	if uo.BypassDocumentValidation != nil && *uo.BypassDocumentValidation {
-		op = op.BypassDocumentValidation(*uo.BypassDocumentValidation)
-	}
+	op = op.Session(sess).WriteConcern(wc).ServerSelector(selector)
 	retry := driver.RetryNone
 	// retryable writes are only enabled updateOne/replaceOne operations
 	if !multi && coll.client.retryWrites {
@@ -571,6 +561,53 @@ func (coll *Collection) updateOrReplace(ctx context.Context, filter bsoncore.Doc
 	return res, err
 }
 
+// updateOperation creates an Update operation for the given filter, update, and options. The caller is responsible
+// for setting the session, write concern, server selector, and retry mode.
+func (coll *Collection) updateOperation(filter bsoncore.Document, update interface{}, multi bool,
+	checkDollarKey bool, opts ...*options.UpdateOptions) (*operation.Update, error) {
+
+	uo := options.MergeUpdateOptions(opts...)
+	uidx, updateDoc := bsoncore.AppendDocumentStart(nil)
+	updateDoc = bsoncore.AppendDocumentElement(updateDoc, "q", filter)
+
+	u, err := transformUpdateValue(coll.registry, update, checkDollarKey)
+	if err != nil {
+		return nil, err
+	}
+	updateDoc = bsoncore.AppendValueElement(updateDoc, "u", u)
+	if multi {
+		updateDoc = bsoncore.AppendBooleanElement(updateDoc, "multi", multi)
+	}
+
+	// collation, arrayFilters, and upsert are included on the individual update documents rather than as part of the
+	// command
+	if uo.Collation != nil {
+		updateDoc = bsoncore.AppendDocumentElement(updateDoc, "collation", bsoncore.Document(uo.Collation.ToDocument()))
+	}
+	if uo.ArrayFilters != nil {
+		arr, err := uo.ArrayFilters.ToArrayDocument()
+		if err != nil {
+			return nil, err
+		}
+		updateDoc = bsoncore.AppendArrayElement(updateDoc, "arrayFilters", arr)
+	}
+	if uo.Upsert != nil {
+		updateDoc = bsoncore.AppendBooleanElement(updateDoc, "upsert", *uo.Upsert)
+	}
+	updateDoc, _ = bsoncore.AppendDocumentEnd(updateDoc, uidx)
+
+	op := operation.NewUpdate(updateDoc).
+		CommandMonitor(coll.client.monitor).ClusterClock(coll.client.clock).
+		Database(coll.db.name).Collection(coll.name).
+		Deployment(coll.client.deployment).Crypt(coll.client.crypt).Timeout(coll.timeout).
+		ServerAPI(coll.client.serverAPI)
+
+	if uo.BypassDocumentValidation != nil && *uo.BypassDocumentValidation {
+		op = op.BypassDocumentValidation(*uo.BypassDocumentValidation)
+	}
+	return op, nil
+}
+
 // UpdateOne executes an update command to update at most one document in the collection.
 //
 // The filter parameter must be a document containing query operators and can be used to select the document to be
@@ -700,6 +737,7 @@ func (coll *Collection) Aggregate(ctx context.Context, pipeline interface{},
 		readSelector:   coll.readSelector,
 		writeSelector:  coll.writeSelector,
 		readPreference: coll.readPreference,
+		timeout:        coll.timeout,
 		opts:           opts,
 	}
 	return aggregate(a)
@@ -747,14 +785,53 @@ func aggregate(a aggregateParams) (*Cursor, error) {
 		selector = makeReadPrefSelector(sess, a.readSelector, a.client.localThreshold)
 	}
 
+	op, cursorOpts, err := aggregateOperation(a, pipelineArr, hasOutputStage)
+	if err != nil {
+		closeImplicitSession(sess)
+		return nil, err
+	}
+	op.Session(sess).WriteConcern(wc).ReadConcern(rc).ReadPreference(a.readPreference).ServerSelector(selector)
+
+	retry := driver.RetryNone
+	if a.retryRead && !hasOutputStage {
+		retry = driver.RetryOncePerCommand
+	}
+	op = op.Retry(retry)
+
+	err = op.Execute(a.ctx)
+	if err != nil {
+		closeImplicitSession(sess)
+		if wce, ok := err.(driver.WriteCommandError); ok && wce.WriteConcernError != nil {
+			return nil, *convertDriverWriteConcernError(wce.WriteConcernError)
+		}
+		return nil, replaceErrors(err)
+	}
+
+	bc, err := op.Result(cursorOpts)
+	if err != nil {
+		closeImplicitSession(sess)
+		return nil, replaceErrors(err)
+	}
+	cursor, err := newCursorWithSession(bc, a.registry, sess)
+	return cursor, replaceErrors(err)
+}
+
+// aggregateOperation creates an Aggregate operation for the given pipeline and the options in a. The caller is
+// responsible for setting the session, concerns, read preference, server selector, and retry mode.
+func aggregateOperation(a aggregateParams, pipelineArr bsoncore.Document,
+	hasOutputStage bool) (*operation.Aggregate, driver.CursorOptions, error) {
+
 	ao := options.MergeAggregateOptions(a.opts...)
 	cursorOpts := driver.CursorOptions{
 		CommandMonitor: a.client.monitor,
 		Crypt:          a.client.crypt,
+		Timeout:        a.timeout,
+		ServerAPI:      a.client.serverAPI,
 	}
 
-	op := operation.NewAggregate(pipelineArr).Session(sess).WriteConcern(wc).ReadConcern(rc).ReadPreference(a.readPreference).CommandMonitor(a.client.monitor).
-		ServerSelector(selector).ClusterClock(a.client.clock).Database(a.db).Collection(a.col).Deployment(a.client.deployment).Crypt(a.client.crypt)
+	op := operation.NewAggregate(pipelineArr).CommandMonitor(a.client.monitor).ClusterClock(a.client.clock).
+		Database(a.db).Collection(a.col).Deployment(a.client.deployment).Crypt(a.client.crypt).Timeout(a.timeout).
+		ServerAPI(a.client.serverAPI)
 	if ao.AllowDiskUse != nil {
 		op.AllowDiskUse(*ao.AllowDiskUse)
 	}
@@ -781,34 +858,12 @@ func aggregate(a aggregateParams) (*Cursor, error) {
 	if ao.Hint != nil {
 		hintVal, err := transformValue(a.registry, ao.Hint)
 		if err != nil {
-			closeImplicitSession(sess)
-			return nil, err
+			return nil, driver.CursorOptions{}, err
 		}
 		op.Hint(hintVal)
 	}
 
-	retry := driver.RetryNone
-	if a.retryRead && !hasOutputStage {
-		retry = driver.RetryOncePerCommand
-	}
-	op = op.Retry(retry)
-
-	err = op.Execute(a.ctx)
-	if err != nil {
-		closeImplicitSession(sess)
-		if wce, ok := err.(driver.WriteCommandError); ok && wce.WriteConcernError != nil {
-			return nil, *convertDriverWriteConcernError(wce.WriteConcernError)
-		}
-		return nil, replaceErrors(err)
-	}
-
-	bc, err := op.Result(cursorOpts)
-	if err != nil {
-		closeImplicitSession(sess)
-		return nil, replaceErrors(err)
-	}
-	cursor, err := newCursorWithSession(bc, a.registry, sess)
-	return cursor, replaceErrors(err)
+	return op, cursorOpts, nil
 }
 
 // CountDocuments returns the number of documents in the collection. For a fast count of the documents in the
@@ -826,9 +881,7 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 		ctx = context.Background()
 	}
 
-	countOpts := options.MergeCountOptions(opts...)
-
-	pipelineArr, err := countDocumentsAggregatePipeline(coll.registry, filter, countOpts)
+	op, err := coll.countDocumentsOperation(filter, opts...)
 	if err != nil {
 		return 0, err
 	}
@@ -851,22 +904,7 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 	}
 
 	selector := makeReadPrefSelector(sess, coll.readSelector, coll.client.localThreshold)
-	op := operation.NewAggregate(pipelineArr).Session(sess).ReadConcern(rc).ReadPreference(coll.readPreference).
-		CommandMonitor(coll.client.monitor).ServerSelector(selector).ClusterClock(coll.client.clock).Database(coll.db.name).
-		Collection(coll.name).Deployment(coll.client.deployment).Crypt(coll.client.crypt)
-	if countOpts.Collation != nil {
-		op.Collation(bsoncore.Document(countOpts.Collation.ToDocument()))
-	}
-	if countOpts.MaxTime != nil {
-		op.MaxTimeMS(int64(*countOpts.MaxTime / time.Millisecond))
-	}
-	if countOpts.Hint != nil {
-		hintVal, err := transformValue(coll.registry, countOpts.Hint)
-		if err != nil {
-			return 0, err
-		}
-		op.Hint(hintVal)
-	}
+	op.Session(sess).ReadConcern(rc).ReadPreference(coll.readPreference).ServerSelector(selector)
 	retry := driver.RetryNone
 	if coll.client.retryReads {
 		retry = driver.RetryOncePerCommand
@@ -896,6 +934,37 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 	return val, nil
 }
 
+// countDocumentsOperation creates the Aggregate operation used by CountDocuments for the given filter and options. The
+// caller is responsible for setting the session, read concern, read preference, server selector, and retry mode.
+func (coll *Collection) countDocumentsOperation(filter interface{},
+	opts ...*options.CountOptions) (*operation.Aggregate, error) {
+
+	countOpts := options.MergeCountOptions(opts...)
+
+	pipelineArr, err := countDocumentsAggregatePipeline(coll.registry, filter, countOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	op := operation.NewAggregate(pipelineArr).CommandMonitor(coll.client.monitor).ClusterClock(coll.client.clock).
+		Database(coll.db.name).Collection(coll.name).Deployment(coll.client.deployment).Crypt(coll.client.crypt).
+		Timeout(coll.timeout).ServerAPI(coll.client.serverAPI)
+	if countOpts.Collation != nil {
+		op.Collation(bsoncore.Document(countOpts.Collation.ToDocument()))
+	}
+	if countOpts.MaxTime != nil {
+		op.MaxTimeMS(int64(*countOpts.MaxTime / time.Millisecond))
+	}
+	if countOpts.Hint != nil {
+		hintVal, err := transformValue(coll.registry, countOpts.Hint)
+		if err != nil {
+			return nil, err
+		}
+		op.Hint(hintVal)
+	}
+	return op, nil
+}
+
 // EstimatedDocumentCount executes a count command and returns an estimate of the number of documents in the collection
 // using collection metadata.
 //
@@ -932,15 +1001,9 @@ func (coll *Collection) EstimatedDocumentCount(ctx context.Context,
 	}
 
 	selector := makeReadPrefSelector(sess, coll.readSelector, coll.client.localThreshold)
-	op := operation.NewCount().Session(sess).ClusterClock(coll.client.clock).
-		Database(coll.db.name).Collection(coll.name).CommandMonitor(coll.client.monitor).
-		Deployment(coll.client.deployment).ReadConcern(rc).ReadPreference(coll.readPreference).
-		ServerSelector(selector).Crypt(coll.client.crypt)
+	op := coll.estimatedDocumentCountOperation(opts...).
+		Session(sess).ReadConcern(rc).ReadPreference(coll.readPreference).ServerSelector(selector)
 
-	co := options.MergeEstimatedDocumentCountOptions(opts...)
-	if co.MaxTime != nil {
-		op = op.MaxTimeMS(int64(*co.MaxTime / time.Millisecond))
-	}
 	retry := driver.RetryNone
 	if coll.client.retryReads {
 		retry = driver.RetryOncePerCommand
@@ -952,6 +1015,21 @@ func (coll *Collection) EstimatedDocumentCount(ctx context.Context,
 	return op.Result().N, replaceErrors(err)
 }
 
+// estimatedDocumentCountOperation creates the Count operation used by EstimatedDocumentCount for the given options.
+// The caller is responsible for setting the session, read concern, read preference, server selector, and retry mode.
+func (coll *Collection) estimatedDocumentCountOperation(opts ...*options.EstimatedDocumentCountOptions) *operation.Count {
+	op := operation.NewCount().ClusterClock(coll.client.clock).
+		Database(coll.db.name).Collection(coll.name).CommandMonitor(coll.client.monitor).
+		Deployment(coll.client.deployment).Crypt(coll.client.crypt).Timeout(coll.timeout).
+		ServerAPI(coll.client.serverAPI)
+
+	co := options.MergeEstimatedDocumentCountOptions(opts...)
+	if co.MaxTime != nil {
+		op = op.MaxTimeMS(int64(*co.MaxTime / time.Millisecond))
+	}
+	return op
+}
+
 // Distinct executes a distinct command to find the unique values for a specified field in the collection.
 //
 // The fieldName parameter specifies the field name for which distinct values should be returned.
@@ -995,20 +1073,9 @@ func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter i
 	}
 
 	selector := makeReadPrefSelector(sess, coll.readSelector, coll.client.localThreshold)
-	option := options.MergeDistinctOptions(opts...)
+	op := coll.distinctOperation(fieldName, f, opts...).
+		Session(sess).ReadConcern(rc).ReadPreference(coll.readPreference).ServerSelector(selector)
 
-	op := operation.NewDistinct(fieldName, bsoncore.Document(f)).
-		Session(sess).ClusterClock(coll.client.clock).
-		Database(coll.db.name).Collection(coll.name).CommandMonitor(coll.client.monitor).
-		Deployment(coll.client.deployment).ReadConcern(rc).ReadPreference(coll.readPreference).
-		ServerSelector(selector).Crypt(coll.client.crypt)
-
-	if option.Collation != nil {
-		op.Collation(bsoncore.Document(option.Collation.ToDocument()))
-	}
-	if option.MaxTime != nil {
-		op.MaxTimeMS(int64(*option.MaxTime / time.Millisecond))
-	}
 	retry := driver.RetryNone
 	if coll.client.retryReads {
 		retry = driver.RetryOncePerCommand
@@ -1043,6 +1110,27 @@ func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter i
 	return retArray, replaceErrors(err)
 }
 
+// distinctOperation creates a Distinct operation for the given field name, filter, and options. The caller is
+// responsible for setting the session, read concern, read preference, server selector, and retry mode.
+func (coll *Collection) distinctOperation(fieldName string, f bsoncore.Document,
+	opts ...*options.DistinctOptions) *operation.Distinct {
+
+	option := options.MergeDistinctOptions(opts...)
+
+	op := operation.NewDistinct(fieldName, f).ClusterClock(coll.client.clock).
+		Database(coll.db.name).Collection(coll.name).CommandMonitor(coll.client.monitor).
+		Deployment(coll.client.deployment).Crypt(coll.client.crypt).Timeout(coll.timeout).
+		ServerAPI(coll.client.serverAPI)
+
+	if option.Collation != nil {
+		op.Collation(bsoncore.Document(option.Collation.ToDocument()))
+	}
+	if option.MaxTime != nil {
+		op.MaxTimeMS(int64(*option.MaxTime / time.Millisecond))
+	}
+	return op
+}
+
 // Find executes a find command and returns a Cursor over the matching documents in the collection.
 //
 // The filter parameter must be a document containing query operators and can be used to select which documents are
@@ -1084,16 +1172,49 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 	}
 
 	selector := makeReadPrefSelector(sess, coll.readSelector, coll.client.localThreshold)
+	op, cursorOpts, err := coll.findOperation(f, opts...)
+	if err != nil {
+		closeImplicitSession(sess)
+		return nil, err
+	}
+	op.Session(sess).ReadConcern(rc).ReadPreference(coll.readPreference).ServerSelector(selector)
+
+	retry := driver.RetryNone
+	if coll.client.retryReads {
+		retry = driver.RetryOncePerCommand
+	}
+	op = op.Retry(retry)
+
+	if err = op.Execute(ctx); err != nil {
+		closeImplicitSession(sess)
+		return nil, replaceErrors(err)
+	}
+
+	bc, err := op.Result(cursorOpts)
+	if err != nil {
+		closeImplicitSession(sess)
+		return nil, replaceErrors(err)
+	}
+	return newCursorWithSession(bc, coll.registry, sess)
+}
+
+// findOperation creates a Find operation for the given filter and options. The caller is responsible for setting the
+// session, read concern, read preference, server selector, and retry mode.
+func (coll *Collection) findOperation(f bsoncore.Document,
+	opts ...*options.FindOptions) (*operation.Find, driver.CursorOptions, error) {
+
 	op := operation.NewFind(f).
-		Session(sess).ReadConcern(rc).ReadPreference(coll.readPreference).
-		CommandMonitor(coll.client.monitor).ServerSelector(selector).
-		ClusterClock(coll.client.clock).Database(coll.db.name).Collection(coll.name).
-		Deployment(coll.client.deployment).Crypt(coll.client.crypt)
+		CommandMonitor(coll.client.monitor).ClusterClock(coll.client.clock).
+		Database(coll.db.name).Collection(coll.name).
+		Deployment(coll.client.deployment).Crypt(coll.client.crypt).Timeout(coll.timeout).
+		ServerAPI(coll.client.serverAPI)
 
 	fo := options.MergeFindOptions(opts...)
 	cursorOpts := driver.CursorOptions{
 		CommandMonitor: coll.client.monitor,
 		Crypt:          coll.client.crypt,
+		Timeout:        coll.timeout,
+		ServerAPI:      coll.client.serverAPI,
 	}
 
 	if fo.AllowPartialResults != nil {
@@ -1116,13 +1237,14 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 		case options.TailableAwait:
 			op.Tailable(true)
 			op.AwaitData(true)
+		case options.Exhaust:
+			cursorOpts.Exhaust = true
 		}
 	}
 	if fo.Hint != nil {
 		hint, err := transformValue(coll.registry, fo.Hint)
 		if err != nil {
-			closeImplicitSession(sess)
-			return nil, err
+			return nil, driver.CursorOptions{}, err
 		}
 		op.Hint(hint)
 	}
@@ -1138,8 +1260,7 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 	if fo.Max != nil {
 		max, err := transformBsoncoreDocument(coll.registry, fo.Max)
 		if err != nil {
-			closeImplicitSession(sess)
-			return nil, err
+			return nil, driver.CursorOptions{}, err
 		}
 		op.Max(max)
 	}
@@ -1152,8 +1273,7 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 	if fo.Min != nil {
 		min, err := transformBsoncoreDocument(coll.registry, fo.Min)
 		if err != nil {
-			closeImplicitSession(sess)
-			return nil, err
+			return nil, driver.CursorOptions{}, err
 		}
 		op.Min(min)
 	}
@@ -1166,8 +1286,7 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 	if fo.Projection != nil {
 		proj, err := transformBsoncoreDocument(coll.registry, fo.Projection)
 		if err != nil {
-			closeImplicitSession(sess)
-			return nil, err
+			return nil, driver.CursorOptions{}, err
 		}
 		op.Projection(proj)
 	}
@@ -1186,28 +1305,12 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 	if fo.Sort != nil {
 		sort, err := transformBsoncoreDocument(coll.registry, fo.Sort)
 		if err != nil {
-			closeImplicitSession(sess)
-			return nil, err
+			return nil, driver.CursorOptions{}, err
 		}
 		op.Sort(sort)
 	}
-	retry := driver.RetryNone
-	if coll.client.retryReads {
-		retry = driver.RetryOncePerCommand
-	}
-	op = op.Retry(retry)
 
-	if err = op.Execute(ctx); err != nil {
-		closeImplicitSession(sess)
-		return nil, replaceErrors(err)
-	}
-
-	bc, err := op.Result(cursorOpts)
-	if err != nil {
-		closeImplicitSession(sess)
-		return nil, replaceErrors(err)
-	}
-	return newCursorWithSession(bc, coll.registry, sess)
+	return op, cursorOpts, nil
 }
 
 // FindOne executes a find command and returns a SingleResult for one document in the collection.
@@ -1300,7 +1403,8 @@ func (coll *Collection) findAndModify(ctx context.Context, op *operation.FindAnd
 		Collection(coll.name).
 		Deployment(coll.client.deployment).
 		Retry(retry).
-		Crypt(coll.client.crypt)
+		Crypt(coll.client.crypt).
+		Timeout(coll.timeout).ServerAPI(coll.client.serverAPI)
 
 	_, err = processWriteError(op.Execute(ctx))
 	if err != nil {
@@ -1515,6 +1619,7 @@ func (coll *Collection) Watch(ctx context.Context, pipeline interface{},
 		readPreference: coll.readPreference,
 		client:         coll.client,
 		registry:       coll.registry,
+		timeout:        coll.timeout,
 		streamType:     CollectionStream,
 		collectionName: coll.Name(),
 		databaseName:   coll.db.Name(),
@@ -1563,7 +1668,8 @@ func (coll *Collection) Drop(ctx context.Context) error {
 		Session(sess).WriteConcern(wc).CommandMonitor(coll.client.monitor).
 		ServerSelector(selector).ClusterClock(coll.client.clock).
 		Database(coll.db.name).Collection(coll.name).
-		Deployment(coll.client.deployment).Crypt(coll.client.crypt)
+		Deployment(coll.client.deployment).Crypt(coll.client.crypt).Timeout(coll.timeout).
+		ServerAPI(coll.client.serverAPI)
 	err = op.Execute(ctx)
 
 	// ignore namespace not found erorrs
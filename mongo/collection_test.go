@@ -9,6 +9,7 @@ package mongo
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
@@ -34,6 +35,8 @@ func compareColls(t *testing.T, expected *Collection, got *Collection) {
 		"mismatch; expected read concern %v, got %v", expected.readConcern, got.readConcern)
 	assert.Equal(t, expected.writeConcern, got.writeConcern,
 		"mismatch; expected write concern %v, got %v", expected.writeConcern, got.writeConcern)
+	assert.Equal(t, expected.timeout, got.timeout,
+		"mismatch; expected timeout %v, got %v", expected.timeout, got.timeout)
 }
 
 func TestCollection(t *testing.T) {
@@ -65,13 +68,15 @@ func TestCollection(t *testing.T) {
 		rpPrimary := readpref.Primary()
 		rcLocal := readconcern.Local()
 		wc1 := writeconcern.New(writeconcern.W(10))
+		timeout := 5 * time.Second
 
-		db := setupDb("foo", options.Database().SetReadPreference(rpPrimary).SetReadConcern(rcLocal))
+		db := setupDb("foo", options.Database().SetReadPreference(rpPrimary).SetReadConcern(rcLocal).SetTimeout(timeout))
 		coll := db.Collection("bar", options.Collection().SetWriteConcern(wc1))
 		expected := &Collection{
 			readPreference: rpPrimary,
 			readConcern:    rcLocal,
 			writeConcern:   wc1,
+			timeout:        &timeout,
 		}
 		compareColls(t, expected, coll)
 	})
@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
@@ -18,6 +19,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 	"go.mongodb.org/mongo-driver/x/bsonx"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/description"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
@@ -38,6 +40,7 @@ type Database struct {
 	readSelector   description.ServerSelector
 	writeSelector  description.ServerSelector
 	registry       *bsoncodec.Registry
+	timeout        *time.Duration
 }
 
 func newDatabase(client *Client, name string, opts ...*options.DatabaseOptions) *Database {
@@ -63,6 +66,11 @@ func newDatabase(client *Client, name string, opts ...*options.DatabaseOptions)
 		reg = dbOpt.Registry
 	}
 
+	timeout := client.timeout
+	if dbOpt.Timeout != nil {
+		timeout = dbOpt.Timeout
+	}
+
 	db := &Database{
 		client:         client,
 		name:           name,
@@ -70,6 +78,7 @@ func newDatabase(client *Client, name string, opts ...*options.DatabaseOptions)
 		readConcern:    rc,
 		writeConcern:   wc,
 		registry:       reg,
+		timeout:        timeout,
 	}
 
 	db.readSelector = description.CompositeSelector([]description.ServerSelector{
@@ -126,6 +135,7 @@ func (db *Database) Aggregate(ctx context.Context, pipeline interface{},
 		readSelector:   db.readSelector,
 		writeSelector:  db.writeSelector,
 		readPreference: db.readPreference,
+		timeout:        db.timeout,
 		opts:           opts,
 	}
 	return aggregate(a)
@@ -156,6 +166,16 @@ func (db *Database) processRunCommand(ctx context.Context, cmd interface{},
 	if err != nil {
 		return nil, sess, err
 	}
+	if db.client.serverAPI != nil {
+		// The declared server API version is appended to the command, so the server would reject a document that
+		// already has these fields.
+		for _, key := range []string{"apiVersion", "apiStrict", "apiDeprecationErrors"} {
+			if _, err := runCmdDoc.LookupErr(key); err == nil {
+				return nil, sess, fmt.Errorf("the %s field cannot be specified in the command document because the "+
+					"Client declares a server API version", key)
+			}
+		}
+	}
 	readSelect := description.CompositeSelector([]description.ServerSelector{
 		description.ReadPrefSelector(ro.ReadPreference),
 		description.LatencySelector(db.client.localThreshold),
@@ -167,7 +187,8 @@ func (db *Database) processRunCommand(ctx context.Context, cmd interface{},
 	return operation.NewCommand(runCmdDoc).
 		Session(sess).CommandMonitor(db.client.monitor).
 		ServerSelector(readSelect).ClusterClock(db.client.clock).
-		Database(db.name).Deployment(db.client.deployment).ReadConcern(db.readConcern).Crypt(db.client.crypt), sess, nil
+		Database(db.name).Deployment(db.client.deployment).ReadConcern(db.readConcern).Crypt(db.client.crypt).
+		Timeout(db.timeout).ServerAPI(db.client.serverAPI), sess, nil
 }
 
 // RunCommand executes the given command against the database.
@@ -175,6 +196,8 @@ func (db *Database) processRunCommand(ctx context.Context, cmd interface{},
 // The runCommand parameter must be a document for the command to be executed. It cannot be nil.
 // This must be an order-preserving type such as bson.D. Map types such as bson.M are not valid.
 // If the command document contains a session ID or any transaction-specific fields, the behavior is undefined.
+// If the Client declares a server API version, the command document cannot contain the apiVersion, apiStrict, or
+// apiDeprecationErrors fields.
 //
 // The opts parameter can be used to specify options for this operation (see the options.RunCmdOptions documentation).
 func (db *Database) RunCommand(ctx context.Context, runCommand interface{}, opts ...*options.RunCmdOptions) *SingleResult {
@@ -203,6 +226,8 @@ func (db *Database) RunCommand(ctx context.Context, runCommand interface{}, opts
 // The runCommand parameter must be a document for the command to be executed. It cannot be nil.
 // This must be an order-preserving type such as bson.D. Map types such as bson.M are not valid.
 // If the command document contains a session ID or any transaction-specific fields, the behavior is undefined.
+// If the Client declares a server API version, the command document cannot contain the apiVersion, apiStrict, or
+// apiDeprecationErrors fields.
 //
 // The opts parameter can be used to specify options for this operation (see the options.RunCmdOptions documentation).
 func (db *Database) RunCommandCursor(ctx context.Context, runCommand interface{}, opts ...*options.RunCmdOptions) (*Cursor, error) {
@@ -221,7 +246,7 @@ func (db *Database) RunCommandCursor(ctx context.Context, runCommand interface{}
 		return nil, replaceErrors(err)
 	}
 
-	bc, err := op.ResultCursor(driver.CursorOptions{})
+	bc, err := op.ResultCursor(driver.CursorOptions{Timeout: db.timeout, ServerAPI: db.client.serverAPI})
 	if err != nil {
 		closeImplicitSession(sess)
 		return nil, replaceErrors(err)
@@ -265,7 +290,8 @@ func (db *Database) Drop(ctx context.Context) error {
 	op := operation.NewDropDatabase().
 		Session(sess).WriteConcern(wc).CommandMonitor(db.client.monitor).
 		ServerSelector(selector).ClusterClock(db.client.clock).
-		Database(db.name).Deployment(db.client.deployment).Crypt(db.client.crypt)
+		Database(db.name).Deployment(db.client.deployment).Crypt(db.client.crypt).
+		Timeout(db.timeout).ServerAPI(db.client.serverAPI)
 
 	err = op.Execute(ctx)
 
@@ -276,6 +302,137 @@ func (db *Database) Drop(ctx context.Context) error {
 	return nil
 }
 
+// CreateCollection executes a create command to explicitly create a new collection with the specified name on the
+// server. If the collection being created already exists, this method will return a mongo.CommandError.
+//
+// The opts parameter can be used to specify options for the operation (see the options.CreateCollectionOptions
+// documentation).
+//
+// For more information about the command, see https://docs.mongodb.com/manual/reference/command/create/.
+func (db *Database) CreateCollection(ctx context.Context, name string, opts ...*options.CreateCollectionOptions) error {
+	cco := options.MergeCreateCollectionOptions(opts...)
+	op := operation.NewCreate().Collection(name)
+
+	if cco.Capped != nil {
+		op.Capped(*cco.Capped)
+	}
+	if cco.Collation != nil {
+		op.Collation(bsoncore.Document(cco.Collation.ToDocument()))
+	}
+	if cco.IndexOptionDefaults != nil {
+		idx, err := transformBsoncoreDocument(db.registry, cco.IndexOptionDefaults)
+		if err != nil {
+			return err
+		}
+		op.IndexOptionDefaults(idx)
+	}
+	if cco.MaxDocuments != nil {
+		op.Max(*cco.MaxDocuments)
+	}
+	if cco.SizeInBytes != nil {
+		op.Size(*cco.SizeInBytes)
+	}
+	if cco.StorageEngine != nil {
+		storageEngine, err := transformBsoncoreDocument(db.registry, cco.StorageEngine)
+		if err != nil {
+			return err
+		}
+		op.StorageEngine(storageEngine)
+	}
+	if cco.ValidationAction != nil {
+		op.ValidationAction(*cco.ValidationAction)
+	}
+	if cco.ValidationLevel != nil {
+		op.ValidationLevel(*cco.ValidationLevel)
+	}
+	if cco.Validator != nil {
+		validator, err := transformBsoncoreDocument(db.registry, cco.Validator)
+		if err != nil {
+			return err
+		}
+		op.Validator(validator)
+	}
+
+	return db.executeCreateOperation(ctx, op)
+}
+
+// CreateView executes a create command to explicitly create a view on the server. See
+// https://docs.mongodb.com/manual/core/views/ for more information about views. This method requires MongoDB version
+// >= 3.4.
+//
+// The viewName parameter specifies the name of the view to create.
+//
+// The viewOn parameter specifies the name of the collection or view on which this view will be created.
+//
+// The pipeline parameter specifies an aggregation pipeline that will be executed against the source collection or
+// view to create this view.
+//
+// The opts parameter can be used to specify options for the operation (see the options.CreateViewOptions
+// documentation).
+//
+// For more information about the command, see https://docs.mongodb.com/manual/reference/command/create/.
+func (db *Database) CreateView(ctx context.Context, viewName, viewOn string, pipeline interface{},
+	opts ...*options.CreateViewOptions) error {
+
+	pipelineArray, _, err := transformAggregatePipelinev2(db.registry, pipeline)
+	if err != nil {
+		return err
+	}
+
+	op := operation.NewCreate().
+		Collection(viewName).
+		ViewOn(viewOn).
+		Pipeline(pipelineArray)
+	cvo := options.MergeCreateViewOptions(opts...)
+	if cvo.Collation != nil {
+		op.Collation(bsoncore.Document(cvo.Collation.ToDocument()))
+	}
+
+	return db.executeCreateOperation(ctx, op)
+}
+
+func (db *Database) executeCreateOperation(ctx context.Context, op *operation.Create) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	sess := sessionFromContext(ctx)
+	if sess == nil && db.client.sessionPool != nil {
+		var err error
+		sess, err = session.NewClientSession(db.client.sessionPool, db.client.id, session.Implicit)
+		if err != nil {
+			return err
+		}
+		defer sess.EndSession()
+	}
+
+	err := db.client.validSession(sess)
+	if err != nil {
+		return err
+	}
+
+	wc := db.writeConcern
+	if sess.TransactionRunning() {
+		wc = nil
+	}
+	if !writeconcern.AckWrite(wc) {
+		sess = nil
+	}
+
+	selector := makePinnedSelector(sess, db.writeSelector)
+	op = op.Session(sess).
+		WriteConcern(wc).
+		CommandMonitor(db.client.monitor).
+		ServerSelector(selector).
+		ClusterClock(db.client.clock).
+		Database(db.name).
+		Deployment(db.client.deployment).
+		Crypt(db.client.crypt).
+		Timeout(db.timeout).ServerAPI(db.client.serverAPI)
+
+	return replaceErrors(op.Execute(ctx))
+}
+
 // ListCollections executes a listCollections command and returns a cursor over the collections in the database.
 //
 // The filter parameter must be a document containing query operators and can be used to select which collections
@@ -320,7 +477,8 @@ func (db *Database) ListCollections(ctx context.Context, filter interface{}, opt
 	op := operation.NewListCollections(filterDoc).
 		Session(sess).ReadPreference(db.readPreference).CommandMonitor(db.client.monitor).
 		ServerSelector(selector).ClusterClock(db.client.clock).
-		Database(db.name).Deployment(db.client.deployment).Crypt(db.client.crypt)
+		Database(db.name).Deployment(db.client.deployment).Crypt(db.client.crypt).
+		Timeout(db.timeout).ServerAPI(db.client.serverAPI)
 	if lco.NameOnly != nil {
 		op = op.NameOnly(*lco.NameOnly)
 	}
@@ -336,7 +494,11 @@ func (db *Database) ListCollections(ctx context.Context, filter interface{}, opt
 		return nil, replaceErrors(err)
 	}
 
-	bc, err := op.Result(driver.CursorOptions{Crypt: db.client.crypt})
+	bc, err := op.Result(driver.CursorOptions{
+		Crypt:     db.client.crypt,
+		Timeout:   db.timeout,
+		ServerAPI: db.client.serverAPI,
+	})
 	if err != nil {
 		closeImplicitSession(sess)
 		return nil, replaceErrors(err)
@@ -427,6 +589,7 @@ func (db *Database) Watch(ctx context.Context, pipeline interface{},
 		readPreference: db.readPreference,
 		client:         db.client,
 		registry:       db.registry,
+		timeout:        db.timeout,
 		streamType:     DatabaseStream,
 		databaseName:   db.Name(),
 	}
@@ -9,7 +9,9 @@ package mongo
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
@@ -35,6 +37,7 @@ func compareDbs(t *testing.T, expected, got *Database) {
 		"expected write concern %v, got %v", expected.writeConcern, got.writeConcern)
 	assert.Equal(t, expected.registry, got.registry,
 		"expected write concern %v, got %v", expected.registry, got.registry)
+	assert.Equal(t, expected.timeout, got.timeout, "expected timeout %v, got %v", expected.timeout, got.timeout)
 }
 
 func TestDatabase(t *testing.T) {
@@ -53,14 +56,17 @@ func TestDatabase(t *testing.T) {
 			rcLocal := readconcern.Local()
 			rcMajority := readconcern.Majority()
 			reg := bsoncodec.NewRegistryBuilder().Build()
+			timeout := 5 * time.Second
 
 			opts := options.Database().SetReadPreference(rpPrimary).SetReadConcern(rcLocal).SetWriteConcern(wc1).
-				SetReadPreference(rpSecondary).SetReadConcern(rcMajority).SetWriteConcern(wc2).SetRegistry(reg)
+				SetReadPreference(rpSecondary).SetReadConcern(rcMajority).SetWriteConcern(wc2).SetRegistry(reg).
+				SetTimeout(timeout)
 			expected := &Database{
 				readPreference: rpSecondary,
 				readConcern:    rcMajority,
 				writeConcern:   wc2,
 				registry:       reg,
+				timeout:        &timeout,
 			}
 			got := setupDb("foo", opts)
 			compareDbs(t, expected, got)
@@ -70,14 +76,17 @@ func TestDatabase(t *testing.T) {
 			rcLocal := readconcern.Local()
 			wc1 := writeconcern.New(writeconcern.W(10))
 			reg := bsoncodec.NewRegistryBuilder().Build()
+			timeout := 5 * time.Second
 
-			client := setupClient(options.Client().SetReadPreference(rpPrimary).SetReadConcern(rcLocal).SetRegistry(reg))
+			client := setupClient(options.Client().SetReadPreference(rpPrimary).SetReadConcern(rcLocal).SetRegistry(reg).
+				SetTimeout(timeout))
 			got := client.Database("foo", options.Database().SetWriteConcern(wc1))
 			expected := &Database{
 				readPreference: rpPrimary,
 				readConcern:    rcLocal,
 				writeConcern:   wc1,
 				registry:       reg,
+				timeout:        &timeout,
 			}
 			compareDbs(t, expected, got)
 		})
@@ -93,6 +102,28 @@ func TestDatabase(t *testing.T) {
 
 		_, err = db.ListCollections(bgCtx, bson.D{})
 		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+
+		err = db.CreateCollection(bgCtx, "foo")
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+
+		err = db.CreateView(bgCtx, "foo", "bar", bson.A{})
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+	})
+	t.Run("server API fields in RunCommand", func(t *testing.T) {
+		serverAPI := options.ServerAPI(options.ServerAPIVersion1)
+		db := setupClient(options.Client().ApplyURI("mongodb://localhost:27017").SetServerAPIOptions(serverAPI)).
+			Database("foo")
+
+		for _, key := range []string{"apiVersion", "apiStrict", "apiDeprecationErrors"} {
+			want := fmt.Errorf("the %s field cannot be specified in the command document because the Client "+
+				"declares a server API version", key)
+
+			err := db.RunCommand(bgCtx, bson.D{{"ping", 1}, {key, "1"}}).Err()
+			assert.Equal(t, want, err, "expected error %v, got %v", want, err)
+
+			_, err = db.RunCommandCursor(bgCtx, bson.D{{"find", "bar"}, {key, "1"}})
+			assert.Equal(t, want, err, "expected error %v, got %v", want, err)
+		}
 	})
 	t.Run("nil document error", func(t *testing.T) {
 		db := setupDb("foo")
@@ -109,5 +140,8 @@ func TestDatabase(t *testing.T) {
 
 		_, err = db.ListCollectionNames(context.Background(), nil)
 		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
+
+		err = db.CreateView(context.Background(), "foo", "bar", nil)
+		assert.Equal(t, watchErr, err, "expected error %v, got %v", watchErr, err)
 	})
 }
@@ -0,0 +1,528 @@
+// Copyright (C) MongoDB, Inc. 2020-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
+)
+
+// ErrNilExplainModel is returned when a nil ExplainModel is passed to Collection.Explain.
+var ErrNilExplainModel = errors.New("explain model is nil")
+
+// ExplainVerbosity specifies the amount of information returned by an explain command. For more information, see
+// https://docs.mongodb.com/manual/reference/command/explain/.
+type ExplainVerbosity string
+
+// These constants are the verbosity modes supported by the explain command.
+const (
+	// QueryPlannerVerbosity returns the plan selected by the query optimizer without executing it.
+	QueryPlannerVerbosity ExplainVerbosity = "queryPlanner"
+	// ExecutionStatsVerbosity executes the winning plan and returns its execution statistics.
+	ExecutionStatsVerbosity ExplainVerbosity = "executionStats"
+	// AllPlansExecutionVerbosity executes the winning plan and also returns statistics for the rejected plans that were
+	// considered during plan selection.
+	AllPlansExecutionVerbosity ExplainVerbosity = "allPlansExecution"
+)
+
+// ExplainModel is an interface implemented by models that can be used in an Explain operation. Each ExplainModel
+// represents the operation to explain.
+//
+// This interface is implemented by FindExplainModel, AggregateExplainModel, CountDocumentsExplainModel,
+// EstimatedDocumentCountExplainModel, DistinctExplainModel, UpdateExplainModel, and DeleteExplainModel. Custom
+// implementations of this interface must not be used.
+type ExplainModel interface {
+	// explainOperation creates the operation to explain and reports whether it is a write.
+	explainOperation(coll *Collection) (operation.Explainable, bool, error)
+}
+
+// FindExplainModel is used to explain a find command.
+type FindExplainModel struct {
+	Filter  interface{}
+	Options []*options.FindOptions
+}
+
+// NewFindExplainModel creates a new FindExplainModel.
+func NewFindExplainModel() *FindExplainModel {
+	return &FindExplainModel{}
+}
+
+// SetFilter specifies the filter of the find command. The filter must be a document containing query operators. It
+// cannot be nil.
+func (fem *FindExplainModel) SetFilter(filter interface{}) *FindExplainModel {
+	fem.Filter = filter
+	return fem
+}
+
+// SetOptions specifies the options of the find command (see the options.FindOptions documentation).
+func (fem *FindExplainModel) SetOptions(opts ...*options.FindOptions) *FindExplainModel {
+	fem.Options = opts
+	return fem
+}
+
+func (fem *FindExplainModel) explainOperation(coll *Collection) (operation.Explainable, bool, error) {
+	f, err := transformBsoncoreDocument(coll.registry, fem.Filter)
+	if err != nil {
+		return nil, false, err
+	}
+	op, _, err := coll.findOperation(f, fem.Options...)
+	return op, false, err
+}
+
+// AggregateExplainModel is used to explain an aggregate command.
+type AggregateExplainModel struct {
+	Pipeline interface{}
+	Options  []*options.AggregateOptions
+}
+
+// NewAggregateExplainModel creates a new AggregateExplainModel.
+func NewAggregateExplainModel() *AggregateExplainModel {
+	return &AggregateExplainModel{}
+}
+
+// SetPipeline specifies the pipeline of the aggregate command. It must be an array of documents and cannot be nil.
+func (aem *AggregateExplainModel) SetPipeline(pipeline interface{}) *AggregateExplainModel {
+	aem.Pipeline = pipeline
+	return aem
+}
+
+// SetOptions specifies the options of the aggregate command (see the options.AggregateOptions documentation).
+func (aem *AggregateExplainModel) SetOptions(opts ...*options.AggregateOptions) *AggregateExplainModel {
+	aem.Options = opts
+	return aem
+}
+
+func (aem *AggregateExplainModel) explainOperation(coll *Collection) (operation.Explainable, bool, error) {
+	pipelineArr, hasOutputStage, err := transformAggregatePipelinev2(coll.registry, aem.Pipeline)
+	if err != nil {
+		return nil, false, err
+	}
+	a := aggregateParams{
+		client:   coll.client,
+		registry: coll.registry,
+		db:       coll.db.name,
+		col:      coll.name,
+		opts:     aem.Options,
+	}
+	op, _, err := aggregateOperation(a, pipelineArr, hasOutputStage)
+	return op, hasOutputStage, err
+}
+
+// CountDocumentsExplainModel is used to explain the aggregate command sent by Collection.CountDocuments.
+type CountDocumentsExplainModel struct {
+	Filter  interface{}
+	Options []*options.CountOptions
+}
+
+// NewCountDocumentsExplainModel creates a new CountDocumentsExplainModel.
+func NewCountDocumentsExplainModel() *CountDocumentsExplainModel {
+	return &CountDocumentsExplainModel{}
+}
+
+// SetFilter specifies the filter used to select which documents contribute to the count. It cannot be nil.
+func (cem *CountDocumentsExplainModel) SetFilter(filter interface{}) *CountDocumentsExplainModel {
+	cem.Filter = filter
+	return cem
+}
+
+// SetOptions specifies the options of the count (see the options.CountOptions documentation).
+func (cem *CountDocumentsExplainModel) SetOptions(opts ...*options.CountOptions) *CountDocumentsExplainModel {
+	cem.Options = opts
+	return cem
+}
+
+func (cem *CountDocumentsExplainModel) explainOperation(coll *Collection) (operation.Explainable, bool, error) {
+	op, err := coll.countDocumentsOperation(cem.Filter, cem.Options...)
+	return op, false, err
+}
+
+// EstimatedDocumentCountExplainModel is used to explain the count command sent by Collection.EstimatedDocumentCount.
+type EstimatedDocumentCountExplainModel struct {
+	Options []*options.EstimatedDocumentCountOptions
+}
+
+// NewEstimatedDocumentCountExplainModel creates a new EstimatedDocumentCountExplainModel.
+func NewEstimatedDocumentCountExplainModel() *EstimatedDocumentCountExplainModel {
+	return &EstimatedDocumentCountExplainModel{}
+}
+
+// SetOptions specifies the options of the count command (see the options.EstimatedDocumentCountOptions
+// documentation).
+func (eem *EstimatedDocumentCountExplainModel) SetOptions(
+	opts ...*options.EstimatedDocumentCountOptions) *EstimatedDocumentCountExplainModel {
+
+	eem.Options = opts
+	return eem
+}
+
+func (eem *EstimatedDocumentCountExplainModel) explainOperation(coll *Collection) (operation.Explainable, bool, error) {
+	return coll.estimatedDocumentCountOperation(eem.Options...), false, nil
+}
+
+// DistinctExplainModel is used to explain a distinct command.
+type DistinctExplainModel struct {
+	FieldName string
+	Filter    interface{}
+	Options   []*options.DistinctOptions
+}
+
+// NewDistinctExplainModel creates a new DistinctExplainModel.
+func NewDistinctExplainModel() *DistinctExplainModel {
+	return &DistinctExplainModel{}
+}
+
+// SetFieldName specifies the field for which distinct values would be returned.
+func (dem *DistinctExplainModel) SetFieldName(fieldName string) *DistinctExplainModel {
+	dem.FieldName = fieldName
+	return dem
+}
+
+// SetFilter specifies the filter used to select which documents are considered. It cannot be nil.
+func (dem *DistinctExplainModel) SetFilter(filter interface{}) *DistinctExplainModel {
+	dem.Filter = filter
+	return dem
+}
+
+// SetOptions specifies the options of the distinct command (see the options.DistinctOptions documentation).
+func (dem *DistinctExplainModel) SetOptions(opts ...*options.DistinctOptions) *DistinctExplainModel {
+	dem.Options = opts
+	return dem
+}
+
+func (dem *DistinctExplainModel) explainOperation(coll *Collection) (operation.Explainable, bool, error) {
+	f, err := transformBsoncoreDocument(coll.registry, dem.Filter)
+	if err != nil {
+		return nil, false, err
+	}
+	return coll.distinctOperation(dem.FieldName, f, dem.Options...), false, nil
+}
+
+// UpdateExplainModel is used to explain an update command. The update is not applied.
+type UpdateExplainModel struct {
+	Filter  interface{}
+	Update  interface{}
+	Multi   bool
+	Options []*options.UpdateOptions
+}
+
+// NewUpdateExplainModel creates a new UpdateExplainModel.
+func NewUpdateExplainModel() *UpdateExplainModel {
+	return &UpdateExplainModel{}
+}
+
+// SetFilter specifies the filter used to select the documents to update. It cannot be nil.
+func (uem *UpdateExplainModel) SetFilter(filter interface{}) *UpdateExplainModel {
+	uem.Filter = filter
+	return uem
+}
+
+// SetUpdate specifies the modifications to be made. It must be a document containing update operators and cannot be
+// nil or empty.
+func (uem *UpdateExplainModel) SetUpdate(update interface{}) *UpdateExplainModel {
+	uem.Update = update
+	return uem
+}
+
+// SetMulti specifies whether the update would apply to all matching documents, as in UpdateMany, rather than at most
+// one, as in UpdateOne. The default value is false.
+func (uem *UpdateExplainModel) SetMulti(multi bool) *UpdateExplainModel {
+	uem.Multi = multi
+	return uem
+}
+
+// SetOptions specifies the options of the update command (see the options.UpdateOptions documentation).
+func (uem *UpdateExplainModel) SetOptions(opts ...*options.UpdateOptions) *UpdateExplainModel {
+	uem.Options = opts
+	return uem
+}
+
+func (uem *UpdateExplainModel) explainOperation(coll *Collection) (operation.Explainable, bool, error) {
+	f, err := transformBsoncoreDocument(coll.registry, uem.Filter)
+	if err != nil {
+		return nil, true, err
+	}
+	op, err := coll.updateOperation(f, uem.Update, uem.Multi, true, uem.Options...)
+	return op, true, err
+}
+
+// DeleteExplainModel is used to explain a delete command. No documents are deleted.
+type DeleteExplainModel struct {
+	Filter  interface{}
+	Multi   bool
+	Options []*options.DeleteOptions
+}
+
+// NewDeleteExplainModel creates a new DeleteExplainModel.
+func NewDeleteExplainModel() *DeleteExplainModel {
+	return &DeleteExplainModel{}
+}
+
+// SetFilter specifies the filter used to select the documents to delete. It cannot be nil.
+func (dem *DeleteExplainModel) SetFilter(filter interface{}) *DeleteExplainModel {
+	dem.Filter = filter
+	return dem
+}
+
+// SetMulti specifies whether the delete would apply to all matching documents, as in DeleteMany, rather than at most
+// one, as in DeleteOne. The default value is false.
+func (dem *DeleteExplainModel) SetMulti(multi bool) *DeleteExplainModel {
+	dem.Multi = multi
+	return dem
+}
+
+// SetOptions specifies the options of the delete command (see the options.DeleteOptions documentation).
+func (dem *DeleteExplainModel) SetOptions(opts ...*options.DeleteOptions) *DeleteExplainModel {
+	dem.Options = opts
+	return dem
+}
+
+func (dem *DeleteExplainModel) explainOperation(coll *Collection) (operation.Explainable, bool, error) {
+	f, err := transformBsoncoreDocument(coll.registry, dem.Filter)
+	if err != nil {
+		return nil, true, err
+	}
+	return coll.deleteOperation(f, !dem.Multi, dem.Options...), true, nil
+}
+
+// Explain executes an explain command for the operation described by model and returns information about how the
+// server would execute it. The operation itself is not performed, so explaining an update or delete does not modify
+// any documents.
+//
+// The verbosity parameter specifies how much information is returned (see the ExplainVerbosity documentation).
+//
+// For more information about the command, see https://docs.mongodb.com/manual/reference/command/explain/.
+func (coll *Collection) Explain(ctx context.Context, verbosity ExplainVerbosity,
+	model ExplainModel) (*ExplainResult, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if model == nil {
+		return nil, ErrNilExplainModel
+	}
+
+	op, write, err := model.explainOperation(coll)
+	if err != nil {
+		return nil, err
+	}
+
+	sess := sessionFromContext(ctx)
+	if sess == nil && coll.client.sessionPool != nil {
+		sess, err = session.NewClientSession(coll.client.sessionPool, coll.client.id, session.Implicit)
+		if err != nil {
+			return nil, err
+		}
+		defer sess.EndSession()
+	}
+
+	err = coll.client.validSession(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	eop := operation.NewExplain(string(verbosity), op).
+		Session(sess).ClusterClock(coll.client.clock).CommandMonitor(coll.client.monitor).
+		Database(coll.db.name).Deployment(coll.client.deployment).Crypt(coll.client.crypt).
+		Timeout(coll.timeout).ServerAPI(coll.client.serverAPI)
+	if write {
+		eop.ServerSelector(makePinnedSelector(sess, coll.writeSelector))
+	} else {
+		eop.ReadPreference(coll.readPreference).
+			ServerSelector(makeReadPrefSelector(sess, coll.readSelector, coll.client.localThreshold))
+	}
+
+	if err = eop.Execute(ctx); err != nil {
+		return nil, replaceErrors(err)
+	}
+	return newExplainResult(bson.Raw(eop.Result()))
+}
+
+// ExplainResult is the result of an Explain operation. Fields that are not returned by the server for the requested
+// verbosity, operation, or deployment are left empty. The complete server response is available in Raw.
+type ExplainResult struct {
+	// QueryPlanner describes the plan selected by the query optimizer. For sharded clusters, the per-shard plans are
+	// available in Shards.
+	QueryPlanner *ExplainQueryPlanner
+	// ExecutionStats describes the execution of the winning plan. It is only set for the executionStats and
+	// allPlansExecution verbosities.
+	ExecutionStats *ExplainExecutionStats
+	// Shards contains a section for each shard that was targeted when explaining against a sharded cluster.
+	Shards []ExplainShard
+	// Stages contains the pipeline stages reported when explaining an aggregate command.
+	Stages []bson.Raw
+	// Raw is the complete explain response.
+	Raw bson.Raw
+}
+
+// ExplainQueryPlanner is the queryPlanner section of an explain response.
+type ExplainQueryPlanner struct {
+	Namespace      string     `bson:"namespace"`
+	IndexFilterSet bool       `bson:"indexFilterSet"`
+	ParsedQuery    bson.Raw   `bson:"parsedQuery"`
+	WinningPlan    bson.Raw   `bson:"winningPlan"`
+	RejectedPlans  []bson.Raw `bson:"rejectedPlans"`
+}
+
+// ExplainExecutionStats is the executionStats section of an explain response.
+type ExplainExecutionStats struct {
+	ExecutionSuccess    bool       `bson:"executionSuccess"`
+	NReturned           int64      `bson:"nReturned"`
+	ExecutionTimeMillis int64      `bson:"executionTimeMillis"`
+	TotalKeysExamined   int64      `bson:"totalKeysExamined"`
+	TotalDocsExamined   int64      `bson:"totalDocsExamined"`
+	ExecutionStages     bson.Raw   `bson:"executionStages"`
+	AllPlansExecution   []bson.Raw `bson:"allPlansExecution"`
+}
+
+// ExplainShard is the section of an explain response for a single shard.
+type ExplainShard struct {
+	ShardName        string
+	ConnectionString string
+	QueryPlanner     *ExplainQueryPlanner
+	ExecutionStats   *ExplainExecutionStats
+}
+
+func newExplainResult(raw bson.Raw) (*ExplainResult, error) {
+	res := &ExplainResult{Raw: raw}
+	if err := res.parseSection(raw, true); err != nil {
+		return nil, err
+	}
+
+	// Aggregate commands run against a sharded cluster report each shard in a document keyed by shard name.
+	shards, ok := raw.Lookup("shards").DocumentOK()
+	if !ok {
+		return res, nil
+	}
+	elems, err := shards.Elements()
+	if err != nil {
+		return nil, err
+	}
+	for _, elem := range elems {
+		shardDoc, ok := elem.Value().DocumentOK()
+		if !ok {
+			continue
+		}
+		shardRes := &ExplainResult{}
+		if err = shardRes.parseSection(shardDoc, false); err != nil {
+			return nil, err
+		}
+		res.Shards = append(res.Shards, ExplainShard{
+			ShardName:        elem.Key(),
+			ConnectionString: lookupExplainString(shardDoc, "host"),
+			QueryPlanner:     shardRes.QueryPlanner,
+			ExecutionStats:   shardRes.ExecutionStats,
+		})
+	}
+	return res, nil
+}
+
+// parseSection fills in the query planner and execution stats found in section. If topLevel is true, the stages of an
+// aggregate pipeline are recorded and the per-shard sections of a find-style sharded explain are collected.
+func (res *ExplainResult) parseSection(section bson.Raw, topLevel bool) error {
+	// Aggregate pipelines report the query planner in the $cursor stage at the front of the pipeline.
+	if stages, ok := section.Lookup("stages").ArrayOK(); ok {
+		values, err := stages.Values()
+		if err != nil {
+			return err
+		}
+		var cursorFound bool
+		for _, val := range values {
+			stage, ok := val.DocumentOK()
+			if !ok {
+				continue
+			}
+			if topLevel {
+				res.Stages = append(res.Stages, stage)
+			}
+			if cursor, ok := stage.Lookup("$cursor").DocumentOK(); ok && !cursorFound {
+				section = cursor
+				cursorFound = true
+			}
+		}
+	}
+
+	if qp, ok := section.Lookup("queryPlanner").DocumentOK(); ok {
+		res.QueryPlanner = new(ExplainQueryPlanner)
+		if err := bson.Unmarshal(qp, res.QueryPlanner); err != nil {
+			return err
+		}
+		if topLevel {
+			if err := res.parseShards(res.QueryPlanner.WinningPlan, func(shard *ExplainShard, doc bson.Raw) error {
+				shard.ConnectionString = lookupExplainString(doc, "connectionString")
+				shard.QueryPlanner = new(ExplainQueryPlanner)
+				return bson.Unmarshal(doc, shard.QueryPlanner)
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if es, ok := section.Lookup("executionStats").DocumentOK(); ok {
+		res.ExecutionStats = new(ExplainExecutionStats)
+		if err := bson.Unmarshal(es, res.ExecutionStats); err != nil {
+			return err
+		}
+		if topLevel {
+			if err := res.parseShards(res.ExecutionStats.ExecutionStages, func(shard *ExplainShard, doc bson.Raw) error {
+				shard.ExecutionStats = new(ExplainExecutionStats)
+				return bson.Unmarshal(doc, shard.ExecutionStats)
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parseShards calls fn with the shard section for each element of the shards array in stage, creating the matching
+// entry in res.Shards if it does not already exist.
+func (res *ExplainResult) parseShards(stage bson.Raw, fn func(*ExplainShard, bson.Raw) error) error {
+	if stage == nil {
+		return nil
+	}
+	shardsVal, err := stage.LookupErr("shards")
+	if err != nil || shardsVal.Type != bsontype.Array {
+		return nil
+	}
+	values, err := shardsVal.Array().Values()
+	if err != nil {
+		return err
+	}
+	for _, val := range values {
+		doc, ok := val.DocumentOK()
+		if !ok {
+			continue
+		}
+		name := lookupExplainString(doc, "shardName")
+		var shard *ExplainShard
+		for i := range res.Shards {
+			if res.Shards[i].ShardName == name {
+				shard = &res.Shards[i]
+				break
+			}
+		}
+		if shard == nil {
+			res.Shards = append(res.Shards, ExplainShard{ShardName: name})
+			shard = &res.Shards[len(res.Shards)-1]
+		}
+		if err = fn(shard, doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func lookupExplainString(doc bson.Raw, key string) string {
+	str, _ := doc.Lookup(key).StringValueOK()
+	return str
+}
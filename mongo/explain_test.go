@@ -0,0 +1,193 @@
+// Copyright (C) MongoDB, Inc. 2020-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestExplain(t *testing.T) {
+	t.Run("nil model", func(t *testing.T) {
+		coll := setupColl("explain")
+		_, err := coll.Explain(context.Background(), QueryPlannerVerbosity, nil)
+		assert.Equal(t, ErrNilExplainModel, err, "expected error %v, got %v", ErrNilExplainModel, err)
+	})
+	t.Run("result", func(t *testing.T) {
+		winningPlan := bson.D{{"stage", "COLLSCAN"}}
+		executionStages := bson.D{{"stage", "COLLSCAN"}, {"nReturned", int32(3)}}
+
+		testCases := []struct {
+			name     string
+			response bson.D
+			expected ExplainResult
+		}{
+			{
+				"standalone find",
+				bson.D{
+					{"queryPlanner", bson.D{
+						{"namespace", "db.coll"},
+						{"indexFilterSet", false},
+						{"winningPlan", winningPlan},
+						{"rejectedPlans", bson.A{}},
+					}},
+					{"executionStats", bson.D{
+						{"executionSuccess", true},
+						{"nReturned", int32(3)},
+						{"executionTimeMillis", int32(1)},
+						{"totalKeysExamined", int32(0)},
+						{"totalDocsExamined", int32(10)},
+						{"executionStages", executionStages},
+					}},
+					{"ok", 1.0},
+				},
+				ExplainResult{
+					QueryPlanner: &ExplainQueryPlanner{
+						Namespace:     "db.coll",
+						WinningPlan:   mustMarshal(winningPlan),
+						RejectedPlans: []bson.Raw{},
+					},
+					ExecutionStats: &ExplainExecutionStats{
+						ExecutionSuccess:    true,
+						NReturned:           3,
+						ExecutionTimeMillis: 1,
+						TotalDocsExamined:   10,
+						ExecutionStages:     mustMarshal(executionStages),
+					},
+				},
+			},
+			{
+				"sharded find",
+				bson.D{
+					{"queryPlanner", bson.D{
+						{"winningPlan", bson.D{
+							{"stage", "SINGLE_SHARD"},
+							{"shards", bson.A{
+								bson.D{
+									{"shardName", "shard01"},
+									{"connectionString", "shard01/localhost:27018"},
+									{"namespace", "db.coll"},
+									{"winningPlan", winningPlan},
+								},
+							}},
+						}},
+					}},
+					{"executionStats", bson.D{
+						{"nReturned", int32(3)},
+						{"executionStages", bson.D{
+							{"stage", "SINGLE_SHARD"},
+							{"shards", bson.A{
+								bson.D{
+									{"shardName", "shard01"},
+									{"executionSuccess", true},
+									{"nReturned", int32(3)},
+									{"executionStages", executionStages},
+								},
+							}},
+						}},
+					}},
+					{"ok", 1.0},
+				},
+				ExplainResult{
+					Shards: []ExplainShard{
+						{
+							ShardName:        "shard01",
+							ConnectionString: "shard01/localhost:27018",
+							QueryPlanner: &ExplainQueryPlanner{
+								Namespace:   "db.coll",
+								WinningPlan: mustMarshal(winningPlan),
+							},
+							ExecutionStats: &ExplainExecutionStats{
+								ExecutionSuccess: true,
+								NReturned:        3,
+								ExecutionStages:  mustMarshal(executionStages),
+							},
+						},
+					},
+				},
+			},
+			{
+				"sharded aggregate",
+				bson.D{
+					{"mergeType", "mongos"},
+					{"shards", bson.D{
+						{"shard01", bson.D{
+							{"host", "localhost:27018"},
+							{"stages", bson.A{
+								bson.D{{"$cursor", bson.D{
+									{"queryPlanner", bson.D{
+										{"namespace", "db.coll"},
+										{"winningPlan", winningPlan},
+									}},
+								}}},
+							}},
+						}},
+					}},
+					{"ok", 1.0},
+				},
+				ExplainResult{
+					Shards: []ExplainShard{
+						{
+							ShardName:        "shard01",
+							ConnectionString: "localhost:27018",
+							QueryPlanner: &ExplainQueryPlanner{
+								Namespace:   "db.coll",
+								WinningPlan: mustMarshal(winningPlan),
+							},
+						},
+					},
+				},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				raw := mustMarshal(tc.response)
+				res, err := newExplainResult(raw)
+				assert.Nil(t, err, "newExplainResult error: %v", err)
+
+				if tc.expected.QueryPlanner != nil {
+					assert.Equal(t, tc.expected.QueryPlanner, res.QueryPlanner,
+						"expected query planner %v, got %v", tc.expected.QueryPlanner, res.QueryPlanner)
+				}
+				if tc.expected.ExecutionStats != nil {
+					assert.Equal(t, tc.expected.ExecutionStats, res.ExecutionStats,
+						"expected execution stats %v, got %v", tc.expected.ExecutionStats, res.ExecutionStats)
+				}
+				assert.Equal(t, tc.expected.Shards, res.Shards, "expected shards %v, got %v", tc.expected.Shards, res.Shards)
+				assert.Equal(t, raw, res.Raw, "expected raw response %v, got %v", raw, res.Raw)
+			})
+		}
+	})
+	t.Run("aggregate stages", func(t *testing.T) {
+		raw := mustMarshal(bson.D{
+			{"stages", bson.A{
+				bson.D{{"$cursor", bson.D{
+					{"queryPlanner", bson.D{{"namespace", "db.coll"}}},
+				}}},
+				bson.D{{"$group", bson.D{{"_id", 1}}}},
+			}},
+			{"ok", 1.0},
+		})
+		res, err := newExplainResult(raw)
+		assert.Nil(t, err, "newExplainResult error: %v", err)
+		assert.Equal(t, 2, len(res.Stages), "expected 2 stages, got %v", len(res.Stages))
+		assert.NotNil(t, res.QueryPlanner, "expected query planner from $cursor stage")
+		assert.Equal(t, "db.coll", res.QueryPlanner.Namespace,
+			"expected namespace db.coll, got %v", res.QueryPlanner.Namespace)
+	})
+}
+
+func mustMarshal(val interface{}) bson.Raw {
+	b, err := bson.Marshal(val)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -95,9 +95,9 @@ func (iv IndexView) List(ctx context.Context, opts ...*options.ListIndexesOption
 		Session(sess).CommandMonitor(iv.coll.client.monitor).
 		ServerSelector(selector).ClusterClock(iv.coll.client.clock).
 		Database(iv.coll.db.name).Collection(iv.coll.name).
-		Deployment(iv.coll.client.deployment)
+		Deployment(iv.coll.client.deployment).Timeout(iv.coll.timeout).ServerAPI(iv.coll.client.serverAPI)
 
-	var cursorOpts driver.CursorOptions
+	cursorOpts := driver.CursorOptions{Timeout: iv.coll.timeout, ServerAPI: iv.coll.client.serverAPI}
 	lio := options.MergeListIndexesOptions(opts...)
 	if lio.BatchSize != nil {
 		op = op.BatchSize(*lio.BatchSize)
@@ -233,7 +233,8 @@ func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ..
 	op := operation.NewCreateIndexes(indexes).
 		Session(sess).WriteConcern(wc).ClusterClock(iv.coll.client.clock).
 		Database(iv.coll.db.name).Collection(iv.coll.name).CommandMonitor(iv.coll.client.monitor).
-		Deployment(iv.coll.client.deployment).ServerSelector(selector)
+		Deployment(iv.coll.client.deployment).ServerSelector(selector).Timeout(iv.coll.timeout).
+		ServerAPI(iv.coll.client.serverAPI)
 
 	if option.MaxTime != nil {
 		op.MaxTimeMS(int64(*option.MaxTime / time.Millisecond))
@@ -365,7 +366,7 @@ func (iv IndexView) drop(ctx context.Context, name string, opts ...*options.Drop
 		Session(sess).WriteConcern(wc).CommandMonitor(iv.coll.client.monitor).
 		ServerSelector(selector).ClusterClock(iv.coll.client.clock).
 		Database(iv.coll.db.name).Collection(iv.coll.name).
-		Deployment(iv.coll.client.deployment)
+		Deployment(iv.coll.client.deployment).Timeout(iv.coll.timeout).ServerAPI(iv.coll.client.serverAPI)
 	if dio.MaxTime != nil {
 		op.MaxTimeMS(int64(*dio.MaxTime / time.Millisecond))
 	}
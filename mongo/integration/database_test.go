@@ -15,6 +15,7 @@ import (
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 const (
@@ -196,6 +197,50 @@ func TestDatabase(t *testing.T) {
 			})
 		}
 	})
+	mt.RunOpts("create collection", noClientOpts, func(mt *mtest.T) {
+		mt.Run("options", func(mt *mtest.T) {
+			collName := "create-collection-options"
+			opts := options.CreateCollection().SetCapped(true).SetSizeInBytes(1024).SetMaxDocuments(10)
+			err := mt.DB.CreateCollection(mtest.Background, collName, opts)
+			assert.Nil(mt, err, "CreateCollection error: %v", err)
+			defer func() { _ = mt.DB.Collection(collName).Drop(mtest.Background) }()
+
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "create", evt.CommandName, "expected command 'create', got '%v'", evt.CommandName)
+			cmdColl := evt.Command.Lookup("create").StringValue()
+			assert.Equal(mt, collName, cmdColl, "expected collection %v, got %v", collName, cmdColl)
+			assert.True(mt, evt.Command.Lookup("capped").Boolean(), "expected capped to be true")
+
+			cursor, err := mt.DB.ListCollections(mtest.Background, bson.D{{"name", collName}})
+			assert.Nil(mt, err, "ListCollections error: %v", err)
+			defer cursor.Close(mtest.Background)
+			assert.True(mt, cursor.Next(mtest.Background), "expected collection %v to exist", collName)
+			capped := cursor.Current.Lookup("options", "capped").Boolean()
+			assert.True(mt, capped, "expected collection %v to be capped", collName)
+		})
+		mt.Run("existing collection", func(mt *mtest.T) {
+			// mt.Coll is created on the server before the test runs
+			err := mt.DB.CreateCollection(mtest.Background, mt.Coll.Name())
+			_, ok := err.(mongo.CommandError)
+			assert.True(mt, ok, "expected error type %T, got %v", mongo.CommandError{}, err)
+		})
+	})
+	createViewOpts := mtest.NewOptions().MinServerVersion("3.4")
+	mt.RunOpts("create view", createViewOpts, func(mt *mtest.T) {
+		_, err := mt.Coll.InsertMany(mtest.Background, []interface{}{bson.D{{"x", 1}}, bson.D{{"x", 2}}})
+		assert.Nil(mt, err, "InsertMany error: %v", err)
+
+		viewName := "create-view-test"
+		pipeline := mongo.Pipeline{{{"$match", bson.D{{"x", 2}}}}}
+		err = mt.DB.CreateView(mtest.Background, viewName, mt.Coll.Name(), pipeline)
+		assert.Nil(mt, err, "CreateView error: %v", err)
+		view := mt.DB.Collection(viewName)
+		defer func() { _ = view.Drop(mtest.Background) }()
+
+		count, err := view.CountDocuments(mtest.Background, bson.D{})
+		assert.Nil(mt, err, "CountDocuments error: %v", err)
+		assert.Equal(mt, int64(1), count, "expected 1 document in view, got %v", count)
+	})
 }
 
 func verifyListCollections(cursor *mongo.Cursor, cappedOnly bool) error {
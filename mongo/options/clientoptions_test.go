@@ -44,6 +44,61 @@ func TestClientOptions(t *testing.T) {
 			t.Errorf("Did not receive expected error. got %v; want %v", got, want)
 		}
 	})
+	t.Run("Validate/loadBalanced", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			opts *ClientOptions
+			err  error
+		}{
+			{"single host", Client().SetHosts([]string{"localhost"}).SetLoadBalanced(true), nil},
+			{
+				"multiple hosts",
+				Client().SetHosts([]string{"localhost:27017", "localhost:27018"}).SetLoadBalanced(true),
+				errors.New("loadBalanced cannot be set to true if multiple hosts are specified"),
+			},
+			{
+				"replica set",
+				Client().SetReplicaSet("rs0").SetLoadBalanced(true),
+				errors.New("loadBalanced cannot be set to true if a replica set name is specified"),
+			},
+			{
+				"direct",
+				Client().SetDirect(true).SetLoadBalanced(true),
+				errors.New("loadBalanced cannot be set to true if the direct connection option is specified"),
+			},
+			{"loadBalanced false", Client().SetReplicaSet("rs0").SetLoadBalanced(false), nil},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				got := tc.opts.Validate()
+				if !cmp.Equal(got, tc.err, cmp.Comparer(compareErrors)) {
+					t.Errorf("Did not receive expected error. got %v; want %v", got, tc.err)
+				}
+			})
+		}
+	})
+	t.Run("Validate/serverAPI", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			opts *ClientOptions
+			err  error
+		}{
+			{"version 1", Client().SetServerAPIOptions(ServerAPI(ServerAPIVersion1).SetStrict(true)), nil},
+			{
+				"unsupported version",
+				Client().SetServerAPIOptions(ServerAPI("2")),
+				errors.New(`api version "2" not supported; this driver version only supports API version "1"`),
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				got := tc.opts.Validate()
+				if !cmp.Equal(got, tc.err, cmp.Comparer(compareErrors)) {
+					t.Errorf("Did not receive expected error. got %v; want %v", got, tc.err)
+				}
+			})
+		}
+	})
 	t.Run("Set", func(t *testing.T) {
 		testCases := []struct {
 			name        string
@@ -57,10 +112,14 @@ func TestClientOptions(t *testing.T) {
 			{"Compressors", (*ClientOptions).SetCompressors, []string{"zstd", "snappy", "zlib"}, "Compressors", true},
 			{"ConnectTimeout", (*ClientOptions).SetConnectTimeout, 5 * time.Second, "ConnectTimeout", true},
 			{"Dialer", (*ClientOptions).SetDialer, testDialer{Num: 12345}, "Dialer", true},
+			{"DisableCertificateRevocationCheck", (*ClientOptions).SetDisableCertificateRevocationCheck, true, "DisableCertificateRevocationCheck", true},
+			{"DisableOCSPEndpointCheck", (*ClientOptions).SetDisableOCSPEndpointCheck, true, "DisableOCSPEndpointCheck", true},
 			{"HeartbeatInterval", (*ClientOptions).SetHeartbeatInterval, 5 * time.Second, "HeartbeatInterval", true},
 			{"Hosts", (*ClientOptions).SetHosts, []string{"localhost:27017", "localhost:27018", "localhost:27019"}, "Hosts", true},
+			{"LoadBalanced", (*ClientOptions).SetLoadBalanced, true, "LoadBalanced", true},
 			{"LocalThreshold", (*ClientOptions).SetLocalThreshold, 5 * time.Second, "LocalThreshold", true},
 			{"MaxConnIdleTime", (*ClientOptions).SetMaxConnIdleTime, 5 * time.Second, "MaxConnIdleTime", true},
+			{"MaxConnecting", (*ClientOptions).SetMaxConnecting, uint64(5), "MaxConnecting", true},
 			{"MaxPoolSize", (*ClientOptions).SetMaxPoolSize, uint64(250), "MaxPoolSize", true},
 			{"MinPoolSize", (*ClientOptions).SetMinPoolSize, uint64(10), "MinPoolSize", true},
 			{"PoolMonitor", (*ClientOptions).SetPoolMonitor, &event.PoolMonitor{}, "PoolMonitor", false},
@@ -70,9 +129,13 @@ func TestClientOptions(t *testing.T) {
 			{"Registry", (*ClientOptions).SetRegistry, bson.NewRegistryBuilder().Build(), "Registry", false},
 			{"ReplicaSet", (*ClientOptions).SetReplicaSet, "example-replicaset", "ReplicaSet", true},
 			{"RetryWrites", (*ClientOptions).SetRetryWrites, true, "RetryWrites", true},
+			{"ServerAPIOptions", (*ClientOptions).SetServerAPIOptions, ServerAPI(ServerAPIVersion1), "ServerAPIOptions", false},
+			{"ServerMonitor", (*ClientOptions).SetServerMonitor, &event.ServerMonitor{}, "ServerMonitor", false},
 			{"ServerSelectionTimeout", (*ClientOptions).SetServerSelectionTimeout, 5 * time.Second, "ServerSelectionTimeout", true},
 			{"Direct", (*ClientOptions).SetDirect, true, "Direct", true},
 			{"SocketTimeout", (*ClientOptions).SetSocketTimeout, 5 * time.Second, "SocketTimeout", true},
+			{"Timeout", (*ClientOptions).SetTimeout, 5 * time.Second, "Timeout", true},
+			{"WaitQueueTimeout", (*ClientOptions).SetWaitQueueTimeout, 5 * time.Second, "WaitQueueTimeout", true},
 			{"TLSConfig", (*ClientOptions).SetTLSConfig, &tls.Config{}, "TLSConfig", false},
 			{"WriteConcern", (*ClientOptions).SetWriteConcern, writeconcern.New(writeconcern.WMajority()), "WriteConcern", false},
 			{"ZlibLevel", (*ClientOptions).SetZlibLevel, 6, "ZlibLevel", true},
@@ -135,6 +198,7 @@ func TestClientOptions(t *testing.T) {
 					cmp.Comparer(func(r1, r2 *bsoncodec.Registry) bool { return r1 == r2 }),
 					cmp.Comparer(func(cfg1, cfg2 *tls.Config) bool { return cfg1 == cfg2 }),
 					cmp.Comparer(func(fp1, fp2 *event.PoolMonitor) bool { return fp1 == fp2 }),
+					cmp.Comparer(func(sm1, sm2 *event.ServerMonitor) bool { return sm1 == sm2 }),
 				) {
 					t.Errorf("Field not set properly. got %v; want %v", got.Interface(), want.Interface())
 				}
@@ -149,6 +213,7 @@ func TestClientOptions(t *testing.T) {
 				cmp.Comparer(func(r1, r2 *bsoncodec.Registry) bool { return r1 == r2 }),
 				cmp.Comparer(func(cfg1, cfg2 *tls.Config) bool { return cfg1 == cfg2 }),
 				cmp.Comparer(func(fp1, fp2 *event.PoolMonitor) bool { return fp1 == fp2 }),
+				cmp.Comparer(func(sm1, sm2 *event.ServerMonitor) bool { return sm1 == sm2 }),
 				cmp.AllowUnexported(ClientOptions{}),
 			); diff != "" {
 				t.Errorf("diff:\n%s", diff)
@@ -208,6 +273,16 @@ func TestClientOptions(t *testing.T) {
 					Hosts: []string{"localhost"},
 				},
 			},
+			{
+				"TLS DisableOCSPEndpointCheck",
+				"mongodb://localhost/?tls=true&tlsDisableOCSPEndpointCheck=true",
+				baseClient().SetTLSConfig(&tls.Config{}).SetDisableOCSPEndpointCheck(true),
+			},
+			{
+				"TLS DisableCertificateRevocationCheck",
+				"mongodb://localhost/?tls=true&tlsDisableCertificateRevocationCheck=true",
+				baseClient().SetTLSConfig(&tls.Config{}).SetDisableCertificateRevocationCheck(true),
+			},
 			{
 				"TLS ClientCertificateKey",
 				"mongodb://localhost/?ssl=true&sslClientCertificateKeyFile=testdata/doesntexist",
@@ -296,6 +371,11 @@ func TestClientOptions(t *testing.T) {
 				"mongodb://localhost:27017,localhost:27018,localhost:27019/",
 				baseClient().SetHosts([]string{"localhost:27017", "localhost:27018", "localhost:27019"}),
 			},
+			{
+				"LoadBalanced",
+				"mongodb://localhost/?loadBalanced=true",
+				baseClient().SetLoadBalanced(true),
+			},
 			{
 				"LocalThreshold",
 				"mongodb://localhost/?localThresholdMS=200",
@@ -306,11 +386,21 @@ func TestClientOptions(t *testing.T) {
 				"mongodb://localhost/?maxIdleTimeMS=300000",
 				baseClient().SetMaxConnIdleTime(5 * time.Minute),
 			},
+			{
+				"MaxConnecting",
+				"mongodb://localhost/?maxConnecting=5",
+				baseClient().SetMaxConnecting(5),
+			},
 			{
 				"MaxPoolSize",
 				"mongodb://localhost/?maxPoolSize=256",
 				baseClient().SetMaxPoolSize(256),
 			},
+			{
+				"WaitQueueTimeout",
+				"mongodb://localhost/?waitQueueTimeoutMS=500",
+				baseClient().SetWaitQueueTimeout(500 * time.Millisecond),
+			},
 			{
 				"ReadConcern",
 				"mongodb://localhost/?readConcernLevel=linearizable",
@@ -351,6 +441,11 @@ func TestClientOptions(t *testing.T) {
 				"mongodb://localhost/?socketTimeoutMS=15000",
 				baseClient().SetSocketTimeout(15 * time.Second),
 			},
+			{
+				"Timeout",
+				"mongodb://localhost/?timeoutMS=10000",
+				baseClient().SetTimeout(10 * time.Second),
+			},
 			{
 				"TLS CACertificate",
 				"mongodb://localhost/?ssl=true&sslCertificateAuthorityFile=testdata/ca.pem",
@@ -371,6 +466,52 @@ func TestClientOptions(t *testing.T) {
 				"mongodb://localhost/?ssl=true&sslClientCertificateKeyFile=testdata/certificate.pem&sslClientCertificateKeyPassword=passphrase",
 				baseClient().SetTLSConfig(&tls.Config{Certificates: make([]tls.Certificate, 1)}),
 			},
+			{
+				"TLS CertificateFile and PrivateKeyFile",
+				"mongodb://localhost/?tlsCertificateFile=testdata/nopass/cert.pem&tlsPrivateKeyFile=testdata/nopass/key.pem",
+				baseClient().SetTLSConfig(&tls.Config{Certificates: make([]tls.Certificate, 1)}),
+			},
+			{
+				"TLS PrivateKeyFile with encrypted PKCS8 key",
+				"mongodb://localhost/?tlsCertificateFile=testdata/nopass/cert.pem&tlsPrivateKeyFile=testdata/pkcs8-key.pem&tlsCertificateKeyFilePassword=passphrase",
+				baseClient().SetTLSConfig(&tls.Config{Certificates: make([]tls.Certificate, 1)}),
+			},
+			{
+				"TLS PrivateKeyFile with encrypted PKCS8 key and no password",
+				"mongodb://localhost/?tlsCertificateFile=testdata/nopass/cert.pem&tlsPrivateKeyFile=testdata/pkcs8-key.pem",
+				&ClientOptions{
+					err:   errors.New("a password is required to decrypt an ENCRYPTED PRIVATE KEY"),
+					Hosts: []string{"localhost"},
+				},
+			},
+			{
+				"TLS AllowInvalidCertificates",
+				"mongodb://localhost/?tls=true&tlsAllowInvalidCertificates=true",
+				baseClient().SetTLSConfig(&tls.Config{InsecureSkipVerify: true}),
+			},
+			{
+				"TLS AllowInvalidHostnames",
+				"mongodb://localhost/?tls=true&tlsAllowInvalidHostnames=true",
+				baseClient().SetTLSConfig(&tls.Config{
+					InsecureSkipVerify:    true,
+					VerifyPeerCertificate: func([][]byte, [][]*x509.Certificate) error { return nil },
+				}),
+			},
+			{
+				"TLS CRLFile",
+				"mongodb://localhost/?tlsCRLFile=testdata/crl.pem",
+				baseClient().SetTLSConfig(&tls.Config{
+					VerifyPeerCertificate: func([][]byte, [][]*x509.Certificate) error { return nil },
+				}),
+			},
+			{
+				"TLS CRLFile error",
+				"mongodb://localhost/?tlsCRLFile=testdata/doesntexist",
+				&ClientOptions{
+					err:   &os.PathError{Op: "open", Path: "testdata/doesntexist"},
+					Hosts: []string{"localhost"},
+				},
+			},
 			{
 				"TLS Username",
 				"mongodb://localhost/?ssl=true&authMechanism=mongodb-x509&sslClientCertificateKeyFile=testdata/nopass/certificate.pem",
@@ -453,6 +594,10 @@ func compareTLSConfig(cfg1, cfg2 *tls.Config) bool {
 		return false
 	}
 
+	if (cfg1.VerifyPeerCertificate == nil) != (cfg2.VerifyPeerCertificate == nil) {
+		return false
+	}
+
 	return true
 }
 
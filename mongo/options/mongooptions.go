@@ -65,7 +65,7 @@ func (co *Collation) ToDocument() bson.Raw {
 }
 
 // CursorType specifies whether a cursor should close when the last data is retrieved. See
-// NonTailable, Tailable, and TailableAwait.
+// NonTailable, Tailable, TailableAwait, and Exhaust.
 type CursorType int8
 
 const (
@@ -76,6 +76,12 @@ const (
 	// TailableAwait specifies that a cursor should not close when the last data is retrieved and
 	// that it should block for a certain amount of time for new data before returning no data.
 	TailableAwait
+	// Exhaust specifies a non-tailable cursor for which the server streams all of the batches after the first
+	// over a single connection, without waiting for the driver to request each one. The connection is reserved
+	// for the cursor until the last batch is read or the cursor is closed. This is useful for reading large result
+	// sets quickly. Exhaust requires MongoDB 4.2 or later; older servers return batches one request at a time as
+	// with NonTailable.
+	Exhaust
 )
 
 // ReturnDocument specifies whether a findAndUpdate operation should return the document as it was
@@ -0,0 +1,61 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+import (
+	"fmt"
+)
+
+// ServerAPIOptions represents options used to declare the server API version an application targets. When a server
+// API version is declared, the server runs every command with the behavior of that version, so upgrading the server
+// does not change how the application's commands behave.
+type ServerAPIOptions struct {
+	// The server API version. This option is required. The only valid value is ServerAPIVersion1.
+	ServerAPIVersion ServerAPIVersion
+
+	// If true, the server returns an error for any command or feature that is not part of the declared API version. The
+	// default value is nil, which means the server's default of false is used.
+	Strict *bool
+
+	// If true, the server returns an error for any command or feature that is deprecated in the declared API version.
+	// The default value is nil, which means the server's default of false is used.
+	DeprecationErrors *bool
+}
+
+// ServerAPI creates a new ServerAPIOptions instance for the given server API version.
+func ServerAPI(serverAPIVersion ServerAPIVersion) *ServerAPIOptions {
+	return &ServerAPIOptions{ServerAPIVersion: serverAPIVersion}
+}
+
+// SetStrict sets the value for the Strict field.
+func (s *ServerAPIOptions) SetStrict(strict bool) *ServerAPIOptions {
+	s.Strict = &strict
+	return s
+}
+
+// SetDeprecationErrors sets the value for the DeprecationErrors field.
+func (s *ServerAPIOptions) SetDeprecationErrors(deprecationErrors bool) *ServerAPIOptions {
+	s.DeprecationErrors = &deprecationErrors
+	return s
+}
+
+// ServerAPIVersion represents a server API version declared by an application.
+type ServerAPIVersion string
+
+const (
+	// ServerAPIVersion1 is the first server API version.
+	ServerAPIVersion1 ServerAPIVersion = "1"
+)
+
+// Validate returns an error if the server API version is not supported by the driver.
+func (sav ServerAPIVersion) Validate() error {
+	switch sav {
+	case ServerAPIVersion1:
+		return nil
+	}
+	return fmt.Errorf("api version %q not supported; this driver version only supports API version %q", sav, ServerAPIVersion1)
+}
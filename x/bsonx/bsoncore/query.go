@@ -0,0 +1,180 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncore
+
+import (
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// QueryWildcard is the path segment that matches every element of an array.
+const QueryWildcard = "$[]"
+
+// QueryPath is a parsed path for Document.Query. A path is a list of segments, each of which is either a key of an
+// embedded document, the index of an array element, or QueryWildcard to match every element of an array.
+type QueryPath struct {
+	segments []string
+}
+
+// ParseQueryPath parses a dotted path such as "a.b.2.c" or "items.$[].price". Numeric segments select array elements
+// by position, and "$[]" segments select every element of an array.
+func ParseQueryPath(path string) (QueryPath, error) {
+	if path == "" {
+		return QueryPath{}, ErrEmptyKey
+	}
+	segments := strings.Split(path, ".")
+	for i, s := range segments {
+		if s == "" {
+			return QueryPath{}, fmt.Errorf("invalid query path %q: segment %d is empty", path, i)
+		}
+	}
+	return QueryPath{segments: segments}, nil
+}
+
+// NewQueryPath returns a QueryPath with the given segments. Unlike ParseQueryPath, keys may contain dots.
+func NewQueryPath(segments ...string) QueryPath {
+	return QueryPath{segments: append([]string(nil), segments...)}
+}
+
+// String returns the path in dot notation.
+func (qp QueryPath) String() string {
+	return strings.Join(qp.segments, ".")
+}
+
+// Query returns an iterator over the values in d that match path. The values reference d directly, so d must not be
+// modified while they are in use.
+//
+// A key segment matches the first element of a document with that key, and an index segment matches the array
+// element at that position. Intermediate values that are not documents or arrays, and missing elements, do not match,
+// so a wildcard can be used across arrays whose elements have different shapes.
+func (d Document) Query(path QueryPath) *QueryIterator {
+	qi := &QueryIterator{doc: d, segments: path.segments}
+	if len(path.segments) == 0 {
+		qi.err = ErrEmptyKey
+		return qi
+	}
+	rem, err := containerElements(d)
+	if err != nil {
+		qi.err = err
+		return qi
+	}
+	qi.stack = append(qi.stack, queryFrame{rem: rem})
+	return qi
+}
+
+// queryFrame is a document or array being searched by a QueryIterator.
+type queryFrame struct {
+	rem   []byte // the unread elements of the container, followed by its terminating null byte
+	depth int    // the index of the path segment matched against the container's elements
+	array bool
+	key   string // the key of the element holding the container, empty for the top level document
+}
+
+// QueryIterator iterates over the values that match a QueryPath. Values are found lazily by walking the document, so
+// iteration can be stopped early without searching the rest of the document.
+type QueryIterator struct {
+	doc      Document
+	segments []string
+	stack    []queryFrame
+	val      Value
+	key      string
+	err      error
+}
+
+// Next advances the iterator to the next matching value. It returns false when there are no more matches or an error
+// occurs, which can be checked with Err.
+func (qi *QueryIterator) Next() bool {
+	qi.val = Value{}
+	for qi.err == nil && len(qi.stack) > 0 {
+		f := &qi.stack[len(qi.stack)-1]
+		if len(f.rem) <= 1 {
+			qi.stack = qi.stack[:len(qi.stack)-1]
+			continue
+		}
+
+		segment := qi.segments[f.depth]
+		wildcard := segment == QueryWildcard
+		if wildcard && !f.array {
+			// Wildcards only match array elements.
+			qi.stack = qi.stack[:len(qi.stack)-1]
+			continue
+		}
+
+		elem, rem, ok := ReadElement(f.rem)
+		if !ok {
+			qi.err = NewInsufficientBytesError(qi.doc, f.rem)
+			return false
+		}
+		f.rem = rem
+
+		key, err := elem.KeyErr()
+		if err != nil {
+			qi.err = err
+			return false
+		}
+		if !wildcard {
+			if key != segment {
+				continue
+			}
+			// Only the first element with a key matches, as with Document.Lookup.
+			f.rem = nil
+		}
+		val, err := elem.ValueErr()
+		if err != nil {
+			qi.err = err
+			return false
+		}
+
+		depth := f.depth + 1
+		if depth == len(qi.segments) {
+			qi.val, qi.key = val, key
+			return true
+		}
+		if val.Type != bsontype.EmbeddedDocument && val.Type != bsontype.Array {
+			continue
+		}
+		elems, err := containerElements(val.Data)
+		if err != nil {
+			qi.err = err
+			return false
+		}
+		qi.stack = append(qi.stack, queryFrame{rem: elems, depth: depth, array: val.Type == bsontype.Array, key: key})
+	}
+	return false
+}
+
+// Value returns the current value. The value is empty before the first call to Next and after Next returns false.
+func (qi *QueryIterator) Value() Value { return qi.val }
+
+// Path returns the path of the current value with wildcards replaced by array indexes, such as
+// ["items", "2", "price"] for the path "items.$[].price".
+func (qi *QueryIterator) Path() []string {
+	if qi.val.Type == bsontype.Type(0) {
+		return nil
+	}
+	// The stack holds one frame for each segment, and each frame after the first was pushed for the element that
+	// matched the previous segment.
+	path := make([]string, 0, len(qi.segments))
+	for _, f := range qi.stack[1:] {
+		path = append(path, f.key)
+	}
+	return append(path, qi.key)
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (qi *QueryIterator) Err() error { return qi.err }
+
+// containerElements returns the elements and terminating null byte of the document or array in b.
+func containerElements(b []byte) ([]byte, error) {
+	length, rem, ok := ReadLength(b)
+	if !ok || int(length) > len(b) || length < 5 {
+		return nil, NewInsufficientBytesError(b, rem)
+	}
+	return b[4:length], nil
+}
@@ -0,0 +1,121 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncore
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+func TestDocumentQuery(t *testing.T) {
+	doc := Document(BuildDocumentFromElements(nil,
+		AppendStringElement(nil, "name", "order"),
+		BuildDocumentElement(nil, "a",
+			BuildArrayElement(nil, "b",
+				Value{Type: bsontype.Int32, Data: AppendInt32(nil, 1)},
+				Value{Type: bsontype.Int32, Data: AppendInt32(nil, 2)},
+				BuildDocumentValue(AppendInt32Element(nil, "c", 3)),
+			),
+		),
+		BuildArrayElement(nil, "items",
+			BuildDocumentValue(AppendStringElement(nil, "sku", "x"), AppendDoubleElement(nil, "price", 1.5)),
+			BuildDocumentValue(AppendStringElement(nil, "sku", "y")),
+			Value{Type: bsontype.String, Data: AppendString(nil, "not a document")},
+			BuildDocumentValue(AppendDoubleElement(nil, "price", 2.5), AppendDoubleElement(nil, "price", 9)),
+		),
+		BuildArrayElement(nil, "matrix",
+			Value{Type: bsontype.Array, Data: BuildArray(nil, Value{Type: bsontype.Int32, Data: AppendInt32(nil, 1)})},
+			Value{Type: bsontype.Array, Data: BuildArray(nil,
+				Value{Type: bsontype.Int32, Data: AppendInt32(nil, 2)},
+				Value{Type: bsontype.Int32, Data: AppendInt32(nil, 3)},
+			)},
+		),
+	))
+
+	type match struct {
+		Path  string
+		Value Value
+	}
+	i32 := func(path string, i int32) match {
+		return match{path, Value{Type: bsontype.Int32, Data: AppendInt32(nil, i)}}
+	}
+	double := func(path string, f float64) match {
+		return match{path, Value{Type: bsontype.Double, Data: AppendDouble(nil, f)}}
+	}
+
+	testCases := []struct {
+		name    string
+		path    string
+		matches []match
+	}{
+		{"top level key", "name", []match{{"name", Value{Type: bsontype.String, Data: AppendString(nil, "order")}}}},
+		{"array index", "a.b.1", []match{i32("a.b.1", 2)}},
+		{"document in array", "a.b.2.c", []match{i32("a.b.2.c", 3)}},
+		{"missing key", "a.x", nil},
+		{"index out of range", "a.b.5", nil},
+		{"through a non-container", "name.x", nil},
+		{"wildcard", "a.b.$[]", []match{i32("a.b.0", 1), i32("a.b.1", 2), match{"a.b.2", doc.Lookup("a", "b", "2")}}},
+		{"wildcard with mixed shapes", "items.$[].price", []match{double("items.0.price", 1.5), double("items.3.price", 2.5)}},
+		{"nested wildcards", "matrix.$[].$[]", []match{i32("matrix.0.0", 1), i32("matrix.1.0", 2), i32("matrix.1.1", 3)}},
+		{"wildcard on a document", "a.$[]", nil},
+		{"wildcard at the top level", "$[]", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path, err := ParseQueryPath(tc.path)
+			noerr(t, err)
+
+			var got []match
+			qi := doc.Query(path)
+			for qi.Next() {
+				got = append(got, match{NewQueryPath(qi.Path()...).String(), qi.Value()})
+			}
+			noerr(t, qi.Err())
+			if !cmp.Equal(got, tc.matches) {
+				t.Errorf("Matches do not match. got %v; want %v", got, tc.matches)
+			}
+			if qi.Value().Type != 0 || qi.Path() != nil {
+				t.Errorf("Expected an empty value and path after the last match, got %v and %v", qi.Value(), qi.Path())
+			}
+		})
+	}
+
+	t.Run("stops early", func(t *testing.T) {
+		qi := doc.Query(NewQueryPath("items", QueryWildcard))
+		if !qi.Next() {
+			t.Fatalf("Expected a match, got none. err: %v", qi.Err())
+		}
+		if got := qi.Path(); !cmp.Equal(got, []string{"items", "0"}) {
+			t.Errorf("Paths do not match. got %v; want %v", got, []string{"items", "0"})
+		}
+	})
+	t.Run("malformed document", func(t *testing.T) {
+		bad := make(Document, len(doc))
+		copy(bad, doc)
+		// Truncate the "a" subdocument's length so its elements run past the end of it.
+		idx := len(AppendStringElement(nil, "name", "order")) + 4
+		binary.LittleEndian.PutUint32(bad[idx+3:], 8)
+
+		qi := bad.Query(NewQueryPath("a", "b", QueryWildcard))
+		for qi.Next() {
+		}
+		if qi.Err() == nil {
+			t.Errorf("Expected an error querying a malformed document")
+		}
+	})
+	t.Run("ParseQueryPath errors", func(t *testing.T) {
+		for _, path := range []string{"", ".", "a.", ".a", "a..b"} {
+			if _, err := ParseQueryPath(path); err == nil {
+				t.Errorf("Expected an error parsing %q", path)
+			}
+		}
+	})
+}
@@ -0,0 +1,291 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncore
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// ErrInvalidUTF8 is returned by a Validator when a key or string is not valid UTF-8.
+const ErrInvalidUTF8 DocumentValidationError = "invalid UTF-8"
+
+// ErrDuplicateKey is returned by a Validator when a document contains the same key more than once.
+const ErrDuplicateKey DocumentValidationError = "duplicate key"
+
+// ErrMaxDepthExceeded is returned by a Validator when documents and arrays are nested too deeply.
+const ErrMaxDepthExceeded DocumentValidationError = "maximum nesting depth exceeded"
+
+// ErrDeprecatedType is returned by a Validator when a value has a deprecated BSON type.
+const ErrDeprecatedType DocumentValidationError = "deprecated BSON type"
+
+// ValidationError is returned by Validator.Validate to describe what is wrong with a document and where.
+type ValidationError struct {
+	// Offset is the position of the invalid bytes, counted from the start of the validated document.
+	Offset int
+	// Path is the keys of the element containing the invalid bytes. It is empty when the top level document itself
+	// is invalid.
+	Path []string
+	Err  error
+}
+
+// Error implements the error interface.
+func (ve ValidationError) Error() string {
+	if len(ve.Path) == 0 {
+		return fmt.Sprintf("invalid BSON at offset %d: %v", ve.Offset, ve.Err)
+	}
+	return fmt.Sprintf("invalid BSON at offset %d (%s): %v", ve.Offset, strings.Join(ve.Path, "."), ve.Err)
+}
+
+// Validator checks documents more thoroughly than Document.Validate. Every validator checks that lengths and null
+// terminators are consistent and that each value is well formed, including the contents of booleans, binary data and
+// JavaScript code with scope. The remaining checks are enabled by setting the corresponding fields.
+//
+// The zero value is a Validator with all of the optional checks disabled.
+type Validator struct {
+	// CheckUTF8 rejects keys, strings and regular expressions that are not valid UTF-8.
+	CheckUTF8 bool
+
+	// CheckDuplicateKeys rejects documents that contain the same key more than once. Array indexes are not checked.
+	CheckDuplicateKeys bool
+
+	// MaxDepth limits how deeply embedded documents and arrays can be nested. The elements of the top level document
+	// are at depth 1, so a MaxDepth of 1 rejects any embedded document or array. Zero means no limit.
+	MaxDepth int
+
+	// RejectDeprecatedTypes rejects Undefined, DBPointer, Symbol and JavaScript code with scope values.
+	RejectDeprecatedTypes bool
+}
+
+// Validate checks that doc is a valid BSON document. The first problem found is returned as a ValidationError. As
+// with Document.Validate, any bytes after the end of the document are ignored.
+func (v Validator) Validate(doc []byte) error {
+	vs := validatorState{Validator: v, src: doc}
+	return vs.document(len(doc), 0, 1, false)
+}
+
+// validatorState holds the document being validated and the path to the element currently being checked.
+type validatorState struct {
+	Validator
+	src  []byte
+	path []string
+}
+
+func (vs *validatorState) error(offset int, err error) error {
+	return ValidationError{Offset: offset, Path: append([]string(nil), vs.path...), Err: err}
+}
+
+// document validates the document or array at start, which must end before limit. The elements of the document are at
+// the given depth.
+func (vs *validatorState) document(limit, start, depth int, array bool) error {
+	if vs.MaxDepth > 0 && depth > vs.MaxDepth {
+		return vs.error(start, ErrMaxDepthExceeded)
+	}
+	length, _, ok := ReadLength(vs.src[start:limit])
+	if !ok || length < 5 {
+		return vs.error(start, ErrInvalidLength)
+	}
+	if int64(length) > int64(limit-start) {
+		return vs.error(start, NewDocumentLengthError(int(length), limit-start))
+	}
+	end := start + int(length)
+	if vs.src[end-1] != 0x00 {
+		return vs.error(end-1, ErrMissingNull)
+	}
+
+	var keys map[string]struct{}
+	if vs.CheckDuplicateKeys && !array {
+		keys = make(map[string]struct{})
+	}
+	pos := start + 4
+	for pos < end-1 {
+		elemStart := pos
+		t := bsontype.Type(vs.src[pos])
+		key, _, ok := readcstringbytes(vs.src[pos+1 : end-1])
+		if !ok {
+			return vs.error(pos+1, DocumentValidationError("key is missing null terminator"))
+		}
+		pos += 1 + len(key) + 1
+
+		vs.path = append(vs.path, string(key))
+		if err := vs.validUTF8(key, elemStart+1); err != nil {
+			return err
+		}
+		if keys != nil {
+			if _, dup := keys[string(key)]; dup {
+				return vs.error(elemStart, ErrDuplicateKey)
+			}
+			keys[string(key)] = struct{}{}
+		}
+		if !validType(t) {
+			return vs.error(elemStart, DocumentValidationError(fmt.Sprintf("invalid type %#x", byte(t))))
+		}
+		if vs.RejectDeprecatedTypes {
+			switch t {
+			case bsontype.Undefined, bsontype.DBPointer, bsontype.Symbol, bsontype.CodeWithScope:
+				return vs.error(elemStart, ErrDeprecatedType)
+			}
+		}
+
+		n, err := vs.value(t, end-1, pos, depth)
+		if err != nil {
+			return err
+		}
+		vs.path = vs.path[:len(vs.path)-1]
+		pos += n
+	}
+	return nil
+}
+
+// value validates the value of type t at start, which must end before limit, and returns its length.
+func (vs *validatorState) value(t bsontype.Type, limit, start, depth int) (int, error) {
+	src := vs.src[start:limit]
+	tooShort := func() (int, error) {
+		return 0, vs.error(start, DocumentValidationError(fmt.Sprintf("too few bytes to read %s value", t)))
+	}
+
+	switch t {
+	case bsontype.Double, bsontype.DateTime, bsontype.Int64, bsontype.Timestamp:
+		if len(src) < 8 {
+			return tooShort()
+		}
+		return 8, nil
+	case bsontype.Int32:
+		if len(src) < 4 {
+			return tooShort()
+		}
+		return 4, nil
+	case bsontype.Decimal128:
+		if len(src) < 16 {
+			return tooShort()
+		}
+		return 16, nil
+	case bsontype.ObjectID:
+		if len(src) < 12 {
+			return tooShort()
+		}
+		return 12, nil
+	case bsontype.Null, bsontype.Undefined, bsontype.MinKey, bsontype.MaxKey:
+		return 0, nil
+	case bsontype.Boolean:
+		if len(src) < 1 {
+			return tooShort()
+		}
+		if src[0] > 1 {
+			return 0, vs.error(start, DocumentValidationError(fmt.Sprintf("invalid boolean value %#x", src[0])))
+		}
+		return 1, nil
+	case bsontype.String, bsontype.JavaScript, bsontype.Symbol:
+		return vs.string(limit, start)
+	case bsontype.DBPointer:
+		n, err := vs.string(limit, start)
+		if err != nil {
+			return 0, err
+		}
+		if len(src) < n+12 {
+			return tooShort()
+		}
+		return n + 12, nil
+	case bsontype.Regex:
+		n := 0
+		for _, name := range []string{"pattern", "options"} {
+			cstr, _, ok := readcstringbytes(src[n:])
+			if !ok {
+				return 0, vs.error(start+n, DocumentValidationError(fmt.Sprintf("regular expression %s is missing null terminator", name)))
+			}
+			if err := vs.validUTF8(cstr, start+n); err != nil {
+				return 0, err
+			}
+			n += len(cstr) + 1
+		}
+		return n, nil
+	case bsontype.Binary:
+		length, rem, ok := ReadLength(src)
+		if !ok || len(rem) < 1 || int64(length) > int64(len(rem)-1) {
+			return tooShort()
+		}
+		if rem[0] == 0x02 {
+			// The old binary subtype repeats the length of the data inside of it.
+			inner, _, ok := ReadLength(rem[1:])
+			if !ok || inner != length-4 {
+				return 0, vs.error(start, DocumentValidationError("invalid length for binary subtype 2"))
+			}
+		}
+		return 4 + 1 + int(length), nil
+	case bsontype.EmbeddedDocument, bsontype.Array:
+		if err := vs.document(limit, start, depth+1, t == bsontype.Array); err != nil {
+			return 0, err
+		}
+		length, _, _ := ReadLength(src)
+		return int(length), nil
+	case bsontype.CodeWithScope:
+		length, _, ok := ReadLength(src)
+		if !ok || length < 14 || int64(length) > int64(len(src)) {
+			return tooShort()
+		}
+		end := start + int(length)
+		n, err := vs.string(end, start+4)
+		if err != nil {
+			return 0, err
+		}
+		if err := vs.document(end, start+4+n, depth+1, false); err != nil {
+			return 0, err
+		}
+		scope, _, _ := ReadLength(vs.src[start+4+n:])
+		if 4+n+int(scope) != int(length) {
+			return 0, vs.error(start, DocumentValidationError("JavaScript code with scope length does not match contents"))
+		}
+		return int(length), nil
+	}
+	return 0, nil
+}
+
+// string validates the length prefixed string at start, which must end before limit, and returns its length.
+func (vs *validatorState) string(limit, start int) (int, error) {
+	length, rem, ok := ReadLength(vs.src[start:limit])
+	if !ok || length < 1 || int64(length) > int64(len(rem)) {
+		return 0, vs.error(start, DocumentValidationError("invalid string length"))
+	}
+	str := rem[:length-1]
+	if rem[length-1] != 0x00 {
+		return 0, vs.error(start+4+int(length)-1, DocumentValidationError("string is missing null terminator"))
+	}
+	if err := vs.validUTF8(str, start+4); err != nil {
+		return 0, err
+	}
+	return 4 + int(length), nil
+}
+
+// validUTF8 checks that b, which is at offset in the document, is valid UTF-8 if CheckUTF8 is set. The error reports the
+// offset of the first invalid byte.
+func (vs *validatorState) validUTF8(b []byte, offset int) error {
+	if !vs.CheckUTF8 || utf8.Valid(b) {
+		return nil
+	}
+	for i := 0; i < len(b); {
+		r, size := utf8.DecodeRune(b[i:])
+		if r == utf8.RuneError && size == 1 {
+			return vs.error(offset+i, ErrInvalidUTF8)
+		}
+		i += size
+	}
+	return vs.error(offset, ErrInvalidUTF8)
+}
+
+func validType(t bsontype.Type) bool {
+	switch t {
+	case bsontype.Double, bsontype.String, bsontype.EmbeddedDocument, bsontype.Array, bsontype.Binary,
+		bsontype.Undefined, bsontype.ObjectID, bsontype.Boolean, bsontype.DateTime, bsontype.Null, bsontype.Regex,
+		bsontype.DBPointer, bsontype.JavaScript, bsontype.Symbol, bsontype.CodeWithScope, bsontype.Int32,
+		bsontype.Timestamp, bsontype.Int64, bsontype.Decimal128, bsontype.MinKey, bsontype.MaxKey:
+		return true
+	}
+	return false
+}
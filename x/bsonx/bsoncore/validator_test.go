@@ -0,0 +1,143 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncore
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestValidator(t *testing.T) {
+	strict := Validator{CheckUTF8: true, CheckDuplicateKeys: true, MaxDepth: 3, RejectDeprecatedTypes: true}
+	nested := BuildDocumentFromElements(nil,
+		AppendInt32Element(nil, "x", 1),
+		BuildDocumentElement(nil, "a",
+			BuildArrayElement(nil, "b",
+				BuildDocumentValue(AppendStringElement(nil, "c", "\xffoo")),
+			),
+		),
+	)
+	// Offset of the contents of the "c" string: the lengths of the documents, the "x" element, the element headers
+	// and the length of the string.
+	cOffset := 4 + 7 + 3 + 4 + 3 + 4 + 3 + 4 + 3 + 4
+	badBool := BuildDocumentFromElements(nil, AppendBooleanElement(nil, "ok", true))
+	badBool[len(badBool)-2] = 0x02
+	badType := BuildDocumentFromElements(nil, AppendNullElement(nil, "n"))
+	badType[4] = 0x20
+	badString := BuildDocumentFromElements(nil, AppendStringElement(nil, "s", "abc"))
+	badString[len(badString)-2] = 'x'
+	oldBinary := BuildDocumentFromElements(nil, AppendBinaryElement(nil, "bin", 0x02, []byte{1, 2, 3}))
+	badOldBinary := BuildDocumentFromElements(nil, AppendBinaryElement(nil, "bin", 0x02, []byte{1, 2, 3}))
+	badOldBinary[4+5+5]++
+	scope := BuildDocumentFromElements(nil, AppendInt32Element(nil, "x", 1))
+	badScope := BuildDocumentFromElements(nil,
+		AppendCodeWithScopeElement(nil, "js", "x", scope),
+		AppendNullElement(nil, "z"),
+	)
+	badScope[4+4]++
+	oid := primitive.NewObjectID()
+
+	testCases := []struct {
+		name      string
+		validator Validator
+		doc       []byte
+		err       error
+		offset    int
+		path      []string
+	}{
+		{"valid", strict, BuildDocumentFromElements(nil,
+			AppendStringElement(nil, "s", "héllo"),
+			AppendRegexElement(nil, "re", "^a", "i"),
+			AppendBinaryElement(nil, "bin", 0x00, []byte{1, 2}),
+			BuildArrayElement(nil, "arr", Value{Type: bsontype.Boolean, Data: AppendBoolean(nil, false)}),
+		), nil, 0, nil},
+		{"old binary subtype", Validator{}, oldBinary, nil, 0, nil},
+		{"empty", Validator{}, []byte{0x05, 0x00}, ErrInvalidLength, 0, nil},
+		{"too long", Validator{}, []byte{0x06, 0x00, 0x00, 0x00, 0x00}, NewDocumentLengthError(6, 5), 0, nil},
+		{"missing null", Validator{}, []byte{0x05, 0x00, 0x00, 0x00, 0x01}, ErrMissingNull, 4, nil},
+		{
+			"value past end", Validator{},
+			[]byte{0x0A, 0x00, 0x00, 0x00, 0x10, 'a', 0x00, 0x01, 0x00, 0x00},
+			DocumentValidationError("too few bytes to read 32-bit integer value"), 7, []string{"a"},
+		},
+		{"invalid boolean", Validator{}, badBool, DocumentValidationError("invalid boolean value 0x2"), 8, []string{"ok"}},
+		{"invalid type", Validator{}, badType, DocumentValidationError("invalid type 0x20"), 4, []string{"n"}},
+		{"string missing null", Validator{}, badString, DocumentValidationError("string is missing null terminator"), 14, []string{"s"}},
+		{"old binary length", Validator{}, badOldBinary, DocumentValidationError("invalid length for binary subtype 2"), 9, []string{"bin"}},
+		{
+			"code with scope length", Validator{}, badScope,
+			DocumentValidationError("JavaScript code with scope length does not match contents"), 8, []string{"js"},
+		},
+		{"utf8 unchecked", Validator{}, nested, nil, 0, nil},
+		{"utf8 in string", Validator{CheckUTF8: true}, nested, ErrInvalidUTF8, cOffset, []string{"a", "b", "0", "c"}},
+		{
+			"utf8 in key", Validator{CheckUTF8: true},
+			BuildDocumentFromElements(nil, AppendNullElement(nil, "ok"), AppendNullElement(nil, "k\xc3")),
+			ErrInvalidUTF8, 4 + 4 + 2, []string{"k\xc3"},
+		},
+		{
+			"utf8 in regex", Validator{CheckUTF8: true},
+			BuildDocumentFromElements(nil, AppendRegexElement(nil, "re", "a", "\xff")),
+			ErrInvalidUTF8, 4 + 4 + 2, []string{"re"},
+		},
+		{
+			"duplicate key", Validator{CheckDuplicateKeys: true},
+			BuildDocumentFromElements(nil,
+				AppendNullElement(nil, "a"),
+				BuildDocumentElement(nil, "b", AppendNullElement(nil, "x"), AppendNullElement(nil, "x")),
+			),
+			ErrDuplicateKey, 4 + 3 + 3 + 4 + 3, []string{"b", "x"},
+		},
+		{
+			"duplicate keys allowed", Validator{},
+			BuildDocumentFromElements(nil, AppendNullElement(nil, "a"), AppendNullElement(nil, "a")),
+			nil, 0, nil,
+		},
+		{"max depth", Validator{MaxDepth: 2}, nested, ErrMaxDepthExceeded, 4 + 7 + 3 + 4 + 3, []string{"a", "b"}},
+		{"max depth not exceeded", Validator{MaxDepth: 4}, nested, nil, 0, nil},
+		{
+			"deprecated type", Validator{RejectDeprecatedTypes: true},
+			BuildDocumentFromElements(nil, AppendNullElement(nil, "a"), AppendDBPointerElement(nil, "p", "db.coll", oid)),
+			ErrDeprecatedType, 4 + 3, []string{"p"},
+		},
+		{
+			"deprecated types allowed", Validator{},
+			BuildDocumentFromElements(nil,
+				AppendUndefinedElement(nil, "u"),
+				AppendSymbolElement(nil, "s", "sym"),
+				AppendDBPointerElement(nil, "p", "db.coll", oid),
+				AppendCodeWithScopeElement(nil, "js", "x", scope),
+			),
+			nil, 0, nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.validator.Validate(tc.doc)
+			if tc.err == nil {
+				noerr(t, err)
+				return
+			}
+			want := ValidationError{Offset: tc.offset, Path: tc.path, Err: tc.err}
+			got, ok := err.(ValidationError)
+			if !ok || got.Offset != want.Offset || !cmp.Equal(got.Path, want.Path) || got.Err != want.Err {
+				t.Errorf("Errors do not match. got %v; want %v", err, want)
+			}
+		})
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		err := ValidationError{Offset: 12, Path: []string{"a", "0"}, Err: ErrDuplicateKey}
+		if got, want := err.Error(), "invalid BSON at offset 12 (a.0): duplicate key"; got != want {
+			t.Errorf("Messages do not match. got %q; want %q", got, want)
+		}
+	})
+}
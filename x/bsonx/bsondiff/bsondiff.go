@@ -0,0 +1,459 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package bsondiff computes the differences between two BSON documents and applies them as patches. Documents are
+// compared element by element on their raw bytes, so values are never decoded into Go types. A bson.Raw can be
+// converted to and from a bsoncore.Document directly.
+//
+// A Diff can be rendered as a MongoDB update document with $set and $unset operators, which lets a document that was
+// read, modified and written back be saved with a minimal update instead of a full replacement:
+//
+//	diff, err := bsondiff.Compute(bsoncore.Document(original), bsoncore.Document(modified))
+//	update, err := diff.Update()
+//	_, err = coll.UpdateOne(ctx, bson.D{{"_id", id}}, bson.Raw(update))
+package bsondiff
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// Op is the kind of a Change.
+type Op uint8
+
+// These constants are the valid Op values.
+const (
+	// Set adds an element or replaces its value.
+	Set Op = iota
+	// Unset removes an element.
+	Unset
+)
+
+func (op Op) String() string {
+	switch op {
+	case Set:
+		return "set"
+	case Unset:
+		return "unset"
+	default:
+		return "Op(" + strconv.Itoa(int(op)) + ")"
+	}
+}
+
+// Change is a single difference between two documents.
+type Change struct {
+	Op Op
+	// Path holds the keys leading from the top level document to the changed element. Array elements are identified
+	// by their index.
+	Path []string
+	// Value is the new value of the element. It is empty for an Unset change.
+	Value bsoncore.Value
+}
+
+// Key returns the path of the changed element in dot notation, such as "items.2.price".
+func (c Change) Key() string {
+	return strings.Join(c.Path, ".")
+}
+
+func (c Change) String() string {
+	if c.Op == Unset {
+		return "unset " + c.Key()
+	}
+	return "set " + c.Key() + " to " + c.Value.String()
+}
+
+// Diff is a list of changes that transforms one document into another. The path of a change in a Diff returned by
+// Compute is never a prefix of the path of another change.
+type Diff []Change
+
+// Compute returns the changes that transform from into to.
+//
+// Elements that only exist in to are Set and elements that only exist in from are Unset. Elements whose values
+// differ are compared as follows:
+//
+// Embedded documents are compared recursively, so a change to a nested element is reported with its full path,
+// unless one of the changed keys contains a '.', starts with a '$' or is empty. Such keys cannot be used in dot
+// notation, so the embedded document is Set as a whole instead.
+//
+// Arrays of the same length are compared element by element. An update cannot remove array elements by position,
+// so an array whose length changed is Set as a whole.
+//
+// Any other value, including a value whose BSON type changed, is Set as a whole.
+//
+// The order of the elements of a document is not compared.
+func Compute(from, to bsoncore.Document) (Diff, error) {
+	changes, _, err := diffDocuments(nil, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return Diff(changes), nil
+}
+
+// diffDocuments returns the changes between the documents from and to at path. The returned boolean reports whether
+// every changed key can be written in dot notation.
+func diffDocuments(path []string, from, to bsoncore.Document) ([]Change, bool, error) {
+	fromElems, err := from.Elements()
+	if err != nil {
+		return nil, false, err
+	}
+	toElems, err := to.Elements()
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Duplicate keys are not valid for the server, so only the first element with a key is compared.
+	fromVals := make(map[string]bsoncore.Value, len(fromElems))
+	for _, elem := range fromElems {
+		if _, ok := fromVals[elem.Key()]; !ok {
+			fromVals[elem.Key()] = elem.Value()
+		}
+	}
+	toKeys := make(map[string]bool, len(toElems))
+
+	var changes []Change
+	dottable := true
+	for _, elem := range toElems {
+		key := elem.Key()
+		if toKeys[key] {
+			continue
+		}
+		toKeys[key] = true
+
+		var elemChanges []Change
+		fromVal, ok := fromVals[key]
+		if !ok {
+			elemChanges = []Change{{Op: Set, Path: childPath(path, key), Value: elem.Value()}}
+		} else {
+			elemChanges, err = diffValues(childPath(path, key), fromVal, elem.Value())
+			if err != nil {
+				return nil, false, err
+			}
+		}
+		if len(elemChanges) > 0 {
+			changes = append(changes, elemChanges...)
+			dottable = dottable && validPathKey(key)
+		}
+	}
+	for _, elem := range fromElems {
+		key := elem.Key()
+		if toKeys[key] {
+			continue
+		}
+		// Mark the key so a duplicate in from is not unset twice.
+		toKeys[key] = true
+		changes = append(changes, Change{Op: Unset, Path: childPath(path, key)})
+		dottable = dottable && validPathKey(key)
+	}
+
+	return changes, dottable, nil
+}
+
+// diffValues returns the changes between two values at path.
+func diffValues(path []string, from, to bsoncore.Value) ([]Change, error) {
+	if from.Type == to.Type && bytes.Equal(from.Data, to.Data) {
+		return nil, nil
+	}
+	set := []Change{{Op: Set, Path: path, Value: to}}
+	if from.Type != to.Type {
+		return set, nil
+	}
+
+	switch to.Type {
+	case bsontype.EmbeddedDocument:
+		changes, dottable, err := diffDocuments(path, from.Document(), to.Document())
+		if err != nil {
+			return nil, err
+		}
+		if !dottable {
+			return set, nil
+		}
+		return changes, nil
+	case bsontype.Array:
+		fromVals, err := from.Array().Values()
+		if err != nil {
+			return nil, err
+		}
+		toVals, err := to.Array().Values()
+		if err != nil {
+			return nil, err
+		}
+		if len(fromVals) != len(toVals) {
+			return set, nil
+		}
+
+		var changes []Change
+		for i := range toVals {
+			elemChanges, err := diffValues(childPath(path, strconv.Itoa(i)), fromVals[i], toVals[i])
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, elemChanges...)
+		}
+		return changes, nil
+	}
+	return set, nil
+}
+
+// Update returns d as a MongoDB update document, with a $set operator holding the new values of the Set changes and
+// an $unset operator holding the keys of the Unset changes. Operators without changes are omitted, so the update
+// document for an empty Diff is empty; callers should skip the update in that case, because the server rejects it.
+//
+// It returns an error if the path of a change cannot be written in dot notation.
+func (d Diff) Update() (bsoncore.Document, error) {
+	var set, unset []Change
+	for _, c := range d {
+		for _, key := range c.Path {
+			if !validPathKey(key) {
+				return nil, fmt.Errorf("cannot write the path %q of a change in dot notation", c.Path)
+			}
+		}
+		switch c.Op {
+		case Set:
+			set = append(set, c)
+		case Unset:
+			unset = append(unset, c)
+		default:
+			return nil, fmt.Errorf("invalid change operation %v", c.Op)
+		}
+	}
+
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+	if len(set) > 0 {
+		var setIdx int32
+		setIdx, doc = bsoncore.AppendDocumentElementStart(doc, "$set")
+		for _, c := range set {
+			doc = bsoncore.AppendValueElement(doc, c.Key(), c.Value)
+		}
+		doc, _ = bsoncore.AppendDocumentEnd(doc, setIdx)
+	}
+	if len(unset) > 0 {
+		var unsetIdx int32
+		unsetIdx, doc = bsoncore.AppendDocumentElementStart(doc, "$unset")
+		for _, c := range unset {
+			doc = bsoncore.AppendStringElement(doc, c.Key(), "")
+		}
+		doc, _ = bsoncore.AppendDocumentEnd(doc, unsetIdx)
+	}
+	doc, _ = bsoncore.AppendDocumentEnd(doc, idx)
+	return doc, nil
+}
+
+// Apply returns a copy of doc with the changes in d applied, following the semantics of the $set and $unset update
+// operators: Setting an element of a missing embedded document creates the document, setting an array element
+// past the end of the array pads it with nulls, unsetting an array element replaces it with null, and unsetting a
+// missing element does nothing. Elements that are Set keep their position, and new elements are appended.
+//
+// Changes are applied in order, and a change whose path has the path of an earlier change as a prefix is applied to
+// the value set by that change.
+func Apply(doc bsoncore.Document, d Diff) (bsoncore.Document, error) {
+	for _, c := range d {
+		if len(c.Path) == 0 {
+			return nil, errors.New("cannot apply a change with an empty path")
+		}
+		if c.Op != Set && c.Op != Unset {
+			return nil, fmt.Errorf("invalid change operation %v", c.Op)
+		}
+	}
+
+	// Changes with overlapping paths must be applied one after another. Otherwise all of the changes are applied in a
+	// single pass over the document.
+	for start := 0; start < len(d); {
+		end := start + 1
+		for end < len(d) && !overlaps(d[start:end], d[end]) {
+			end++
+		}
+		var err error
+		doc, err = applyChanges(nil, doc, false, d[start:end], 0)
+		if err != nil {
+			return nil, err
+		}
+		start = end
+	}
+	return doc, nil
+}
+
+// applyChanges appends doc to dst with changes applied. The elements of doc are identified by the key at index depth
+// of the path of each change.
+func applyChanges(dst []byte, doc bsoncore.Document, isArray bool, changes []Change, depth int) ([]byte, error) {
+	groups := make(map[string][]Change)
+	var order []string
+	for _, c := range changes {
+		key := c.Path[depth]
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], c)
+	}
+
+	var elems []bsoncore.Element
+	if doc != nil {
+		var err error
+		elems, err = doc.Elements()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	idx, dst := bsoncore.AppendDocumentStart(dst)
+	applied := make(map[string]bool, len(groups))
+	for _, elem := range elems {
+		key := elem.Key()
+		group, ok := groups[key]
+		if !ok || applied[key] {
+			dst = bsoncore.AppendValueElement(dst, key, elem.Value())
+			continue
+		}
+		applied[key] = true
+
+		val, keep, err := applyValue(elem.Value(), true, group, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case keep:
+			dst = bsoncore.AppendValueElement(dst, key, val)
+		case isArray:
+			dst = bsoncore.AppendNullElement(dst, key)
+		}
+	}
+
+	if isArray {
+		// New array elements must be appended in order of their indexes, padding any gap with nulls.
+		next := len(elems)
+		sort.Stable(byArrayIndex(order))
+		for _, key := range order {
+			if applied[key] {
+				continue
+			}
+			i := arrayIndex(key)
+			if i < 0 {
+				return nil, fmt.Errorf("cannot apply a change to the array element %q of %q", key, changes[0].Path[:depth])
+			}
+			val, keep, err := applyValue(bsoncore.Value{}, false, groups[key], depth+1)
+			if err != nil {
+				return nil, err
+			}
+			if !keep {
+				continue
+			}
+			for ; next < i; next++ {
+				dst = bsoncore.AppendNullElement(dst, strconv.Itoa(next))
+			}
+			dst = bsoncore.AppendValueElement(dst, key, val)
+			next++
+		}
+	} else {
+		for _, key := range order {
+			if applied[key] {
+				continue
+			}
+			val, keep, err := applyValue(bsoncore.Value{}, false, groups[key], depth+1)
+			if err != nil {
+				return nil, err
+			}
+			if keep {
+				dst = bsoncore.AppendValueElement(dst, key, val)
+			}
+		}
+	}
+
+	return bsoncore.AppendDocumentEnd(dst, idx)
+}
+
+// applyValue applies changes to the value cur at depth, where exists reports whether cur is present. It returns the
+// new value and whether the element should be kept.
+func applyValue(cur bsoncore.Value, exists bool, changes []Change, depth int) (bsoncore.Value, bool, error) {
+	// Changes with overlapping paths are applied separately by Apply, so a change to the element itself is the only
+	// change in the group.
+	if c := changes[0]; len(c.Path) == depth {
+		if c.Op == Unset {
+			return bsoncore.Value{}, false, nil
+		}
+		return c.Value, true, nil
+	}
+
+	var doc bsoncore.Document
+	var isArray bool
+	switch {
+	case !exists:
+		onlyUnset := true
+		for _, c := range changes {
+			onlyUnset = onlyUnset && c.Op == Unset
+		}
+		if onlyUnset {
+			return bsoncore.Value{}, false, nil
+		}
+	case cur.Type == bsontype.EmbeddedDocument:
+		doc = cur.Document()
+	case cur.Type == bsontype.Array:
+		doc, isArray = cur.Array(), true
+	default:
+		return bsoncore.Value{}, false, fmt.Errorf("cannot apply a change to %q: the value at %q is a %v, not a document",
+			changes[0].Key(), changes[0].Path[:depth], cur.Type)
+	}
+
+	data, err := applyChanges(nil, doc, isArray, changes, depth)
+	if err != nil {
+		return bsoncore.Value{}, false, err
+	}
+	if isArray {
+		return bsoncore.Value{Type: bsontype.Array, Data: data}, true, nil
+	}
+	return bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: data}, true, nil
+}
+
+// overlaps reports whether the path of c is equal to or a prefix of the path of a change in changes, or the reverse.
+func overlaps(changes []Change, c Change) bool {
+	for _, prev := range changes {
+		n := len(prev.Path)
+		if len(c.Path) < n {
+			n = len(c.Path)
+		}
+		prefix := true
+		for i := 0; i < n && prefix; i++ {
+			prefix = prev.Path[i] == c.Path[i]
+		}
+		if prefix {
+			return true
+		}
+	}
+	return false
+}
+
+// arrayIndex returns the array index represented by key, or -1 if key is not an index.
+func arrayIndex(key string) int {
+	i, err := strconv.Atoi(key)
+	if err != nil || i < 0 || strconv.Itoa(i) != key {
+		return -1
+	}
+	return i
+}
+
+// byArrayIndex sorts array element keys by their index.
+type byArrayIndex []string
+
+func (keys byArrayIndex) Len() int           { return len(keys) }
+func (keys byArrayIndex) Less(i, j int) bool { return arrayIndex(keys[i]) < arrayIndex(keys[j]) }
+func (keys byArrayIndex) Swap(i, j int)      { keys[i], keys[j] = keys[j], keys[i] }
+
+// validPathKey reports whether key can be used as part of a path in dot notation.
+func validPathKey(key string) bool {
+	return key != "" && !strings.Contains(key, ".") && !strings.HasPrefix(key, "$")
+}
+
+func childPath(path []string, key string) []string {
+	child := make([]string, len(path)+1)
+	copy(child, path)
+	child[len(path)] = key
+	return child
+}
@@ -0,0 +1,206 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsondiff
+
+import (
+	"bytes"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func marshal(t *testing.T, val interface{}) bsoncore.Document {
+	t.Helper()
+
+	b, err := bson.Marshal(val)
+	assert.Nil(t, err, "Marshal error: %v", err)
+	return b
+}
+
+func TestCompute(t *testing.T) {
+	base := bson.D{
+		{"_id", 1},
+		{"name", "widget"},
+		{"price", bson.D{{"amount", 10}, {"currency", "USD"}}},
+		{"tags", bson.A{"a", "b", "c"}},
+		{"items", bson.A{bson.D{{"sku", "x"}, {"qty", 1}}, bson.D{{"sku", "y"}, {"qty", 2}}}},
+		{"note", "old"},
+	}
+	with := func(key string, val interface{}) bson.D {
+		d := make(bson.D, 0, len(base)+1)
+		found := false
+		for _, e := range base {
+			if e.Key == key {
+				found = true
+				if val == nil {
+					continue
+				}
+				e.Value = val
+			}
+			d = append(d, e)
+		}
+		if !found {
+			d = append(d, bson.E{Key: key, Value: val})
+		}
+		return d
+	}
+
+	testCases := []struct {
+		name   string
+		to     bson.D
+		update bson.D
+	}{
+		{"no changes", base, bson.D{}},
+		{"top level value", with("name", "gadget"), bson.D{{"$set", bson.D{{"name", "gadget"}}}}},
+		{"type change", with("_id", int64(1)), bson.D{{"$set", bson.D{{"_id", int64(1)}}}}},
+		{"added element", with("stock", 5), bson.D{{"$set", bson.D{{"stock", 5}}}}},
+		{"removed element", with("note", nil), bson.D{{"$unset", bson.D{{"note", ""}}}}},
+		{
+			"nested value",
+			with("price", bson.D{{"amount", 12}, {"currency", "USD"}}),
+			bson.D{{"$set", bson.D{{"price.amount", 12}}}},
+		},
+		{
+			"nested removal",
+			with("price", bson.D{{"amount", 10}}),
+			bson.D{{"$unset", bson.D{{"price.currency", ""}}}},
+		},
+		{
+			"array element",
+			with("tags", bson.A{"a", "z", "c"}),
+			bson.D{{"$set", bson.D{{"tags.1", "z"}}}},
+		},
+		{
+			"document in array",
+			with("items", bson.A{bson.D{{"sku", "x"}, {"qty", 1}}, bson.D{{"sku", "y"}, {"qty", 3}}}),
+			bson.D{{"$set", bson.D{{"items.1.qty", 3}}}},
+		},
+		{
+			"array length change",
+			with("tags", bson.A{"a", "b"}),
+			bson.D{{"$set", bson.D{{"tags", bson.A{"a", "b"}}}}},
+		},
+		{
+			"key that cannot be dotted",
+			with("price", bson.D{{"amount", 10}, {"currency", "USD"}, {"a.b", 1}}),
+			bson.D{{"$set", bson.D{{"price", bson.D{{"amount", 10}, {"currency", "USD"}, {"a.b", 1}}}}}},
+		},
+		{
+			"reordered elements",
+			bson.D{base[1], base[0], base[2], base[3], base[4], base[5]},
+			bson.D{},
+		},
+		{
+			"set and unset",
+			bson.D{base[0], {"name", "gadget"}, base[2], base[3], base[4]},
+			bson.D{{"$set", bson.D{{"name", "gadget"}}}, {"$unset", bson.D{{"note", ""}}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			from, to := marshal(t, base), marshal(t, tc.to)
+			diff, err := Compute(from, to)
+			assert.Nil(t, err, "Compute error: %v", err)
+
+			update, err := diff.Update()
+			assert.Nil(t, err, "Update error: %v", err)
+			want := marshal(t, tc.update)
+			if !bytes.Equal(update, want) {
+				t.Errorf("update documents do not match; expected %v, got %v", want, update)
+			}
+
+			patched, err := Apply(from, diff)
+			assert.Nil(t, err, "Apply error: %v", err)
+			again, err := Compute(patched, to)
+			assert.Nil(t, err, "Compute error: %v", err)
+			assert.Equal(t, 0, len(again), "expected patched document %v to equal %v, differences: %v", patched, to, again)
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	doc := marshal(t, bson.D{{"a", 1}, {"b", bson.D{{"c", 2}}}, {"arr", bson.A{1, 2}}})
+	set := func(val interface{}, path ...string) Change {
+		typ, data, err := bson.MarshalValue(val)
+		assert.Nil(t, err, "MarshalValue error: %v", err)
+		return Change{Op: Set, Path: path, Value: bsoncore.Value{Type: typ, Data: data}}
+	}
+	unset := func(path ...string) Change {
+		return Change{Op: Unset, Path: path}
+	}
+
+	testCases := []struct {
+		name string
+		diff Diff
+		want bson.D
+	}{
+		{
+			"set keeps position",
+			Diff{set(5, "a")},
+			bson.D{{"a", 5}, {"b", bson.D{{"c", 2}}}, {"arr", bson.A{1, 2}}},
+		},
+		{
+			"set creates documents",
+			Diff{set("x", "d", "e", "f")},
+			bson.D{{"a", 1}, {"b", bson.D{{"c", 2}}}, {"arr", bson.A{1, 2}}, {"d", bson.D{{"e", bson.D{{"f", "x"}}}}}},
+		},
+		{
+			"set pads arrays",
+			Diff{set(5, "arr", "4"), set(3, "arr", "2")},
+			bson.D{{"a", 1}, {"b", bson.D{{"c", 2}}}, {"arr", bson.A{1, 2, 3, nil, 5}}},
+		},
+		{
+			"unset array element",
+			Diff{unset("arr", "0")},
+			bson.D{{"a", 1}, {"b", bson.D{{"c", 2}}}, {"arr", bson.A{nil, 2}}},
+		},
+		{
+			"unset missing element",
+			Diff{unset("x", "y"), unset("b", "z")},
+			bson.D{{"a", 1}, {"b", bson.D{{"c", 2}}}, {"arr", bson.A{1, 2}}},
+		},
+		{
+			"overlapping changes",
+			Diff{set(bson.D{{"x", 1}}, "b"), set(2, "b", "y"), unset("b", "x")},
+			bson.D{{"a", 1}, {"b", bson.D{{"y", 2}}}, {"arr", bson.A{1, 2}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Apply(doc, tc.diff)
+			assert.Nil(t, err, "Apply error: %v", err)
+			want := marshal(t, tc.want)
+			if !bytes.Equal(got, want) {
+				t.Errorf("documents do not match; expected %v, got %v", want, got)
+			}
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		for _, diff := range []Diff{
+			{set(1, "a", "b")},
+			{set(1, "arr", "x")},
+			{unset()},
+			{Change{Op: Op(5), Path: []string{"a"}}},
+		} {
+			_, err := Apply(doc, diff)
+			assert.NotNil(t, err, "expected an error applying %v", diff)
+		}
+	})
+}
+
+func TestDiffUpdateErrors(t *testing.T) {
+	diff, err := Compute(marshal(t, bson.D{{"a.b", 1}}), marshal(t, bson.D{{"a.b", 2}}))
+	assert.Nil(t, err, "Compute error: %v", err)
+	assert.Equal(t, 1, len(diff), "expected 1 change, got %v", diff)
+	_, err = diff.Update()
+	assert.NotNil(t, err, "expected an error for a top level key containing a '.'")
+}
@@ -0,0 +1,475 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package bsongen generates MarshalBSON and UnmarshalBSON methods for struct types. The generated methods build
+// documents with the bsoncore.AppendXxxElement functions and read them with bsoncore.Document.Lookup, so encoding and
+// decoding the supported field types does not use reflection.
+//
+// Fields are named and tagged exactly as they are for the default struct codec, using
+// bsoncodec.DefaultStructTagParser. Fields of the following types are handled by the generated code: strings,
+// booleans, integers, floats, []byte, time.Time, primitive.ObjectID, primitive.Decimal128, primitive.DateTime, other
+// struct types generated in the same run, and pointers to or slices of any of these. Fields of any other type, and
+// values whose BSON type differs from the one the field is normally encoded as, fall back to bson.MarshalValue and
+// bson.RawValue.Unmarshal with the default registry.
+//
+// Unlike the struct codec, the generated UnmarshalBSON matches element keys exactly and does not fall back to a
+// lowercase comparison. Inline fields must be struct types generated in the same run; inline maps are not supported.
+package bsongen
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+)
+
+const primitivePkg = "go.mongodb.org/mongo-driver/bson/primitive"
+
+type kind int
+
+const (
+	kindOther kind = iota
+	kindString
+	kindBool
+	kindInt
+	kindUint
+	kindFloat
+	kindBytes
+	kindTime
+	kindObjectID
+	kindDecimal128
+	kindDateTime
+	kindStruct
+	kindPointer
+	kindSlice
+)
+
+// fieldType describes the Go type of a field and how the generated code handles it.
+type fieldType struct {
+	kind kind
+	expr string // Go source for the type
+	bits int    // size of integer and float types, 0 for int and uint
+	elem *fieldType
+
+	// zero is the Go expression, with %s standing for the value, that reports whether a value of this type is
+	// considered empty for omitempty. It is empty if values of this type are never omitted.
+	zero string
+	// omitErr is set when the zero value of this type cannot be determined from source.
+	omitErr bool
+	// iface is set for interface types, whose nil values are encoded as null.
+	iface bool
+
+	imports map[string]string // package name to import path of packages used in expr
+}
+
+// Field is a struct field that is encoded as a BSON element.
+type Field struct {
+	Name      string
+	Key       string
+	OmitEmpty bool
+	MinSize   bool
+	Truncate  bool
+	Inline    bool
+
+	typ *fieldType
+}
+
+// Type is a struct type for which methods are generated.
+type Type struct {
+	Name   string
+	Fields []Field
+}
+
+// Package holds the types to generate methods for along with the package they belong to.
+type Package struct {
+	Name  string
+	Types []*Type
+
+	generated map[string]*Type
+}
+
+// ParseDir parses the Go package in dir and returns a Package describing the named struct types. Test files are
+// ignored.
+func ParseDir(dir string, typeNames ...string) (*Package, error) {
+	if len(typeNames) == 0 {
+		return nil, errors.New("at least one type name is required")
+	}
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		names := make([]string, 0, len(pkgs))
+		for name := range pkgs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("expected exactly one package in %s, found %v", dir, names)
+	}
+
+	var astPkg *ast.Package
+	for _, p := range pkgs {
+		astPkg = p
+	}
+
+	p := &parsedPackage{
+		specs:   make(map[string]*ast.TypeSpec),
+		files:   make(map[string]*ast.File),
+		methods: make(map[string]map[string]bool),
+	}
+	fileNames := make([]string, 0, len(astPkg.Files))
+	for name := range astPkg.Files {
+		fileNames = append(fileNames, name)
+	}
+	sort.Strings(fileNames)
+	for _, name := range fileNames {
+		p.collect(astPkg.Files[name])
+	}
+
+	pkg := &Package{Name: astPkg.Name, generated: make(map[string]*Type)}
+	for _, name := range typeNames {
+		spec, ok := p.specs[name]
+		if !ok {
+			return nil, fmt.Errorf("type %s not found in %s", name, dir)
+		}
+		if _, ok := spec.Type.(*ast.StructType); !ok {
+			return nil, fmt.Errorf("type %s is not a struct type", name)
+		}
+		t := &Type{Name: name}
+		pkg.Types = append(pkg.Types, t)
+		pkg.generated[name] = t
+	}
+	for _, t := range pkg.Types {
+		spec := p.specs[t.Name]
+		t.Fields, err = p.fields(pkg, t.Name, spec.Type.(*ast.StructType), p.files[t.Name])
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range pkg.Types {
+		if _, err = pkg.keys(t, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return pkg, nil
+}
+
+// keys returns the element keys t is encoded with, including those of inline fields, and reports duplicates.
+func (pkg *Package) keys(t *Type, seen []string) (map[string]bool, error) {
+	for _, name := range seen {
+		if name == t.Name {
+			return nil, fmt.Errorf("type %s is inlined into itself", t.Name)
+		}
+	}
+	keys := make(map[string]bool)
+	for _, f := range t.Fields {
+		fkeys := map[string]bool{f.Key: true}
+		if f.Inline {
+			var err error
+			fkeys, err = pkg.keys(pkg.generated[f.typ.expr], append(seen, t.Name))
+			if err != nil {
+				return nil, err
+			}
+		}
+		for key := range fkeys {
+			if keys[key] {
+				return nil, fmt.Errorf("(struct %s) duplicated key %s", t.Name, key)
+			}
+			keys[key] = true
+		}
+	}
+	return keys, nil
+}
+
+type parsedPackage struct {
+	specs   map[string]*ast.TypeSpec
+	files   map[string]*ast.File       // file each type is declared in
+	methods map[string]map[string]bool // methods declared for each type, by name
+}
+
+func (p *parsedPackage) collect(file *ast.File) {
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				ts := spec.(*ast.TypeSpec)
+				p.specs[ts.Name.Name] = ts
+				p.files[ts.Name.Name] = file
+			}
+		case *ast.FuncDecl:
+			if decl.Recv == nil || len(decl.Recv.List) != 1 {
+				continue
+			}
+			// Only value receivers are part of the method set used to check for Zeroer on a value.
+			recv, ok := decl.Recv.List[0].Type.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			if p.methods[recv.Name] == nil {
+				p.methods[recv.Name] = make(map[string]bool)
+			}
+			p.methods[recv.Name][decl.Name.Name] = isZeroerMethod(decl.Type)
+		}
+	}
+}
+
+// isZeroerMethod reports whether ft is the signature of Zeroer.IsZero.
+func isZeroerMethod(ft *ast.FuncType) bool {
+	if len(ft.Params.List) != 0 || ft.Results == nil || len(ft.Results.List) != 1 {
+		return false
+	}
+	ident, ok := ft.Results.List[0].Type.(*ast.Ident)
+	return ok && ident.Name == "bool" && len(ft.Results.List[0].Names) <= 1
+}
+
+func (p *parsedPackage) fields(pkg *Package, typeName string, st *ast.StructType, file *ast.File) ([]Field, error) {
+	imports := fileImports(file)
+
+	var fields []Field
+	for _, f := range st.Fields.List {
+		names := make([]string, 0, len(f.Names))
+		for _, name := range f.Names {
+			names = append(names, name.Name)
+		}
+		if len(names) == 0 {
+			names = append(names, embeddedName(f.Type))
+		}
+
+		var tag reflect.StructTag
+		if f.Tag != nil {
+			unquoted, err := strconv.Unquote(f.Tag.Value)
+			if err != nil {
+				return nil, fmt.Errorf("(struct %s) invalid tag %s: %v", typeName, f.Tag.Value, err)
+			}
+			tag = reflect.StructTag(unquoted)
+		}
+
+		for _, name := range names {
+			if !ast.IsExported(name) {
+				continue
+			}
+			stags, err := bsoncodec.DefaultStructTagParser(reflect.StructField{Name: name, Tag: tag})
+			if err != nil {
+				return nil, err
+			}
+			if stags.Skip {
+				continue
+			}
+
+			field := Field{
+				Name:      name,
+				Key:       stags.Name,
+				OmitEmpty: stags.OmitEmpty,
+				MinSize:   stags.MinSize,
+				Truncate:  stags.Truncate,
+				Inline:    stags.Inline,
+				typ:       p.resolve(pkg, f.Type, imports),
+			}
+			if field.Inline && field.typ.kind != kindStruct {
+				return nil, fmt.Errorf("(struct %s) inline field %s must be a struct type generated in the same run",
+					typeName, name)
+			}
+			if field.OmitEmpty && field.typ.omitErr {
+				return nil, fmt.Errorf("(struct %s) omitempty is not supported for field %s of type %s",
+					typeName, name, field.typ.expr)
+			}
+			fields = append(fields, field)
+		}
+	}
+	return fields, nil
+}
+
+func embeddedName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(e.X)
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.Ident:
+		return e.Name
+	}
+	return ""
+}
+
+func fileImports(file *ast.File) map[string]string {
+	imports := make(map[string]string)
+	if file == nil {
+		return imports
+	}
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = path
+	}
+	return imports
+}
+
+// resolve determines how values of the type expressed by expr are handled.
+func (p *parsedPackage) resolve(pkg *Package, expr ast.Expr, imports map[string]string) *fieldType {
+	ft := &fieldType{expr: exprString(expr), imports: make(map[string]string)}
+
+	switch e := expr.(type) {
+	case *ast.Ident:
+		switch e.Name {
+		case "string":
+			ft.kind, ft.zero = kindString, `%s != ""`
+		case "bool":
+			ft.kind, ft.zero = kindBool, "%s"
+		case "int", "int8", "int16", "int32", "int64":
+			ft.kind, ft.zero = kindInt, "%s != 0"
+			ft.bits, _ = strconv.Atoi(strings.TrimPrefix(e.Name, "int"))
+		case "uint", "uint8", "uint16", "uint32", "uint64", "byte":
+			ft.kind, ft.zero = kindUint, "%s != 0"
+			ft.bits, _ = strconv.Atoi(strings.TrimPrefix(e.Name, "uint"))
+			if e.Name == "byte" {
+				ft.bits = 8
+			}
+		case "float32", "float64":
+			ft.kind, ft.zero = kindFloat, "%s != 0"
+			ft.bits, _ = strconv.Atoi(strings.TrimPrefix(e.Name, "float"))
+		default:
+			if _, ok := pkg.generated[e.Name]; ok {
+				ft.kind = kindStruct
+			}
+			ft.zero, ft.omitErr = p.namedZero(e.Name)
+		}
+	case *ast.SelectorExpr:
+		x, ok := e.X.(*ast.Ident)
+		if !ok {
+			ft.omitErr = true
+			break
+		}
+		path := imports[x.Name]
+		ft.imports[x.Name] = path
+		switch {
+		case path == "time" && e.Sel.Name == "Time":
+			ft.kind, ft.zero = kindTime, "!%s.IsZero()"
+		case path == primitivePkg && e.Sel.Name == "ObjectID":
+			ft.kind, ft.zero = kindObjectID, "!%s.IsZero()"
+		case path == primitivePkg && e.Sel.Name == "Decimal128":
+			ft.kind = kindDecimal128
+		case path == primitivePkg && e.Sel.Name == "DateTime":
+			ft.kind, ft.zero = kindDateTime, "%s != 0"
+		default:
+			ft.omitErr = true
+		}
+	case *ast.StarExpr:
+		ft.zero = "%s != nil"
+		elem := p.resolve(pkg, e.X, imports)
+		if elem.kind != kindOther && elem.kind != kindPointer && elem.kind != kindSlice {
+			ft.kind, ft.elem = kindPointer, elem
+		}
+		if elem.zero != "" && strings.HasPrefix(elem.zero, "!%s.") {
+			// A non-nil pointer to a Zeroer is empty if the value it points to is.
+			ft.zero = "%[1]s != nil && " + strings.Replace(elem.zero, "%s", "%[1]s", -1)
+		}
+		ft.imports = elem.imports
+	case *ast.ArrayType:
+		ft.zero = "len(%s) != 0"
+		if e.Len != nil {
+			ft.zero = ""
+			if lit, ok := e.Len.(*ast.BasicLit); ok && lit.Value == "0" {
+				ft.zero = "false"
+			}
+			break
+		}
+		elem := p.resolve(pkg, e.Elt, imports)
+		switch {
+		case elem.kind == kindUint && elem.bits == 8:
+			ft.kind = kindBytes
+		case elem.kind != kindOther && elem.kind != kindPointer && elem.kind != kindSlice && elem.kind != kindBytes:
+			ft.kind, ft.elem = kindSlice, elem
+		}
+		ft.imports = elem.imports
+	case *ast.MapType:
+		ft.zero = "len(%s) != 0"
+	case *ast.InterfaceType:
+		ft.zero, ft.iface = "%s != nil", true
+	case *ast.ChanType, *ast.FuncType:
+		ft.zero = "%s != nil"
+	default:
+		ft.omitErr = true
+	}
+	return ft
+}
+
+// namedZero returns the omitempty check for a named type declared in the package.
+func (p *parsedPackage) namedZero(name string) (string, bool) {
+	if p.methods[name]["IsZero"] {
+		return "!%s.IsZero()", false
+	}
+	spec, ok := p.specs[name]
+	if !ok {
+		return "", true
+	}
+	switch t := spec.Type.(type) {
+	case *ast.StructType:
+		return "", false
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			return `%s != ""`, false
+		case "bool":
+			return "bool(%s)", false
+		case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "byte",
+			"float32", "float64":
+			return "%s != 0", false
+		}
+	case *ast.ArrayType, *ast.MapType:
+		if t, ok := t.(*ast.ArrayType); ok && t.Len != nil {
+			return "", false
+		}
+		return "len(%s) != 0", false
+	case *ast.StarExpr, *ast.InterfaceType, *ast.ChanType, *ast.FuncType:
+		return "%s != nil", false
+	}
+	return "", true
+}
+
+func exprString(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return exprString(e.X) + "." + e.Sel.Name
+	case *ast.StarExpr:
+		return "*" + exprString(e.X)
+	case *ast.ArrayType:
+		if e.Len == nil {
+			return "[]" + exprString(e.Elt)
+		}
+		return "[" + exprString(e.Len) + "]" + exprString(e.Elt)
+	case *ast.MapType:
+		return "map[" + exprString(e.Key) + "]" + exprString(e.Value)
+	case *ast.BasicLit:
+		return e.Value
+	case *ast.InterfaceType:
+		return "interface{}"
+	}
+	return fmt.Sprintf("%T", expr)
+}
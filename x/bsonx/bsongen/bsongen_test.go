@@ -0,0 +1,85 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsongen
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	dir := filepath.Join("internal", "example")
+	pkg, err := ParseDir(dir, "Event", "Metadata", "Base")
+	if err != nil {
+		t.Fatalf("Unexpected error while parsing the example package: %v", err)
+	}
+	var b bytes.Buffer
+	err = pkg.Generate(&b)
+	if err != nil {
+		t.Fatalf("Unexpected error while generating methods: %v", err)
+	}
+	want, err := ioutil.ReadFile(filepath.Join(dir, "example_bsongen.go"))
+	if err != nil {
+		t.Fatalf("Unexpected error while reading the generated file: %v", err)
+	}
+	if !bytes.Equal(b.Bytes(), want) {
+		t.Errorf("Generated code does not match example_bsongen.go. Regenerate it with cmd/bsongen.")
+	}
+}
+
+func TestParseDirErrors(t *testing.T) {
+	testCases := []struct {
+		name  string
+		src   string
+		types []string
+		err   string
+	}{
+		{"no types", "type T struct{}", nil, "at least one type name is required"},
+		{"missing type", "type T struct{}", []string{"U"}, "type U not found"},
+		{"not a struct", "type T int", []string{"T"}, "type T is not a struct type"},
+		{
+			"duplicate key",
+			"type T struct {\nA string `bson:\"a\"`\nB string `bson:\"a\"`\n}",
+			[]string{"T"},
+			"duplicated key a",
+		},
+		{
+			"inline of non-generated type",
+			"type U struct{}\ntype T struct {\nU `bson:\",inline\"`\n}",
+			[]string{"T"},
+			"inline field U must be a struct type generated in the same run",
+		},
+		{
+			"omitempty on unknown type",
+			"import \"sync\"\ntype T struct {\nA sync.Mutex `bson:\",omitempty\"`\n}",
+			[]string{"T"},
+			"omitempty is not supported for field A",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "bsongen")
+			if err != nil {
+				t.Fatalf("Unexpected error while creating a temporary directory: %v", err)
+			}
+			defer os.RemoveAll(dir)
+			err = ioutil.WriteFile(filepath.Join(dir, "types.go"), []byte("package p\n\n"+tc.src+"\n"), 0644)
+			if err != nil {
+				t.Fatalf("Unexpected error while writing source file: %v", err)
+			}
+
+			_, err = ParseDir(dir, tc.types...)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("Expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
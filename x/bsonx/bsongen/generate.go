@@ -0,0 +1,397 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsongen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Generate writes a Go source file containing the generated methods for the package's types to w. The output is
+// formatted with gofmt.
+func (pkg *Package) Generate(w io.Writer) error {
+	g := &generator{
+		pkg:     pkg,
+		imports: map[string]string{"bsoncore": "go.mongodb.org/mongo-driver/x/bsonx/bsoncore"},
+		body:    new(bytes.Buffer),
+	}
+	for _, t := range pkg.Types {
+		g.encoder(t)
+		g.decoder(t)
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintln(&out, "// Code generated by bsongen. DO NOT EDIT.")
+	fmt.Fprintln(&out)
+	fmt.Fprintf(&out, "package %s\n\n", pkg.Name)
+	fmt.Fprintln(&out, "import (")
+	names := make([]string, 0, len(g.imports))
+	for name := range g.imports {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		// Standard library packages are listed first.
+		istd, jstd := isStdlib(g.imports[names[i]]), isStdlib(g.imports[names[j]])
+		if istd != jstd {
+			return istd
+		}
+		return g.imports[names[i]] < g.imports[names[j]]
+	})
+	for i, name := range names {
+		path := g.imports[name]
+		if i > 0 && isStdlib(g.imports[names[i-1]]) && !isStdlib(path) {
+			fmt.Fprintln(&out)
+		}
+		if path[strings.LastIndex(path, "/")+1:] == name {
+			fmt.Fprintf(&out, "\t%q\n", path)
+		} else {
+			fmt.Fprintf(&out, "\t%s %q\n", name, path)
+		}
+	}
+	fmt.Fprintln(&out, ")")
+	out.Write(g.body.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return fmt.Errorf("could not format generated code: %v", err)
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+func isStdlib(path string) bool {
+	return !strings.Contains(strings.SplitN(path, "/", 2)[0], ".")
+}
+
+type generator struct {
+	pkg     *Package
+	imports map[string]string
+	body    *bytes.Buffer
+	usesErr bool
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(g.body, format, args...)
+}
+
+func (g *generator) use(name, path string) {
+	g.imports[name] = path
+}
+
+func (g *generator) useType(ft *fieldType) {
+	for name, path := range ft.imports {
+		g.use(name, path)
+	}
+}
+
+func (g *generator) encoder(t *Type) {
+	g.printf(`
+// MarshalBSON implements the bson.Marshaler interface.
+func (v %[1]s) MarshalBSON() ([]byte, error) {
+	idx, dst := bsoncore.AppendDocumentStart(nil)
+	dst, err := v.appendBSONElements(dst)
+	if err != nil {
+		return nil, err
+	}
+	return bsoncore.AppendDocumentEnd(dst, idx)
+}
+
+// appendBSONElement appends v to dst as an embedded document element with the given key.
+func (v %[1]s) appendBSONElement(dst []byte, key string) ([]byte, error) {
+	idx, dst := bsoncore.AppendDocumentElementStart(dst, key)
+	dst, err := v.appendBSONElements(dst)
+	if err != nil {
+		return dst, err
+	}
+	return bsoncore.AppendDocumentEnd(dst, idx)
+}
+
+// appendBSONElements appends the elements of the BSON document for v to dst.
+func (v %[1]s) appendBSONElements(dst []byte) ([]byte, error) {
+`, t.Name)
+
+	// The body is generated first so the err variable is only declared when it is used.
+	outer := g.body
+	g.body = new(bytes.Buffer)
+	g.usesErr = false
+	for _, f := range t.Fields {
+		x := "v." + f.Name
+		if f.Inline {
+			g.usesErr = true
+			g.printf("if dst, err = %s.appendBSONElements(dst); err != nil {\nreturn dst, err\n}\n", x)
+			continue
+		}
+		if !f.OmitEmpty || f.typ.zero == "" {
+			g.encodeElement(strconv.Quote(f.Key), x, f.typ, f, false, false)
+			continue
+		}
+		// A value that passes the omitempty check is never nil.
+		g.printf("if %s {\n", fmt.Sprintf(f.typ.zero, x))
+		g.encodeElement(strconv.Quote(f.Key), x, f.typ, f, true, true)
+		g.printf("}\n")
+	}
+	body := g.body
+	g.body = outer
+	if g.usesErr {
+		g.printf("var err error\n")
+	}
+	g.body.Write(body.Bytes())
+	g.printf("return dst, nil\n}\n")
+}
+
+// encodeElement generates code that appends the element with key k and value x of type ft to dst. If scoped is
+// true the code is generated inside a block of its own, and if nonNil is true x is known not to be nil.
+func (g *generator) encodeElement(k, x string, ft *fieldType, f Field, scoped, nonNil bool) {
+	switch ft.kind {
+	case kindString:
+		g.printf("dst = bsoncore.AppendStringElement(dst, %s, %s)\n", k, x)
+	case kindBool:
+		g.printf("dst = bsoncore.AppendBooleanElement(dst, %s, %s)\n", k, x)
+	case kindInt:
+		switch {
+		case ft.bits > 0 && ft.bits <= 32:
+			g.printf("dst = bsoncore.AppendInt32Element(dst, %s, int32(%s))\n", k, x)
+		case ft.bits == 0 || f.MinSize:
+			g.use("math", "math")
+			g.printf("if %[2]s >= math.MinInt32 && %[2]s <= math.MaxInt32 {\n", k, x)
+			g.printf("dst = bsoncore.AppendInt32Element(dst, %s, int32(%s))\n", k, x)
+			g.printf("} else {\ndst = bsoncore.AppendInt64Element(dst, %s, int64(%s))\n}\n", k, x)
+		default:
+			g.printf("dst = bsoncore.AppendInt64Element(dst, %s, %s)\n", k, x)
+		}
+	case kindUint:
+		switch {
+		case ft.bits == 8 || ft.bits == 16:
+			g.printf("dst = bsoncore.AppendInt32Element(dst, %s, int32(%s))\n", k, x)
+		case f.MinSize:
+			g.use("math", "math")
+			g.printf("if %s <= math.MaxInt32 {\n", x)
+			g.printf("dst = bsoncore.AppendInt32Element(dst, %s, int32(%s))\n", k, x)
+			if ft.bits != 32 {
+				g.use("fmt", "fmt")
+				g.printf("} else if uint64(%[1]s) > math.MaxInt64 {\nreturn dst, fmt.Errorf(\"%%d overflows int64\", %[1]s)\n", x)
+			}
+			g.printf("} else {\ndst = bsoncore.AppendInt64Element(dst, %s, int64(%s))\n}\n", k, x)
+		default:
+			if ft.bits != 32 {
+				g.use("fmt", "fmt")
+				g.use("math", "math")
+				g.printf("if uint64(%[1]s) > math.MaxInt64 {\nreturn dst, fmt.Errorf(\"%%d overflows int64\", %[1]s)\n}\n", x)
+			}
+			g.printf("dst = bsoncore.AppendInt64Element(dst, %s, int64(%s))\n", k, x)
+		}
+	case kindFloat:
+		g.printf("dst = bsoncore.AppendDoubleElement(dst, %s, float64(%s))\n", k, x)
+	case kindBytes:
+		if !nonNil {
+			g.printf("if %s == nil {\ndst = bsoncore.AppendNullElement(dst, %s)\n} else {\n", x, k)
+		}
+		g.printf("dst = bsoncore.AppendBinaryElement(dst, %s, 0x00, %s)\n", k, x)
+		if !nonNil {
+			g.printf("}\n")
+		}
+	case kindTime:
+		g.printf("dst = bsoncore.AppendDateTimeElement(dst, %[1]s, %[2]s.Unix()*1000+int64(%[2]s.Nanosecond()/1e6))\n", k, x)
+	case kindObjectID:
+		g.printf("dst = bsoncore.AppendObjectIDElement(dst, %s, %s)\n", k, x)
+	case kindDecimal128:
+		g.printf("dst = bsoncore.AppendDecimal128Element(dst, %s, %s)\n", k, x)
+	case kindDateTime:
+		g.printf("dst = bsoncore.AppendDateTimeElement(dst, %s, int64(%s))\n", k, x)
+	case kindStruct:
+		g.usesErr = true
+		g.printf("if dst, err = %s.appendBSONElement(dst, %s); err != nil {\nreturn dst, err\n}\n", x, k)
+	case kindPointer:
+		if nonNil {
+			g.encodeElement(k, "(*"+x+")", ft.elem, f, scoped, false)
+			break
+		}
+		g.printf("if %s == nil {\ndst = bsoncore.AppendNullElement(dst, %s)\n} else {\n", x, k)
+		g.encodeElement(k, "(*"+x+")", ft.elem, f, true, false)
+		g.printf("}\n")
+	case kindSlice:
+		g.use("strconv", "strconv")
+		g.usesErr = true
+		switch {
+		case !nonNil:
+			g.printf("if %s == nil {\ndst = bsoncore.AppendNullElement(dst, %s)\n} else {\n", x, k)
+		case !scoped:
+			g.printf("{\n")
+		}
+		g.printf("var aidx int32\naidx, dst = bsoncore.AppendArrayElementStart(dst, %s)\n", k)
+		g.printf("for i, e := range %s {\n", x)
+		g.encodeElement("strconv.Itoa(i)", "e", ft.elem, f, true, false)
+		g.printf("}\nif dst, err = bsoncore.AppendArrayEnd(dst, aidx); err != nil {\nreturn dst, err\n}\n")
+		if !nonNil || !scoped {
+			g.printf("}\n")
+		}
+	default:
+		g.use("bson", "go.mongodb.org/mongo-driver/bson")
+		switch {
+		case ft.iface && !nonNil:
+			g.printf("if %s == nil {\ndst = bsoncore.AppendNullElement(dst, %s)\n} else {\n", x, k)
+		case !scoped:
+			g.printf("{\n")
+		}
+		g.printf("t, data, err := bson.MarshalValue(%s)\nif err != nil {\nreturn dst, err\n}\n", x)
+		g.printf("dst = append(bsoncore.AppendHeader(dst, t, %s), data...)\n", k)
+		if (ft.iface && !nonNil) || !scoped {
+			g.printf("}\n")
+		}
+	}
+}
+
+func (g *generator) decoder(t *Type) {
+	g.printf(`
+// UnmarshalBSON implements the bson.Unmarshaler interface.
+func (v *%[1]s) UnmarshalBSON(data []byte) error {
+	doc := bsoncore.Document(data)
+	if err := doc.Validate(); err != nil {
+		return err
+	}
+	return v.unmarshalBSONDocument(doc)
+}
+
+// unmarshalBSONDocument sets the fields of v from the elements of doc, which must be valid.
+func (v *%[1]s) unmarshalBSONDocument(doc bsoncore.Document) error {
+`, t.Name)
+	for _, f := range t.Fields {
+		x := "v." + f.Name
+		if f.Inline {
+			g.printf("if err := %s.unmarshalBSONDocument(doc); err != nil {\nreturn err\n}\n", x)
+			continue
+		}
+		g.printf("if val := doc.Lookup(%q); val.Type != 0 {\n", f.Key)
+		g.decodeValue(x, f.typ, f)
+		g.printf("}\n")
+	}
+	g.printf("return nil\n}\n")
+}
+
+// fallback generates code that decodes val into x with the default registry.
+func (g *generator) fallback(x string) {
+	g.use("bson", "go.mongodb.org/mongo-driver/bson")
+	g.printf("if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&%s); err != nil {\nreturn err\n}\n", x)
+}
+
+// decodeValue generates code that decodes the bsoncore.Value val into x of type ft.
+func (g *generator) decodeValue(x string, ft *fieldType, f Field) {
+	if ft.kind == kindOther {
+		g.fallback(x)
+		return
+	}
+
+	g.use("bsontype", "go.mongodb.org/mongo-driver/bson/bsontype")
+	g.printf("switch val.Type {\n")
+	switch ft.kind {
+	case kindString:
+		g.printf("case bsontype.String:\n%s = val.StringValue()\n", x)
+	case kindBool:
+		g.printf("case bsontype.Boolean:\n%s = val.Boolean()\n", x)
+	case kindInt, kindUint:
+		g.printf("case bsontype.Int32, bsontype.Int64:\ni64 := val.AsInt64()\n")
+		g.checkRange(x, ft)
+		if f.Truncate {
+			g.use("fmt", "fmt")
+			g.use("math", "math")
+			g.printf("case bsontype.Double:\nf64 := val.Double()\n")
+			g.printf("if f64 > float64(math.MaxInt64) {\nreturn fmt.Errorf(\"%%g overflows int64\", f64)\n}\n")
+			g.printf("i64 := int64(f64)\n")
+			g.checkRange(x, ft)
+		}
+	case kindFloat:
+		if ft.bits == 64 {
+			g.printf("case bsontype.Double:\n%s = val.Double()\n", x)
+		} else if f.Truncate {
+			g.printf("case bsontype.Double:\n%s = float32(val.Double())\n", x)
+		} else {
+			g.use("errors", "errors")
+			g.printf("case bsontype.Double:\nf64 := val.Double()\nif float64(float32(f64)) != f64 {\n")
+			g.printf("return errors.New(\"FloatDecodeValue can only convert float64 to float32 when truncation is allowed\")\n}\n")
+			g.printf("%s = float32(f64)\n", x)
+		}
+	case kindBytes:
+		g.printf("case bsontype.Binary:\nsubtype, data := val.Binary()\nif subtype != 0x00 {\n")
+		g.fallback(x)
+		g.printf("break\n}\n%s = append([]byte(nil), data...)\n", x)
+	case kindTime:
+		g.use("time", "time")
+		g.printf("case bsontype.DateTime:\ndt := val.DateTime()\n%s = time.Unix(dt/1000, dt%%1000*1000000).UTC()\n", x)
+	case kindObjectID:
+		g.printf("case bsontype.ObjectID:\n%s = val.ObjectID()\n", x)
+	case kindDecimal128:
+		g.printf("case bsontype.Decimal128:\n%s = val.Decimal128()\n", x)
+	case kindDateTime:
+		g.useType(ft)
+		g.printf("case bsontype.DateTime:\n%s = %s(val.DateTime())\n", x, ft.expr)
+	case kindStruct:
+		g.use("fmt", "fmt")
+		g.printf("case bsontype.EmbeddedDocument:\nif err := %s.unmarshalBSONDocument(val.Document()); err != nil {\nreturn err\n}\n", x)
+		g.printf("case bsontype.Null, bsontype.Undefined:\n%s = %s{}\n", x, ft.expr)
+		g.printf("default:\nreturn fmt.Errorf(\"cannot decode %%v into a %s\", val.Type)\n}\n", ft.expr)
+		return
+	case kindPointer:
+		g.useType(ft.elem)
+		g.printf("case bsontype.Null, bsontype.Undefined:\n%s = nil\ndefault:\n", x)
+		g.printf("if %s == nil {\n%s = new(%s)\n}\n", x, x, ft.elem.expr)
+		g.decodeValue("(*"+x+")", ft.elem, f)
+		g.printf("}\n")
+		return
+	case kindSlice:
+		g.useType(ft.elem)
+		g.printf("case bsontype.Array:\nvals, err := val.Array().Values()\nif err != nil {\nreturn err\n}\n")
+		g.printf("if %[1]s == nil {\n%[1]s = make([]%[2]s, 0, len(vals))\n} else {\n%[1]s = %[1]s[:0]\n}\n", x, ft.elem.expr)
+		g.printf("for _, val := range vals {\nvar e %s\n", ft.elem.expr)
+		g.decodeValue("e", ft.elem, f)
+		g.printf("%s = append(%s, e)\n}\n", x, x)
+		g.printf("case bsontype.Null:\n%s = nil\n", x)
+	}
+	g.printf("default:\n")
+	g.fallback(x)
+	g.printf("}\n")
+}
+
+// checkRange generates the overflow check for the int64 i64 being assigned to x, followed by the assignment.
+func (g *generator) checkRange(x string, ft *fieldType) {
+	name := "int"
+	if ft.kind == kindUint {
+		name = "uint"
+	}
+	if ft.bits != 0 {
+		name += strconv.Itoa(ft.bits)
+	}
+
+	var cond string
+	switch {
+	case ft.kind == kindInt && ft.bits == 0:
+		cond = "int64(int(i64)) != i64"
+	case ft.kind == kindInt && ft.bits < 64:
+		g.use("math", "math")
+		cond = fmt.Sprintf("i64 < math.Min%[1]s || i64 > math.Max%[1]s", strings.Title(name))
+	case ft.kind == kindUint && ft.bits == 0:
+		cond = "i64 < 0 || int64(uint(i64)) != i64"
+	case ft.kind == kindUint && ft.bits < 64:
+		g.use("math", "math")
+		cond = fmt.Sprintf("i64 < 0 || i64 > math.Max%s", strings.Title(name))
+	case ft.kind == kindUint:
+		cond = "i64 < 0"
+	}
+	if cond != "" {
+		g.use("fmt", "fmt")
+		g.printf("if %s {\nreturn fmt.Errorf(\"%%d overflows %s\", i64)\n}\n", cond, name)
+	}
+	if ft.expr == "int64" {
+		g.printf("%s = i64\n", x)
+		return
+	}
+	g.printf("%s = %s(i64)\n", x, ft.expr)
+}
@@ -0,0 +1,59 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package example contains types used to test the code generated by bsongen. The methods in example_bsongen.go are
+// generated for the Event, Metadata and Base types by running bsongen on this directory.
+package example
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Base holds fields inlined into Event.
+type Base struct {
+	ID      primitive.ObjectID `bson:"_id"`
+	Version uint32             `bson:"v"`
+}
+
+// Metadata is a nested document.
+type Metadata struct {
+	Source string
+	Tags   []string `bson:",omitempty"`
+	When   time.Time
+}
+
+// Status is a named type without generated methods.
+type Status string
+
+// Event is an event payload.
+type Event struct {
+	Base      `bson:",inline"`
+	Name      string
+	Small     int8
+	Count     int
+	Total     int64 `bson:",minsize"`
+	Unsigned  uint64
+	Ratio     float64
+	Narrow    float32 `bson:",truncate"`
+	Active    bool
+	Data      []byte
+	Price     primitive.Decimal128
+	Stamp     primitive.DateTime
+	Created   time.Time
+	Updated   *time.Time `bson:",omitempty"`
+	Meta      Metadata
+	History   []Metadata
+	Parent    *Metadata
+	Scores    []int32
+	Status    Status
+	Labels    map[string]string `bson:",omitempty"`
+	Extra     interface{}
+	Skipped   string `bson:"-"`
+	Renamed   string `bson:"r,omitempty"`
+	unexposed string
+}
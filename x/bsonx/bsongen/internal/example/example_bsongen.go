@@ -0,0 +1,610 @@
+// Code generated by bsongen. DO NOT EDIT.
+
+package example
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// MarshalBSON implements the bson.Marshaler interface.
+func (v Event) MarshalBSON() ([]byte, error) {
+	idx, dst := bsoncore.AppendDocumentStart(nil)
+	dst, err := v.appendBSONElements(dst)
+	if err != nil {
+		return nil, err
+	}
+	return bsoncore.AppendDocumentEnd(dst, idx)
+}
+
+// appendBSONElement appends v to dst as an embedded document element with the given key.
+func (v Event) appendBSONElement(dst []byte, key string) ([]byte, error) {
+	idx, dst := bsoncore.AppendDocumentElementStart(dst, key)
+	dst, err := v.appendBSONElements(dst)
+	if err != nil {
+		return dst, err
+	}
+	return bsoncore.AppendDocumentEnd(dst, idx)
+}
+
+// appendBSONElements appends the elements of the BSON document for v to dst.
+func (v Event) appendBSONElements(dst []byte) ([]byte, error) {
+	var err error
+	if dst, err = v.Base.appendBSONElements(dst); err != nil {
+		return dst, err
+	}
+	dst = bsoncore.AppendStringElement(dst, "name", v.Name)
+	dst = bsoncore.AppendInt32Element(dst, "small", int32(v.Small))
+	if v.Count >= math.MinInt32 && v.Count <= math.MaxInt32 {
+		dst = bsoncore.AppendInt32Element(dst, "count", int32(v.Count))
+	} else {
+		dst = bsoncore.AppendInt64Element(dst, "count", int64(v.Count))
+	}
+	if v.Total >= math.MinInt32 && v.Total <= math.MaxInt32 {
+		dst = bsoncore.AppendInt32Element(dst, "total", int32(v.Total))
+	} else {
+		dst = bsoncore.AppendInt64Element(dst, "total", int64(v.Total))
+	}
+	if uint64(v.Unsigned) > math.MaxInt64 {
+		return dst, fmt.Errorf("%d overflows int64", v.Unsigned)
+	}
+	dst = bsoncore.AppendInt64Element(dst, "unsigned", int64(v.Unsigned))
+	dst = bsoncore.AppendDoubleElement(dst, "ratio", float64(v.Ratio))
+	dst = bsoncore.AppendDoubleElement(dst, "narrow", float64(v.Narrow))
+	dst = bsoncore.AppendBooleanElement(dst, "active", v.Active)
+	if v.Data == nil {
+		dst = bsoncore.AppendNullElement(dst, "data")
+	} else {
+		dst = bsoncore.AppendBinaryElement(dst, "data", 0x00, v.Data)
+	}
+	dst = bsoncore.AppendDecimal128Element(dst, "price", v.Price)
+	dst = bsoncore.AppendDateTimeElement(dst, "stamp", int64(v.Stamp))
+	dst = bsoncore.AppendDateTimeElement(dst, "created", v.Created.Unix()*1000+int64(v.Created.Nanosecond()/1e6))
+	if v.Updated != nil && !v.Updated.IsZero() {
+		dst = bsoncore.AppendDateTimeElement(dst, "updated", (*v.Updated).Unix()*1000+int64((*v.Updated).Nanosecond()/1e6))
+	}
+	if dst, err = v.Meta.appendBSONElement(dst, "meta"); err != nil {
+		return dst, err
+	}
+	if v.History == nil {
+		dst = bsoncore.AppendNullElement(dst, "history")
+	} else {
+		var aidx int32
+		aidx, dst = bsoncore.AppendArrayElementStart(dst, "history")
+		for i, e := range v.History {
+			if dst, err = e.appendBSONElement(dst, strconv.Itoa(i)); err != nil {
+				return dst, err
+			}
+		}
+		if dst, err = bsoncore.AppendArrayEnd(dst, aidx); err != nil {
+			return dst, err
+		}
+	}
+	if v.Parent == nil {
+		dst = bsoncore.AppendNullElement(dst, "parent")
+	} else {
+		if dst, err = (*v.Parent).appendBSONElement(dst, "parent"); err != nil {
+			return dst, err
+		}
+	}
+	if v.Scores == nil {
+		dst = bsoncore.AppendNullElement(dst, "scores")
+	} else {
+		var aidx int32
+		aidx, dst = bsoncore.AppendArrayElementStart(dst, "scores")
+		for i, e := range v.Scores {
+			dst = bsoncore.AppendInt32Element(dst, strconv.Itoa(i), int32(e))
+		}
+		if dst, err = bsoncore.AppendArrayEnd(dst, aidx); err != nil {
+			return dst, err
+		}
+	}
+	{
+		t, data, err := bson.MarshalValue(v.Status)
+		if err != nil {
+			return dst, err
+		}
+		dst = append(bsoncore.AppendHeader(dst, t, "status"), data...)
+	}
+	if len(v.Labels) != 0 {
+		t, data, err := bson.MarshalValue(v.Labels)
+		if err != nil {
+			return dst, err
+		}
+		dst = append(bsoncore.AppendHeader(dst, t, "labels"), data...)
+	}
+	if v.Extra == nil {
+		dst = bsoncore.AppendNullElement(dst, "extra")
+	} else {
+		t, data, err := bson.MarshalValue(v.Extra)
+		if err != nil {
+			return dst, err
+		}
+		dst = append(bsoncore.AppendHeader(dst, t, "extra"), data...)
+	}
+	if v.Renamed != "" {
+		dst = bsoncore.AppendStringElement(dst, "r", v.Renamed)
+	}
+	return dst, nil
+}
+
+// UnmarshalBSON implements the bson.Unmarshaler interface.
+func (v *Event) UnmarshalBSON(data []byte) error {
+	doc := bsoncore.Document(data)
+	if err := doc.Validate(); err != nil {
+		return err
+	}
+	return v.unmarshalBSONDocument(doc)
+}
+
+// unmarshalBSONDocument sets the fields of v from the elements of doc, which must be valid.
+func (v *Event) unmarshalBSONDocument(doc bsoncore.Document) error {
+	if err := v.Base.unmarshalBSONDocument(doc); err != nil {
+		return err
+	}
+	if val := doc.Lookup("name"); val.Type != 0 {
+		switch val.Type {
+		case bsontype.String:
+			v.Name = val.StringValue()
+		default:
+			if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&v.Name); err != nil {
+				return err
+			}
+		}
+	}
+	if val := doc.Lookup("small"); val.Type != 0 {
+		switch val.Type {
+		case bsontype.Int32, bsontype.Int64:
+			i64 := val.AsInt64()
+			if i64 < math.MinInt8 || i64 > math.MaxInt8 {
+				return fmt.Errorf("%d overflows int8", i64)
+			}
+			v.Small = int8(i64)
+		default:
+			if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&v.Small); err != nil {
+				return err
+			}
+		}
+	}
+	if val := doc.Lookup("count"); val.Type != 0 {
+		switch val.Type {
+		case bsontype.Int32, bsontype.Int64:
+			i64 := val.AsInt64()
+			if int64(int(i64)) != i64 {
+				return fmt.Errorf("%d overflows int", i64)
+			}
+			v.Count = int(i64)
+		default:
+			if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&v.Count); err != nil {
+				return err
+			}
+		}
+	}
+	if val := doc.Lookup("total"); val.Type != 0 {
+		switch val.Type {
+		case bsontype.Int32, bsontype.Int64:
+			i64 := val.AsInt64()
+			v.Total = i64
+		default:
+			if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&v.Total); err != nil {
+				return err
+			}
+		}
+	}
+	if val := doc.Lookup("unsigned"); val.Type != 0 {
+		switch val.Type {
+		case bsontype.Int32, bsontype.Int64:
+			i64 := val.AsInt64()
+			if i64 < 0 {
+				return fmt.Errorf("%d overflows uint64", i64)
+			}
+			v.Unsigned = uint64(i64)
+		default:
+			if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&v.Unsigned); err != nil {
+				return err
+			}
+		}
+	}
+	if val := doc.Lookup("ratio"); val.Type != 0 {
+		switch val.Type {
+		case bsontype.Double:
+			v.Ratio = val.Double()
+		default:
+			if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&v.Ratio); err != nil {
+				return err
+			}
+		}
+	}
+	if val := doc.Lookup("narrow"); val.Type != 0 {
+		switch val.Type {
+		case bsontype.Double:
+			v.Narrow = float32(val.Double())
+		default:
+			if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&v.Narrow); err != nil {
+				return err
+			}
+		}
+	}
+	if val := doc.Lookup("active"); val.Type != 0 {
+		switch val.Type {
+		case bsontype.Boolean:
+			v.Active = val.Boolean()
+		default:
+			if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&v.Active); err != nil {
+				return err
+			}
+		}
+	}
+	if val := doc.Lookup("data"); val.Type != 0 {
+		switch val.Type {
+		case bsontype.Binary:
+			subtype, data := val.Binary()
+			if subtype != 0x00 {
+				if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&v.Data); err != nil {
+					return err
+				}
+				break
+			}
+			v.Data = append([]byte(nil), data...)
+		default:
+			if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&v.Data); err != nil {
+				return err
+			}
+		}
+	}
+	if val := doc.Lookup("price"); val.Type != 0 {
+		switch val.Type {
+		case bsontype.Decimal128:
+			v.Price = val.Decimal128()
+		default:
+			if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&v.Price); err != nil {
+				return err
+			}
+		}
+	}
+	if val := doc.Lookup("stamp"); val.Type != 0 {
+		switch val.Type {
+		case bsontype.DateTime:
+			v.Stamp = primitive.DateTime(val.DateTime())
+		default:
+			if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&v.Stamp); err != nil {
+				return err
+			}
+		}
+	}
+	if val := doc.Lookup("created"); val.Type != 0 {
+		switch val.Type {
+		case bsontype.DateTime:
+			dt := val.DateTime()
+			v.Created = time.Unix(dt/1000, dt%1000*1000000).UTC()
+		default:
+			if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&v.Created); err != nil {
+				return err
+			}
+		}
+	}
+	if val := doc.Lookup("updated"); val.Type != 0 {
+		switch val.Type {
+		case bsontype.Null, bsontype.Undefined:
+			v.Updated = nil
+		default:
+			if v.Updated == nil {
+				v.Updated = new(time.Time)
+			}
+			switch val.Type {
+			case bsontype.DateTime:
+				dt := val.DateTime()
+				(*v.Updated) = time.Unix(dt/1000, dt%1000*1000000).UTC()
+			default:
+				if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&(*v.Updated)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if val := doc.Lookup("meta"); val.Type != 0 {
+		switch val.Type {
+		case bsontype.EmbeddedDocument:
+			if err := v.Meta.unmarshalBSONDocument(val.Document()); err != nil {
+				return err
+			}
+		case bsontype.Null, bsontype.Undefined:
+			v.Meta = Metadata{}
+		default:
+			return fmt.Errorf("cannot decode %v into a Metadata", val.Type)
+		}
+	}
+	if val := doc.Lookup("history"); val.Type != 0 {
+		switch val.Type {
+		case bsontype.Array:
+			vals, err := val.Array().Values()
+			if err != nil {
+				return err
+			}
+			if v.History == nil {
+				v.History = make([]Metadata, 0, len(vals))
+			} else {
+				v.History = v.History[:0]
+			}
+			for _, val := range vals {
+				var e Metadata
+				switch val.Type {
+				case bsontype.EmbeddedDocument:
+					if err := e.unmarshalBSONDocument(val.Document()); err != nil {
+						return err
+					}
+				case bsontype.Null, bsontype.Undefined:
+					e = Metadata{}
+				default:
+					return fmt.Errorf("cannot decode %v into a Metadata", val.Type)
+				}
+				v.History = append(v.History, e)
+			}
+		case bsontype.Null:
+			v.History = nil
+		default:
+			if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&v.History); err != nil {
+				return err
+			}
+		}
+	}
+	if val := doc.Lookup("parent"); val.Type != 0 {
+		switch val.Type {
+		case bsontype.Null, bsontype.Undefined:
+			v.Parent = nil
+		default:
+			if v.Parent == nil {
+				v.Parent = new(Metadata)
+			}
+			switch val.Type {
+			case bsontype.EmbeddedDocument:
+				if err := (*v.Parent).unmarshalBSONDocument(val.Document()); err != nil {
+					return err
+				}
+			case bsontype.Null, bsontype.Undefined:
+				(*v.Parent) = Metadata{}
+			default:
+				return fmt.Errorf("cannot decode %v into a Metadata", val.Type)
+			}
+		}
+	}
+	if val := doc.Lookup("scores"); val.Type != 0 {
+		switch val.Type {
+		case bsontype.Array:
+			vals, err := val.Array().Values()
+			if err != nil {
+				return err
+			}
+			if v.Scores == nil {
+				v.Scores = make([]int32, 0, len(vals))
+			} else {
+				v.Scores = v.Scores[:0]
+			}
+			for _, val := range vals {
+				var e int32
+				switch val.Type {
+				case bsontype.Int32, bsontype.Int64:
+					i64 := val.AsInt64()
+					if i64 < math.MinInt32 || i64 > math.MaxInt32 {
+						return fmt.Errorf("%d overflows int32", i64)
+					}
+					e = int32(i64)
+				default:
+					if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&e); err != nil {
+						return err
+					}
+				}
+				v.Scores = append(v.Scores, e)
+			}
+		case bsontype.Null:
+			v.Scores = nil
+		default:
+			if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&v.Scores); err != nil {
+				return err
+			}
+		}
+	}
+	if val := doc.Lookup("status"); val.Type != 0 {
+		if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&v.Status); err != nil {
+			return err
+		}
+	}
+	if val := doc.Lookup("labels"); val.Type != 0 {
+		if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&v.Labels); err != nil {
+			return err
+		}
+	}
+	if val := doc.Lookup("extra"); val.Type != 0 {
+		if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&v.Extra); err != nil {
+			return err
+		}
+	}
+	if val := doc.Lookup("r"); val.Type != 0 {
+		switch val.Type {
+		case bsontype.String:
+			v.Renamed = val.StringValue()
+		default:
+			if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&v.Renamed); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalBSON implements the bson.Marshaler interface.
+func (v Metadata) MarshalBSON() ([]byte, error) {
+	idx, dst := bsoncore.AppendDocumentStart(nil)
+	dst, err := v.appendBSONElements(dst)
+	if err != nil {
+		return nil, err
+	}
+	return bsoncore.AppendDocumentEnd(dst, idx)
+}
+
+// appendBSONElement appends v to dst as an embedded document element with the given key.
+func (v Metadata) appendBSONElement(dst []byte, key string) ([]byte, error) {
+	idx, dst := bsoncore.AppendDocumentElementStart(dst, key)
+	dst, err := v.appendBSONElements(dst)
+	if err != nil {
+		return dst, err
+	}
+	return bsoncore.AppendDocumentEnd(dst, idx)
+}
+
+// appendBSONElements appends the elements of the BSON document for v to dst.
+func (v Metadata) appendBSONElements(dst []byte) ([]byte, error) {
+	var err error
+	dst = bsoncore.AppendStringElement(dst, "source", v.Source)
+	if len(v.Tags) != 0 {
+		var aidx int32
+		aidx, dst = bsoncore.AppendArrayElementStart(dst, "tags")
+		for i, e := range v.Tags {
+			dst = bsoncore.AppendStringElement(dst, strconv.Itoa(i), e)
+		}
+		if dst, err = bsoncore.AppendArrayEnd(dst, aidx); err != nil {
+			return dst, err
+		}
+	}
+	dst = bsoncore.AppendDateTimeElement(dst, "when", v.When.Unix()*1000+int64(v.When.Nanosecond()/1e6))
+	return dst, nil
+}
+
+// UnmarshalBSON implements the bson.Unmarshaler interface.
+func (v *Metadata) UnmarshalBSON(data []byte) error {
+	doc := bsoncore.Document(data)
+	if err := doc.Validate(); err != nil {
+		return err
+	}
+	return v.unmarshalBSONDocument(doc)
+}
+
+// unmarshalBSONDocument sets the fields of v from the elements of doc, which must be valid.
+func (v *Metadata) unmarshalBSONDocument(doc bsoncore.Document) error {
+	if val := doc.Lookup("source"); val.Type != 0 {
+		switch val.Type {
+		case bsontype.String:
+			v.Source = val.StringValue()
+		default:
+			if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&v.Source); err != nil {
+				return err
+			}
+		}
+	}
+	if val := doc.Lookup("tags"); val.Type != 0 {
+		switch val.Type {
+		case bsontype.Array:
+			vals, err := val.Array().Values()
+			if err != nil {
+				return err
+			}
+			if v.Tags == nil {
+				v.Tags = make([]string, 0, len(vals))
+			} else {
+				v.Tags = v.Tags[:0]
+			}
+			for _, val := range vals {
+				var e string
+				switch val.Type {
+				case bsontype.String:
+					e = val.StringValue()
+				default:
+					if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&e); err != nil {
+						return err
+					}
+				}
+				v.Tags = append(v.Tags, e)
+			}
+		case bsontype.Null:
+			v.Tags = nil
+		default:
+			if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&v.Tags); err != nil {
+				return err
+			}
+		}
+	}
+	if val := doc.Lookup("when"); val.Type != 0 {
+		switch val.Type {
+		case bsontype.DateTime:
+			dt := val.DateTime()
+			v.When = time.Unix(dt/1000, dt%1000*1000000).UTC()
+		default:
+			if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&v.When); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalBSON implements the bson.Marshaler interface.
+func (v Base) MarshalBSON() ([]byte, error) {
+	idx, dst := bsoncore.AppendDocumentStart(nil)
+	dst, err := v.appendBSONElements(dst)
+	if err != nil {
+		return nil, err
+	}
+	return bsoncore.AppendDocumentEnd(dst, idx)
+}
+
+// appendBSONElement appends v to dst as an embedded document element with the given key.
+func (v Base) appendBSONElement(dst []byte, key string) ([]byte, error) {
+	idx, dst := bsoncore.AppendDocumentElementStart(dst, key)
+	dst, err := v.appendBSONElements(dst)
+	if err != nil {
+		return dst, err
+	}
+	return bsoncore.AppendDocumentEnd(dst, idx)
+}
+
+// appendBSONElements appends the elements of the BSON document for v to dst.
+func (v Base) appendBSONElements(dst []byte) ([]byte, error) {
+	dst = bsoncore.AppendObjectIDElement(dst, "_id", v.ID)
+	dst = bsoncore.AppendInt64Element(dst, "v", int64(v.Version))
+	return dst, nil
+}
+
+// UnmarshalBSON implements the bson.Unmarshaler interface.
+func (v *Base) UnmarshalBSON(data []byte) error {
+	doc := bsoncore.Document(data)
+	if err := doc.Validate(); err != nil {
+		return err
+	}
+	return v.unmarshalBSONDocument(doc)
+}
+
+// unmarshalBSONDocument sets the fields of v from the elements of doc, which must be valid.
+func (v *Base) unmarshalBSONDocument(doc bsoncore.Document) error {
+	if val := doc.Lookup("_id"); val.Type != 0 {
+		switch val.Type {
+		case bsontype.ObjectID:
+			v.ID = val.ObjectID()
+		default:
+			if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&v.ID); err != nil {
+				return err
+			}
+		}
+	}
+	if val := doc.Lookup("v"); val.Type != 0 {
+		switch val.Type {
+		case bsontype.Int32, bsontype.Int64:
+			i64 := val.AsInt64()
+			if i64 < 0 || i64 > math.MaxUint32 {
+				return fmt.Errorf("%d overflows uint32", i64)
+			}
+			v.Version = uint32(i64)
+		default:
+			if err := (bson.RawValue{Type: val.Type, Value: val.Data}).Unmarshal(&v.Version); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package example
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// reflectMarshal encodes v with a StructCodec so that the generated MarshalBSON method of v is not used.
+func reflectMarshal(t *testing.T, v interface{}) []byte {
+	t.Helper()
+
+	var sw bsonrw.SliceWriter
+	vw, err := bsonrw.NewBSONValueWriter(&sw)
+	if err != nil {
+		t.Fatalf("NewBSONValueWriter error: %v", err)
+	}
+	err = structCodec.EncodeValue(bsoncodec.EncodeContext{Registry: bson.DefaultRegistry}, vw, reflect.ValueOf(v))
+	if err != nil {
+		t.Fatalf("EncodeValue error: %v", err)
+	}
+	return sw
+}
+
+// reflectUnmarshal decodes doc into v with a StructCodec so that the generated UnmarshalBSON method of v is not used.
+func reflectUnmarshal(t *testing.T, doc []byte, v interface{}) {
+	t.Helper()
+
+	dc := bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}
+	err := structCodec.DecodeValue(dc, bsonrw.NewBSONDocumentReader(doc), reflect.ValueOf(v).Elem())
+	if err != nil {
+		t.Fatalf("DecodeValue error: %v", err)
+	}
+}
+
+var structCodec, _ = bsoncodec.NewStructCodec(bsoncodec.DefaultStructTagParser)
+
+func newEvent() Event {
+	updated := time.Date(2020, 1, 2, 3, 4, 5, 6000000, time.UTC)
+	return Event{
+		Base:     Base{ID: primitive.NewObjectID(), Version: 7},
+		Name:     "event",
+		Small:    -8,
+		Count:    math.MaxInt32 + 1,
+		Total:    42,
+		Unsigned: math.MaxInt64,
+		Ratio:    1.5,
+		Narrow:   0.25,
+		Active:   true,
+		Data:     []byte{0x01, 0x02},
+		Price:    primitive.NewDecimal128(1, 2),
+		Stamp:    primitive.DateTime(1234),
+		Created:  time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC),
+		Updated:  &updated,
+		Meta:     Metadata{Source: "test", Tags: []string{"a", "b"}, When: updated},
+		History:  []Metadata{{Source: "first"}, {Source: "second", Tags: []string{"c"}}},
+		Scores:   []int32{1, 2, 3},
+		Status:   "active",
+		Labels:   map[string]string{"k": "v"},
+		Extra:    "extra",
+		Skipped:  "skipped",
+		Renamed:  "renamed",
+	}
+}
+
+func TestMarshalBSON(t *testing.T) {
+	testCases := []struct {
+		name string
+		v    interface{}
+	}{
+		{"zero", Event{}},
+		{"full", newEvent()},
+		{"metadata", Metadata{Source: "test", Tags: []string{"a"}, When: time.Unix(1, 0).UTC()}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := bson.Marshal(tc.v)
+			if err != nil {
+				t.Fatalf("Marshal error: %v", err)
+			}
+			want := reflectMarshal(t, tc.v)
+			if !bytes.Equal(got, want) {
+				t.Errorf("Documents do not match. got %v; want %v", bson.Raw(got), bson.Raw(want))
+			}
+		})
+	}
+	t.Run("uint64 overflow", func(t *testing.T) {
+		_, err := bson.Marshal(Event{Unsigned: math.MaxUint64})
+		if err == nil {
+			t.Errorf("Expected an error for an overflowing uint64, got nil")
+		}
+	})
+}
+
+func TestUnmarshalBSON(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		ev := newEvent()
+		doc, err := bson.Marshal(ev)
+		if err != nil {
+			t.Fatalf("Marshal error: %v", err)
+		}
+
+		var got Event
+		err = bson.Unmarshal(doc, &got)
+		if err != nil {
+			t.Fatalf("Unmarshal error: %v", err)
+		}
+		var want Event
+		reflectUnmarshal(t, doc, &want)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Events do not match. got %+v; want %+v", got, want)
+		}
+		ev.Skipped = ""
+		if !reflect.DeepEqual(got, ev) {
+			t.Errorf("Events do not match. got %+v; want %+v", got, ev)
+		}
+	})
+	t.Run("fallback conversions", func(t *testing.T) {
+		doc := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendDoubleElement(nil, "count", 3),
+			bsoncore.AppendNullElement(nil, "parent"),
+			bsoncore.AppendInt32Element(nil, "ratio", 2),
+		)
+		got := Event{Parent: &Metadata{}}
+		err := bson.Unmarshal(doc, &got)
+		if err != nil {
+			t.Fatalf("Unmarshal error: %v", err)
+		}
+		want := Event{Count: 3, Ratio: 2}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Events do not match. got %+v; want %+v", got, want)
+		}
+	})
+	t.Run("null struct", func(t *testing.T) {
+		doc := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendNullElement(nil, "meta"),
+			bsoncore.AppendArrayElement(nil, "history", bsoncore.BuildArray(nil, bsoncore.Value{Type: bsontype.Null})),
+		)
+		got := Event{Meta: Metadata{Source: "old"}}
+		err := bson.Unmarshal(doc, &got)
+		if err != nil {
+			t.Fatalf("Unmarshal error: %v", err)
+		}
+		want := Event{History: []Metadata{{}}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Events do not match. got %+v; want %+v", got, want)
+		}
+	})
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			doc  bsoncore.Document
+		}{
+			{"invalid document", bsoncore.Document{0x05, 0x00}},
+			{"overflow", bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendInt32Element(nil, "small", 300))},
+			{"wrong type", bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendStringElement(nil, "meta", "x"))},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				var got Event
+				if err := got.UnmarshalBSON(tc.doc); err == nil {
+					t.Errorf("Expected an error, got nil")
+				}
+			})
+		}
+	})
+}
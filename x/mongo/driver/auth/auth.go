@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/address"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/description"
@@ -29,6 +30,7 @@ func init() {
 	RegisterAuthenticatorFactory(PLAIN, newPlainAuthenticator)
 	RegisterAuthenticatorFactory(GSSAPI, newGSSAPIAuthenticator)
 	RegisterAuthenticatorFactory(MongoDBX509, newMongoDBX509Authenticator)
+	RegisterAuthenticatorFactory(MongoDBAWS, newMongoDBAWSAuthenticator)
 }
 
 // CreateAuthenticator creates an authenticator.
@@ -53,12 +55,19 @@ type HandshakeOptions struct {
 	Authenticator         Authenticator
 	Compressors           []string
 	DBUser                string
+	LoadBalanced          bool
 	PerformAuthentication func(description.Server) bool
+	ServerAPI             *driver.ServerAPIOptions
 }
 
+// authHandshaker is created for each connection, so it can hold the state of a speculative authentication
+// conversation between GetDescription and FinishHandshake.
 type authHandshaker struct {
 	wrapped driver.Handshaker
 	options *HandshakeOptions
+
+	conversation        SpeculativeConversation
+	speculativeResponse bsoncore.Document
 }
 
 // GetDescription performs an isMaster to retrieve the initial description for conn.
@@ -67,14 +76,34 @@ func (ah *authHandshaker) GetDescription(ctx context.Context, addr address.Addre
 		return ah.wrapped.GetDescription(ctx, addr, conn)
 	}
 
-	desc, err := operation.NewIsMaster().
+	op := operation.NewIsMaster().
 		AppName(ah.options.AppName).
 		Compressors(ah.options.Compressors).
 		SASLSupportedMechs(ah.options.DBUser).
-		GetDescription(ctx, addr, conn)
+		LoadBalanced(ah.options.LoadBalanced).
+		ServerAPI(ah.options.ServerAPI)
+
+	if speculativeAuth, ok := ah.options.Authenticator.(SpeculativeAuthenticator); ok {
+		var err error
+		ah.conversation, err = speculativeAuth.CreateSpeculativeConversation()
+		if err != nil {
+			return description.Server{}, newAuthError("failed to create conversation", err)
+		}
+
+		firstMsg, err := ah.conversation.FirstMessage()
+		if err != nil {
+			return description.Server{}, newAuthError("failed to create speculative authentication message", err)
+		}
+
+		op = op.SpeculativeAuthenticate(firstMsg)
+	}
+
+	desc, err := op.GetDescription(ctx, addr, conn)
 	if err != nil {
 		return description.Server{}, newAuthError("handshake failure", err)
 	}
+
+	ah.speculativeResponse = op.SpeculativeAuthenticateResponse()
 	return desc, nil
 }
 
@@ -89,8 +118,13 @@ func (ah *authHandshaker) FinishHandshake(ctx context.Context, conn driver.Conne
 	}
 	desc := conn.Description()
 	if performAuth(desc) && ah.options.Authenticator != nil {
-		err := ah.options.Authenticator.Auth(ctx, desc, conn)
-		if err != nil {
+		// If the server replied to the speculative authentication attempt, continue that conversation. Otherwise,
+		// fall back to authenticating from scratch.
+		if ah.conversation != nil && ah.speculativeResponse != nil {
+			if err := ah.conversation.Finish(ctx, conn, ah.speculativeResponse); err != nil {
+				return newAuthError("speculative auth error", err)
+			}
+		} else if err := ah.options.Authenticator.Auth(ctx, desc, conn); err != nil {
 			return newAuthError("auth error", err)
 		}
 	}
@@ -115,6 +149,20 @@ type Authenticator interface {
 	Auth(context.Context, description.Server, driver.Connection) error
 }
 
+// SpeculativeAuthenticator is an Authenticator that can embed the first step of its conversation in the
+// connection handshake, saving the round trips needed to start it separately.
+type SpeculativeAuthenticator interface {
+	CreateSpeculativeConversation() (SpeculativeConversation, error)
+}
+
+// SpeculativeConversation is an authentication conversation that is started during the connection handshake.
+type SpeculativeConversation interface {
+	// FirstMessage returns the command to send in the speculativeAuthenticate field of the handshake.
+	FirstMessage() (bsoncore.Document, error)
+	// Finish completes the conversation using the server's reply to the first message.
+	Finish(ctx context.Context, conn driver.Connection, firstResponse bsoncore.Document) error
+}
+
 func newAuthError(msg string, inner error) error {
 	return &Error{
 		message: msg,
@@ -8,6 +8,7 @@ package auth
 
 import (
 	"context"
+	"sync"
 
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/description"
@@ -23,6 +24,44 @@ func newDefaultAuthenticator(cred *Cred) (Authenticator, error) {
 // on the server version.
 type DefaultAuthenticator struct {
 	Cred *Cred
+
+	// The SCRAM authenticators are kept so that connections share their cached keys.
+	mu          sync.Mutex
+	scramSHA1   Authenticator
+	scramSHA256 Authenticator
+}
+
+// scramAuthenticator returns the SCRAM authenticator for mechanism, creating it the first time it is needed.
+func (a *DefaultAuthenticator) scramAuthenticator(mechanism string) (Authenticator, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var err error
+	switch mechanism {
+	case SCRAMSHA256:
+		if a.scramSHA256 == nil {
+			a.scramSHA256, err = newScramSHA256Authenticator(a.Cred)
+		}
+		return a.scramSHA256, err
+	default:
+		if a.scramSHA1 == nil {
+			a.scramSHA1, err = newScramSHA1Authenticator(a.Cred)
+		}
+		return a.scramSHA1, err
+	}
+}
+
+var _ SpeculativeAuthenticator = (*DefaultAuthenticator)(nil)
+
+// CreateSpeculativeConversation creates a speculative conversation for SCRAM-SHA-256 authentication. The mechanism
+// the server supports isn't known until it replies to the handshake, so SCRAM-SHA-256 is tried speculatively and Auth
+// negotiates the mechanism as usual if the server does not accept it.
+func (a *DefaultAuthenticator) CreateSpeculativeConversation() (SpeculativeConversation, error) {
+	scramAuth, err := a.scramAuthenticator(SCRAMSHA256)
+	if err != nil {
+		return nil, newAuthError("failed to create internal authenticator", err)
+	}
+	return scramAuth.(SpeculativeAuthenticator).CreateSpeculativeConversation()
 }
 
 // Auth authenticates the connection.
@@ -32,9 +71,9 @@ func (a *DefaultAuthenticator) Auth(ctx context.Context, desc description.Server
 
 	switch chooseAuthMechanism(desc) {
 	case SCRAMSHA256:
-		actual, err = newScramSHA256Authenticator(a.Cred)
+		actual, err = a.scramAuthenticator(SCRAMSHA256)
 	case SCRAMSHA1:
-		actual, err = newScramSHA1Authenticator(a.Cred)
+		actual, err = a.scramAuthenticator(SCRAMSHA1)
 	default:
 		actual, err = newMongoDBCRAuthenticator(a.Cred)
 	}
@@ -0,0 +1,363 @@
+// Copyright (C) MongoDB, Inc. 2020-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/description"
+)
+
+// MongoDBAWS is the mechanism name for MongoDBAWS.
+const MongoDBAWS = "MONGODB-AWS"
+
+// AWSSessionToken is the name of the auth mechanism property that holds an AWS session token.
+const AWSSessionToken = "AWS_SESSION_TOKEN"
+
+const (
+	defaultAWSECSEndpoint = "http://169.254.170.2"
+	defaultAWSEC2Endpoint = "http://169.254.169.254"
+	defaultAWSSTSHost     = "sts.amazonaws.com"
+
+	awsClientNonceLength = 32
+	awsServerNonceLength = 64
+	awsGS2CBFlag         = 'n'
+	awsRequestBody       = "Action=GetCallerIdentity&Version=2011-06-15"
+	awsAmzDateFormat     = "20060102T150405Z"
+	awsShortDateFormat   = "20060102"
+	awsSigningAlgorithm  = "AWS4-HMAC-SHA256"
+	awsMetadataTimeout   = 10 * time.Second
+)
+
+func newMongoDBAWSAuthenticator(cred *Cred) (Authenticator, error) {
+	if cred.Source != "" && cred.Source != "$external" {
+		return nil, newAuthError("MONGODB-AWS source must be empty or $external", nil)
+	}
+	return &MongoDBAWSAuthenticator{
+		Source:       cred.Source,
+		Username:     cred.Username,
+		Password:     cred.Password,
+		SessionToken: cred.Props[AWSSessionToken],
+	}, nil
+}
+
+// MongoDBAWSAuthenticator uses AWS IAM credentials over SASL to authenticate a connection. The credentials are
+// taken from the authenticator's fields if Username is set. Otherwise they are read from the AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY, and AWS_SESSION_TOKEN environment variables and, if those are not set, from the ECS or
+// EC2 instance metadata endpoints.
+type MongoDBAWSAuthenticator struct {
+	Source       string
+	Username     string
+	Password     string
+	SessionToken string
+
+	// ECSEndpoint is the base URL of the ECS container credentials endpoint. If empty, "http://169.254.170.2" is used.
+	ECSEndpoint string
+	// EC2Endpoint is the base URL of the EC2 instance metadata endpoint. If empty, "http://169.254.169.254" is used.
+	EC2Endpoint string
+	// HTTPClient is the client used to contact the metadata endpoints. If nil, a client with a 10 second timeout is
+	// used.
+	HTTPClient *http.Client
+}
+
+// Auth authenticates the connection.
+func (a *MongoDBAWSAuthenticator) Auth(ctx context.Context, _ description.Server, conn driver.Connection) error {
+	creds, err := a.credentials(ctx)
+	if err != nil {
+		return newAuthError("error retrieving AWS credentials", err)
+	}
+
+	adapter := &awsSaslAdapter{creds: creds}
+	err = ConductSaslConversation(ctx, conn, a.Source, adapter)
+	if err != nil {
+		return newAuthError("sasl conversation error", err)
+	}
+	return nil
+}
+
+type awsCredentials struct {
+	AccessKeyID     string `json:"AccessKeyId"`
+	SecretAccessKey string `json:"SecretAccessKey"`
+	Token           string `json:"Token"`
+}
+
+func (a *MongoDBAWSAuthenticator) credentials(ctx context.Context) (awsCredentials, error) {
+	// Credentials from the URI or client options.
+	if a.Username != "" || a.Password != "" {
+		if a.Username == "" || a.Password == "" {
+			return awsCredentials{}, errors.New("username and password must both be specified")
+		}
+		return awsCredentials{AccessKeyID: a.Username, SecretAccessKey: a.Password, Token: a.SessionToken}, nil
+	}
+	if a.SessionToken != "" {
+		return awsCredentials{}, errors.New("AWS_SESSION_TOKEN requires a username and password")
+	}
+
+	// Credentials from the environment.
+	accessKeyID := os.Getenv("AWS_ACCESS_KEY_ID")
+	secretAccessKey := os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if accessKeyID != "" || secretAccessKey != "" {
+		if accessKeyID == "" || secretAccessKey == "" {
+			return awsCredentials{}, errors.New("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must both be set")
+		}
+		return awsCredentials{
+			AccessKeyID:     accessKeyID,
+			SecretAccessKey: secretAccessKey,
+			Token:           os.Getenv("AWS_SESSION_TOKEN"),
+		}, nil
+	}
+
+	// Credentials from the ECS container endpoint.
+	if relativeURI := os.Getenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI"); relativeURI != "" {
+		endpoint := a.ECSEndpoint
+		if endpoint == "" {
+			endpoint = defaultAWSECSEndpoint
+		}
+		return a.metadataCredentials(ctx, http.MethodGet, endpoint+relativeURI, nil)
+	}
+
+	// Credentials from the EC2 instance metadata endpoint.
+	return a.ec2Credentials(ctx)
+}
+
+func (a *MongoDBAWSAuthenticator) ec2Credentials(ctx context.Context) (awsCredentials, error) {
+	endpoint := a.EC2Endpoint
+	if endpoint == "" {
+		endpoint = defaultAWSEC2Endpoint
+	}
+
+	token, err := a.metadataRequest(ctx, http.MethodPut, endpoint+"/latest/api/token",
+		map[string]string{"X-aws-ec2-metadata-token-ttl-seconds": "30"})
+	if err != nil {
+		return awsCredentials{}, err
+	}
+	tokenHeader := map[string]string{"X-aws-ec2-metadata-token": string(token)}
+
+	rolePath := endpoint + "/latest/meta-data/iam/security-credentials/"
+	role, err := a.metadataRequest(ctx, http.MethodGet, rolePath, tokenHeader)
+	if err != nil {
+		return awsCredentials{}, err
+	}
+	roleName := strings.TrimSpace(string(role))
+	if roleName == "" {
+		return awsCredentials{}, errors.New("EC2 instance has no IAM role")
+	}
+
+	return a.metadataCredentials(ctx, http.MethodGet, rolePath+roleName, tokenHeader)
+}
+
+func (a *MongoDBAWSAuthenticator) metadataCredentials(ctx context.Context, method, url string, headers map[string]string) (awsCredentials, error) {
+	body, err := a.metadataRequest(ctx, method, url, headers)
+	if err != nil {
+		return awsCredentials{}, err
+	}
+
+	var creds awsCredentials
+	if err = json.Unmarshal(body, &creds); err != nil {
+		return awsCredentials{}, fmt.Errorf("invalid credentials response from %s: %v", url, err)
+	}
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return awsCredentials{}, fmt.Errorf("incomplete credentials response from %s", url)
+	}
+	return creds, nil
+}
+
+func (a *MongoDBAWSAuthenticator) metadataRequest(ctx context.Context, method, url string, headers map[string]string) ([]byte, error) {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	client := a.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: awsMetadataTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s returned status %d", method, url, resp.StatusCode)
+	}
+	return body, nil
+}
+
+// awsSaslAdapter implements the client side of the MONGODB-AWS conversation. The client sends a nonce, the server
+// answers with its own nonce and the STS host to use, and the client replies with the headers of a signed STS
+// GetCallerIdentity request, which the server forwards to STS to verify the caller's identity.
+type awsSaslAdapter struct {
+	creds       awsCredentials
+	clientNonce []byte
+	done        bool
+}
+
+func (a *awsSaslAdapter) Start() (string, []byte, error) {
+	a.clientNonce = make([]byte, awsClientNonceLength)
+	if _, err := rand.Read(a.clientNonce); err != nil {
+		return MongoDBAWS, nil, err
+	}
+
+	payload := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendBinaryElement(nil, "r", 0x00, a.clientNonce),
+		bsoncore.AppendInt32Element(nil, "p", awsGS2CBFlag),
+	)
+	return MongoDBAWS, payload, nil
+}
+
+func (a *awsSaslAdapter) Next(challenge []byte) ([]byte, error) {
+	if a.done {
+		return nil, errors.New("unexpected server challenge")
+	}
+
+	var serverFirst struct {
+		Nonce []byte `bson:"s"`
+		Host  string `bson:"h"`
+	}
+	if err := bson.Unmarshal(challenge, &serverFirst); err != nil {
+		return nil, err
+	}
+	if len(serverFirst.Nonce) != awsServerNonceLength {
+		return nil, fmt.Errorf("server nonce must be %d bytes, got %d", awsServerNonceLength, len(serverFirst.Nonce))
+	}
+	if !bytes.Equal(serverFirst.Nonce[:awsClientNonceLength], a.clientNonce) {
+		return nil, errors.New("server nonce did not extend client nonce")
+	}
+	region, err := awsRegionFromHost(serverFirst.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	authz, amzDate := signGetCallerIdentity(a.creds, serverFirst.Host, region, serverFirst.Nonce, time.Now().UTC())
+
+	elems := [][]byte{
+		bsoncore.AppendStringElement(nil, "a", authz),
+		bsoncore.AppendStringElement(nil, "d", amzDate),
+	}
+	if a.creds.Token != "" {
+		elems = append(elems, bsoncore.AppendStringElement(nil, "t", a.creds.Token))
+	}
+	a.done = true
+	return bsoncore.BuildDocumentFromElements(nil, elems...), nil
+}
+
+func (a *awsSaslAdapter) Completed() bool {
+	return a.done
+}
+
+// awsRegionFromHost validates the STS host sent by the server and derives the signing region from it.
+func awsRegionFromHost(host string) (string, error) {
+	if len(host) == 0 || len(host) > 255 {
+		return "", fmt.Errorf("invalid STS host %q", host)
+	}
+	labels := strings.Split(host, ".")
+	for _, label := range labels {
+		if label == "" {
+			return "", fmt.Errorf("invalid STS host %q", host)
+		}
+	}
+	if host == defaultAWSSTSHost || len(labels) == 1 {
+		return "us-east-1", nil
+	}
+	return labels[1], nil
+}
+
+// signGetCallerIdentity signs an STS GetCallerIdentity request using AWS Signature Version 4 and returns the
+// Authorization header and the X-Amz-Date header used in the signature.
+func signGetCallerIdentity(creds awsCredentials, host, region string, serverNonce []byte, now time.Time) (string, string) {
+	amzDate := now.Format(awsAmzDateFormat)
+	shortDate := now.Format(awsShortDateFormat)
+
+	headers := map[string]string{
+		"content-length":         fmt.Sprintf("%d", len(awsRequestBody)),
+		"content-type":           "application/x-www-form-urlencoded",
+		"host":                   host,
+		"x-amz-date":             amzDate,
+		"x-mongodb-gs2-cb-flag":  string(awsGS2CBFlag),
+		"x-mongodb-server-nonce": base64.StdEncoding.EncodeToString(serverNonce),
+	}
+	if creds.Token != "" {
+		headers["x-amz-security-token"] = creds.Token
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders bytes.Buffer
+	for _, name := range names {
+		canonicalHeaders.WriteString(name)
+		canonicalHeaders.WriteByte(':')
+		canonicalHeaders.WriteString(strings.TrimSpace(headers[name]))
+		canonicalHeaders.WriteByte('\n')
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	bodyHash := sha256.Sum256([]byte(awsRequestBody))
+	canonicalRequest := strings.Join([]string{
+		http.MethodPost,
+		"/",
+		"",
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+
+	scope := strings.Join([]string{shortDate, region, "sts", "aws4_request"}, "/")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		awsSigningAlgorithm,
+		amzDate,
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := awsHMAC([]byte("AWS4"+creds.SecretAccessKey), shortDate)
+	key = awsHMAC(key, region)
+	key = awsHMAC(key, "sts")
+	key = awsHMAC(key, "aws4_request")
+	signature := hex.EncodeToString(awsHMAC(key, stringToSign))
+
+	authz := fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		awsSigningAlgorithm, creds.AccessKeyID, scope, signedHeaders, signature)
+	return authz, amzDate
+}
+
+func awsHMAC(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,59 @@
+// Copyright (C) MongoDB, Inc. 2020-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignGetCallerIdentity(t *testing.T) {
+	creds := awsCredentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		Token:           "sessiontoken",
+	}
+	nonce := make([]byte, awsServerNonceLength)
+	for i := range nonce {
+		nonce[i] = byte(i)
+	}
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	authz, amzDate := signGetCallerIdentity(creds, "sts.us-west-2.amazonaws.com", "us-west-2", nonce, now)
+	require.Equal(t, "20200102T030405Z", amzDate)
+	require.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20200102/us-west-2/sts/aws4_request, "+
+		"SignedHeaders=content-length;content-type;host;x-amz-date;x-amz-security-token;x-mongodb-gs2-cb-flag;x-mongodb-server-nonce, "+
+		"Signature=1395bfe2fd8839dcce40ef449e417f301215fa9d749c3af539fa86bc389c1197", authz)
+}
+
+func TestAWSRegionFromHost(t *testing.T) {
+	testCases := []struct {
+		host   string
+		region string
+		valid  bool
+	}{
+		{"sts.amazonaws.com", "us-east-1", true},
+		{"sts.us-west-2.amazonaws.com", "us-west-2", true},
+		{"localhost", "us-east-1", true},
+		{"", "", false},
+		{"sts..amazonaws.com", "", false},
+		{"sts.amazonaws.com.", "", false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.host, func(t *testing.T) {
+			region, err := awsRegionFromHost(tc.host)
+			if !tc.valid {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.region, region)
+		})
+	}
+}
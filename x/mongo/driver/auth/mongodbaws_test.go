@@ -0,0 +1,266 @@
+// Copyright (C) MongoDB, Inc. 2020-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	. "go.mongodb.org/mongo-driver/x/mongo/driver/auth"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/description"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/drivertest"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/wiremessage"
+)
+
+func TestMongoDBAWSAuthenticator_Succeeds(t *testing.T) {
+	authenticator := &MongoDBAWSAuthenticator{
+		Source:       "$external",
+		Username:     "AKIDEXAMPLE",
+		Password:     "secret",
+		SessionToken: "token",
+	}
+
+	payload := runAWSConversation(t, authenticator)
+
+	authz := payload.Lookup("a").StringValue()
+	require.True(t, strings.HasPrefix(authz, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"), "unexpected authorization header %q", authz)
+	require.Contains(t, authz, "/us-west-2/sts/aws4_request")
+	require.Contains(t, authz, "x-amz-security-token")
+	require.Equal(t, "token", payload.Lookup("t").StringValue())
+	_, ok := payload.Lookup("d").StringValueOK()
+	require.True(t, ok, "expected X-Amz-Date in client payload")
+}
+
+func TestMongoDBAWSAuthenticator_BadServerNonce(t *testing.T) {
+	authenticator := &MongoDBAWSAuthenticator{
+		Username: "AKIDEXAMPLE",
+		Password: "secret",
+	}
+
+	badNonce := func(clientNonce []byte) []byte {
+		nonce := make([]byte, 64)
+		_, _ = rand.Read(nonce)
+		nonce[0] = clientNonce[0] + 1
+		return nonce
+	}
+	resps := make(chan []byte, 1)
+	c := &drivertest.ChannelConn{
+		Written:  make(chan []byte, 2),
+		ReadResp: resps,
+		Desc:     awsTestDescription,
+	}
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- authenticator.Auth(context.Background(), awsTestDescription, c)
+	}()
+
+	start := readSaslPayload(t, <-c.Written)
+	_, clientNonce := start.Lookup("r").Binary()
+	resps <- drivertest.MakeReply(awsServerFirst(badNonce(clientNonce), "sts.amazonaws.com"))
+
+	err := <-errChan
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "server nonce did not extend client nonce")
+}
+
+func TestMongoDBAWSAuthenticator_EnvironmentCredentials(t *testing.T) {
+	defer setAWSEnv(t, map[string]string{
+		"AWS_ACCESS_KEY_ID":     "AKIDENV",
+		"AWS_SECRET_ACCESS_KEY": "secret",
+		"AWS_SESSION_TOKEN":     "envtoken",
+	})()
+
+	payload := runAWSConversation(t, &MongoDBAWSAuthenticator{})
+
+	require.Contains(t, payload.Lookup("a").StringValue(), "Credential=AKIDENV/")
+	require.Equal(t, "envtoken", payload.Lookup("t").StringValue())
+}
+
+func TestMongoDBAWSAuthenticator_ECSCredentials(t *testing.T) {
+	defer setAWSEnv(t, map[string]string{
+		"AWS_CONTAINER_CREDENTIALS_RELATIVE_URI": "/v2/credentials/abc",
+	})()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/credentials/abc" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"AccessKeyId":"AKIDECS","SecretAccessKey":"secret","Token":"ecstoken"}`))
+	}))
+	defer server.Close()
+
+	payload := runAWSConversation(t, &MongoDBAWSAuthenticator{ECSEndpoint: server.URL})
+
+	require.Contains(t, payload.Lookup("a").StringValue(), "Credential=AKIDECS/")
+	require.Equal(t, "ecstoken", payload.Lookup("t").StringValue())
+}
+
+func TestMongoDBAWSAuthenticator_EC2Credentials(t *testing.T) {
+	defer setAWSEnv(t, nil)()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/latest/api/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.Header.Get("X-aws-ec2-metadata-token-ttl-seconds") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte("ec2token"))
+	})
+	mux.HandleFunc("/latest/meta-data/iam/security-credentials/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-aws-ec2-metadata-token") != "ec2token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/latest/meta-data/iam/security-credentials/":
+			_, _ = w.Write([]byte("role"))
+		case "/latest/meta-data/iam/security-credentials/role":
+			_, _ = w.Write([]byte(`{"AccessKeyId":"AKIDEC2","SecretAccessKey":"secret","Token":"roletoken"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	payload := runAWSConversation(t, &MongoDBAWSAuthenticator{EC2Endpoint: server.URL})
+
+	require.Contains(t, payload.Lookup("a").StringValue(), "Credential=AKIDEC2/")
+	require.Equal(t, "roletoken", payload.Lookup("t").StringValue())
+}
+
+func TestMongoDBAWSAuthenticator_MissingPassword(t *testing.T) {
+	authenticator := &MongoDBAWSAuthenticator{Username: "AKIDEXAMPLE"}
+	c := &drivertest.ChannelConn{
+		Written:  make(chan []byte, 1),
+		ReadResp: make(chan []byte, 1),
+		Desc:     awsTestDescription,
+	}
+
+	err := authenticator.Auth(context.Background(), awsTestDescription, c)
+	require.Error(t, err)
+	require.Equal(t, 0, len(c.Written), "expected no messages to be sent")
+}
+
+var awsTestDescription = description.Server{
+	WireVersion: &description.VersionRange{
+		Max: 9,
+	},
+}
+
+// runAWSConversation plays the server side of a successful MONGODB-AWS conversation and returns the client's final
+// payload.
+func runAWSConversation(t *testing.T, authenticator Authenticator) bsoncore.Document {
+	t.Helper()
+
+	resps := make(chan []byte, 1)
+	c := &drivertest.ChannelConn{
+		Written:  make(chan []byte, 2),
+		ReadResp: resps,
+		Desc:     awsTestDescription,
+	}
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- authenticator.Auth(context.Background(), awsTestDescription, c)
+	}()
+
+	var start bsoncore.Document
+	select {
+	case wm := <-c.Written:
+		start = readSaslPayload(t, wm)
+	case err := <-errChan:
+		t.Fatalf("expected no error but got %v", err)
+	}
+	require.Equal(t, int32('n'), start.Lookup("p").Int32())
+	_, clientNonce := start.Lookup("r").Binary()
+	require.Equal(t, 32, len(clientNonce))
+
+	serverNonce := make([]byte, 64)
+	copy(serverNonce, clientNonce)
+	_, _ = rand.Read(serverNonce[32:])
+	resps <- drivertest.MakeReply(awsServerFirst(serverNonce, "sts.us-west-2.amazonaws.com"))
+
+	finish := readSaslPayload(t, <-c.Written)
+	resps <- drivertest.MakeReply(bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendInt32Element(nil, "ok", 1),
+		bsoncore.AppendInt32Element(nil, "conversationId", 1),
+		bsoncore.AppendBinaryElement(nil, "payload", 0x00, []byte{}),
+		bsoncore.AppendBooleanElement(nil, "done", true),
+	))
+
+	require.NoError(t, <-errChan)
+	return finish
+}
+
+func awsServerFirst(serverNonce []byte, host string) bsoncore.Document {
+	payload := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendBinaryElement(nil, "s", 0x00, serverNonce),
+		bsoncore.AppendStringElement(nil, "h", host),
+	)
+	return bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendInt32Element(nil, "ok", 1),
+		bsoncore.AppendInt32Element(nil, "conversationId", 1),
+		bsoncore.AppendBinaryElement(nil, "payload", 0x00, payload),
+		bsoncore.AppendBooleanElement(nil, "done", false),
+	)
+}
+
+// readSaslPayload extracts the payload of a saslStart or saslContinue command sent using OP_MSG.
+func readSaslPayload(t *testing.T, wm []byte) bsoncore.Document {
+	t.Helper()
+
+	var ok bool
+	_, _, _, _, wm, ok = wiremessage.ReadHeader(wm)
+	require.True(t, ok, "could not read header")
+	_, wm, ok = wiremessage.ReadMsgFlags(wm)
+	require.True(t, ok, "could not read flags")
+	_, wm, ok = wiremessage.ReadMsgSectionType(wm)
+	require.True(t, ok, "could not read section type")
+	cmd, _, ok := wiremessage.ReadMsgSectionSingleDocument(wm)
+	require.True(t, ok, "could not read command")
+
+	_, payload := cmd.Lookup("payload").Binary()
+	return payload
+}
+
+// setAWSEnv clears the AWS credential environment variables, sets the given ones, and returns a function that
+// restores the original environment.
+func setAWSEnv(t *testing.T, env map[string]string) func() {
+	names := []string{
+		"AWS_ACCESS_KEY_ID",
+		"AWS_SECRET_ACCESS_KEY",
+		"AWS_SESSION_TOKEN",
+		"AWS_CONTAINER_CREDENTIALS_RELATIVE_URI",
+	}
+	saved := make(map[string]string)
+	for _, name := range names {
+		if val, ok := os.LookupEnv(name); ok {
+			saved[name] = val
+		}
+		require.NoError(t, os.Unsetenv(name))
+	}
+	for name, val := range env {
+		require.NoError(t, os.Setenv(name, val))
+	}
+
+	return func() {
+		for _, name := range names {
+			_ = os.Unsetenv(name)
+		}
+		for name, val := range saved {
+			_ = os.Setenv(name, val)
+		}
+	}
+}
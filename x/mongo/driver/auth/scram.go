@@ -14,7 +14,11 @@ package auth
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/xdg/scram"
 	"github.com/xdg/stringprep"
@@ -30,16 +34,11 @@ const SCRAMSHA256 = "SCRAM-SHA-256"
 
 func newScramSHA1Authenticator(cred *Cred) (Authenticator, error) {
 	passdigest := mongoPasswordDigest(cred.Username, cred.Password)
-	client, err := scram.SHA1.NewClientUnprepped(cred.Username, passdigest, "")
+	a, err := newScramAuthenticator(SCRAMSHA1, scram.SHA1, cred.Username, passdigest, cred.Source)
 	if err != nil {
-		return nil, newAuthError("error initializing SCRAM-SHA-1 client", err)
+		return nil, err
 	}
-	client.WithMinIterations(4096)
-	return &ScramAuthenticator{
-		mechanism: SCRAMSHA1,
-		source:    cred.Source,
-		client:    client,
-	}, nil
+	return a, nil
 }
 
 func newScramSHA256Authenticator(cred *Cred) (Authenticator, error) {
@@ -47,38 +46,105 @@ func newScramSHA256Authenticator(cred *Cred) (Authenticator, error) {
 	if err != nil {
 		return nil, newAuthError(fmt.Sprintf("error SASLprepping password '%s'", cred.Password), err)
 	}
-	client, err := scram.SHA256.NewClientUnprepped(cred.Username, passprep, "")
+	a, err := newScramAuthenticator(SCRAMSHA256, scram.SHA256, cred.Username, passprep, cred.Source)
 	if err != nil {
-		return nil, newAuthError("error initializing SCRAM-SHA-256 client", err)
+		return nil, err
 	}
-	client.WithMinIterations(4096)
-	return &ScramAuthenticator{
-		mechanism: SCRAMSHA256,
-		source:    cred.Source,
-		client:    client,
-	}, nil
+	return a, nil
+}
+
+func newScramAuthenticator(mechanism string, hashGen scram.HashGeneratorFcn, username, password, source string) (*ScramAuthenticator, error) {
+	a := &ScramAuthenticator{
+		mechanism: mechanism,
+		source:    source,
+		hashGen:   hashGen,
+		username:  username,
+		password:  password,
+	}
+	client, err := a.newClient()
+	if err != nil {
+		return nil, err
+	}
+	a.client = client
+	return a, nil
 }
 
 // ScramAuthenticator uses the SCRAM algorithm over SASL to authenticate a connection.
+//
+// Deriving the keys for a password is deliberately expensive, so the keys are cached and reused by every connection
+// the authenticator authenticates. The cache is cleared when the server sends a different salt or iteration count,
+// which happens when the user's password is changed.
 type ScramAuthenticator struct {
 	mechanism string
 	source    string
-	client    *scram.Client
+	hashGen   scram.HashGeneratorFcn
+	username  string
+	password  string
+
+	mu         sync.Mutex
+	client     *scram.Client
+	keyFactors scram.KeyFactors
+}
+
+var _ SpeculativeAuthenticator = (*ScramAuthenticator)(nil)
+
+// newClient creates a scram.Client, which caches the keys derived for each salt and iteration count it is used with.
+func (a *ScramAuthenticator) newClient() (*scram.Client, error) {
+	client, err := a.hashGen.NewClientUnprepped(a.username, a.password, "")
+	if err != nil {
+		return nil, newAuthError(fmt.Sprintf("error initializing %s client", a.mechanism), err)
+	}
+	client.WithMinIterations(4096)
+	return client, nil
+}
+
+// newSaslAdapter starts a new conversation using the shared client.
+func (a *ScramAuthenticator) newSaslAdapter() *scramSaslAdapter {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return &scramSaslAdapter{
+		mechanism:     a.mechanism,
+		conversation:  a.client.NewConversation(),
+		authenticator: a,
+	}
+}
+
+// updateKeyFactors records the salt and iteration count sent by the server. If they have changed, the keys cached for
+// the previous ones can no longer be used, so the client is replaced with one that has an empty cache.
+func (a *ScramAuthenticator) updateKeyFactors(kf scram.KeyFactors) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.keyFactors == kf {
+		return
+	}
+	if a.keyFactors != (scram.KeyFactors{}) {
+		if client, err := a.newClient(); err == nil {
+			a.client = client
+		}
+	}
+	a.keyFactors = kf
 }
 
 // Auth authenticates the connection.
 func (a *ScramAuthenticator) Auth(ctx context.Context, _ description.Server, conn driver.Connection) error {
-	adapter := &scramSaslAdapter{conversation: a.client.NewConversation(), mechanism: a.mechanism}
-	err := ConductSaslConversation(ctx, conn, a.source, adapter)
+	err := ConductSaslConversation(ctx, conn, a.source, a.newSaslAdapter())
 	if err != nil {
 		return newAuthError("sasl conversation error", err)
 	}
 	return nil
 }
 
+// CreateSpeculativeConversation creates a speculative conversation for SCRAM authentication.
+func (a *ScramAuthenticator) CreateSpeculativeConversation() (SpeculativeConversation, error) {
+	return newSaslConversation(a.newSaslAdapter(), a.source, true), nil
+}
+
 type scramSaslAdapter struct {
-	mechanism    string
-	conversation *scram.ClientConversation
+	mechanism     string
+	conversation  *scram.ClientConversation
+	authenticator *ScramAuthenticator
+	started       bool
 }
 
 func (a *scramSaslAdapter) Start() (string, []byte, error) {
@@ -90,6 +156,13 @@ func (a *scramSaslAdapter) Start() (string, []byte, error) {
 }
 
 func (a *scramSaslAdapter) Next(challenge []byte) ([]byte, error) {
+	if !a.started {
+		a.started = true
+		if kf, ok := parseKeyFactors(string(challenge)); ok {
+			a.authenticator.updateKeyFactors(kf)
+		}
+	}
+
 	step, err := a.conversation.Step(string(challenge))
 	if err != nil {
 		return nil, err
@@ -100,3 +173,27 @@ func (a *scramSaslAdapter) Next(challenge []byte) ([]byte, error) {
 func (a *scramSaslAdapter) Completed() bool {
 	return a.conversation.Done()
 }
+
+// parseKeyFactors reads the salt and iteration count from a server-first-message. Malformed messages are left for the
+// conversation to reject.
+func parseKeyFactors(serverFirst string) (scram.KeyFactors, bool) {
+	var kf scram.KeyFactors
+	var haveSalt, haveIters bool
+	for _, attr := range strings.Split(serverFirst, ",") {
+		switch {
+		case strings.HasPrefix(attr, "s="):
+			salt, err := base64.StdEncoding.DecodeString(attr[2:])
+			if err != nil {
+				return kf, false
+			}
+			kf.Salt, haveSalt = string(salt), true
+		case strings.HasPrefix(attr, "i="):
+			iters, err := strconv.Atoi(attr[2:])
+			if err != nil {
+				return kf, false
+			}
+			kf.Iters, haveIters = iters, true
+		}
+	}
+	return kf, haveSalt && haveIters
+}
@@ -0,0 +1,159 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xdg/scram"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/address"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/description"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/drivertest"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/wiremessage"
+)
+
+func TestScramAuthenticatorKeyCache(t *testing.T) {
+	cred := &Cred{Username: "user", Password: "pencil", PasswordSet: true}
+	desc := description.Server{WireVersion: &description.VersionRange{Max: 6}}
+
+	authenticator, err := newScramSHA256Authenticator(cred)
+	require.NoError(t, err)
+	a := authenticator.(*ScramAuthenticator)
+
+	conn := newScramTestConn(t, cred, "salt", 4096)
+	require.NoError(t, a.Auth(context.Background(), desc, conn))
+	client := a.client
+	require.Equal(t, scram.KeyFactors{Salt: "salt", Iters: 4096}, a.keyFactors)
+
+	require.NoError(t, a.Auth(context.Background(), desc, conn))
+	require.True(t, a.client == client, "expected the client and its cached keys to be reused")
+
+	// Changing the password changes the salt, so the cached keys must be discarded.
+	conn = newScramTestConn(t, cred, "new salt", 4096)
+	require.NoError(t, a.Auth(context.Background(), desc, conn))
+	require.False(t, a.client == client, "expected the client to be replaced after the salt changed")
+	require.Equal(t, scram.KeyFactors{Salt: "new salt", Iters: 4096}, a.keyFactors)
+
+	t.Run("default authenticator", func(t *testing.T) {
+		authenticator := &DefaultAuthenticator{Cred: cred}
+		desc := description.Server{
+			WireVersion:        &description.VersionRange{Max: 6},
+			SaslSupportedMechs: []string{SCRAMSHA256},
+		}
+		for i := 0; i < 2; i++ {
+			require.NoError(t, authenticator.Auth(context.Background(), desc, conn))
+		}
+
+		first, err := authenticator.scramAuthenticator(SCRAMSHA256)
+		require.NoError(t, err)
+		second, err := authenticator.scramAuthenticator(SCRAMSHA256)
+		require.NoError(t, err)
+		require.True(t, first == second, "expected the SCRAM authenticator to be shared")
+	})
+}
+
+func BenchmarkScramAuthentication(b *testing.B) {
+	const poolSize = 100
+	cred := &Cred{Username: "user", Password: "pencil", PasswordSet: true}
+	desc := description.Server{WireVersion: &description.VersionRange{Max: 6}}
+	conn := newScramTestConn(b, cred, "salt", 15000)
+
+	authenticate := func(b *testing.B, authenticator Authenticator) {
+		if err := authenticator.Auth(context.Background(), desc, conn); err != nil {
+			b.Fatalf("error authenticating: %v", err)
+		}
+	}
+	newAuthenticator := func(b *testing.B) Authenticator {
+		authenticator, err := newScramSHA256Authenticator(cred)
+		if err != nil {
+			b.Fatalf("error creating authenticator: %v", err)
+		}
+		return authenticator
+	}
+
+	b.Run(fmt.Sprintf("%d connections with shared keys", poolSize), func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			authenticator := newAuthenticator(b)
+			for j := 0; j < poolSize; j++ {
+				authenticate(b, authenticator)
+			}
+		}
+	})
+	b.Run(fmt.Sprintf("%d connections without shared keys", poolSize), func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < poolSize; j++ {
+				authenticate(b, newAuthenticator(b))
+			}
+		}
+	})
+}
+
+// scramTestConn is a connection to a server that authenticates a single user with SCRAM-SHA-256.
+type scramTestConn struct {
+	server       *scram.Server
+	conversation *scram.ServerConversation
+	replies      [][]byte
+}
+
+func newScramTestConn(tb testing.TB, cred *Cred, salt string, iters int) *scramTestConn {
+	client, err := scram.SHA256.NewClient(cred.Username, cred.Password, "")
+	require.NoError(tb, err)
+	stored := client.GetStoredCredentials(scram.KeyFactors{Salt: salt, Iters: iters})
+	server, err := scram.SHA256.NewServer(func(string) (scram.StoredCredentials, error) {
+		return stored, nil
+	})
+	require.NoError(tb, err)
+	return &scramTestConn{server: server}
+}
+
+func (c *scramTestConn) WriteWireMessage(_ context.Context, wm []byte) error {
+	_, _, _, _, wm, _ = wiremessage.ReadHeader(wm)
+	_, wm, _ = wiremessage.ReadMsgFlags(wm)
+	_, wm, _ = wiremessage.ReadMsgSectionType(wm)
+	cmd, _, ok := wiremessage.ReadMsgSectionSingleDocument(wm)
+	if !ok {
+		return errors.New("malformed command")
+	}
+	if _, ok := cmd.Lookup("saslStart").Int32OK(); ok {
+		c.conversation = c.server.NewConversation()
+	}
+
+	_, payload := cmd.Lookup("payload").Binary()
+	resp, err := c.conversation.Step(string(payload))
+	if err != nil {
+		return err
+	}
+	c.replies = append(c.replies, drivertest.MakeReply(bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendInt32Element(nil, "ok", 1),
+		bsoncore.AppendInt32Element(nil, "conversationId", 1),
+		bsoncore.AppendBooleanElement(nil, "done", c.conversation.Done()),
+		bsoncore.AppendBinaryElement(nil, "payload", 0x00, []byte(resp)),
+	)))
+	return nil
+}
+
+func (c *scramTestConn) ReadWireMessage(_ context.Context, dst []byte) ([]byte, error) {
+	if len(c.replies) == 0 {
+		return nil, errors.New("no reply")
+	}
+	wm := c.replies[0]
+	c.replies = c.replies[1:]
+	return append(dst, wm...), nil
+}
+
+func (c *scramTestConn) Description() description.Server {
+	return description.Server{WireVersion: &description.VersionRange{Max: 6}}
+}
+
+func (c *scramTestConn) Close() error             { return nil }
+func (c *scramTestConn) ID() string               { return "scram" }
+func (c *scramTestConn) Address() address.Address { return address.Address("localhost:27017") }
@@ -0,0 +1,211 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package auth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+	"github.com/xdg/scram"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	. "go.mongodb.org/mongo-driver/x/mongo/driver/auth"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/description"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/drivertest"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/wiremessage"
+)
+
+func TestSpeculativeX509(t *testing.T) {
+	authenticator, err := CreateAuthenticator(MongoDBX509, &Cred{Username: "user"})
+	require.NoError(t, err)
+
+	speculativeCmd := bsoncore.Document(bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendInt32Element(nil, "authenticate", 1),
+		bsoncore.AppendStringElement(nil, "mechanism", MongoDBX509),
+		bsoncore.AppendStringElement(nil, "user", "user"),
+		bsoncore.AppendStringElement(nil, "db", "$external"),
+	))
+
+	t.Run("speculative response included", func(t *testing.T) {
+		speculativeResp := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendStringElement(nil, "dbname", "$external"),
+			bsoncore.AppendStringElement(nil, "user", "user"),
+		)
+		conn := newSpeculativeConn(2, isMasterReply(speculativeResp))
+
+		handshaker := Handshaker(nil, &HandshakeOptions{Authenticator: authenticator})
+		_, err := handshaker.GetDescription(context.Background(), conn.Address(), conn)
+		require.NoError(t, err)
+		require.NoError(t, handshaker.FinishHandshake(context.Background(), conn))
+
+		if len(conn.Written) != 1 {
+			t.Fatalf("expected 1 message to be sent, got %d", len(conn.Written))
+		}
+		got := speculativeAuthenticate(t, <-conn.Written)
+		if !cmp.Equal(got, speculativeCmd) {
+			t.Errorf("speculativeAuthenticate documents do not match. got %v; want %v", got, speculativeCmd)
+		}
+	})
+	t.Run("speculative response not included", func(t *testing.T) {
+		conn := newSpeculativeConn(2,
+			isMasterReply(nil),
+			bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendInt32Element(nil, "ok", 1)),
+		)
+
+		handshaker := Handshaker(nil, &HandshakeOptions{Authenticator: authenticator})
+		_, err := handshaker.GetDescription(context.Background(), conn.Address(), conn)
+		require.NoError(t, err)
+		require.NoError(t, handshaker.FinishHandshake(context.Background(), conn))
+
+		if len(conn.Written) != 2 {
+			t.Fatalf("expected 2 messages to be sent, got %d", len(conn.Written))
+		}
+		<-conn.Written
+		authCmd := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "authenticate", 1),
+			bsoncore.AppendStringElement(nil, "mechanism", MongoDBX509),
+		)
+		compareResponses(t, <-conn.Written, authCmd, "$external")
+	})
+}
+
+func TestSpeculativeSCRAM(t *testing.T) {
+	cred := &Cred{Username: "user", Password: "pencil", PasswordSet: true, Source: "admin"}
+
+	client, err := scram.SHA256.NewClient(cred.Username, cred.Password, "")
+	require.NoError(t, err)
+	stored := client.GetStoredCredentials(scram.KeyFactors{Salt: "salt", Iters: 4096})
+	server, err := scram.SHA256.NewServer(func(string) (scram.StoredCredentials, error) {
+		return stored, nil
+	})
+	require.NoError(t, err)
+
+	for _, mech := range []string{SCRAMSHA256, ""} {
+		name := mech
+		if name == "" {
+			name = "default"
+		}
+		t.Run(name, func(t *testing.T) {
+			authenticator, err := CreateAuthenticator(mech, cred)
+			require.NoError(t, err)
+
+			conn := newSpeculativeConn(2)
+			handshaker := Handshaker(nil, &HandshakeOptions{Authenticator: authenticator})
+			errCh := make(chan error, 1)
+			go func() {
+				if _, err := handshaker.GetDescription(context.Background(), conn.Address(), conn); err != nil {
+					errCh <- err
+					return
+				}
+				errCh <- handshaker.FinishHandshake(context.Background(), conn)
+			}()
+
+			// The first step of the conversation is sent in the isMaster and the server replies to it in the
+			// isMaster response.
+			serverConv := server.NewConversation()
+			saslStart := speculativeAuthenticate(t, <-conn.Written)
+			if db := saslStart.Lookup("db").StringValue(); db != "admin" {
+				t.Errorf("expected the speculative saslStart to be for database admin, got %q", db)
+			}
+			if mechanism := saslStart.Lookup("mechanism").StringValue(); mechanism != SCRAMSHA256 {
+				t.Errorf("expected mechanism %s, got %q", SCRAMSHA256, mechanism)
+			}
+			writeReplies(t, conn.ReadResp, isMasterReply(saslReply(t, serverConv, saslStart, false)))
+
+			// The rest of the conversation happens in saslContinue commands.
+			saslContinue := command(t, <-conn.Written)
+			if _, ok := saslContinue.Lookup("saslContinue").Int32OK(); !ok {
+				t.Fatalf("expected a saslContinue command, got %v", saslContinue)
+			}
+			writeReplies(t, conn.ReadResp, saslReply(t, serverConv, saslContinue, true))
+
+			require.NoError(t, <-errCh)
+			if !serverConv.Valid() {
+				t.Errorf("expected the server to authenticate the client")
+			}
+			if len(conn.Written) != 0 {
+				t.Errorf("expected no more messages to be sent, got %d", len(conn.Written))
+			}
+		})
+	}
+}
+
+func newSpeculativeConn(size int, replies ...bsoncore.Document) *drivertest.ChannelConn {
+	resps := make(chan []byte, size)
+	for _, reply := range replies {
+		resps <- drivertest.MakeReply(reply)
+	}
+	return &drivertest.ChannelConn{
+		Written:  make(chan []byte, size),
+		ReadResp: resps,
+		Desc: description.Server{
+			WireVersion: &description.VersionRange{Max: 6},
+		},
+	}
+}
+
+// isMasterReply creates an isMaster response that includes speculativeResp in the speculativeAuthenticate field if it
+// is not nil.
+func isMasterReply(speculativeResp bsoncore.Document) bsoncore.Document {
+	elems := [][]byte{
+		bsoncore.AppendInt32Element(nil, "ok", 1),
+		bsoncore.AppendBooleanElement(nil, "ismaster", true),
+		bsoncore.AppendInt32Element(nil, "maxWireVersion", 6),
+	}
+	if speculativeResp != nil {
+		elems = append(elems, bsoncore.AppendDocumentElement(nil, "speculativeAuthenticate", speculativeResp))
+	}
+	return bsoncore.BuildDocumentFromElements(nil, elems...)
+}
+
+// saslReply passes the payload of cmd to the server conversation and returns the server's response.
+func saslReply(t *testing.T, conv *scram.ServerConversation, cmd bsoncore.Document, done bool) bsoncore.Document {
+	t.Helper()
+
+	_, payload := cmd.Lookup("payload").Binary()
+	resp, err := conv.Step(string(payload))
+	require.NoError(t, err)
+	return bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendInt32Element(nil, "ok", 1),
+		bsoncore.AppendInt32Element(nil, "conversationId", 1),
+		bsoncore.AppendBooleanElement(nil, "done", done),
+		bsoncore.AppendBinaryElement(nil, "payload", 0x00, []byte(resp)),
+	)
+}
+
+// speculativeAuthenticate returns the speculativeAuthenticate document from the isMaster in wm.
+func speculativeAuthenticate(t *testing.T, wm []byte) bsoncore.Document {
+	t.Helper()
+
+	doc, ok := command(t, wm).Lookup("speculativeAuthenticate").DocumentOK()
+	if !ok {
+		t.Fatalf("expected the isMaster to include a speculativeAuthenticate document")
+	}
+	return doc
+}
+
+// command returns the command document from the OP_MSG in wm.
+func command(t *testing.T, wm []byte) bsoncore.Document {
+	t.Helper()
+
+	_, _, _, _, wm, ok := wiremessage.ReadHeader(wm)
+	if ok {
+		_, wm, ok = wiremessage.ReadMsgFlags(wm)
+	}
+	if ok {
+		_, wm, ok = wiremessage.ReadMsgSectionType(wm)
+	}
+	var doc bsoncore.Document
+	if ok {
+		doc, _, ok = wiremessage.ReadMsgSectionSingleDocument(wm)
+	}
+	if !ok {
+		t.Fatalf("wiremessage is too short to unmarshal")
+	}
+	return doc
+}
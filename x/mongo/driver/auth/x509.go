@@ -27,17 +27,58 @@ type MongoDBX509Authenticator struct {
 	User string
 }
 
-// Auth implements the Authenticator interface.
-func (a *MongoDBX509Authenticator) Auth(ctx context.Context, desc description.Server, conn driver.Connection) error {
-	requestDoc := bsoncore.AppendInt32Element(nil, "authenticate", 1)
-	requestDoc = bsoncore.AppendStringElement(requestDoc, "mechanism", MongoDBX509)
+var _ SpeculativeAuthenticator = (*MongoDBX509Authenticator)(nil)
+
+// x509Conversation is a speculative conversation for X.509 authentication. The authenticate command only takes a
+// single step, so the conversation is complete once the server has replied to it.
+type x509Conversation struct {
+	user string
+}
+
+var _ SpeculativeConversation = (*x509Conversation)(nil)
+
+// FirstMessage returns the authenticate command for speculative authentication.
+func (c *x509Conversation) FirstMessage() (bsoncore.Document, error) {
+	return createFirstX509Message(description.Server{}, c.user, true), nil
+}
+
+// Finish implements the SpeculativeConversation interface and is a no-op because an X.509 conversation only has one
+// step.
+func (c *x509Conversation) Finish(context.Context, driver.Connection, bsoncore.Document) error {
+	return nil
+}
+
+// createFirstX509Message creates the authenticate command. The user is required by servers that are too old to
+// determine it from the client certificate. The server description isn't known yet for a speculative command, so it
+// only includes the user if one was set, and it names the database because it is sent as part of the handshake.
+func createFirstX509Message(desc description.Server, user string, speculative bool) bsoncore.Document {
+	elements := [][]byte{
+		bsoncore.AppendInt32Element(nil, "authenticate", 1),
+		bsoncore.AppendStringElement(nil, "mechanism", MongoDBX509),
+	}
 
-	if desc.WireVersion == nil || desc.WireVersion.Max < 5 {
-		requestDoc = bsoncore.AppendStringElement(requestDoc, "user", a.User)
+	switch {
+	case speculative:
+		if user != "" {
+			elements = append(elements, bsoncore.AppendStringElement(nil, "user", user))
+		}
+		elements = append(elements, bsoncore.AppendStringElement(nil, "db", "$external"))
+	case desc.WireVersion == nil || desc.WireVersion.Max < 5:
+		elements = append(elements, bsoncore.AppendStringElement(nil, "user", user))
 	}
 
+	return bsoncore.BuildDocumentFromElements(nil, elements...)
+}
+
+// CreateSpeculativeConversation creates a speculative conversation for X.509 authentication.
+func (a *MongoDBX509Authenticator) CreateSpeculativeConversation() (SpeculativeConversation, error) {
+	return &x509Conversation{user: a.User}, nil
+}
+
+// Auth implements the Authenticator interface.
+func (a *MongoDBX509Authenticator) Auth(ctx context.Context, desc description.Server, conn driver.Connection) error {
 	authCmd := operation.
-		NewCommand(bsoncore.BuildDocument(nil, requestDoc)).
+		NewCommand(createFirstX509Message(desc, a.User, false)).
 		Database("$external").
 		Deployment(driver.SingleConnectionDeployment{conn})
 	err := authCmd.Execute(ctx)
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/event"
@@ -30,6 +31,16 @@ type BatchCursor struct {
 	cmdMonitor           *event.CommandMonitor
 	postBatchResumeToken bsoncore.Document
 	crypt                *Crypt
+	timeout              *time.Duration
+	serverAPI            *ServerAPIOptions
+
+	// exhaust cursor fields
+	exhaust     bool
+	exhaustConn *exhaustConnection // the connection pinned to the cursor while the server streams batches
+
+	// pinnedConn is the connection the cursor was created on when the deployment is behind a load balancer. All of
+	// the cursor's commands are sent over it until the cursor is exhausted or killed.
+	pinnedConn PinnedConnection
 
 	// legacy server (< 3.2) fields
 	legacy      bool // This field is provided for ListCollectionsBatchCursor.
@@ -47,10 +58,15 @@ type CursorResponse struct {
 	Collection           string
 	ID                   int64
 	postBatchResumeToken bsoncore.Document
+
+	// Connection is the connection the cursor is pinned to. It is only set when the deployment is behind a load
+	// balancer and the cursor has more results.
+	Connection PinnedConnection
 }
 
 // NewCursorResponse constructs a cursor response from the given response and server. This method
-// can be used within the ProcessResponse method for an operation.
+// can be used within the ProcessResponse method for an operation. If the response was received over a connection to a
+// server behind a load balancer and the cursor has more results, the connection is pinned to the cursor.
 func NewCursorResponse(response bsoncore.Document, server Server, desc description.Server) (CursorResponse, error) {
 	cur, ok := response.Lookup("cursor").DocumentOK()
 	if !ok {
@@ -60,6 +76,10 @@ func NewCursorResponse(response bsoncore.Document, server Server, desc descripti
 	if err != nil {
 		return CursorResponse{}, err
 	}
+	var pinnedConn PinnedConnection
+	if ps, ok := server.(*pinnedServer); ok {
+		server, pinnedConn = ps.Server, ps.conn
+	}
 	curresp := CursorResponse{Server: server, Desc: desc}
 
 	for _, elem := range elems {
@@ -93,6 +113,13 @@ func NewCursorResponse(response bsoncore.Document, server Server, desc descripti
 			}
 		}
 	}
+
+	if pinnedConn != nil && curresp.ID != 0 {
+		if err := pinnedConn.PinToCursor(); err != nil {
+			return CursorResponse{}, fmt.Errorf("error pinning the connection to the cursor: %v", err)
+		}
+		curresp.Connection = pinnedConn
+	}
 	return curresp, nil
 }
 
@@ -103,6 +130,13 @@ type CursorOptions struct {
 	Limit          int32
 	CommandMonitor *event.CommandMonitor
 	Crypt          *Crypt
+	Timeout        *time.Duration
+	ServerAPI      *ServerAPIOptions
+
+	// Exhaust makes the cursor ask the server to stream the remaining batches over a single connection after the
+	// first getMore, instead of sending a getMore for each batch. This requires OP_MSG and MongoDB 4.2 or later. Older
+	// servers ignore the request and the cursor sends getMores as usual.
+	Exhaust bool
 }
 
 // NewBatchCursor creates a new BatchCursor from the provided parameters.
@@ -121,6 +155,13 @@ func NewBatchCursor(cr CursorResponse, clientSession *session.Client, clock *ses
 		firstBatch:           true,
 		postBatchResumeToken: cr.postBatchResumeToken,
 		crypt:                opts.Crypt,
+		timeout:              opts.Timeout,
+		serverAPI:            opts.ServerAPI,
+		exhaust:              opts.Exhaust,
+	}
+	if cr.Connection != nil {
+		bc.pinnedConn = cr.Connection
+		bc.server = &pinnedServer{Server: cr.Server, conn: cr.Connection}
 	}
 
 	if ds != nil {
@@ -214,9 +255,24 @@ func (bc *BatchCursor) clearBatch() {
 
 // KillCursor kills cursor on server without closing batch cursor
 func (bc *BatchCursor) KillCursor(ctx context.Context) error {
+	if bc.exhaustConn != nil {
+		// The server is still streaming batches, so the connection can't be used again. Closing it ends the stream
+		// and the server kills the cursor. Behind a load balancer the connection is also pinned to the cursor, so that
+		// pin is released as well.
+		err := bc.unpinExhaustConnection(true)
+		if uerr := bc.unpinConnection(true); err == nil {
+			err = uerr
+		}
+		return err
+	}
 	if bc.server == nil || bc.id == 0 {
 		return nil
 	}
+	if bc.pinnedConn != nil {
+		defer func() {
+			_ = bc.unpinConnection(false)
+		}()
+	}
 
 	return Operation{
 		CommandFn: func(dst []byte, desc description.SelectedServer) ([]byte, error) {
@@ -230,6 +286,7 @@ func (bc *BatchCursor) KillCursor(ctx context.Context) error {
 		Clock:          bc.clock,
 		Legacy:         LegacyKillCursors,
 		CommandMonitor: bc.cmdMonitor,
+		ServerAPI:      bc.serverAPI,
 	}.Execute(ctx, nil)
 }
 
@@ -252,7 +309,7 @@ func (bc *BatchCursor) getMore(ctx context.Context) {
 		}
 	}
 
-	bc.err = Operation{
+	op := Operation{
 		CommandFn: func(dst []byte, desc description.SelectedServer) ([]byte, error) {
 			dst = bsoncore.AppendInt64Element(dst, "getMore", bc.id)
 			dst = bsoncore.AppendStringElement(dst, "collection", bc.collection)
@@ -266,6 +323,7 @@ func (bc *BatchCursor) getMore(ctx context.Context) {
 		},
 		Database:   bc.database,
 		Deployment: SingleServerDeployment{Server: bc.server},
+		Timeout:    bc.timeout,
 		ProcessResponseFn: func(response bsoncore.Document, srvr Server, desc description.Server) error {
 			id, ok := response.Lookup("cursor", "id").Int64OK()
 			if !ok {
@@ -303,7 +361,23 @@ func (bc *BatchCursor) getMore(ctx context.Context) {
 		Legacy:         LegacyGetMore,
 		CommandMonitor: bc.cmdMonitor,
 		Crypt:          bc.crypt,
-	}.Execute(ctx, nil)
+	}
+	if bc.exhaust {
+		bc.err = bc.exhaustGetMore(ctx, op)
+	} else {
+		bc.err = op.Execute(ctx, nil)
+	}
+
+	if bc.pinnedConn != nil {
+		// The pinned connection is released once the server has exhausted the cursor. After a network error the
+		// cursor can't be used anymore, so the connection is discarded and the server cleans the cursor up.
+		if derr, ok := bc.err.(Error); ok && derr.NetworkError() {
+			bc.id = 0
+			_ = bc.unpinConnection(true)
+		} else if bc.id == 0 {
+			_ = bc.unpinConnection(false)
+		}
+	}
 
 	// Required for legacy operations which don't support limit.
 	if bc.limit != 0 && bc.numReturned >= bc.limit {
@@ -316,6 +390,118 @@ func (bc *BatchCursor) getMore(ctx context.Context) {
 	return
 }
 
+// exhaustGetMore gets the next batch of an exhaust cursor. The first getMore is sent on a connection that is pinned to
+// the cursor, with the exhaustAllowed flag set. If the server replies with moreToCome, it streams the following batches
+// on that connection and they are read without sending more getMores. The connection is unpinned once the server
+// stops streaming.
+func (bc *BatchCursor) exhaustGetMore(ctx context.Context, op Operation) error {
+	if bc.exhaustConn == nil {
+		conn, err := bc.server.Connection(ctx)
+		if err != nil {
+			return err
+		}
+		bc.exhaustConn = &exhaustConnection{Connection: conn}
+	}
+
+	var err error
+	if bc.exhaustConn.CurrentlyStreaming() {
+		err = op.ExecuteExhaust(ctx, bc.exhaustConn, nil)
+	} else {
+		op.Deployment = SingleConnectionDeployment{C: bc.exhaustConn}
+		err = op.Execute(ctx, nil)
+	}
+
+	// A network error can leave part of a reply unread, so the connection is discarded rather than being returned to
+	// the pool.
+	var discard bool
+	if derr, ok := err.(Error); ok && derr.NetworkError() {
+		discard = true
+	}
+	if discard || !bc.exhaustConn.CurrentlyStreaming() {
+		if uerr := bc.unpinExhaustConnection(discard); err == nil {
+			err = uerr
+		}
+	}
+	return err
+}
+
+// unpinExhaustConnection releases the connection pinned to an exhaust cursor. If discard is true or the server is
+// still streaming to the connection, the connection is closed instead of being returned to the pool.
+func (bc *BatchCursor) unpinExhaustConnection(discard bool) error {
+	conn := bc.exhaustConn
+	bc.exhaustConn = nil
+	if discard || conn.CurrentlyStreaming() {
+		if exp, ok := conn.Connection.(Expirable); ok {
+			return exp.Expire()
+		}
+	}
+	return conn.Close()
+}
+
+// unpinConnection releases the connection pinned to the cursor when the deployment is behind a load balancer. If
+// discard is true, the connection is closed instead of being returned to the pool.
+func (bc *BatchCursor) unpinConnection(discard bool) error {
+	conn := bc.pinnedConn
+	if conn == nil {
+		return nil
+	}
+	bc.pinnedConn = nil
+
+	err := conn.UnpinFromCursor()
+	if discard {
+		if exp, ok := conn.(Expirable); ok {
+			if eerr := exp.Expire(); err == nil {
+				err = eerr
+			}
+			return err
+		}
+	}
+	if cerr := conn.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// pinnedServer is a Server whose connections are all the same pinned connection. Operation.Execute passes it to
+// ProcessResponseFn for responses received from a server behind a load balancer so NewCursorResponse can pin the
+// connection to the cursor, and a BatchCursor uses it to send its commands over the pinned connection.
+type pinnedServer struct {
+	Server
+	conn PinnedConnection
+}
+
+var _ ErrorProcessor = (*pinnedServer)(nil)
+
+// Connection implements the Server interface. It returns the pinned connection.
+func (ps *pinnedServer) Connection(context.Context) (Connection, error) {
+	return ps.conn, nil
+}
+
+// ProcessError implements the ErrorProcessor interface by passing the error to the wrapped server.
+func (ps *pinnedServer) ProcessError(err error, conn Connection) {
+	if ep, ok := ps.Server.(ErrorProcessor); ok {
+		ep.ProcessError(err, conn)
+	}
+}
+
+// exhaustConnection is a Connection pinned to an exhaust cursor. It tracks whether the server is streaming replies on
+// the connection and allows the exhaustAllowed flag to be set on the messages sent over it.
+type exhaustConnection struct {
+	Connection
+	streaming bool
+}
+
+var _ StreamerConnection = (*exhaustConnection)(nil)
+
+// SetStreaming implements the StreamerConnection interface.
+func (ec *exhaustConnection) SetStreaming(streaming bool) { ec.streaming = streaming }
+
+// CurrentlyStreaming implements the StreamerConnection interface.
+func (ec *exhaustConnection) CurrentlyStreaming() bool { return ec.streaming }
+
+// SupportsStreaming implements the StreamerConnection interface.
+func (ec *exhaustConnection) SupportsStreaming() bool { return true }
+
 // PostBatchResumeToken returns the latest seen post batch resume token.
 func (bc *BatchCursor) PostBatchResumeToken() bsoncore.Document {
 	return bc.postBatchResumeToken
@@ -0,0 +1,381 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package driver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/address"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/description"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/wiremessage"
+)
+
+func TestBatchCursorExhaust(t *testing.T) {
+	batchReply := func(flags wiremessage.MsgFlag, id int64, vals ...int32) []byte {
+		var docs []bsoncore.Value
+		for _, v := range vals {
+			docs = append(docs, bsoncore.Value{
+				Type: bsontype.EmbeddedDocument,
+				Data: bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendInt32Element(nil, "x", v)),
+			})
+		}
+		doc := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "ok", 1),
+			bsoncore.BuildDocumentElement(nil, "cursor",
+				bsoncore.AppendInt64Element(nil, "id", id),
+				bsoncore.BuildArrayElement(nil, "nextBatch", docs...),
+			),
+		)
+		idx, wm := wiremessage.AppendHeaderStart(nil, wiremessage.NextRequestID(), 0, wiremessage.OpMsg)
+		wm = wiremessage.AppendMsgFlags(wm, flags)
+		wm = wiremessage.AppendMsgSectionType(wm, wiremessage.SingleDocument)
+		wm = append(wm, doc...)
+		return bsoncore.UpdateLength(wm, idx, int32(len(wm[idx:])))
+	}
+	newCursor := func(t *testing.T, conn *exhaustTestConn, monitor ...*event.CommandMonitor) *BatchCursor {
+		t.Helper()
+
+		opts := CursorOptions{Exhaust: true}
+		if len(monitor) > 0 {
+			opts.CommandMonitor = monitor[0]
+		}
+
+		cr := CursorResponse{
+			Server:     &exhaustTestServer{conn: conn},
+			Desc:       conn.Description(),
+			FirstBatch: &bsoncore.DocumentSequence{Style: bsoncore.ArrayStyle, Data: bsoncore.BuildArray(nil)},
+			Database:   "db",
+			Collection: "coll",
+			ID:         42,
+		}
+		bc, err := NewBatchCursor(cr, nil, nil, opts)
+		noerr(t, err)
+		if bc.Next(context.Background()) {
+			t.Fatalf("expected the first batch to be empty")
+		}
+		return bc
+	}
+	next := func(t *testing.T, bc *BatchCursor, want int) {
+		t.Helper()
+
+		if !bc.Next(context.Background()) {
+			t.Fatalf("expected a batch, got none. err: %v", bc.Err())
+		}
+		if got := bc.Batch().DocumentCount(); got != want {
+			t.Fatalf("expected %d documents, got %d", want, got)
+		}
+	}
+
+	t.Run("streamed batches", func(t *testing.T) {
+		conn := newExhaustTestConn(
+			batchReply(wiremessage.MoreToCome, 42, 1, 2),
+			batchReply(wiremessage.MoreToCome, 42, 3),
+			batchReply(0, 0, 4),
+		)
+		bc := newCursor(t, conn)
+		next(t, bc, 2)
+		next(t, bc, 1)
+		next(t, bc, 1)
+		if bc.Next(context.Background()) {
+			t.Fatalf("expected no more batches")
+		}
+		noerr(t, bc.Err())
+
+		if len(conn.written) != 1 {
+			t.Fatalf("expected 1 getMore to be sent, got %d", len(conn.written))
+		}
+		flags, _, _ := wiremessage.ReadMsgFlags(conn.written[0][16:])
+		if flags&wiremessage.ExhaustAllowed == 0 {
+			t.Errorf("expected the exhaustAllowed flag to be set on the getMore")
+		}
+		if conn.closed != 1 || conn.expired {
+			t.Errorf("expected the connection to be returned to the pool, got closed %d, expired %v", conn.closed, conn.expired)
+		}
+	})
+	t.Run("streamed batches are monitored", func(t *testing.T) {
+		var succeeded []*event.CommandSucceededEvent
+		monitor := &event.CommandMonitor{
+			Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+				succeeded = append(succeeded, evt)
+			},
+		}
+		conn := newExhaustTestConn(
+			batchReply(wiremessage.MoreToCome, 42, 1, 2),
+			batchReply(wiremessage.MoreToCome, 42, 3),
+			batchReply(0, 0, 4),
+		)
+		bc := newCursor(t, conn, monitor)
+		for bc.Next(context.Background()) {
+		}
+		noerr(t, bc.Err())
+
+		if len(succeeded) != 3 {
+			t.Fatalf("expected a succeeded event for each of the 3 replies, got %d", len(succeeded))
+		}
+		for i, evt := range succeeded {
+			if evt.CommandName != "getMore" {
+				t.Errorf("expected event %d to be for a getMore, got %q", i, evt.CommandName)
+			}
+			if got := evt.Reply.Lookup("cursor", "nextBatch"); got.Type != bsontype.Array {
+				t.Errorf("expected event %d to contain the batch, got %v", i, evt.Reply)
+			}
+		}
+	})
+	t.Run("close while streaming", func(t *testing.T) {
+		conn := newExhaustTestConn(batchReply(wiremessage.MoreToCome, 42, 1))
+		bc := newCursor(t, conn)
+		next(t, bc, 1)
+		noerr(t, bc.Close(context.Background()))
+
+		if !conn.expired || conn.closed != 0 {
+			t.Errorf("expected the connection to be closed, got closed %d, expired %v", conn.closed, conn.expired)
+		}
+		if len(conn.written) != 1 {
+			t.Errorf("expected no killCursors to be sent on the streaming connection, got %d messages", len(conn.written))
+		}
+	})
+	t.Run("server does not stream", func(t *testing.T) {
+		conn := newExhaustTestConn(batchReply(0, 42, 1), batchReply(0, 0, 2))
+		bc := newCursor(t, conn)
+		next(t, bc, 1)
+		next(t, bc, 1)
+
+		if len(conn.written) != 2 {
+			t.Errorf("expected 2 getMores to be sent, got %d", len(conn.written))
+		}
+		if conn.closed != 2 || conn.expired {
+			t.Errorf("expected the connection to be returned to the pool after each batch, got closed %d, expired %v",
+				conn.closed, conn.expired)
+		}
+	})
+	t.Run("network error", func(t *testing.T) {
+		conn := newExhaustTestConn(batchReply(wiremessage.MoreToCome, 42, 1))
+		bc := newCursor(t, conn)
+		next(t, bc, 1)
+		if bc.Next(context.Background()) {
+			t.Fatalf("expected no batch after a network error")
+		}
+		if err, ok := bc.Err().(Error); !ok || !err.NetworkError() {
+			t.Errorf("expected a network error, got %v", bc.Err())
+		}
+		if !conn.expired {
+			t.Errorf("expected the connection to be closed after a network error")
+		}
+	})
+}
+
+func TestBatchCursorLoadBalanced(t *testing.T) {
+	reply := func(id int64, batch string, vals ...int32) []byte {
+		var docs []bsoncore.Value
+		for _, v := range vals {
+			docs = append(docs, bsoncore.Value{
+				Type: bsontype.EmbeddedDocument,
+				Data: bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendInt32Element(nil, "x", v)),
+			})
+		}
+		return bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "ok", 1),
+			bsoncore.BuildDocumentElement(nil, "cursor",
+				bsoncore.AppendInt64Element(nil, "id", id),
+				bsoncore.AppendStringElement(nil, "ns", "db.coll"),
+				bsoncore.BuildArrayElement(nil, batch, docs...),
+			),
+		)
+	}
+	wireReply := func(flags wiremessage.MsgFlag, id int64, vals ...int32) []byte {
+		idx, wm := wiremessage.AppendHeaderStart(nil, wiremessage.NextRequestID(), 0, wiremessage.OpMsg)
+		wm = wiremessage.AppendMsgFlags(wm, flags)
+		wm = wiremessage.AppendMsgSectionType(wm, wiremessage.SingleDocument)
+		wm = append(wm, reply(id, "nextBatch", vals...)...)
+		return bsoncore.UpdateLength(wm, idx, int32(len(wm[idx:])))
+	}
+	newCursor := func(t *testing.T, conn *pinnedTestConn, id int64, opts ...CursorOptions) *BatchCursor {
+		t.Helper()
+
+		var cursorOpts CursorOptions
+		if len(opts) > 0 {
+			cursorOpts = opts[0]
+		}
+		server := &pinnedServer{Server: &exhaustTestServer{conn: conn.exhaustTestConn}, conn: conn}
+		cr, err := NewCursorResponse(reply(id, "firstBatch", 1), server, conn.Description())
+		noerr(t, err)
+		bc, err := NewBatchCursor(cr, nil, nil, cursorOpts)
+		noerr(t, err)
+		return bc
+	}
+
+	t.Run("exhausted cursor is not pinned", func(t *testing.T) {
+		conn := newPinnedTestConn()
+		bc := newCursor(t, conn, 0)
+		if conn.cursorPins != 0 || bc.pinnedConn != nil {
+			t.Errorf("expected the connection not to be pinned to the cursor")
+		}
+	})
+	t.Run("getMore uses the pinned connection", func(t *testing.T) {
+		conn := newPinnedTestConn(wireReply(0, 42, 2), wireReply(0, 0, 3))
+		bc := newCursor(t, conn, 42)
+		if conn.cursorPins != 1 {
+			t.Fatalf("expected the connection to be pinned to the cursor, got %d pins", conn.cursorPins)
+		}
+
+		for bc.Next(context.Background()) {
+		}
+		noerr(t, bc.Err())
+		if len(conn.written) != 2 {
+			t.Errorf("expected 2 getMores to be sent on the pinned connection, got %d", len(conn.written))
+		}
+		if conn.cursorPins != 0 || conn.closed != 1 || conn.expired {
+			t.Errorf("expected the connection to be unpinned and returned to the pool, got %d pins, closed %d, expired %v",
+				conn.cursorPins, conn.closed, conn.expired)
+		}
+	})
+	t.Run("close unpins the connection", func(t *testing.T) {
+		conn := newPinnedTestConn(wireReply(0, 0))
+		bc := newCursor(t, conn, 42)
+		noerr(t, bc.Close(context.Background()))
+
+		if len(conn.written) != 1 {
+			t.Errorf("expected killCursors to be sent on the pinned connection, got %d messages", len(conn.written))
+		}
+		if conn.cursorPins != 0 || conn.closed != 1 || conn.expired {
+			t.Errorf("expected the connection to be unpinned and returned to the pool, got %d pins, closed %d, expired %v",
+				conn.cursorPins, conn.closed, conn.expired)
+		}
+	})
+	t.Run("network error discards the connection", func(t *testing.T) {
+		conn := newPinnedTestConn()
+		bc := newCursor(t, conn, 42)
+		if !bc.Next(context.Background()) {
+			t.Fatalf("expected the first batch, got none. err: %v", bc.Err())
+		}
+		if bc.Next(context.Background()) {
+			t.Fatalf("expected no batch after a network error")
+		}
+		if conn.cursorPins != 0 || !conn.expired {
+			t.Errorf("expected the connection to be unpinned and closed, got %d pins, expired %v", conn.cursorPins, conn.expired)
+		}
+		noerr(t, bc.Close(context.Background()))
+		if len(conn.written) != 1 {
+			t.Errorf("expected no killCursors to be sent after a network error, got %d messages", len(conn.written))
+		}
+	})
+	t.Run("close while streaming unpins the connection", func(t *testing.T) {
+		conn := newPinnedTestConn(wireReply(wiremessage.MoreToCome, 42, 2))
+		bc := newCursor(t, conn, 42, CursorOptions{Exhaust: true})
+		for i := 0; i < 2; i++ {
+			if !bc.Next(context.Background()) {
+				t.Fatalf("expected batch %d, got none. err: %v", i+1, bc.Err())
+			}
+		}
+		noerr(t, bc.Close(context.Background()))
+
+		if len(conn.written) != 1 {
+			t.Errorf("expected no killCursors to be sent on the streaming connection, got %d messages", len(conn.written))
+		}
+		if conn.cursorPins != 0 || !conn.expired || bc.pinnedConn != nil {
+			t.Errorf("expected the connection to be unpinned and closed, got %d pins, expired %v", conn.cursorPins, conn.expired)
+		}
+	})
+}
+
+type exhaustTestServer struct {
+	conn *exhaustTestConn
+}
+
+func (s *exhaustTestServer) Connection(context.Context) (Connection, error) { return s.conn, nil }
+
+// exhaustTestConn is a connection that returns replies in order and records how it was released.
+type exhaustTestConn struct {
+	replies [][]byte
+	written [][]byte
+	closed  int
+	expired bool
+}
+
+var _ Expirable = (*exhaustTestConn)(nil)
+
+func newExhaustTestConn(replies ...[]byte) *exhaustTestConn {
+	return &exhaustTestConn{replies: replies}
+}
+
+func (c *exhaustTestConn) WriteWireMessage(_ context.Context, wm []byte) error {
+	c.written = append(c.written, append([]byte(nil), wm...))
+	return nil
+}
+
+func (c *exhaustTestConn) ReadWireMessage(_ context.Context, dst []byte) ([]byte, error) {
+	if len(c.replies) == 0 {
+		return nil, errors.New("connection reset")
+	}
+	wm := c.replies[0]
+	c.replies = c.replies[1:]
+	return append(dst, wm...), nil
+}
+
+func (c *exhaustTestConn) Description() description.Server {
+	return description.Server{Kind: description.Standalone, WireVersion: &description.VersionRange{Max: 8}}
+}
+
+func (c *exhaustTestConn) Close() error             { c.closed++; return nil }
+func (c *exhaustTestConn) Expire() error            { c.expired = true; return nil }
+func (c *exhaustTestConn) Alive() bool              { return !c.expired }
+func (c *exhaustTestConn) ID() string               { return "exhaust" }
+func (c *exhaustTestConn) Address() address.Address { return address.Address("localhost:27017") }
+
+// pinnedTestConn is an exhaustTestConn for a server behind a load balancer. Like a pooled connection, closing it is a
+// no-op while it is pinned.
+type pinnedTestConn struct {
+	*exhaustTestConn
+	serviceID  primitive.ObjectID
+	cursorPins int
+	txnPins    int
+}
+
+var _ PinnedConnection = (*pinnedTestConn)(nil)
+
+func newPinnedTestConn(replies ...[]byte) *pinnedTestConn {
+	return &pinnedTestConn{exhaustTestConn: newExhaustTestConn(replies...), serviceID: primitive.NewObjectID()}
+}
+
+func (c *pinnedTestConn) Description() description.Server {
+	desc := c.exhaustTestConn.Description()
+	desc.Kind = description.LoadBalancer
+	desc.ServiceID = &c.serviceID
+	return desc
+}
+
+func (c *pinnedTestConn) Close() error {
+	if c.cursorPins > 0 || c.txnPins > 0 {
+		return nil
+	}
+	return c.exhaustTestConn.Close()
+}
+
+func (c *pinnedTestConn) PinToCursor() error      { c.cursorPins++; return nil }
+func (c *pinnedTestConn) PinToTransaction() error { c.txnPins++; return nil }
+
+func (c *pinnedTestConn) UnpinFromCursor() error {
+	if c.cursorPins == 0 {
+		return errors.New("not pinned to a cursor")
+	}
+	c.cursorPins--
+	return nil
+}
+
+func (c *pinnedTestConn) UnpinFromTransaction() error {
+	if c.txnPins == 0 {
+		return errors.New("not pinned to a transaction")
+	}
+	c.txnPins--
+	return nil
+}
@@ -49,10 +49,14 @@ type ConnString struct {
 	Hosts                              []string
 	J                                  bool
 	JSet                               bool
+	LoadBalanced                       bool
+	LoadBalancedSet                    bool
 	LocalThreshold                     time.Duration
 	LocalThresholdSet                  bool
 	MaxConnIdleTime                    time.Duration
 	MaxConnIdleTimeSet                 bool
+	MaxConnecting                      uint64
+	MaxConnectingSet                   bool
 	MaxPoolSize                        uint64
 	MaxPoolSizeSet                     bool
 	MinPoolSize                        uint64
@@ -76,6 +80,14 @@ type ConnString struct {
 	SocketTimeoutSet                   bool
 	SSL                                bool
 	SSLSet                             bool
+	SSLAllowInvalidCertificates        bool
+	SSLAllowInvalidCertificatesSet     bool
+	SSLAllowInvalidHostnames           bool
+	SSLAllowInvalidHostnamesSet        bool
+	SSLCertificateFile                 string
+	SSLCertificateFileSet              bool
+	SSLPrivateKeyFile                  string
+	SSLPrivateKeyFileSet               bool
 	SSLClientCertificateKeyFile        string
 	SSLClientCertificateKeyFileSet     bool
 	SSLClientCertificateKeyPassword    func() string
@@ -84,6 +96,16 @@ type ConnString struct {
 	SSLInsecureSet                     bool
 	SSLCaFile                          string
 	SSLCaFileSet                       bool
+	SSLCRLFile                         string
+	SSLCRLFileSet                      bool
+	SSLDisableOCSPEndpointCheck        bool
+	SSLDisableOCSPEndpointCheckSet     bool
+	SSLDisableRevocationCheck          bool
+	SSLDisableRevocationCheckSet       bool
+	Timeout                            time.Duration
+	TimeoutSet                         bool
+	WaitQueueTimeout                   time.Duration
+	WaitQueueTimeoutSet                bool
 	WString                            string
 	WNumber                            int
 	WNumberSet                         bool
@@ -247,11 +269,21 @@ func (p *parser) parse(original string) error {
 		return err
 	}
 
+	err = p.validateSSL()
+	if err != nil {
+		return err
+	}
+
 	err = p.validateAuth()
 	if err != nil {
 		return err
 	}
 
+	err = p.validateLoadBalanced()
+	if err != nil {
+		return err
+	}
+
 	// Check for invalid write concern (i.e. w=0 and j=true)
 	if p.WNumberSet && p.WNumber == 0 && p.JSet && p.J {
 		return writeconcern.ErrInconsistent
@@ -283,7 +315,7 @@ func (p *parser) setDefaultAuthParams(dbName string) error {
 			p.AuthMechanismProperties["SERVICE_NAME"] = "mongodb"
 		}
 		fallthrough
-	case "mongodb-x509":
+	case "mongodb-aws", "mongodb-x509":
 		if p.AuthSource == "" {
 			p.AuthSource = "$external"
 		} else if p.AuthSource != "$external" {
@@ -341,6 +373,18 @@ func (p *parser) validateAuth() error {
 				return fmt.Errorf("invalid auth property for GSSAPI")
 			}
 		}
+	case "mongodb-aws":
+		if p.Username != "" && p.Password == "" {
+			return fmt.Errorf("password required for MONGODB-AWS when username is specified")
+		}
+		if p.Username == "" && p.Password != "" {
+			return fmt.Errorf("username required for MONGODB-AWS when password is specified")
+		}
+		for k := range p.AuthMechanismProperties {
+			if k != "AWS_SESSION_TOKEN" {
+				return fmt.Errorf("invalid auth property for MONGODB-AWS")
+			}
+		}
 	case "plain":
 		if p.Username == "" {
 			return fmt.Errorf("username required for PLAIN")
@@ -381,6 +425,56 @@ func (p *parser) validateAuth() error {
 	return nil
 }
 
+func (p *parser) validateLoadBalanced() error {
+	if !p.LoadBalanced {
+		return nil
+	}
+	if len(p.Hosts) > 1 {
+		return errors.New("loadBalanced cannot be specified with multiple hosts")
+	}
+	if p.ReplicaSet != "" {
+		return errors.New("loadBalanced cannot be specified with replicaSet")
+	}
+	if p.ConnectSet && p.Connect == SingleConnect {
+		return errors.New("loadBalanced cannot be specified with connect=direct")
+	}
+	return nil
+}
+
+func (p *parser) validateSSL() error {
+	if p.SSLInsecureSet {
+		if p.SSLAllowInvalidCertificatesSet {
+			return errors.New("tlsInsecure and tlsAllowInvalidCertificates cannot be specified together")
+		}
+		if p.SSLAllowInvalidHostnamesSet {
+			return errors.New("tlsInsecure and tlsAllowInvalidHostnames cannot be specified together")
+		}
+		if p.SSLDisableOCSPEndpointCheckSet {
+			return errors.New("tlsInsecure and tlsDisableOCSPEndpointCheck cannot be specified together")
+		}
+		if p.SSLDisableRevocationCheckSet {
+			return errors.New("tlsInsecure and tlsDisableCertificateRevocationCheck cannot be specified together")
+		}
+	}
+	if p.SSLAllowInvalidCertificatesSet {
+		if p.SSLDisableOCSPEndpointCheckSet {
+			return errors.New("tlsAllowInvalidCertificates and tlsDisableOCSPEndpointCheck cannot be specified together")
+		}
+		if p.SSLDisableRevocationCheckSet {
+			return errors.New("tlsAllowInvalidCertificates and tlsDisableCertificateRevocationCheck cannot be " +
+				"specified together")
+		}
+	}
+
+	if p.SSLClientCertificateKeyFileSet && (p.SSLCertificateFileSet || p.SSLPrivateKeyFileSet) {
+		return errors.New("tlsCertificateKeyFile cannot be specified with tlsCertificateFile or tlsPrivateKeyFile")
+	}
+	if p.SSLCertificateFileSet != p.SSLPrivateKeyFileSet {
+		return errors.New("tlsCertificateFile and tlsPrivateKeyFile must be specified together")
+	}
+	return nil
+}
+
 func (p *parser) addHost(host string) error {
 	if host == "" {
 		return nil
@@ -487,6 +581,17 @@ func (p *parser) addOption(pair string) error {
 		}
 
 		p.JSet = true
+	case "loadbalanced":
+		switch value {
+		case "true":
+			p.LoadBalanced = true
+		case "false":
+			p.LoadBalanced = false
+		default:
+			return fmt.Errorf("invalid value for %s: %s", key, value)
+		}
+
+		p.LoadBalancedSet = true
 	case "localthresholdms":
 		n, err := strconv.Atoi(value)
 		if err != nil || n < 0 {
@@ -501,6 +606,13 @@ func (p *parser) addOption(pair string) error {
 		}
 		p.MaxConnIdleTime = time.Duration(n) * time.Millisecond
 		p.MaxConnIdleTimeSet = true
+	case "maxconnecting":
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid value for %s: %s", key, value)
+		}
+		p.MaxConnecting = uint64(n)
+		p.MaxConnectingSet = true
 	case "maxpoolsize":
 		n, err := strconv.Atoi(value)
 		if err != nil || n < 0 {
@@ -597,6 +709,38 @@ func (p *parser) addOption(pair string) error {
 		*p.tlsssl = p.SSL
 
 		p.SSLSet = true
+	case "sslallowinvalidcertificates", "tlsallowinvalidcertificates":
+		switch value {
+		case "true":
+			p.SSLAllowInvalidCertificates = true
+		case "false":
+			p.SSLAllowInvalidCertificates = false
+		default:
+			return fmt.Errorf("invalid value for %s: %s", key, value)
+		}
+
+		p.SSLAllowInvalidCertificatesSet = true
+	case "sslallowinvalidhostnames", "tlsallowinvalidhostnames":
+		switch value {
+		case "true":
+			p.SSLAllowInvalidHostnames = true
+		case "false":
+			p.SSLAllowInvalidHostnames = false
+		default:
+			return fmt.Errorf("invalid value for %s: %s", key, value)
+		}
+
+		p.SSLAllowInvalidHostnamesSet = true
+	case "tlscertificatefile":
+		p.SSL = true
+		p.SSLSet = true
+		p.SSLCertificateFile = value
+		p.SSLCertificateFileSet = true
+	case "tlsprivatekeyfile":
+		p.SSL = true
+		p.SSLSet = true
+		p.SSLPrivateKeyFile = value
+		p.SSLPrivateKeyFileSet = true
 	case "sslclientcertificatekeyfile", "tlscertificatekeyfile":
 		p.SSL = true
 		p.SSLSet = true
@@ -621,6 +765,47 @@ func (p *parser) addOption(pair string) error {
 		p.SSLSet = true
 		p.SSLCaFile = value
 		p.SSLCaFileSet = true
+	case "tlscrlfile":
+		p.SSL = true
+		p.SSLSet = true
+		p.SSLCRLFile = value
+		p.SSLCRLFileSet = true
+	case "tlsdisableocspendpointcheck":
+		switch value {
+		case "true":
+			p.SSLDisableOCSPEndpointCheck = true
+		case "false":
+			p.SSLDisableOCSPEndpointCheck = false
+		default:
+			return fmt.Errorf("invalid value for %s: %s", key, value)
+		}
+
+		p.SSLDisableOCSPEndpointCheckSet = true
+	case "tlsdisablecertificaterevocationcheck":
+		switch value {
+		case "true":
+			p.SSLDisableRevocationCheck = true
+		case "false":
+			p.SSLDisableRevocationCheck = false
+		default:
+			return fmt.Errorf("invalid value for %s: %s", key, value)
+		}
+
+		p.SSLDisableRevocationCheckSet = true
+	case "timeoutms":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid value for %s: %s", key, value)
+		}
+		p.Timeout = time.Duration(n) * time.Millisecond
+		p.TimeoutSet = true
+	case "waitqueuetimeoutms":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid value for %s: %s", key, value)
+		}
+		p.WaitQueueTimeout = time.Duration(n) * time.Millisecond
+		p.WaitQueueTimeoutSet = true
 	case "w":
 		if w, err := strconv.Atoi(value); err == nil {
 			if w < 0 {
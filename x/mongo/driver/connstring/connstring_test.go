@@ -171,6 +171,36 @@ func TestHeartbeatInterval(t *testing.T) {
 	}
 }
 
+func TestLoadBalanced(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected bool
+		err      bool
+	}{
+		{s: "mongodb://localhost/?loadBalanced=true", expected: true},
+		{s: "mongodb://localhost/?loadBalanced=false", expected: false},
+		{s: "mongodb://localhost/?loadBalanced=foobar", err: true},
+		{s: "mongodb://localhost,localhost:27018/?loadBalanced=true", err: true},
+		{s: "mongodb://localhost,localhost:27018/?loadBalanced=false", expected: false},
+		{s: "mongodb://localhost/?loadBalanced=true&replicaSet=rs0", err: true},
+		{s: "mongodb://localhost/?loadBalanced=true&connect=direct", err: true},
+		{s: "mongodb://localhost/?loadBalanced=true&connect=automatic", expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			cs, err := connstring.Parse(test.s)
+			if test.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, cs.LoadBalanced)
+			require.True(t, cs.LoadBalancedSet)
+		})
+	}
+}
+
 func TestLocalThreshold(t *testing.T) {
 	tests := []struct {
 		s        string
@@ -224,6 +254,34 @@ func TestMaxConnIdleTime(t *testing.T) {
 	}
 }
 
+func TestMaxConnecting(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected uint64
+		err      bool
+	}{
+		{s: "maxConnecting=1", expected: 1},
+		{s: "maxConnecting=10", expected: 10},
+		{s: "maxConnecting=0", err: true},
+		{s: "maxConnecting=-2", err: true},
+		{s: "maxConnecting=gsdge", err: true},
+	}
+
+	for _, test := range tests {
+		s := fmt.Sprintf("mongodb://localhost/?%s", test.s)
+		t.Run(s, func(t *testing.T) {
+			cs, err := connstring.Parse(s)
+			if test.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.True(t, cs.MaxConnectingSet)
+				require.Equal(t, test.expected, cs.MaxConnecting)
+			}
+		})
+	}
+}
+
 func TestMaxPoolSize(t *testing.T) {
 	tests := []struct {
 		s        string
@@ -251,6 +309,33 @@ func TestMaxPoolSize(t *testing.T) {
 	}
 }
 
+func TestWaitQueueTimeout(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected time.Duration
+		err      bool
+	}{
+		{s: "waitQueueTimeoutMS=10", expected: 10 * time.Millisecond},
+		{s: "waitQueueTimeoutMS=0", expected: 0},
+		{s: "waitQueueTimeoutMS=-2", err: true},
+		{s: "waitQueueTimeoutMS=gsdge", err: true},
+	}
+
+	for _, test := range tests {
+		s := fmt.Sprintf("mongodb://localhost/?%s", test.s)
+		t.Run(s, func(t *testing.T) {
+			cs, err := connstring.Parse(s)
+			if test.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.True(t, cs.WaitQueueTimeoutSet)
+				require.Equal(t, test.expected, cs.WaitQueueTimeout)
+			}
+		})
+	}
+}
+
 func TestMinPoolSize(t *testing.T) {
 	tests := []struct {
 		s        string
@@ -496,6 +581,188 @@ func TestSocketTimeout(t *testing.T) {
 	}
 }
 
+func TestTLSAllowInvalidCertificates(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected bool
+		err      bool
+	}{
+		{s: "tlsAllowInvalidCertificates=true", expected: true},
+		{s: "tlsAllowInvalidCertificates=false", expected: false},
+		{s: "sslAllowInvalidCertificates=true", expected: true},
+		{s: "tlsAllowInvalidCertificates=1", err: true},
+		{s: "tlsAllowInvalidCertificates=true&tlsInsecure=true", err: true},
+		{s: "tlsAllowInvalidCertificates=true&tlsDisableOCSPEndpointCheck=true", err: true},
+		{s: "tlsAllowInvalidCertificates=true&tlsDisableCertificateRevocationCheck=true", err: true},
+	}
+
+	for _, test := range tests {
+		s := fmt.Sprintf("mongodb://localhost/?%s", test.s)
+		t.Run(s, func(t *testing.T) {
+			cs, err := connstring.Parse(s)
+			if test.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, cs.SSLAllowInvalidCertificates)
+				require.True(t, cs.SSLAllowInvalidCertificatesSet)
+			}
+		})
+	}
+}
+
+func TestTLSAllowInvalidHostnames(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected bool
+		err      bool
+	}{
+		{s: "tlsAllowInvalidHostnames=true", expected: true},
+		{s: "tlsAllowInvalidHostnames=false", expected: false},
+		{s: "sslAllowInvalidHostnames=true", expected: true},
+		{s: "tlsAllowInvalidHostnames=true&tlsDisableOCSPEndpointCheck=true", expected: true},
+		{s: "tlsAllowInvalidHostnames=yes", err: true},
+		{s: "tlsInsecure=false&tlsAllowInvalidHostnames=true", err: true},
+	}
+
+	for _, test := range tests {
+		s := fmt.Sprintf("mongodb://localhost/?%s", test.s)
+		t.Run(s, func(t *testing.T) {
+			cs, err := connstring.Parse(s)
+			if test.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, cs.SSLAllowInvalidHostnames)
+				require.True(t, cs.SSLAllowInvalidHostnamesSet)
+			}
+		})
+	}
+}
+
+func TestTLSCertificateAndPrivateKeyFiles(t *testing.T) {
+	tests := []struct {
+		s        string
+		certFile string
+		keyFile  string
+		err      bool
+	}{
+		{s: "tlsCertificateFile=cert.pem&tlsPrivateKeyFile=key.pem", certFile: "cert.pem", keyFile: "key.pem"},
+		{s: "tlsCertificateFile=cert.pem", err: true},
+		{s: "tlsPrivateKeyFile=key.pem", err: true},
+		{s: "tlsCertificateKeyFile=both.pem&tlsCertificateFile=cert.pem&tlsPrivateKeyFile=key.pem", err: true},
+	}
+
+	for _, test := range tests {
+		s := fmt.Sprintf("mongodb://localhost/?%s", test.s)
+		t.Run(s, func(t *testing.T) {
+			cs, err := connstring.Parse(s)
+			if test.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.True(t, cs.SSL)
+				require.Equal(t, test.certFile, cs.SSLCertificateFile)
+				require.True(t, cs.SSLCertificateFileSet)
+				require.Equal(t, test.keyFile, cs.SSLPrivateKeyFile)
+				require.True(t, cs.SSLPrivateKeyFileSet)
+			}
+		})
+	}
+}
+
+func TestTLSCRLFile(t *testing.T) {
+	cs, err := connstring.Parse("mongodb://localhost/?tlsCRLFile=crl.pem")
+	require.NoError(t, err)
+	require.True(t, cs.SSL)
+	require.Equal(t, "crl.pem", cs.SSLCRLFile)
+	require.True(t, cs.SSLCRLFileSet)
+}
+
+func TestTLSDisableOCSPEndpointCheck(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected bool
+		err      bool
+	}{
+		{s: "tlsDisableOCSPEndpointCheck=true", expected: true},
+		{s: "tlsDisableOCSPEndpointCheck=false", expected: false},
+		{s: "tlsDisableOCSPEndpointCheck=1", err: true},
+		{s: "tlsDisableOCSPEndpointCheck=true&tlsInsecure=true", err: true},
+		{s: "tlsInsecure=false&tlsDisableOCSPEndpointCheck=false", err: true},
+	}
+
+	for _, test := range tests {
+		s := fmt.Sprintf("mongodb://localhost/?%s", test.s)
+		t.Run(s, func(t *testing.T) {
+			cs, err := connstring.Parse(s)
+			if test.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, cs.SSLDisableOCSPEndpointCheck)
+				require.True(t, cs.SSLDisableOCSPEndpointCheckSet)
+			}
+		})
+	}
+}
+
+func TestTLSDisableCertificateRevocationCheck(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected bool
+		err      bool
+	}{
+		{s: "tlsDisableCertificateRevocationCheck=true", expected: true},
+		{s: "tlsDisableCertificateRevocationCheck=false", expected: false},
+		{s: "tlsDisableCertificateRevocationCheck=yes", err: true},
+		{s: "tlsDisableCertificateRevocationCheck=true&tlsInsecure=true", err: true},
+		{s: "tlsInsecure=false&tlsDisableCertificateRevocationCheck=false", err: true},
+	}
+
+	for _, test := range tests {
+		s := fmt.Sprintf("mongodb://localhost/?%s", test.s)
+		t.Run(s, func(t *testing.T) {
+			cs, err := connstring.Parse(s)
+			if test.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, cs.SSLDisableRevocationCheck)
+				require.True(t, cs.SSLDisableRevocationCheckSet)
+			}
+		})
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected time.Duration
+		err      bool
+	}{
+		{s: "timeoutMS=0", expected: time.Duration(0)},
+		{s: "timeoutMS=10", expected: time.Duration(10) * time.Millisecond},
+		{s: "timeoutMS=100", expected: time.Duration(100) * time.Millisecond},
+		{s: "timeoutMS=-2", err: true},
+		{s: "timeoutMS=gsdge", err: true},
+	}
+
+	for _, test := range tests {
+		s := fmt.Sprintf("mongodb://localhost/?%s", test.s)
+		t.Run(s, func(t *testing.T) {
+			cs, err := connstring.Parse(s)
+			if test.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, cs.Timeout)
+				require.True(t, cs.TimeoutSet)
+			}
+		})
+	}
+}
+
 func TestWTimeout(t *testing.T) {
 	tests := []struct {
 		s        string
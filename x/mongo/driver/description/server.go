@@ -45,10 +45,12 @@ type Server struct {
 	MaxMessageSize        uint32
 	Members               []address.Address
 	ReadOnly              bool
+	ServiceID             *primitive.ObjectID // only set for servers that are deployed behind a load balancer
 	SessionTimeoutMinutes uint32
 	SetName               string
 	SetVersion            uint32
 	Tags                  tag.Set
+	TopologyVersion       *TopologyVersion
 	Kind                  ServerKind
 	WireVersion           *VersionRange
 
@@ -238,6 +240,13 @@ func NewServer(addr address.Address, response bsoncore.Document) Server {
 				return desc
 			}
 			desc.SetVersion = uint32(i64)
+		case "serviceId":
+			oid, ok := element.Value().ObjectIDOK()
+			if !ok {
+				desc.LastError = fmt.Errorf("expected 'serviceId' to be an ObjectId but it's a BSON %s", element.Value().Type)
+				return desc
+			}
+			desc.ServiceID = &oid
 		case "tags":
 			m, err := decodeStringMap(element, "tags")
 			if err != nil {
@@ -245,6 +254,12 @@ func NewServer(addr address.Address, response bsoncore.Document) Server {
 				return desc
 			}
 			desc.Tags = tag.NewTagSetFromMap(m)
+		case "topologyVersion":
+			desc.TopologyVersion, err = NewTopologyVersionFromValue(element.Value())
+			if err != nil {
+				desc.LastError = err
+				return desc
+			}
 		}
 	}
 
@@ -305,6 +320,103 @@ func (s Server) DataBearing() bool {
 		s.Kind == Standalone
 }
 
+// Equal compares two server descriptions and returns true if they are equal. Round trip times and update times are
+// not compared, as they change on every heartbeat without the server's state changing.
+func (s Server) Equal(other Server) bool {
+	if s.CanonicalAddr.String() != other.CanonicalAddr.String() {
+		return false
+	}
+
+	if !sliceStringEqual(s.Compression, other.Compression) {
+		return false
+	}
+
+	if s.ElectionID != other.ElectionID {
+		return false
+	}
+
+	if s.Kind != other.Kind {
+		return false
+	}
+
+	if !errorsEqual(s.LastError, other.LastError) {
+		return false
+	}
+
+	if !s.LastWriteTime.Equal(other.LastWriteTime) {
+		return false
+	}
+
+	if s.MaxBatchCount != other.MaxBatchCount {
+		return false
+	}
+
+	if s.MaxDocumentSize != other.MaxDocumentSize {
+		return false
+	}
+
+	if s.MaxMessageSize != other.MaxMessageSize {
+		return false
+	}
+
+	if len(s.Members) != len(other.Members) {
+		return false
+	}
+
+	membersSet := make(map[string]bool)
+	for _, member := range s.Members {
+		membersSet[member.String()] = true
+	}
+	for _, member := range other.Members {
+		if !membersSet[member.String()] {
+			return false
+		}
+	}
+
+	if s.ReadOnly != other.ReadOnly {
+		return false
+	}
+
+	if (s.ServiceID == nil) != (other.ServiceID == nil) {
+		return false
+	}
+	if s.ServiceID != nil && *s.ServiceID != *other.ServiceID {
+		return false
+	}
+
+	if s.SessionTimeoutMinutes != other.SessionTimeoutMinutes {
+		return false
+	}
+
+	if s.SetName != other.SetName {
+		return false
+	}
+
+	if s.SetVersion != other.SetVersion {
+		return false
+	}
+
+	if len(s.Tags) != len(other.Tags) || !s.Tags.ContainsAll(other.Tags) {
+		return false
+	}
+
+	if (s.TopologyVersion == nil) != (other.TopologyVersion == nil) {
+		return false
+	}
+	if s.TopologyVersion != nil && *s.TopologyVersion != *other.TopologyVersion {
+		return false
+	}
+
+	if (s.WireVersion == nil) != (other.WireVersion == nil) {
+		return false
+	}
+	if s.WireVersion != nil && *s.WireVersion != *other.WireVersion {
+		return false
+	}
+
+	return true
+}
+
 // SelectServer selects this server if it is in the list of given candidates.
 func (s Server) SelectServer(_ Topology, candidates []Server) ([]Server, error) {
 	for _, candidate := range candidates {
@@ -355,3 +467,22 @@ func decodeStringMap(element bsoncore.Element, name string) (map[string]string,
 	}
 	return m, nil
 }
+
+func sliceStringEqual(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if v != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func errorsEqual(a, b error) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Error() == b.Error()
+}
@@ -28,6 +28,31 @@ func (t Topology) Server(addr address.Address) (Server, bool) {
 	return Server{}, false
 }
 
+// Equal compares two topology descriptions and returns true if they are equal. Servers are compared by address
+// using Server.Equal and the order of the servers is ignored.
+func (t Topology) Equal(other Topology) bool {
+	if t.Kind != other.Kind || t.SessionTimeoutMinutes != other.SessionTimeoutMinutes {
+		return false
+	}
+
+	if len(t.Servers) != len(other.Servers) {
+		return false
+	}
+
+	servers := make(map[string]Server, len(t.Servers))
+	for _, s := range t.Servers {
+		servers[s.Addr.String()] = s
+	}
+	for _, s := range other.Servers {
+		otherServer, ok := servers[s.Addr.String()]
+		if !ok || !s.Equal(otherServer) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // TopologyDiff is the difference between two different topology descriptions.
 type TopologyDiff struct {
 	Added   []Server
@@ -63,3 +63,37 @@ func TestTopology_DiffHostlist(t *testing.T) {
 	assert.EqualValues(t, []Server{s6, s1, s3, s2}, topo.Servers)
 	assert.EqualValues(t, []string{h2, h4, h3, h5}, hostlist)
 }
+
+func TestTopology_Equal(t *testing.T) {
+	wv := NewVersionRange(0, 8)
+	s1 := Server{Addr: "1.0.0.0:27017", CanonicalAddr: "1.0.0.0:27017", Kind: RSPrimary, WireVersion: &wv}
+	s2 := Server{Addr: "2.0.0.0:27017", CanonicalAddr: "2.0.0.0:27017", Kind: RSSecondary, WireVersion: &wv}
+
+	t.Run("server order is ignored", func(t *testing.T) {
+		t1 := Topology{Kind: ReplicaSetWithPrimary, Servers: []Server{s1, s2}}
+		t2 := Topology{Kind: ReplicaSetWithPrimary, Servers: []Server{s2, s1}}
+		assert.True(t, t1.Equal(t2))
+	})
+	t.Run("RTT changes are ignored", func(t *testing.T) {
+		t1 := Topology{Kind: ReplicaSetWithPrimary, Servers: []Server{s1, s2}}
+		t2 := Topology{Kind: ReplicaSetWithPrimary, Servers: []Server{s1.SetAverageRTT(10), s2}}
+		assert.True(t, t1.Equal(t2))
+	})
+	t.Run("different kind", func(t *testing.T) {
+		t1 := Topology{Kind: ReplicaSetWithPrimary, Servers: []Server{s1, s2}}
+		t2 := Topology{Kind: ReplicaSetNoPrimary, Servers: []Server{s1, s2}}
+		assert.False(t, t1.Equal(t2))
+	})
+	t.Run("different server kind", func(t *testing.T) {
+		s2Unknown := s2
+		s2Unknown.Kind = Unknown
+		t1 := Topology{Kind: ReplicaSetWithPrimary, Servers: []Server{s1, s2}}
+		t2 := Topology{Kind: ReplicaSetWithPrimary, Servers: []Server{s1, s2Unknown}}
+		assert.False(t, t1.Equal(t2))
+	})
+	t.Run("different servers", func(t *testing.T) {
+		t1 := Topology{Kind: ReplicaSetWithPrimary, Servers: []Server{s1, s2}}
+		t2 := Topology{Kind: ReplicaSetWithPrimary, Servers: []Server{s1}}
+		assert.False(t, t1.Equal(t2))
+	})
+}
@@ -0,0 +1,36 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package description
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func TestNewTopologyVersionFromValue(t *testing.T) {
+	processID := primitive.NewObjectID()
+	tvDoc := bsoncore.BuildDocument(nil,
+		bsoncore.AppendObjectIDElement(nil, "processId", processID),
+		bsoncore.AppendInt64Element(nil, "counter", 5),
+	)
+
+	tv, err := NewTopologyVersionFromValue(bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: tvDoc})
+	assert.Nil(t, err, "NewTopologyVersionFromValue error: %v", err)
+	assert.Equal(t, TopologyVersion{ProcessID: processID, Counter: 5}, *tv, "expected %v, got %v",
+		TopologyVersion{ProcessID: processID, Counter: 5}, *tv)
+
+	_, err = NewTopologyVersionFromValue(bsoncore.Value{Type: bsontype.Int32, Data: bsoncore.AppendInt32(nil, 1)})
+	assert.NotNil(t, err, "expected error for a non-document value, got nil")
+
+	invalid := bsoncore.BuildDocument(nil, bsoncore.AppendInt32Element(nil, "counter", 5))
+	_, err = NewTopologyVersionFromValue(bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: invalid})
+	assert.NotNil(t, err, "expected error for an int32 counter, got nil")
+}
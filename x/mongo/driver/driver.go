@@ -54,11 +54,43 @@ type Connection interface {
 	Address() address.Address
 }
 
+// StreamerConnection represents a Connection that supports streaming wire protocol messages using the moreToCome and
+// exhaustAllowed flags.
+//
+// The SetStreaming and CurrentlyStreaming functions correspond to the moreToCome flag on server responses. If a
+// response has moreToCome set, SetStreaming(true) will be called and CurrentlyStreaming() should return true.
+//
+// SupportsStreaming corresponds to the exhaustAllowed flag. The operations layer will set exhaustAllowed on outgoing
+// wire messages to inform the server that the driver supports streaming.
+type StreamerConnection interface {
+	Connection
+	SetStreaming(bool)
+	CurrentlyStreaming() bool
+	SupportsStreaming() bool
+}
+
 // LocalAddresser is a type that is able to supply its local address
 type LocalAddresser interface {
 	LocalAddress() address.Address
 }
 
+// PinnedConnection represents a Connection that can be pinned by one or more cursors or transactions. This is used
+// when the deployment is behind a load balancer, where every command of a cursor or transaction must be sent over the
+// connection it started on. Implementations must maintain the following invariants:
+//
+// 1. Each Pin* call increments the number of resources that have pinned the connection.
+//
+// 2. Each Unpin* call decrements the number of resources that have pinned the connection.
+//
+// 3. Calls to Close are ignored until every resource has unpinned the connection.
+type PinnedConnection interface {
+	Connection
+	PinToCursor() error
+	PinToTransaction() error
+	UnpinFromCursor() error
+	UnpinFromTransaction() error
+}
+
 // Expirable represents an expirable object.
 type Expirable interface {
 	Expire() error
@@ -74,9 +106,10 @@ type Compressor interface {
 
 // ErrorProcessor implementations can handle processing errors, which may modify their internal state.
 // If this type is implemented by a Server, then Operation.Execute will call it's ProcessError
-// method after it decodes a wire message.
+// method after it decodes a wire message. The connection the error occurred on is passed so that
+// servers behind a load balancer can scope their error handling to the connection's service.
 type ErrorProcessor interface {
-	ProcessError(error)
+	ProcessError(err error, conn Connection)
 }
 
 // Handshaker is the interface implemented by types that can perform a MongoDB
@@ -141,6 +174,25 @@ type nopCloserConnection struct{ Connection }
 
 func (ncc nopCloserConnection) Close() error { return nil }
 
+// SetStreaming passes through to the wrapped connection if it is a StreamerConnection.
+func (ncc nopCloserConnection) SetStreaming(streaming bool) {
+	if sc, ok := ncc.Connection.(StreamerConnection); ok {
+		sc.SetStreaming(streaming)
+	}
+}
+
+// CurrentlyStreaming passes through to the wrapped connection if it is a StreamerConnection.
+func (ncc nopCloserConnection) CurrentlyStreaming() bool {
+	sc, ok := ncc.Connection.(StreamerConnection)
+	return ok && sc.CurrentlyStreaming()
+}
+
+// SupportsStreaming passes through to the wrapped connection if it is a StreamerConnection.
+func (ncc nopCloserConnection) SupportsStreaming() bool {
+	sc, ok := ncc.Connection.(StreamerConnection)
+	return ok && sc.SupportsStreaming()
+}
+
 // TODO(GODRIVER-617): We can likely use 1 type for both the Type and the RetryMode by using
 // 2 bits for the mode and 1 bit for the type. Although in the practical sense, we might not want to
 // do that since the type of retryability is tied to the operation itself and isn't going change,
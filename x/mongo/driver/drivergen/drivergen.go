@@ -248,6 +248,8 @@ func (p Properties) Builtins() []Builtin {
 		ClusterClock:   {},
 		Collection:     {},
 		Crypt:          {},
+		Timeout:        {},
+		ServerAPI:      {},
 	}
 	for _, builtin := range p.Disabled {
 		delete(defaults, builtin)
@@ -278,9 +280,16 @@ func (p Properties) Builtins() []Builtin {
 	if _, ok := defaults[Crypt]; ok {
 		builtins = append(builtins, Crypt)
 	}
+	if _, ok := defaults[Timeout]; ok {
+		builtins = append(builtins, Timeout)
+	}
+	if _, ok := defaults[ServerAPI]; ok {
+		builtins = append(builtins, ServerAPI)
+	}
 	for _, builtin := range p.Enabled {
 		switch builtin {
-		case Deployment, Database, Selector, CommandMonitor, ClientSession, ClusterClock, Collection, Crypt:
+		case Deployment, Database, Selector, CommandMonitor, ClientSession, ClusterClock, Collection, Crypt, Timeout,
+			ServerAPI:
 			continue // If someone added a default to enable, just ignore it.
 		}
 		builtins = append(builtins, builtin)
@@ -393,6 +402,8 @@ const (
 	Database       Builtin = "database"
 	Deployment     Builtin = "deployment"
 	Crypt          Builtin = "crypt"
+	Timeout        Builtin = "timeout"
+	ServerAPI      Builtin = "serverAPI"
 )
 
 // ExecuteName provides the name used when setting this built-in on a driver.Operation.
@@ -419,6 +430,10 @@ func (b Builtin) ExecuteName() string {
 		execname = "Deployment"
 	case Crypt:
 		execname = "Crypt"
+	case Timeout:
+		execname = "Timeout"
+	case ServerAPI:
+		execname = "ServerAPI"
 	}
 	return execname
 }
@@ -450,6 +465,10 @@ func (b Builtin) ReferenceName() string {
 		refname = "deployment"
 	case Crypt:
 		refname = "crypt"
+	case Timeout:
+		refname = "timeout"
+	case ServerAPI:
+		refname = "serverAPI"
 	}
 	return refname
 }
@@ -480,6 +499,10 @@ func (b Builtin) SetterName() string {
 		setter = "Deployment"
 	case Crypt:
 		setter = "Crypt"
+	case Timeout:
+		setter = "Timeout"
+	case ServerAPI:
+		setter = "ServerAPI"
 	}
 	return setter
 }
@@ -510,6 +533,10 @@ func (b Builtin) Type() string {
 		t = "driver.Deployment"
 	case Crypt:
 		t = "*driver.Crypt"
+	case Timeout:
+		t = "*time.Duration"
+	case ServerAPI:
+		t = "*driver.ServerAPIOptions"
 	}
 	return t
 }
@@ -540,6 +567,10 @@ func (b Builtin) Documentation() string {
 		doc = "Deployment sets the deployment to use for this operation."
 	case Crypt:
 		doc = "Crypt sets the Crypt object to use for automatic encryption and decryption."
+	case Timeout:
+		doc = "Timeout sets the timeout for this operation."
+	case ServerAPI:
+		doc = "ServerAPI sets the server API version for this operation."
 	}
 	return doc
 }
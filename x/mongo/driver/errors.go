@@ -32,6 +32,9 @@ var (
 	// ErrUnsupportedStorageEngine is returned when a retryable write is attempted against a server
 	// that uses a storage engine that does not support retryable writes
 	ErrUnsupportedStorageEngine = errors.New("this MongoDB deployment does not support retryable writes. Please add retryWrites=false to your connection string")
+	// ErrDeadlineWouldBeExceeded is returned when an operation has a timeout and there is not enough time remaining
+	// before its deadline to send a command to the server.
+	ErrDeadlineWouldBeExceeded = errors.New("operation not sent to the server because its deadline would be exceeded")
 )
 
 // QueryFailureError is an error representing a command failure as a document.
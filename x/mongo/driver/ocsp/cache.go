@@ -0,0 +1,100 @@
+// Copyright (C) MongoDB, Inc. 2020-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package ocsp
+
+import (
+	"crypto"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// Cache represents an OCSP response cache. Implementations must be goroutine safe.
+type Cache interface {
+	// Update stores the response for the request if it is newer than the currently cached response and returns the
+	// response that should be used. Responses without a nextUpdate time are never cached.
+	Update(*ocsp.Request, *ResponseDetails) *ResponseDetails
+
+	// Get returns the cached response for the request, or nil if there is no unexpired cached response.
+	Get(request *ocsp.Request) *ResponseDetails
+}
+
+// cacheKey identifies a certificate. It contains the fields of an OCSP request's CertID.
+type cacheKey struct {
+	HashAlgorithm  crypto.Hash
+	IssuerNameHash string
+	IssuerKeyHash  string
+	SerialNumber   string
+}
+
+// ConcurrentCache is an implementation of Cache that is safe for concurrent use.
+type ConcurrentCache struct {
+	cache map[cacheKey]*ResponseDetails
+	sync.Mutex
+}
+
+var _ Cache = (*ConcurrentCache)(nil)
+
+// NewCache creates an empty OCSP cache.
+func NewCache() *ConcurrentCache {
+	return &ConcurrentCache{
+		cache: make(map[cacheKey]*ResponseDetails),
+	}
+}
+
+// Update implements the Cache interface.
+func (c *ConcurrentCache) Update(request *ocsp.Request, response *ResponseDetails) *ResponseDetails {
+	key := createCacheKey(request)
+
+	c.Lock()
+	defer c.Unlock()
+
+	current := c.getLocked(key)
+	if response == nil {
+		return current
+	}
+	if response.NextUpdate.IsZero() {
+		return response
+	}
+	if current == nil || response.NextUpdate.After(current.NextUpdate) {
+		c.cache[key] = response
+		return response
+	}
+	return current
+}
+
+// Get implements the Cache interface.
+func (c *ConcurrentCache) Get(request *ocsp.Request) *ResponseDetails {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.getLocked(createCacheKey(request))
+}
+
+// getLocked returns the unexpired response for the key, removing it from the cache if it has expired. The caller
+// must hold the lock.
+func (c *ConcurrentCache) getLocked(key cacheKey) *ResponseDetails {
+	current, ok := c.cache[key]
+	if !ok {
+		return nil
+	}
+	if time.Now().After(current.NextUpdate) {
+		delete(c.cache, key)
+		return nil
+	}
+	return current
+}
+
+func createCacheKey(request *ocsp.Request) cacheKey {
+	return cacheKey{
+		HashAlgorithm:  request.HashAlgorithm,
+		IssuerNameHash: string(request.IssuerNameHash),
+		IssuerKeyHash:  string(request.IssuerKeyHash),
+		SerialNumber:   request.SerialNumber.String(),
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (C) MongoDB, Inc. 2020-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package ocsp
+
+import (
+	"crypto"
+	"math/big"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"golang.org/x/crypto/ocsp"
+)
+
+func TestCache(t *testing.T) {
+	request := &ocsp.Request{
+		HashAlgorithm:  crypto.SHA1,
+		IssuerNameHash: []byte("name"),
+		IssuerKeyHash:  []byte("key"),
+		SerialNumber:   big.NewInt(1),
+	}
+	now := time.Now()
+	current := &ResponseDetails{Status: ocsp.Good, NextUpdate: now.Add(time.Hour)}
+
+	t.Run("get", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			cached   *ResponseDetails
+			expected *ResponseDetails
+		}{
+			{"empty cache", nil, nil},
+			{"unexpired response", current, current},
+			{"expired response", &ResponseDetails{NextUpdate: now.Add(-time.Hour)}, nil},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				cache := NewCache()
+				if tc.cached != nil {
+					cache.cache[createCacheKey(request)] = tc.cached
+				}
+				got := cache.Get(request)
+				assert.Equal(t, tc.expected, got, "expected response %v, got %v", tc.expected, got)
+			})
+		}
+	})
+	t.Run("update", func(t *testing.T) {
+		newer := &ResponseDetails{Status: ocsp.Revoked, NextUpdate: now.Add(2 * time.Hour)}
+		older := &ResponseDetails{Status: ocsp.Revoked, NextUpdate: now.Add(30 * time.Minute)}
+		noNextUpdate := &ResponseDetails{Status: ocsp.Good}
+		expired := &ResponseDetails{Status: ocsp.Good, NextUpdate: now.Add(-time.Hour)}
+
+		testCases := []struct {
+			name           string
+			cached         *ResponseDetails
+			update         *ResponseDetails
+			expectedReturn *ResponseDetails
+			expectedCached *ResponseDetails
+		}{
+			{"new response added to empty cache", nil, current, current, current},
+			{"nil response", current, nil, current, current},
+			{"newer response replaces cached", current, newer, newer, newer},
+			{"older response ignored", current, older, current, current},
+			{"response without nextUpdate not cached", nil, noNextUpdate, noNextUpdate, nil},
+			{"expired response replaced", expired, older, older, older},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				cache := NewCache()
+				if tc.cached != nil {
+					cache.cache[createCacheKey(request)] = tc.cached
+				}
+
+				got := cache.Update(request, tc.update)
+				assert.Equal(t, tc.expectedReturn, got, "expected Update to return %v, got %v", tc.expectedReturn, got)
+				cached := cache.cache[createCacheKey(request)]
+				assert.Equal(t, tc.expectedCached, cached, "expected cached response %v, got %v", tc.expectedCached, cached)
+			})
+		}
+	})
+}
@@ -0,0 +1,56 @@
+// Copyright (C) MongoDB, Inc. 2020-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package ocsp
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+type config struct {
+	serverCert, issuer      *x509.Certificate
+	cache                   Cache
+	disableEndpointChecking bool
+	httpClient              *http.Client
+	ocspRequest             *ocsp.Request
+	ocspRequestBytes        []byte
+}
+
+func newConfig(certChain []*x509.Certificate, opts *VerifyOptions) (config, error) {
+	cfg := config{
+		serverCert:              certChain[0],
+		issuer:                  certChain[1],
+		cache:                   opts.Cache,
+		disableEndpointChecking: opts.DisableEndpointChecking,
+		httpClient:              opts.HTTPClient,
+	}
+	if cfg.httpClient == nil {
+		cfg.httpClient = http.DefaultClient
+	}
+
+	var err error
+	cfg.ocspRequestBytes, err = ocsp.CreateRequest(cfg.serverCert, cfg.issuer, nil)
+	if err != nil {
+		return cfg, fmt.Errorf("error creating OCSP request: %v", err)
+	}
+	cfg.ocspRequest, err = ocsp.ParseRequest(cfg.ocspRequestBytes)
+	if err != nil {
+		return cfg, fmt.Errorf("error parsing OCSP request bytes: %v", err)
+	}
+	return cfg, nil
+}
+
+// updateCache stores the response in the cache, if there is one, and returns the response that should be used.
+func (cfg config) updateCache(res *ResponseDetails) *ResponseDetails {
+	if cfg.cache == nil {
+		return res
+	}
+	return cfg.cache.Update(cfg.ocspRequest, res)
+}
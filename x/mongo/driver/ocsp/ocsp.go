@@ -0,0 +1,250 @@
+// Copyright (C) MongoDB, Inc. 2020-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package ocsp implements OCSP certificate revocation checking for TLS connections. Stapled responses are verified
+// first, OCSP responders are contacted if no usable stapled response is available, and validated responses are
+// stored in a Cache so they can be reused by later connections.
+package ocsp // import "go.mongodb.org/mongo-driver/x/mongo/driver/ocsp"
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+var (
+	// tlsFeatureExtensionOID is the OID of the TLS Feature certificate extension defined in RFC 7633.
+	tlsFeatureExtensionOID = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 24}
+	// mustStapleFeatureValue is the value of the status_request TLS feature, which marks a certificate as Must-Staple.
+	mustStapleFeatureValue = big.NewInt(5)
+
+	defaultRequestTimeout = 5 * time.Second
+)
+
+// Error represents an OCSP verification error.
+type Error struct {
+	wrapped error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return fmt.Sprintf("OCSP verification failed: %v", e.wrapped)
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.wrapped
+}
+
+func newOCSPError(wrapped error) error {
+	return &Error{wrapped: wrapped}
+}
+
+// ResponseDetails contains the subset of an OCSP response that is needed after the response has been validated.
+type ResponseDetails struct {
+	Status     int
+	NextUpdate time.Time
+}
+
+func extractResponseDetails(res *ocsp.Response) *ResponseDetails {
+	return &ResponseDetails{
+		Status:     res.Status,
+		NextUpdate: res.NextUpdate,
+	}
+}
+
+// VerifyOptions specifies options to configure OCSP verification.
+type VerifyOptions struct {
+	// Cache is used to store and look up validated OCSP responses. If nil, responses are not reused between calls
+	// to Verify.
+	Cache Cache
+
+	// DisableEndpointChecking prevents OCSP responders from being contacted if the server does not staple a response.
+	DisableEndpointChecking bool
+
+	// HTTPClient is the client used to contact OCSP responders. If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+}
+
+// Verify performs OCSP verification for the provided ConnectionState, which must be the result of a completed TLS
+// handshake with certificate verification enabled. An error is returned if the server's certificate is revoked, if
+// a stapled response is invalid, or if the certificate requires a stapled response and none was provided. If no
+// response can be obtained, the certificate is assumed to be valid.
+func Verify(ctx context.Context, connState tls.ConnectionState, opts *VerifyOptions) error {
+	if opts == nil {
+		opts = &VerifyOptions{}
+	}
+	if len(connState.VerifiedChains) == 0 {
+		return newOCSPError(errors.New("no verified certificate chains reported after TLS handshake"))
+	}
+
+	certChain := connState.VerifiedChains[0]
+	if len(certChain) < 2 {
+		// The server certificate is itself a trusted root, so there is no issuer to check it against.
+		return nil
+	}
+
+	cfg, err := newConfig(certChain, opts)
+	if err != nil {
+		return newOCSPError(err)
+	}
+
+	res, err := getParsedResponse(ctx, cfg, connState)
+	if err != nil {
+		return err
+	}
+	if res == nil {
+		// No response could be obtained, so we soft-fail and allow the connection.
+		return nil
+	}
+
+	if res.Status == ocsp.Revoked {
+		return newOCSPError(errors.New("certificate is revoked"))
+	}
+	return nil
+}
+
+// getParsedResponse returns the details of a validated OCSP response for the server certificate, or nil if no
+// response could be obtained.
+func getParsedResponse(ctx context.Context, cfg config, connState tls.ConnectionState) (*ResponseDetails, error) {
+	if cfg.cache != nil {
+		if cached := cfg.cache.Get(cfg.ocspRequest); cached != nil {
+			return cached, nil
+		}
+	}
+
+	if connState.OCSPResponse != nil {
+		res, err := processStaple(cfg, connState.OCSPResponse)
+		if err != nil {
+			return nil, err
+		}
+		return cfg.updateCache(res), nil
+	}
+
+	mustStaple, err := isMustStapleCertificate(cfg.serverCert)
+	if err != nil {
+		return nil, newOCSPError(err)
+	}
+	if mustStaple {
+		return nil, newOCSPError(errors.New("server provided a certificate with the Must-Staple extension but did " +
+			"not staple an OCSP response"))
+	}
+
+	if cfg.disableEndpointChecking {
+		return nil, nil
+	}
+	res := contactResponders(ctx, cfg)
+	if res == nil {
+		return nil, nil
+	}
+	return cfg.updateCache(res), nil
+}
+
+// processStaple parses and validates a stapled OCSP response. Unlike responses fetched from a responder, an invalid
+// stapled response is a hard failure.
+func processStaple(cfg config, staple []byte) (*ResponseDetails, error) {
+	parsed, err := ocsp.ParseResponseForCert(staple, cfg.serverCert, cfg.issuer)
+	if err != nil {
+		return nil, newOCSPError(fmt.Errorf("error parsing stapled response: %v", err))
+	}
+	if err = verifyResponse(parsed); err != nil {
+		return nil, newOCSPError(fmt.Errorf("error validating stapled response: %v", err))
+	}
+	return extractResponseDetails(parsed), nil
+}
+
+// isMustStapleCertificate returns true if the certificate has a TLS Feature extension that includes status_request.
+func isMustStapleCertificate(cert *x509.Certificate) (bool, error) {
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(tlsFeatureExtensionOID) {
+			continue
+		}
+
+		var features []*big.Int
+		if _, err := asn1.Unmarshal(ext.Value, &features); err != nil {
+			return false, fmt.Errorf("error unmarshalling TLS feature extension values: %v", err)
+		}
+		for _, feature := range features {
+			if feature.Cmp(mustStapleFeatureValue) == 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return false, nil
+}
+
+// contactResponders sends the OCSP request to each responder listed in the server certificate in turn and returns
+// the first valid response. Errors from individual responders are ignored and nil is returned if no responder
+// provided a valid response.
+func contactResponders(ctx context.Context, cfg config) *ResponseDetails {
+	for _, endpoint := range cfg.serverCert.OCSPServer {
+		res, err := contactResponder(ctx, cfg, endpoint)
+		if err != nil {
+			continue
+		}
+		return res
+	}
+	return nil
+}
+
+func contactResponder(ctx context.Context, cfg config, endpoint string) (*ResponseDetails, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(cfg.ocspRequestBytes))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/ocsp-request")
+
+	httpRes, err := cfg.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = httpRes.Body.Close() }()
+
+	if httpRes.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OCSP responder returned status %d", httpRes.StatusCode)
+	}
+	body, err := ioutil.ReadAll(httpRes.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := ocsp.ParseResponseForCert(body, cfg.serverCert, cfg.issuer)
+	if err != nil {
+		return nil, err
+	}
+	if err = verifyResponse(parsed); err != nil {
+		return nil, err
+	}
+	return extractResponseDetails(parsed), nil
+}
+
+// verifyResponse checks that the response is currently valid. The signature and the certificate the response is for
+// are checked by ocsp.ParseResponseForCert.
+func verifyResponse(res *ocsp.Response) error {
+	now := time.Now()
+	if res.ThisUpdate.After(now) {
+		return fmt.Errorf("reported thisUpdate time %s is after current time %s", res.ThisUpdate, now)
+	}
+	if !res.NextUpdate.IsZero() && res.NextUpdate.Before(now) {
+		return fmt.Errorf("reported nextUpdate time %s is before current time %s", res.NextUpdate, now)
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+// Copyright (C) MongoDB, Inc. 2020-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package ocsp
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"golang.org/x/crypto/ocsp"
+)
+
+func TestVerify(t *testing.T) {
+	ca := newTestCA(t)
+
+	t.Run("stapled responses", func(t *testing.T) {
+		responder := newTestResponder(t, ca)
+		defer responder.Close()
+		leaf := ca.newLeaf(t, []string{responder.URL}, false)
+		// The responder reports the certificate as revoked so the tests can assert that it isn't contacted.
+		responder.setResponse(ca.newResponse(t, leaf, ocsp.Revoked, time.Now().Add(time.Hour)))
+
+		testCases := []struct {
+			name      string
+			staple    []byte
+			expectErr bool
+		}{
+			{"good", ca.newResponse(t, leaf, ocsp.Good, time.Now().Add(time.Hour)), false},
+			{"revoked", ca.newResponse(t, leaf, ocsp.Revoked, time.Now().Add(time.Hour)), true},
+			{"expired", ca.newResponse(t, leaf, ocsp.Good, time.Now().Add(-time.Minute)), true},
+			{"malformed", []byte{1, 2, 3}, true},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := Verify(context.Background(), ca.connState(leaf, tc.staple), &VerifyOptions{})
+				checkVerifyError(t, err, tc.expectErr)
+				assert.Equal(t, int32(0), responder.requestCount(), "expected responder not to be contacted")
+			})
+		}
+	})
+	t.Run("responder", func(t *testing.T) {
+		testCases := []struct {
+			name                    string
+			status                  int
+			disableEndpointChecking bool
+			expectErr               bool
+			expectedRequests        int32
+		}{
+			{"good", ocsp.Good, false, false, 1},
+			{"revoked", ocsp.Revoked, false, true, 1},
+			{"unknown", ocsp.Unknown, false, false, 1},
+			{"endpoint checking disabled", ocsp.Revoked, true, false, 0},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				responder := newTestResponder(t, ca)
+				defer responder.Close()
+				leaf := ca.newLeaf(t, []string{responder.URL}, false)
+				responder.setResponse(ca.newResponse(t, leaf, tc.status, time.Now().Add(time.Hour)))
+
+				opts := &VerifyOptions{DisableEndpointChecking: tc.disableEndpointChecking}
+				err := Verify(context.Background(), ca.connState(leaf, nil), opts)
+				checkVerifyError(t, err, tc.expectErr)
+				assert.Equal(t, tc.expectedRequests, responder.requestCount(), "expected %v responder requests, got %v",
+					tc.expectedRequests, responder.requestCount())
+			})
+		}
+	})
+	t.Run("unavailable responder soft fails", func(t *testing.T) {
+		responder := newTestResponder(t, ca)
+		leaf := ca.newLeaf(t, []string{responder.URL}, false)
+		responder.Close()
+
+		err := Verify(context.Background(), ca.connState(leaf, nil), &VerifyOptions{})
+		assert.Nil(t, err, "Verify error: %v", err)
+	})
+	t.Run("responders are tried in order", func(t *testing.T) {
+		failing := newTestResponder(t, ca)
+		defer failing.Close()
+		responder := newTestResponder(t, ca)
+		defer responder.Close()
+		leaf := ca.newLeaf(t, []string{failing.URL, responder.URL}, false)
+		responder.setResponse(ca.newResponse(t, leaf, ocsp.Revoked, time.Now().Add(time.Hour)))
+
+		err := Verify(context.Background(), ca.connState(leaf, nil), &VerifyOptions{})
+		checkVerifyError(t, err, true)
+		assert.Equal(t, int32(1), failing.requestCount(), "expected first responder to be contacted")
+	})
+	t.Run("must-staple certificate without staple", func(t *testing.T) {
+		responder := newTestResponder(t, ca)
+		defer responder.Close()
+		leaf := ca.newLeaf(t, []string{responder.URL}, true)
+		responder.setResponse(ca.newResponse(t, leaf, ocsp.Good, time.Now().Add(time.Hour)))
+
+		err := Verify(context.Background(), ca.connState(leaf, nil), &VerifyOptions{})
+		checkVerifyError(t, err, true)
+
+		staple := ca.newResponse(t, leaf, ocsp.Good, time.Now().Add(time.Hour))
+		err = Verify(context.Background(), ca.connState(leaf, staple), &VerifyOptions{})
+		assert.Nil(t, err, "Verify error with stapled response: %v", err)
+	})
+	t.Run("cached responses are reused", func(t *testing.T) {
+		responder := newTestResponder(t, ca)
+		defer responder.Close()
+		leaf := ca.newLeaf(t, []string{responder.URL}, false)
+		responder.setResponse(ca.newResponse(t, leaf, ocsp.Revoked, time.Now().Add(time.Hour)))
+
+		opts := &VerifyOptions{Cache: NewCache()}
+		for i := 0; i < 2; i++ {
+			err := Verify(context.Background(), ca.connState(leaf, nil), opts)
+			checkVerifyError(t, err, true)
+		}
+		assert.Equal(t, int32(1), responder.requestCount(), "expected 1 responder request, got %v",
+			responder.requestCount())
+
+		// A stapled response should not be consulted if there is a cached response.
+		staple := ca.newResponse(t, leaf, ocsp.Good, time.Now().Add(time.Hour))
+		err := Verify(context.Background(), ca.connState(leaf, staple), opts)
+		checkVerifyError(t, err, true)
+	})
+	t.Run("self-signed certificate", func(t *testing.T) {
+		connState := tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{ca.cert}}}
+		err := Verify(context.Background(), connState, nil)
+		assert.Nil(t, err, "Verify error: %v", err)
+	})
+	t.Run("no verified chains", func(t *testing.T) {
+		err := Verify(context.Background(), tls.ConnectionState{}, nil)
+		checkVerifyError(t, err, true)
+	})
+}
+
+func checkVerifyError(t *testing.T, err error, expectErr bool) {
+	t.Helper()
+
+	if !expectErr {
+		assert.Nil(t, err, "Verify error: %v", err)
+		return
+	}
+	_, ok := err.(*Error)
+	assert.True(t, ok, "expected error of type %T, got %v (type %T)", &Error{}, err, err)
+}
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+
+	key := newTestKey(t)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "OCSP test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err, "CreateCertificate error: %v", err)
+	cert, err := x509.ParseCertificate(der)
+	assert.Nil(t, err, "ParseCertificate error: %v", err)
+
+	return &testCA{cert: cert, key: key}
+}
+
+var testSerial int64 = 1
+
+func (ca *testCA) newLeaf(t *testing.T, ocspServers []string, mustStaple bool) *x509.Certificate {
+	t.Helper()
+
+	key := newTestKey(t)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(atomic.AddInt64(&testSerial, 1)),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		OCSPServer:   ocspServers,
+	}
+	if mustStaple {
+		value, err := asn1.Marshal([]int{5})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		template.ExtraExtensions = []pkix.Extension{{Id: tlsFeatureExtensionOID, Value: value}}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	assert.Nil(t, err, "CreateCertificate error: %v", err)
+	cert, err := x509.ParseCertificate(der)
+	assert.Nil(t, err, "ParseCertificate error: %v", err)
+	return cert
+}
+
+func (ca *testCA) newResponse(t *testing.T, leaf *x509.Certificate, status int, nextUpdate time.Time) []byte {
+	t.Helper()
+
+	template := ocsp.Response{
+		Status:       status,
+		SerialNumber: leaf.SerialNumber,
+		ThisUpdate:   nextUpdate.Add(-2 * time.Hour),
+		NextUpdate:   nextUpdate,
+	}
+	if status == ocsp.Revoked {
+		template.RevokedAt = template.ThisUpdate
+		template.RevocationReason = ocsp.KeyCompromise
+	}
+	res, err := ocsp.CreateResponse(ca.cert, ca.cert, template, ca.key)
+	assert.Nil(t, err, "CreateResponse error: %v", err)
+	return res
+}
+
+func (ca *testCA) connState(leaf *x509.Certificate, staple []byte) tls.ConnectionState {
+	return tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{leaf, ca.cert}},
+		OCSPResponse:   staple,
+	}
+}
+
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err, "GenerateKey error: %v", err)
+	return key
+}
+
+// testResponder is a local OCSP responder that replies to every request with a fixed response. If no response has
+// been set, it replies with an HTTP error.
+type testResponder struct {
+	*httptest.Server
+	response atomic.Value
+	requests int32
+}
+
+func newTestResponder(t *testing.T, ca *testCA) *testResponder {
+	t.Helper()
+
+	r := &testResponder{}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&r.requests, 1)
+
+		res, _ := r.response.Load().([]byte)
+		if res == nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/ocsp-response")
+		_, _ = w.Write(res)
+	}))
+	return r
+}
+
+func (r *testResponder) setResponse(res []byte) {
+	r.response.Store(res)
+}
+
+func (r *testResponder) requestCount() int32 {
+	return atomic.LoadInt32(&r.requests)
+}
@@ -23,6 +23,13 @@ import (
 
 const defaultLocalThreshold = 15 * time.Millisecond
 
+const (
+	// minRetryBackoff and maxRetryBackoff bound the wait between attempts of an operation that is retried until its
+	// context expires.
+	minRetryBackoff = 10 * time.Millisecond
+	maxRetryBackoff = time.Second
+)
+
 var dollarCmd = [...]byte{'.', '$', 'c', 'm', 'd'}
 
 var (
@@ -179,6 +186,18 @@ type Operation struct {
 
 	// Crypt specifies a Crypt object to use for automatic client side encryption and decryption.
 	Crypt *Crypt
+
+	// Timeout is the amount of time that the whole operation, including server selection, connection checkout,
+	// retries, and reading the response, can take. If the context passed to Execute already has a deadline, that
+	// deadline is used instead. When a deadline is in effect, a maxTimeMS value derived from the remaining time is
+	// added to the command unless the command already specifies one. Operations that fail with a retryable error are
+	// retried until the deadline expires. A nil or zero Timeout means no client-side timeout is applied.
+	Timeout *time.Duration
+
+	// ServerAPI specifies the server API version declared by the application. If set, the apiVersion, apiStrict and
+	// apiDeprecationErrors fields are added to the command unless it is a getMore or a command in a transaction other
+	// than the first.
+	ServerAPI *ServerAPIOptions
 }
 
 // shouldEncrypt returns true if this operation should automatically be encrypted.
@@ -238,12 +257,20 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 		return err
 	}
 
+	if op.Timeout != nil && *op.Timeout > 0 {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, *op.Timeout)
+			defer cancel()
+		}
+	}
+
 	srvr, err := op.selectServer(ctx)
 	if err != nil {
 		return err
 	}
 
-	conn, err := srvr.Connection(ctx)
+	conn, err := op.getConnection(ctx, srvr)
 	if err != nil {
 		return err
 	}
@@ -274,7 +301,7 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 	var res bsoncore.Document
 	var operationErr WriteCommandError
 	var original error
-	var retries int
+	var retries, retried int
 	retryable := op.retryable(desc.Server)
 	if retryable && op.RetryMode != nil {
 		switch op.Type {
@@ -288,6 +315,9 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 			case RetryContext:
 				retries = -1
 			}
+			if op.timeoutEnabled(ctx) && *op.RetryMode > RetryNone {
+				retries = -1
+			}
 
 			op.Client.RetryWrite = false
 			if *op.RetryMode > RetryNone {
@@ -304,6 +334,9 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 			case RetryContext:
 				retries = -1
 			}
+			if op.timeoutEnabled(ctx) && *op.RetryMode > RetryNone {
+				retries = -1
+			}
 		}
 	}
 	batching := op.Batches.Valid()
@@ -330,7 +363,7 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 		if len(scratch) > 0 {
 			scratch = scratch[:0]
 		}
-		wm, startedInfo, err := op.createWireMessage(ctx, scratch, desc)
+		wm, startedInfo, err := op.createWireMessage(ctx, scratch, desc, conn)
 		if err != nil {
 			return err
 		}
@@ -366,7 +399,7 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 		}
 		res, err = roundTrip(ctx, conn, wm)
 		if ep, ok := srvr.(ErrorProcessor); ok {
-			ep.ProcessError(err)
+			ep.ProcessError(err, conn)
 		}
 
 		finishedInfo.response = res
@@ -390,7 +423,12 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 		}
 		var perr error
 		if op.ProcessResponseFn != nil {
-			perr = op.ProcessResponseFn(res, srvr, desc.Server)
+			respSrvr := srvr
+			if pinnedConn, ok := conn.(PinnedConnection); ok && desc.Server.ServiceID != nil {
+				// Cursors created by this response must send their getMores over the same connection.
+				respSrvr = &pinnedServer{Server: srvr, conn: pinnedConn}
+			}
+			perr = op.ProcessResponseFn(res, respSrvr, desc.Server)
 		}
 		switch tt := err.(type) {
 		case WriteCommandError:
@@ -401,11 +439,15 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 				retries--
 				original, err = err, nil
 				conn.Close() // Avoid leaking the connection.
+				if retries < 0 && !retryBackoff(ctx, retried) {
+					return original
+				}
+				retried++
 				srvr, err = op.selectServer(ctx)
 				if err != nil {
 					return original
 				}
-				conn, err = srvr.Connection(ctx)
+				conn, err = op.getConnection(ctx, srvr)
 				if err != nil || conn == nil || !op.retryable(conn.Description()) {
 					if conn != nil {
 						conn.Close()
@@ -441,7 +483,7 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 			operationErr.WriteErrors = append(operationErr.WriteErrors, tt.WriteErrors...)
 		case Error:
 			if tt.HasErrorLabel(TransientTransactionError) || tt.HasErrorLabel(UnknownTransactionCommitResult) {
-				op.Client.ClearPinnedServer()
+				_ = op.Client.ClearPinnedResources()
 			}
 			if e := err.(Error); retryable && op.Type == Write && e.UnsupportedStorageEngine() {
 				return ErrUnsupportedStorageEngine
@@ -450,11 +492,15 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 				retries--
 				original, err = err, nil
 				conn.Close() // Avoid leaking the connection.
+				if retries < 0 && !retryBackoff(ctx, retried) {
+					return original
+				}
+				retried++
 				srvr, err = op.selectServer(ctx)
 				if err != nil {
 					return original
 				}
-				conn, err = srvr.Connection(ctx)
+				conn, err = op.getConnection(ctx, srvr)
 				if err != nil || conn == nil || !op.retryable(conn.Description()) {
 					if conn != nil {
 						conn.Close()
@@ -490,7 +536,7 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 				if *op.RetryMode > RetryNone {
 					op.Client.IncrementTxnNumber()
 				}
-				if *op.RetryMode == RetryOncePerCommand {
+				if *op.RetryMode == RetryOncePerCommand && !op.timeoutEnabled(ctx) {
 					retries = 1
 				}
 			}
@@ -505,6 +551,87 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 	return nil
 }
 
+// getConnection returns a connection to srvr for this operation. When the deployment is behind a load balancer, every
+// command of a transaction must be sent over the same connection, so the connection the session's transaction is
+// pinned to is returned, and the connection used for the first command of a transaction is pinned to it.
+func (op Operation) getConnection(ctx context.Context, srvr Server) (Connection, error) {
+	if op.Client != nil && op.Client.PinnedConnection != nil &&
+		(op.Client.TransactionRunning() || op.Client.Committing || op.Client.Aborting) {
+		return op.Client.PinnedConnection, nil
+	}
+
+	conn, err := srvr.Connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if op.Client == nil || !op.Client.TransactionStarting() || conn.Description().ServiceID == nil {
+		return conn, nil
+	}
+
+	pinnedConn, ok := conn.(PinnedConnection)
+	if !ok {
+		_ = conn.Close()
+		return nil, fmt.Errorf("expected the connection used to start a transaction to be a PinnedConnection, but got %T", conn)
+	}
+	if err := pinnedConn.PinToTransaction(); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("error pinning the connection to the transaction: %v", err)
+	}
+	op.Client.PinnedConnection = pinnedConn
+	return pinnedConn, nil
+}
+
+// ExecuteExhaust reads a response from the provided StreamerConnection. This will error if the connection's
+// CurrentlyStreaming function returns false. A CommandSucceededEvent or CommandFailedEvent is published for the
+// response, with the RequestID of the message it responds to.
+func (op Operation) ExecuteExhaust(ctx context.Context, conn StreamerConnection, scratch []byte) error {
+	if !conn.CurrentlyStreaming() {
+		return errors.New("exhaust read must be done with a connection that is currently streaming")
+	}
+
+	if op.Timeout != nil && *op.Timeout > 0 {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, *op.Timeout)
+			defer cancel()
+		}
+	}
+
+	finishedInfo := finishedInformation{
+		cmdName:   op.exhaustCommandName(conn.Description()),
+		startTime: time.Now(),
+		connID:    conn.ID(),
+	}
+	scratch = scratch[:0]
+	res, responseTo, err := op.readWireMessage(ctx, conn, scratch)
+	finishedInfo.requestID = responseTo
+	finishedInfo.response = res
+	finishedInfo.cmdErr = err
+	op.publishFinishedEvent(ctx, finishedInfo)
+	if err != nil {
+		return err
+	}
+	if op.ProcessResponseFn != nil {
+		return op.ProcessResponseFn(res, nil, description.Server{})
+	}
+	return nil
+}
+
+// exhaustCommandName returns the name of the command whose responses are being streamed, for the events published by
+// ExecuteExhaust. The command is only built if the operation is being monitored.
+func (op Operation) exhaustCommandName(desc description.Server) string {
+	if op.CommandMonitor == nil || op.CommandFn == nil {
+		return ""
+	}
+	idx, cmd := bsoncore.AppendDocumentStart(nil)
+	cmd, err := op.CommandFn(cmd, description.SelectedServer{Server: desc})
+	if err != nil || len(cmd) == int(idx)+4 {
+		return ""
+	}
+	cmd, _ = bsoncore.AppendDocumentEnd(cmd, idx)
+	return op.getCommandName(cmd)
+}
+
 // Retryable writes are supported if the server supports sessions, the operation is not
 // within a transaction, and the write is acknowledged
 func (op Operation) retryable(desc description.Server) bool {
@@ -549,6 +676,16 @@ func (op Operation) roundTrip(ctx context.Context, conn Connection, wm []byte) (
 		return nil, Error{Message: err.Error(), Labels: labels, Wrapped: err}
 	}
 
+	res, _, err := op.readWireMessage(ctx, conn, wm)
+	return res, err
+}
+
+// readWireMessage reads a wiremessage from the connection and decodes the command response from it. The wm parameter
+// is reused when reading the wiremessage. If the connection is a StreamerConnection, its streaming state is updated
+// based on the moreToCome flag of the response. The responseTo field of the wiremessage is returned with the response.
+func (op Operation) readWireMessage(ctx context.Context, conn Connection, wm []byte) ([]byte, int32, error) {
+	var err error
+
 	wm, err = conn.ReadWireMessage(ctx, wm[:0])
 	if err != nil {
 		labels := []string{NetworkError}
@@ -561,13 +698,21 @@ func (op Operation) roundTrip(ctx context.Context, conn Connection, wm []byte) (
 		if op.Client != nil && op.Client.Committing {
 			labels = append(labels, UnknownTransactionCommitResult)
 		}
-		return nil, Error{Message: err.Error(), Labels: labels, Wrapped: err}
+		return nil, 0, Error{Message: err.Error(), Labels: labels, Wrapped: err}
 	}
 
+	_, _, responseTo, _, _, _ := wiremessage.ReadHeader(wm)
+
 	// decompress wiremessage
 	wm, err = op.decompressWireMessage(wm)
 	if err != nil {
-		return nil, err
+		return nil, responseTo, err
+	}
+
+	// If we're using a streamable connection, we set its streaming state based on the moreToCome flag in the server
+	// response.
+	if streamer, ok := conn.(StreamerConnection); ok {
+		streamer.SetStreaming(wiremessage.IsMsgMoreToCome(wm))
 	}
 
 	// decode
@@ -577,7 +722,7 @@ func (op Operation) roundTrip(ctx context.Context, conn Connection, wm []byte) (
 	op.updateClusterTimes(res)
 	op.updateOperationTime(res)
 
-	return res, err
+	return res, responseTo, err
 }
 
 // moreToComeRoundTrip writes a wiremessage to the provided connection. This is used when an OP_MSG is
@@ -640,12 +785,12 @@ func (Operation) decompressWireMessage(wm []byte) ([]byte, error) {
 }
 
 func (op Operation) createWireMessage(ctx context.Context, dst []byte,
-	desc description.SelectedServer) ([]byte, startedInformation, error) {
+	desc description.SelectedServer, conn Connection) ([]byte, startedInformation, error) {
 
 	if desc.WireVersion == nil || desc.WireVersion.Max < wiremessage.OpmsgWireVersion {
-		return op.createQueryWireMessage(dst, desc)
+		return op.createQueryWireMessage(ctx, dst, desc)
 	}
-	return op.createMsgWireMessage(ctx, dst, desc)
+	return op.createMsgWireMessage(ctx, dst, desc, conn)
 }
 
 func (op Operation) addBatchArray(dst []byte) []byte {
@@ -657,7 +802,9 @@ func (op Operation) addBatchArray(dst []byte) []byte {
 	return dst
 }
 
-func (op Operation) createQueryWireMessage(dst []byte, desc description.SelectedServer) ([]byte, startedInformation, error) {
+func (op Operation) createQueryWireMessage(ctx context.Context, dst []byte,
+	desc description.SelectedServer) ([]byte, startedInformation, error) {
+
 	var info startedInformation
 	flags := op.slaveOK(desc)
 	var wmindex int32
@@ -689,6 +836,12 @@ func (op Operation) createQueryWireMessage(dst []byte, desc description.Selected
 	if op.Batches != nil && len(op.Batches.Current) > 0 {
 		dst = op.addBatchArray(dst)
 	}
+	dst = op.addServerAPI(dst)
+
+	dst, err = op.addMaxTimeMS(ctx, dst, idx, desc)
+	if err != nil {
+		return dst, info, err
+	}
 
 	dst, err = op.addReadConcern(dst, desc)
 	if err != nil {
@@ -723,7 +876,9 @@ func (op Operation) createQueryWireMessage(dst []byte, desc description.Selected
 	return bsoncore.UpdateLength(dst, wmindex, int32(len(dst[wmindex:]))), info, nil
 }
 
-func (op Operation) createMsgWireMessage(ctx context.Context, dst []byte, desc description.SelectedServer) ([]byte, startedInformation, error) {
+func (op Operation) createMsgWireMessage(ctx context.Context, dst []byte, desc description.SelectedServer,
+	conn Connection) ([]byte, startedInformation, error) {
+
 	var info startedInformation
 	var flags wiremessage.MsgFlag
 	var wmindex int32
@@ -732,6 +887,11 @@ func (op Operation) createMsgWireMessage(ctx context.Context, dst []byte, desc d
 	if op.WriteConcern != nil && !writeconcern.AckWrite(op.WriteConcern) && (op.Batches == nil || len(op.Batches.Documents) == 0) {
 		flags = wiremessage.MoreToCome
 	}
+	// Set the ExhaustAllowed flag if the connection supports streaming. This will tell the server that it can
+	// respond with the MoreToCome flag and then stream responses over this connection.
+	if streamer, ok := conn.(StreamerConnection); ok && streamer.SupportsStreaming() {
+		flags |= wiremessage.ExhaustAllowed
+	}
 	info.requestID = wiremessage.NextRequestID()
 	wmindex, dst = wiremessage.AppendHeaderStart(dst, info.requestID, 0, wiremessage.OpMsg)
 	dst = wiremessage.AppendMsgFlags(dst, flags)
@@ -744,6 +904,10 @@ func (op Operation) createMsgWireMessage(ctx context.Context, dst []byte, desc d
 	if err != nil {
 		return dst, info, err
 	}
+	dst, err = op.addMaxTimeMS(ctx, dst, idx, desc)
+	if err != nil {
+		return dst, info, err
+	}
 	dst, err = op.addReadConcern(dst, desc)
 	if err != nil {
 		return dst, info, err
@@ -797,7 +961,11 @@ func (op Operation) createMsgWireMessage(ctx context.Context, dst []byte, desc d
 // has already been added and does not add the final 0 byte.
 func (op Operation) addCommandFields(ctx context.Context, dst []byte, desc description.SelectedServer) ([]byte, error) {
 	if !op.shouldEncrypt() {
-		return op.CommandFn(dst, desc)
+		dst, err := op.CommandFn(dst, desc)
+		if err != nil {
+			return dst, err
+		}
+		return op.addServerAPI(dst), nil
 	}
 
 	if desc.WireVersion.Max < cryptMinWireVersion {
@@ -824,7 +992,94 @@ func (op Operation) addCommandFields(ctx context.Context, dst []byte, desc descr
 	}
 	// append encrypted command to original destination, removing the first 4 bytes (length) and final byte (terminator)
 	dst = append(dst, encrypted[4:len(encrypted)-1]...)
-	return dst, nil
+	return op.addServerAPI(dst), nil
+}
+
+// addServerAPI adds the fields for the declared server API version to dst. The fields are not added to getMore commands
+// or to commands in a transaction other than the first because the server uses the values from the command that
+// created the cursor or started the transaction.
+func (op Operation) addServerAPI(dst []byte) []byte {
+	if op.ServerAPI == nil || op.Legacy == LegacyGetMore {
+		return dst
+	}
+	if op.Client != nil && (op.Client.TransactionInProgress() || op.Client.Committing || op.Client.Aborting) {
+		return dst
+	}
+	return op.ServerAPI.appendServerAPI(dst)
+}
+
+// timeoutEnabled returns true if a Timeout is set for this operation and the context has a deadline.
+func (op Operation) timeoutEnabled(ctx context.Context) bool {
+	if op.Timeout == nil || *op.Timeout <= 0 {
+		return false
+	}
+	_, ok := ctx.Deadline()
+	return ok
+}
+
+// retryBackoff waits before the next attempt of an operation that is retried until its context expires, so a command
+// that fails quickly is not sent in a tight loop. The first retry is sent immediately and the wait doubles with each
+// retry after that, up to maxRetryBackoff. It returns false if the context expires before the wait is over.
+func retryBackoff(ctx context.Context, retried int) bool {
+	if retried == 0 {
+		return true
+	}
+	backoff := minRetryBackoff
+	for i := 1; i < retried && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// addMaxTimeMS appends a maxTimeMS derived from the time remaining before the context's deadline if a Timeout is set
+// for this operation. The command document starts at cmdStart in dst and has not been terminated. Commands that
+// already contain a maxTimeMS and getMore commands, for which maxTimeMS has a different meaning, are left unchanged.
+func (op Operation) addMaxTimeMS(ctx context.Context, dst []byte, cmdStart int32,
+	desc description.SelectedServer) ([]byte, error) {
+
+	if !op.timeoutEnabled(ctx) {
+		return dst, nil
+	}
+	if op.getCommandName(dst[cmdStart:]) == "getMore" || hasElement(dst[cmdStart+4:], "maxTimeMS") {
+		return dst, nil
+	}
+
+	deadline, _ := ctx.Deadline()
+	remaining := time.Until(deadline)
+	if desc.AverageRTTSet {
+		remaining -= desc.AverageRTT
+	}
+	maxTimeMS := int64(remaining / time.Millisecond)
+	if maxTimeMS <= 0 {
+		return dst, ErrDeadlineWouldBeExceeded
+	}
+	return bsoncore.AppendInt64Element(dst, "maxTimeMS", maxTimeMS), nil
+}
+
+// hasElement returns true if the given sequence of BSON elements contains an element with the given key.
+func hasElement(elems []byte, key string) bool {
+	for len(elems) > 0 {
+		elem, rem, ok := bsoncore.ReadElement(elems)
+		if !ok {
+			return false
+		}
+		if elem.Key() == key {
+			return true
+		}
+		elems = rem
+	}
+	return false
 }
 
 func (op Operation) addReadConcern(dst []byte, desc description.SelectedServer) ([]byte, error) {
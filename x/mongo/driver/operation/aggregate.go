@@ -11,6 +11,7 @@ package operation
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/event"
@@ -43,6 +44,8 @@ type Aggregate struct {
 	readPreference           *readpref.ReadPref
 	retry                    *driver.RetryMode
 	selector                 description.ServerSelector
+	timeout                  *time.Duration
+	serverAPI                *driver.ServerAPIOptions
 	writeConcern             *writeconcern.WriteConcern
 	crypt                    *driver.Crypt
 
@@ -97,6 +100,8 @@ func (a *Aggregate) Execute(ctx context.Context) error {
 		Type:                           driver.Read,
 		RetryMode:                      a.retry,
 		Selector:                       a.selector,
+		Timeout:                        a.timeout,
+		ServerAPI:                      a.serverAPI,
 		WriteConcern:                   a.writeConcern,
 		Crypt:                          a.crypt,
 		MinimumWriteConcernWireVersion: 5,
@@ -322,6 +327,26 @@ func (a *Aggregate) ServerSelector(selector description.ServerSelector) *Aggrega
 	return a
 }
 
+// Timeout sets the timeout for this operation.
+func (a *Aggregate) Timeout(timeout *time.Duration) *Aggregate {
+	if a == nil {
+		a = new(Aggregate)
+	}
+
+	a.timeout = timeout
+	return a
+}
+
+// ServerAPI sets the server API version for this operation.
+func (a *Aggregate) ServerAPI(serverAPI *driver.ServerAPIOptions) *Aggregate {
+	if a == nil {
+		a = new(Aggregate)
+	}
+
+	a.serverAPI = serverAPI
+	return a
+}
+
 // WriteConcern sets the write concern for this operation.
 func (a *Aggregate) WriteConcern(writeConcern *writeconcern.WriteConcern) *Aggregate {
 	if a == nil {
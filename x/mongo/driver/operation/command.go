@@ -5,6 +5,7 @@ package operation
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
@@ -22,6 +23,8 @@ type Command struct {
 	database       string
 	deployment     driver.Deployment
 	selector       description.ServerSelector
+	timeout        *time.Duration
+	serverAPI      *driver.ServerAPIOptions
 	readPreference *readpref.ReadPref
 	clock          *session.ClusterClock
 	session        *session.Client
@@ -71,6 +74,8 @@ func (c *Command) Execute(ctx context.Context) error {
 		Deployment:     c.deployment,
 		ReadPreference: c.readPreference,
 		Selector:       c.selector,
+		Timeout:        c.timeout,
+		ServerAPI:      c.serverAPI,
 		Crypt:          c.crypt,
 	}.Execute(ctx, nil)
 }
@@ -165,6 +170,26 @@ func (c *Command) ServerSelector(selector description.ServerSelector) *Command {
 	return c
 }
 
+// Timeout sets the timeout for this operation.
+func (c *Command) Timeout(timeout *time.Duration) *Command {
+	if c == nil {
+		c = new(Command)
+	}
+
+	c.timeout = timeout
+	return c
+}
+
+// ServerAPI sets the server API version for this operation.
+func (c *Command) ServerAPI(serverAPI *driver.ServerAPIOptions) *Command {
+	if c == nil {
+		c = new(Command)
+	}
+
+	c.serverAPI = serverAPI
+	return c
+}
+
 // Crypt sets the Crypt object to use for automatic encryption and decryption.
 func (c *Command) Crypt(crypt *driver.Crypt) *Command {
 	if c == nil {
@@ -12,6 +12,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
@@ -33,6 +34,8 @@ type CreateIndexes struct {
 	database     string
 	deployment   driver.Deployment
 	selector     description.ServerSelector
+	serverAPI    *driver.ServerAPIOptions
+	timeout      *time.Duration
 	writeConcern *writeconcern.WriteConcern
 	result       CreateIndexesResult
 }
@@ -109,6 +112,8 @@ func (ci *CreateIndexes) Execute(ctx context.Context) error {
 		Database:          ci.database,
 		Deployment:        ci.deployment,
 		Selector:          ci.selector,
+		ServerAPI:         ci.serverAPI,
+		Timeout:           ci.timeout,
 		WriteConcern:      ci.writeConcern,
 	}.Execute(ctx, nil)
 
@@ -225,6 +230,26 @@ func (ci *CreateIndexes) ServerSelector(selector description.ServerSelector) *Cr
 	return ci
 }
 
+// ServerAPI sets the server API version for this operation.
+func (ci *CreateIndexes) ServerAPI(serverAPI *driver.ServerAPIOptions) *CreateIndexes {
+	if ci == nil {
+		ci = new(CreateIndexes)
+	}
+
+	ci.serverAPI = serverAPI
+	return ci
+}
+
+// Timeout sets the timeout for this operation.
+func (ci *CreateIndexes) Timeout(timeout *time.Duration) *CreateIndexes {
+	if ci == nil {
+		ci = new(CreateIndexes)
+	}
+
+	ci.timeout = timeout
+	return ci
+}
+
 // WriteConcern sets the write concern for this operation.
 func (ci *CreateIndexes) WriteConcern(writeConcern *writeconcern.WriteConcern) *CreateIndexes {
 	if ci == nil {
@@ -12,6 +12,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
@@ -33,6 +34,8 @@ type Delete struct {
 	database     string
 	deployment   driver.Deployment
 	selector     description.ServerSelector
+	serverAPI    *driver.ServerAPIOptions
+	timeout      *time.Duration
 	writeConcern *writeconcern.WriteConcern
 	retry        *driver.RetryMode
 	result       DeleteResult
@@ -102,6 +105,8 @@ func (d *Delete) Execute(ctx context.Context) error {
 		Database:          d.database,
 		Deployment:        d.deployment,
 		Selector:          d.selector,
+		ServerAPI:         d.serverAPI,
+		Timeout:           d.timeout,
 		WriteConcern:      d.writeConcern,
 	}.Execute(ctx, nil)
 
@@ -218,6 +223,26 @@ func (d *Delete) ServerSelector(selector description.ServerSelector) *Delete {
 	return d
 }
 
+// ServerAPI sets the server API version for this operation.
+func (d *Delete) ServerAPI(serverAPI *driver.ServerAPIOptions) *Delete {
+	if d == nil {
+		d = new(Delete)
+	}
+
+	d.serverAPI = serverAPI
+	return d
+}
+
+// Timeout sets the timeout for this operation.
+func (d *Delete) Timeout(timeout *time.Duration) *Delete {
+	if d == nil {
+		d = new(Delete)
+	}
+
+	d.timeout = timeout
+	return d
+}
+
 // WriteConcern sets the write concern for this operation.
 func (d *Delete) WriteConcern(writeConcern *writeconcern.WriteConcern) *Delete {
 	if d == nil {
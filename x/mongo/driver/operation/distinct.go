@@ -11,6 +11,7 @@ package operation
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
@@ -37,6 +38,8 @@ type Distinct struct {
 	readConcern    *readconcern.ReadConcern
 	readPreference *readpref.ReadPref
 	selector       description.ServerSelector
+	serverAPI      *driver.ServerAPIOptions
+	timeout        *time.Duration
 	retry          *driver.RetryMode
 	result         DistinctResult
 }
@@ -98,6 +101,8 @@ func (d *Distinct) Execute(ctx context.Context) error {
 		ReadConcern:       d.readConcern,
 		ReadPreference:    d.readPreference,
 		Selector:          d.selector,
+		ServerAPI:         d.serverAPI,
+		Timeout:           d.timeout,
 	}.Execute(ctx, nil)
 
 }
@@ -262,6 +267,26 @@ func (d *Distinct) ServerSelector(selector description.ServerSelector) *Distinct
 	return d
 }
 
+// ServerAPI sets the server API version for this operation.
+func (d *Distinct) ServerAPI(serverAPI *driver.ServerAPIOptions) *Distinct {
+	if d == nil {
+		d = new(Distinct)
+	}
+
+	d.serverAPI = serverAPI
+	return d
+}
+
+// Timeout sets the timeout for this operation.
+func (d *Distinct) Timeout(timeout *time.Duration) *Distinct {
+	if d == nil {
+		d = new(Distinct)
+	}
+
+	d.timeout = timeout
+	return d
+}
+
 // Retry enables retryable mode for this operation. Retries are handled automatically in driver.Operation.Execute based
 // on how the operation is set.
 func (d *Distinct) Retry(retry driver.RetryMode) *Distinct {
@@ -12,6 +12,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
@@ -31,6 +32,8 @@ type DropCollection struct {
 	database     string
 	deployment   driver.Deployment
 	selector     description.ServerSelector
+	serverAPI    *driver.ServerAPIOptions
+	timeout      *time.Duration
 	writeConcern *writeconcern.WriteConcern
 	result       DropCollectionResult
 }
@@ -97,6 +100,8 @@ func (dc *DropCollection) Execute(ctx context.Context) error {
 		Database:          dc.database,
 		Deployment:        dc.deployment,
 		Selector:          dc.selector,
+		ServerAPI:         dc.serverAPI,
+		Timeout:           dc.timeout,
 		WriteConcern:      dc.writeConcern,
 	}.Execute(ctx, nil)
 
@@ -187,6 +192,26 @@ func (dc *DropCollection) ServerSelector(selector description.ServerSelector) *D
 	return dc
 }
 
+// ServerAPI sets the server API version for this operation.
+func (dc *DropCollection) ServerAPI(serverAPI *driver.ServerAPIOptions) *DropCollection {
+	if dc == nil {
+		dc = new(DropCollection)
+	}
+
+	dc.serverAPI = serverAPI
+	return dc
+}
+
+// Timeout sets the timeout for this operation.
+func (dc *DropCollection) Timeout(timeout *time.Duration) *DropCollection {
+	if dc == nil {
+		dc = new(DropCollection)
+	}
+
+	dc.timeout = timeout
+	return dc
+}
+
 // WriteConcern sets the write concern for this operation.
 func (dc *DropCollection) WriteConcern(writeConcern *writeconcern.WriteConcern) *DropCollection {
 	if dc == nil {
@@ -12,6 +12,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
@@ -30,6 +31,8 @@ type DropDatabase struct {
 	database     string
 	deployment   driver.Deployment
 	selector     description.ServerSelector
+	serverAPI    *driver.ServerAPIOptions
+	timeout      *time.Duration
 	writeConcern *writeconcern.WriteConcern
 	result       DropDatabaseResult
 }
@@ -88,6 +91,8 @@ func (dd *DropDatabase) Execute(ctx context.Context) error {
 		Database:          dd.database,
 		Deployment:        dd.deployment,
 		Selector:          dd.selector,
+		ServerAPI:         dd.serverAPI,
+		Timeout:           dd.timeout,
 		WriteConcern:      dd.writeConcern,
 	}.Execute(ctx, nil)
 
@@ -169,6 +174,26 @@ func (dd *DropDatabase) ServerSelector(selector description.ServerSelector) *Dro
 	return dd
 }
 
+// ServerAPI sets the server API version for this operation.
+func (dd *DropDatabase) ServerAPI(serverAPI *driver.ServerAPIOptions) *DropDatabase {
+	if dd == nil {
+		dd = new(DropDatabase)
+	}
+
+	dd.serverAPI = serverAPI
+	return dd
+}
+
+// Timeout sets the timeout for this operation.
+func (dd *DropDatabase) Timeout(timeout *time.Duration) *DropDatabase {
+	if dd == nil {
+		dd = new(DropDatabase)
+	}
+
+	dd.timeout = timeout
+	return dd
+}
+
 // WriteConcern sets the write concern for this operation.
 func (dd *DropDatabase) WriteConcern(writeConcern *writeconcern.WriteConcern) *DropDatabase {
 	if dd == nil {
@@ -11,6 +11,7 @@ package operation
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
@@ -29,6 +30,8 @@ type EndSessions struct {
 	database   string
 	deployment driver.Deployment
 	selector   description.ServerSelector
+	serverAPI  *driver.ServerAPIOptions
+	timeout    *time.Duration
 }
 
 // NewEndSessions constructs and returns a new EndSessions.
@@ -59,6 +62,8 @@ func (es *EndSessions) Execute(ctx context.Context) error {
 		Database:          es.database,
 		Deployment:        es.deployment,
 		Selector:          es.selector,
+		ServerAPI:         es.serverAPI,
+		Timeout:           es.timeout,
 	}.Execute(ctx, nil)
 
 }
@@ -149,3 +154,23 @@ func (es *EndSessions) ServerSelector(selector description.ServerSelector) *EndS
 	es.selector = selector
 	return es
 }
+
+// ServerAPI sets the server API version for this operation.
+func (es *EndSessions) ServerAPI(serverAPI *driver.ServerAPIOptions) *EndSessions {
+	if es == nil {
+		es = new(EndSessions)
+	}
+
+	es.serverAPI = serverAPI
+	return es
+}
+
+// Timeout sets the timeout for this operation.
+func (es *EndSessions) Timeout(timeout *time.Duration) *EndSessions {
+	if es == nil {
+		es = new(EndSessions)
+	}
+
+	es.timeout = timeout
+	return es
+}
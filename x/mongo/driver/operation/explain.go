@@ -0,0 +1,261 @@
+// Copyright (C) MongoDB, Inc. 2020-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// NOTE: This file is maintained by hand because operationgen cannot generate it.
+
+package operation
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/description"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
+)
+
+// Explainable is implemented by operations that can be wrapped in an explain command. These are Find, Aggregate,
+// Count, Distinct, Update, and Delete.
+type Explainable interface {
+	// explainCommand appends the elements of the command that would be sent if the operation were executed
+	// directly. Document sequences are appended as arrays because explain requires a single command document.
+	explainCommand(dst []byte, desc description.SelectedServer) ([]byte, error)
+}
+
+// Explain performs an explain operation, which returns information about how the server would execute another
+// operation.
+type Explain struct {
+	verbosity      string
+	explainable    Explainable
+	database       string
+	deployment     driver.Deployment
+	selector       description.ServerSelector
+	timeout        *time.Duration
+	serverAPI      *driver.ServerAPIOptions
+	readPreference *readpref.ReadPref
+	clock          *session.ClusterClock
+	session        *session.Client
+	monitor        *event.CommandMonitor
+	crypt          *driver.Crypt
+	result         bsoncore.Document
+}
+
+// NewExplain constructs and returns a new Explain that explains the given operation. The verbosity must be one of
+// "queryPlanner", "executionStats", or "allPlansExecution".
+func NewExplain(verbosity string, explainable Explainable) *Explain {
+	return &Explain{
+		verbosity:   verbosity,
+		explainable: explainable,
+	}
+}
+
+// Result returns the result of executing this operation.
+func (e *Explain) Result() bsoncore.Document { return e.result }
+
+func (e *Explain) processResponse(response bsoncore.Document, srvr driver.Server, desc description.Server) error {
+	e.result = response
+	return nil
+}
+
+// Execute runs this operations and returns an error if the operaiton did not execute successfully.
+func (e *Explain) Execute(ctx context.Context) error {
+	if e.deployment == nil {
+		return errors.New("the Explain operation must have a Deployment set before Execute can be called")
+	}
+	if e.explainable == nil {
+		return errors.New("the Explain operation must have an operation to explain set before Execute can be called")
+	}
+
+	return driver.Operation{
+		CommandFn:         e.command,
+		ProcessResponseFn: e.processResponse,
+		Client:            e.session,
+		Clock:             e.clock,
+		CommandMonitor:    e.monitor,
+		Crypt:             e.crypt,
+		Database:          e.database,
+		Deployment:        e.deployment,
+		ReadPreference:    e.readPreference,
+		Selector:          e.selector,
+		Timeout:           e.timeout,
+		ServerAPI:         e.serverAPI,
+		Type:              driver.Read,
+	}.Execute(ctx, nil)
+}
+
+func (e *Explain) command(dst []byte, desc description.SelectedServer) ([]byte, error) {
+	var idx int32
+	var err error
+	idx, dst = bsoncore.AppendDocumentElementStart(dst, "explain")
+	dst, err = e.explainable.explainCommand(dst, desc)
+	if err != nil {
+		return nil, err
+	}
+	dst, err = bsoncore.AppendDocumentEnd(dst, idx)
+	if err != nil {
+		return nil, err
+	}
+	if e.verbosity != "" {
+		dst = bsoncore.AppendStringElement(dst, "verbosity", e.verbosity)
+	}
+	return dst, nil
+}
+
+// Verbosity sets the verbosity of the explain output.
+func (e *Explain) Verbosity(verbosity string) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.verbosity = verbosity
+	return e
+}
+
+// Session sets the session for this operation.
+func (e *Explain) Session(session *session.Client) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.session = session
+	return e
+}
+
+// ClusterClock sets the cluster clock for this operation.
+func (e *Explain) ClusterClock(clock *session.ClusterClock) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.clock = clock
+	return e
+}
+
+// CommandMonitor sets the monitor to use for APM events.
+func (e *Explain) CommandMonitor(monitor *event.CommandMonitor) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.monitor = monitor
+	return e
+}
+
+// Crypt sets the Crypt object to use for automatic encryption and decryption.
+func (e *Explain) Crypt(crypt *driver.Crypt) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.crypt = crypt
+	return e
+}
+
+// Database sets the database to run this operation against.
+func (e *Explain) Database(database string) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.database = database
+	return e
+}
+
+// Deployment sets the deployment to use for this operation.
+func (e *Explain) Deployment(deployment driver.Deployment) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.deployment = deployment
+	return e
+}
+
+// ReadPreference set the read prefernce used with this operation.
+func (e *Explain) ReadPreference(readPreference *readpref.ReadPref) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.readPreference = readPreference
+	return e
+}
+
+// ServerSelector sets the selector used to retrieve a server.
+func (e *Explain) ServerSelector(selector description.ServerSelector) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.selector = selector
+	return e
+}
+
+// Timeout sets the timeout for this operation.
+func (e *Explain) Timeout(timeout *time.Duration) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.timeout = timeout
+	return e
+}
+
+// ServerAPI sets the server API version for this operation.
+func (e *Explain) ServerAPI(serverAPI *driver.ServerAPIOptions) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.serverAPI = serverAPI
+	return e
+}
+
+func (f *Find) explainCommand(dst []byte, desc description.SelectedServer) ([]byte, error) {
+	return f.command(dst, desc)
+}
+
+func (a *Aggregate) explainCommand(dst []byte, desc description.SelectedServer) ([]byte, error) {
+	return a.command(dst, desc)
+}
+
+func (c *Count) explainCommand(dst []byte, desc description.SelectedServer) ([]byte, error) {
+	return c.command(dst, desc)
+}
+
+func (d *Distinct) explainCommand(dst []byte, desc description.SelectedServer) ([]byte, error) {
+	return d.command(dst, desc)
+}
+
+func (u *Update) explainCommand(dst []byte, desc description.SelectedServer) ([]byte, error) {
+	dst, err := u.command(dst, desc)
+	if err != nil {
+		return nil, err
+	}
+	return appendDocumentArray(dst, "updates", u.updates), nil
+}
+
+func (d *Delete) explainCommand(dst []byte, desc description.SelectedServer) ([]byte, error) {
+	dst, err := d.command(dst, desc)
+	if err != nil {
+		return nil, err
+	}
+	return appendDocumentArray(dst, "deletes", d.deletes), nil
+}
+
+func appendDocumentArray(dst []byte, key string, docs []bsoncore.Document) []byte {
+	aidx, dst := bsoncore.AppendArrayElementStart(dst, key)
+	for i, doc := range docs {
+		dst = bsoncore.AppendDocumentElement(dst, strconv.Itoa(i), doc)
+	}
+	dst, _ = bsoncore.AppendArrayEnd(dst, aidx)
+	return dst
+}
@@ -0,0 +1,63 @@
+// Copyright (C) MongoDB, Inc. 2020-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package operation
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/description"
+)
+
+func TestExplain(t *testing.T) {
+	filter := bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendInt32Element(nil, "x", 1))
+	deleteDoc := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendDocumentElement(nil, "q", filter),
+		bsoncore.AppendInt32Element(nil, "limit", 1),
+	)
+	desc := description.SelectedServer{Server: description.Server{WireVersion: &description.VersionRange{Max: 8}}}
+
+	testCases := []struct {
+		name        string
+		explainable Explainable
+		inner       bsoncore.Document
+	}{
+		{
+			"find",
+			NewFind(filter).Collection("coll"),
+			bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendStringElement(nil, "find", "coll"),
+				bsoncore.AppendDocumentElement(nil, "filter", filter),
+			),
+		},
+		{
+			"delete",
+			NewDelete(deleteDoc).Collection("coll"),
+			bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendStringElement(nil, "delete", "coll"),
+				bsoncore.BuildArrayElement(nil, "deletes",
+					bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: deleteDoc}),
+			),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			idx, got := bsoncore.AppendDocumentStart(nil)
+			got, err := NewExplain("executionStats", tc.explainable).command(got, desc)
+			assert.Nil(t, err, "command error: %v", err)
+			got, _ = bsoncore.AppendDocumentEnd(got, idx)
+
+			expected := bsoncore.Document(bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendDocumentElement(nil, "explain", tc.inner),
+				bsoncore.AppendStringElement(nil, "verbosity", "executionStats"),
+			))
+			assert.Equal(t, expected, bsoncore.Document(got), "expected command %v, got %v", expected, bsoncore.Document(got))
+		})
+	}
+}
@@ -11,6 +11,7 @@ package operation
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/event"
@@ -55,6 +56,8 @@ type Find struct {
 	readConcern         *readconcern.ReadConcern
 	readPreference      *readpref.ReadPref
 	selector            description.ServerSelector
+	serverAPI           *driver.ServerAPIOptions
+	timeout             *time.Duration
 	retry               *driver.RetryMode
 	result              driver.CursorResponse
 }
@@ -97,6 +100,8 @@ func (f *Find) Execute(ctx context.Context) error {
 		ReadConcern:       f.readConcern,
 		ReadPreference:    f.readPreference,
 		Selector:          f.selector,
+		ServerAPI:         f.serverAPI,
+		Timeout:           f.timeout,
 		Legacy:            driver.LegacyFind,
 	}.Execute(ctx, nil)
 
@@ -483,6 +488,26 @@ func (f *Find) ServerSelector(selector description.ServerSelector) *Find {
 	return f
 }
 
+// ServerAPI sets the server API version for this operation.
+func (f *Find) ServerAPI(serverAPI *driver.ServerAPIOptions) *Find {
+	if f == nil {
+		f = new(Find)
+	}
+
+	f.serverAPI = serverAPI
+	return f
+}
+
+// Timeout sets the timeout for this operation.
+func (f *Find) Timeout(timeout *time.Duration) *Find {
+	if f == nil {
+		f = new(Find)
+	}
+
+	f.timeout = timeout
+	return f
+}
+
 // Retry enables retryable mode for this operation. Retries are handled automatically in driver.Operation.Execute based
 // on how the operation is set.
 func (f *Find) Retry(retry driver.RetryMode) *Find {
@@ -12,6 +12,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/event"
@@ -42,6 +43,8 @@ type FindAndModify struct {
 	database                 string
 	deployment               driver.Deployment
 	selector                 description.ServerSelector
+	timeout                  *time.Duration
+	serverAPI                *driver.ServerAPIOptions
 	writeConcern             *writeconcern.WriteConcern
 	retry                    *driver.RetryMode
 	crypt                    *driver.Crypt
@@ -131,6 +134,8 @@ func (fam *FindAndModify) Execute(ctx context.Context) error {
 		Database:       fam.database,
 		Deployment:     fam.deployment,
 		Selector:       fam.selector,
+		Timeout:        fam.timeout,
+		ServerAPI:      fam.serverAPI,
 		WriteConcern:   fam.writeConcern,
 		Crypt:          fam.crypt,
 	}.Execute(ctx, nil)
@@ -373,6 +378,26 @@ func (fam *FindAndModify) ServerSelector(selector description.ServerSelector) *F
 	return fam
 }
 
+// Timeout sets the timeout for this operation.
+func (fam *FindAndModify) Timeout(timeout *time.Duration) *FindAndModify {
+	if fam == nil {
+		fam = new(FindAndModify)
+	}
+
+	fam.timeout = timeout
+	return fam
+}
+
+// ServerAPI sets the server API version for this operation.
+func (fam *FindAndModify) ServerAPI(serverAPI *driver.ServerAPIOptions) *FindAndModify {
+	if fam == nil {
+		fam = new(FindAndModify)
+	}
+
+	fam.serverAPI = serverAPI
+	return fam
+}
+
 // WriteConcern sets the write concern for this operation.
 func (fam *FindAndModify) WriteConcern(writeConcern *writeconcern.WriteConcern) *FindAndModify {
 	if fam == nil {
@@ -24,6 +24,11 @@ type IsMaster struct {
 	saslSupportedMechs string
 	d                  driver.Deployment
 	clock              *session.ClusterClock
+	topologyVersion    *description.TopologyVersion
+	maxAwaitTimeMS     *int64
+	speculativeAuth    bsoncore.Document
+	loadBalanced       bool
+	serverAPI          *driver.ServerAPIOptions
 
 	res bsoncore.Document
 }
@@ -55,6 +60,20 @@ func (im *IsMaster) Compressors(compressors []string) *IsMaster {
 	return im
 }
 
+// LoadBalanced specifies whether or not this operation is being sent over a connection to a load balanced cluster. If
+// true, the handshake asks the server to include a serviceId in its response.
+func (im *IsMaster) LoadBalanced(lb bool) *IsMaster {
+	im.loadBalanced = lb
+	return im
+}
+
+// ServerAPI sets the server API version for this operation. The apiVersion, apiStrict and apiDeprecationErrors fields
+// are appended to both the handshake and heartbeats.
+func (im *IsMaster) ServerAPI(serverAPI *driver.ServerAPIOptions) *IsMaster {
+	im.serverAPI = serverAPI
+	return im
+}
+
 // SASLSupportedMechs retrieves the supported SASL mechanism for the given user when this operation
 // is run.
 func (im *IsMaster) SASLSupportedMechs(username string) *IsMaster {
@@ -62,6 +81,26 @@ func (im *IsMaster) SASLSupportedMechs(username string) *IsMaster {
 	return im
 }
 
+// SpeculativeAuthenticate sets the document to be used for speculative authentication. The document is sent in the
+// speculativeAuthenticate field of the handshake and should contain the first command of the authentication
+// conversation.
+func (im *IsMaster) SpeculativeAuthenticate(doc bsoncore.Document) *IsMaster {
+	im.speculativeAuth = doc
+	return im
+}
+
+// TopologyVersion sets the TopologyVersion to be used for heartbeats.
+func (im *IsMaster) TopologyVersion(tv *description.TopologyVersion) *IsMaster {
+	im.topologyVersion = tv
+	return im
+}
+
+// MaxAwaitTimeMS sets the maximum time for the sever to wait for topology changes during a heartbeat.
+func (im *IsMaster) MaxAwaitTimeMS(awaitTime int64) *IsMaster {
+	im.maxAwaitTimeMS = &awaitTime
+	return im
+}
+
 // Deployment sets the Deployment for this operation.
 func (im *IsMaster) Deployment(d driver.Deployment) *IsMaster {
 	im.d = d
@@ -251,6 +290,13 @@ func (im *IsMaster) Result(addr address.Address) description.Server {
 				return desc
 			}
 			desc.SetVersion = uint32(i64)
+		case "serviceId":
+			oid, ok := element.Value().ObjectIDOK()
+			if !ok {
+				desc.LastError = fmt.Errorf("expected 'serviceId' to be an ObjectId but it's a BSON %s", element.Value().Type)
+				return desc
+			}
+			desc.ServiceID = &oid
 		case "tags":
 			m, err := im.decodeStringMap(element, "tags")
 			if err != nil {
@@ -258,6 +304,12 @@ func (im *IsMaster) Result(addr address.Address) description.Server {
 				return desc
 			}
 			desc.Tags = tag.NewTagSetFromMap(m)
+		case "topologyVersion":
+			desc.TopologyVersion, err = description.NewTopologyVersionFromValue(element.Value())
+			if err != nil {
+				desc.LastError = err
+				return desc
+			}
 		}
 	}
 
@@ -339,7 +391,18 @@ func (im *IsMaster) decodeStringMap(element bsoncore.Element, name string) (map[
 	return m, nil
 }
 
-// handshakeCommand appends all necessary command fields as well as client metadata, SASL supported mechs, and compression.
+// SpeculativeAuthenticateResponse returns the speculativeAuthenticate document from the server's handshake response. It
+// returns nil if the handshake did not include speculative authentication or the server did not reply to it.
+func (im *IsMaster) SpeculativeAuthenticateResponse() bsoncore.Document {
+	doc, ok := im.res.Lookup("speculativeAuthenticate").DocumentOK()
+	if !ok {
+		return nil
+	}
+	return doc
+}
+
+// handshakeCommand appends all necessary command fields as well as client metadata, SASL supported mechs, speculative
+// authentication, and compression.
 func (im *IsMaster) handshakeCommand(dst []byte, desc description.SelectedServer) ([]byte, error) {
 	dst, err := im.command(dst, desc)
 	if err != nil {
@@ -356,6 +419,13 @@ func (im *IsMaster) handshakeCommand(dst []byte, desc description.SelectedServer
 	}
 	dst, _ = bsoncore.AppendArrayEnd(dst, idx)
 
+	if im.speculativeAuth != nil {
+		dst = bsoncore.AppendDocumentElement(dst, "speculativeAuthenticate", im.speculativeAuth)
+	}
+	if im.loadBalanced {
+		dst = bsoncore.AppendBooleanElement(dst, "loadBalanced", true)
+	}
+
 	// append client metadata
 	idx, dst = bsoncore.AppendDocumentElementStart(dst, "client")
 
@@ -382,7 +452,21 @@ func (im *IsMaster) handshakeCommand(dst []byte, desc description.SelectedServer
 
 // command appends all necessary command fields.
 func (im *IsMaster) command(dst []byte, _ description.SelectedServer) ([]byte, error) {
-	return bsoncore.AppendInt32Element(dst, "isMaster", 1), nil
+	dst = bsoncore.AppendInt32Element(dst, "isMaster", 1)
+
+	// Only append the topologyVersion and maxAwaitTimeMS fields if both are set. These are only used for awaitable
+	// isMaster commands sent by the streaming server monitor.
+	if im.topologyVersion != nil && im.maxAwaitTimeMS != nil {
+		var tvIdx int32
+		tvIdx, dst = bsoncore.AppendDocumentElementStart(dst, "topologyVersion")
+		dst = bsoncore.AppendObjectIDElement(dst, "processId", im.topologyVersion.ProcessID)
+		dst = bsoncore.AppendInt64Element(dst, "counter", im.topologyVersion.Counter)
+		dst, _ = bsoncore.AppendDocumentEnd(dst, tvIdx)
+
+		dst = bsoncore.AppendInt64Element(dst, "maxAwaitTimeMS", *im.maxAwaitTimeMS)
+	}
+
+	return dst, nil
 }
 
 // Execute runs this operation.
@@ -391,16 +475,27 @@ func (im *IsMaster) Execute(ctx context.Context) error {
 		return errors.New("an IsMaster must have a Deployment set before Execute can be called")
 	}
 
+	return im.createOperation().Execute(ctx, nil)
+}
+
+// StreamResponse gets the next streaming isMaster response from the server. The connection must be streaming, i.e.
+// the previous response must have had the moreToCome flag set.
+func (im *IsMaster) StreamResponse(ctx context.Context, conn driver.StreamerConnection) error {
+	return im.createOperation().ExecuteExhaust(ctx, conn, nil)
+}
+
+func (im *IsMaster) createOperation() driver.Operation {
 	return driver.Operation{
 		Clock:      im.clock,
 		CommandFn:  im.command,
 		Database:   "admin",
 		Deployment: im.d,
+		ServerAPI:  im.serverAPI,
 		ProcessResponseFn: func(response bsoncore.Document, _ driver.Server, _ description.Server) error {
 			im.res = response
 			return nil
 		},
-	}.Execute(ctx, nil)
+	}
 }
 
 // GetDescription retrieves the server description for the given connection. This function implements the Handshaker
@@ -411,6 +506,7 @@ func (im *IsMaster) GetDescription(ctx context.Context, _ address.Address, c dri
 		CommandFn:  im.handshakeCommand,
 		Deployment: driver.SingleConnectionDeployment{c},
 		Database:   "admin",
+		ServerAPI:  im.serverAPI,
 		ProcessResponseFn: func(response bsoncore.Document, _ driver.Server, _ description.Server) error {
 			im.res = response
 			return nil
@@ -419,7 +515,12 @@ func (im *IsMaster) GetDescription(ctx context.Context, _ address.Address, c dri
 	if err != nil {
 		return description.Server{}, err
 	}
-	return im.Result(c.Address()), nil
+
+	desc := im.Result(c.Address())
+	if im.loadBalanced && desc.ServiceID == nil {
+		return description.Server{}, errors.New("the server is behind a load balancer but its isMaster response does not contain a serviceId")
+	}
+	return desc, nil
 }
 
 // FinishHandshake implements the Handshaker interface. This is a no-op function because a non-authenticated connection
@@ -257,6 +257,45 @@ func TestOperation(t *testing.T) {
 			t.Errorf("WriteConcern elements do not match. got %v; want %v", got, want)
 		}
 	})
+	t.Run("addServerAPI", func(t *testing.T) {
+		sessPool := session.NewPool(nil)
+		id, err := uuid.New()
+		noerr(t, err)
+		sessStartingTransaction, err := session.NewClientSession(sessPool, id, session.Explicit)
+		noerr(t, err)
+		noerr(t, sessStartingTransaction.StartTransaction(nil))
+		sessInProgressTransaction, err := session.NewClientSession(sessPool, id, session.Explicit)
+		noerr(t, err)
+		noerr(t, sessInProgressTransaction.StartTransaction(nil))
+		sessInProgressTransaction.ApplyCommand(description.Server{})
+
+		serverAPI := NewServerAPIOptions("1").SetStrict(true).SetDeprecationErrors(false)
+		version := bsoncore.AppendStringElement(nil, "apiVersion", "1")
+		all := bsoncore.AppendStringElement(nil, "apiVersion", "1")
+		all = bsoncore.AppendBooleanElement(all, "apiStrict", true)
+		all = bsoncore.AppendBooleanElement(all, "apiDeprecationErrors", false)
+
+		testCases := []struct {
+			name string
+			op   Operation
+			want []byte
+		}{
+			{"nil", Operation{}, nil},
+			{"version only", Operation{ServerAPI: NewServerAPIOptions("1")}, version},
+			{"all fields", Operation{ServerAPI: serverAPI}, all},
+			{"getMore", Operation{ServerAPI: serverAPI, Legacy: LegacyGetMore}, nil},
+			{"starting transaction", Operation{ServerAPI: serverAPI, Client: sessStartingTransaction}, all},
+			{"transaction in progress", Operation{ServerAPI: serverAPI, Client: sessInProgressTransaction}, nil},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				got := tc.op.addServerAPI(nil)
+				if !bytes.Equal(got, tc.want) {
+					t.Errorf("server API elements do not match. got %v; want %v", got, tc.want)
+				}
+			})
+		}
+	})
 	t.Run("addSession", func(t *testing.T) { t.Skip("These tests should be covered by spec tests.") })
 	t.Run("addClusterTime", func(t *testing.T) {
 		t.Run("adds max cluster time", func(t *testing.T) {
@@ -437,6 +476,187 @@ func TestOperation(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Timeout", func(t *testing.T) {
+		timeout := 10 * time.Second
+		cmdFn := func(dst []byte, desc description.SelectedServer) ([]byte, error) {
+			return bsoncore.AppendInt32Element(dst, "ping", 1), nil
+		}
+
+		t.Run("sets deadline", func(t *testing.T) {
+			d := new(mockDeployment)
+			d.returns.err = errors.New("selection error")
+			op := Operation{CommandFn: cmdFn, Deployment: d, Database: "testing", Timeout: &timeout}
+			_ = op.Execute(context.Background(), nil)
+
+			deadline, ok := d.params.ctx.Deadline()
+			if !ok {
+				t.Fatal("expected server selection context to have a deadline")
+			}
+			if remaining := time.Until(deadline); remaining <= 0 || remaining > timeout {
+				t.Errorf("expected deadline within %v, got %v", timeout, remaining)
+			}
+		})
+		t.Run("context deadline takes precedence", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+			defer cancel()
+			want, _ := ctx.Deadline()
+
+			d := new(mockDeployment)
+			d.returns.err = errors.New("selection error")
+			op := Operation{CommandFn: cmdFn, Deployment: d, Database: "testing", Timeout: &timeout}
+			_ = op.Execute(ctx, nil)
+
+			got, ok := d.params.ctx.Deadline()
+			if !ok || !got.Equal(want) {
+				t.Errorf("expected deadline %v, got %v", want, got)
+			}
+		})
+		t.Run("retries back off", func(t *testing.T) {
+			timeout := 100 * time.Millisecond
+			retry := RetryOnce
+			conn := newExhaustTestConn() // every read fails with a network error
+			d := new(mockDeployment)
+			d.returns.server = &exhaustTestServer{conn: conn}
+			op := Operation{
+				CommandFn:  cmdFn,
+				Deployment: d,
+				Database:   "testing",
+				Type:       Read,
+				RetryMode:  &retry,
+				Timeout:    &timeout,
+			}
+			err := op.Execute(context.Background(), nil)
+			if derr, ok := err.(Error); !ok || !derr.NetworkError() {
+				t.Fatalf("expected a network error, got %v", err)
+			}
+			// Backing off from 10ms, there is time for 5 attempts before the deadline.
+			if attempts := len(conn.written); attempts < 2 || attempts > 10 {
+				t.Errorf("expected the command to be retried with a backoff, got %d attempts", attempts)
+			}
+		})
+	})
+	t.Run("addMaxTimeMS", func(t *testing.T) {
+		timeout := 10 * time.Second
+		addMaxTimeMS := func(t *testing.T, ctx context.Context, op Operation, desc description.SelectedServer) (bsoncore.Document, error) {
+			t.Helper()
+
+			idx, dst := bsoncore.AppendDocumentStart(nil)
+			dst, err := op.CommandFn(dst, desc)
+			noerr(t, err)
+			dst, err = op.addMaxTimeMS(ctx, dst, idx, desc)
+			if err != nil {
+				return nil, err
+			}
+			dst, err = bsoncore.AppendDocumentEnd(dst, idx)
+			noerr(t, err)
+			return dst, nil
+		}
+		commandFn := func(elems ...[]byte) func([]byte, description.SelectedServer) ([]byte, error) {
+			return func(dst []byte, desc description.SelectedServer) ([]byte, error) {
+				for _, elem := range elems {
+					dst = append(dst, elem...)
+				}
+				return dst, nil
+			}
+		}
+
+		t.Run("derived from deadline", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			op := Operation{CommandFn: commandFn(bsoncore.AppendInt32Element(nil, "ping", 1)), Timeout: &timeout}
+
+			cmd, err := addMaxTimeMS(t, ctx, op, description.SelectedServer{})
+			noerr(t, err)
+			maxTimeMS, ok := cmd.Lookup("maxTimeMS").Int64OK()
+			if !ok {
+				t.Fatalf("expected maxTimeMS in command %v", cmd)
+			}
+			if maxTimeMS <= 0 || maxTimeMS > int64(timeout/time.Millisecond) {
+				t.Errorf("expected maxTimeMS in (0, %d], got %d", int64(timeout/time.Millisecond), maxTimeMS)
+			}
+		})
+		t.Run("not added without timeout", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			op := Operation{CommandFn: commandFn(bsoncore.AppendInt32Element(nil, "ping", 1))}
+
+			cmd, err := addMaxTimeMS(t, ctx, op, description.SelectedServer{})
+			noerr(t, err)
+			if _, err := cmd.LookupErr("maxTimeMS"); err == nil {
+				t.Errorf("expected no maxTimeMS in command %v", cmd)
+			}
+		})
+		t.Run("explicit maxTimeMS is kept", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			op := Operation{
+				CommandFn: commandFn(
+					bsoncore.AppendInt32Element(nil, "find", 1),
+					bsoncore.AppendInt64Element(nil, "maxTimeMS", 5),
+				),
+				Timeout: &timeout,
+			}
+
+			cmd, err := addMaxTimeMS(t, ctx, op, description.SelectedServer{})
+			noerr(t, err)
+			elems, err := cmd.Elements()
+			noerr(t, err)
+			if len(elems) != 2 || cmd.Lookup("maxTimeMS").Int64() != 5 {
+				t.Errorf("expected command to be unchanged, got %v", cmd)
+			}
+		})
+		t.Run("not added to getMore", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			op := Operation{CommandFn: commandFn(bsoncore.AppendInt64Element(nil, "getMore", 1)), Timeout: &timeout}
+
+			cmd, err := addMaxTimeMS(t, ctx, op, description.SelectedServer{})
+			noerr(t, err)
+			if _, err := cmd.LookupErr("maxTimeMS"); err == nil {
+				t.Errorf("expected no maxTimeMS in command %v", cmd)
+			}
+		})
+		t.Run("deadline would be exceeded", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			op := Operation{CommandFn: commandFn(bsoncore.AppendInt32Element(nil, "ping", 1)), Timeout: &timeout}
+			desc := description.SelectedServer{Server: description.Server{AverageRTT: time.Minute, AverageRTTSet: true}}
+
+			_, err := addMaxTimeMS(t, ctx, op, desc)
+			if err != ErrDeadlineWouldBeExceeded {
+				t.Errorf("expected error %v, got %v", ErrDeadlineWouldBeExceeded, err)
+			}
+		})
+	})
+	t.Run("getConnection pins load balanced transactions", func(t *testing.T) {
+		sessPool := session.NewPool(nil)
+		id, err := uuid.New()
+		noerr(t, err)
+		sess, err := session.NewClientSession(sessPool, id, session.Explicit)
+		noerr(t, err)
+		noerr(t, sess.StartTransaction(nil))
+
+		conn := newPinnedTestConn()
+		op := Operation{Client: sess}
+		got, err := op.getConnection(context.Background(), &pinnedServer{conn: conn})
+		noerr(t, err)
+		if got != conn || sess.PinnedConnection != conn || conn.txnPins != 1 {
+			t.Fatalf("expected the connection to be pinned to the transaction, got %d pins", conn.txnPins)
+		}
+
+		sess.ApplyCommand(conn.Description())
+		got, err = op.getConnection(context.Background(), &exhaustTestServer{conn: newExhaustTestConn()})
+		noerr(t, err)
+		if got != conn {
+			t.Errorf("expected the pinned connection to be reused, got %v", got)
+		}
+
+		noerr(t, sess.ClearPinnedResources())
+		if sess.PinnedConnection != nil || conn.txnPins != 0 || conn.closed != 1 {
+			t.Errorf("expected the connection to be unpinned and returned to the pool, got %d pins, closed %d",
+				conn.txnPins, conn.closed)
+		}
+	})
 	t.Run("$query to mongos only", func(t *testing.T) {
 		testCases := []struct {
 			name   string
@@ -474,7 +694,7 @@ func TestOperation(t *testing.T) {
 						Kind: tc.server,
 					},
 				}
-				wm, _, err := op.createQueryWireMessage(wm, desc)
+				wm, _, err := op.createQueryWireMessage(context.Background(), wm, desc)
 				noerr(t, err)
 
 				// We know where the $query would be within the OP_QUERY, so we'll just index into there.
@@ -493,6 +713,7 @@ func TestOperation(t *testing.T) {
 
 type mockDeployment struct {
 	params struct {
+		ctx      context.Context
 		selector description.ServerSelector
 	}
 	returns struct {
@@ -504,6 +725,7 @@ type mockDeployment struct {
 }
 
 func (m *mockDeployment) SelectServer(ctx context.Context, desc description.ServerSelector) (Server, error) {
+	m.params.ctx = ctx
 	m.params.selector = desc
 	return m.returns.server, m.returns.err
 }
@@ -0,0 +1,49 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package driver
+
+import (
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// ServerAPIOptions represents the server API version declared by an application. If set on an Operation, the
+// apiVersion, apiStrict and apiDeprecationErrors fields are appended to the commands it sends.
+type ServerAPIOptions struct {
+	ServerAPIVersion  string
+	Strict            *bool
+	DeprecationErrors *bool
+}
+
+// NewServerAPIOptions creates a new ServerAPIOptions for the given server API version.
+func NewServerAPIOptions(serverAPIVersion string) *ServerAPIOptions {
+	return &ServerAPIOptions{ServerAPIVersion: serverAPIVersion}
+}
+
+// SetStrict specifies whether the server should return errors for features that are not part of the declared API
+// version.
+func (s *ServerAPIOptions) SetStrict(strict bool) *ServerAPIOptions {
+	s.Strict = &strict
+	return s
+}
+
+// SetDeprecationErrors specifies whether the server should return errors for deprecated features.
+func (s *ServerAPIOptions) SetDeprecationErrors(deprecationErrors bool) *ServerAPIOptions {
+	s.DeprecationErrors = &deprecationErrors
+	return s
+}
+
+// appendServerAPI appends the apiVersion, apiStrict and apiDeprecationErrors fields to dst.
+func (s *ServerAPIOptions) appendServerAPI(dst []byte) []byte {
+	dst = bsoncore.AppendStringElement(dst, "apiVersion", s.ServerAPIVersion)
+	if s.Strict != nil {
+		dst = bsoncore.AppendBooleanElement(dst, "apiStrict", *s.Strict)
+	}
+	if s.DeprecationErrors != nil {
+		dst = bsoncore.AppendBooleanElement(dst, "apiDeprecationErrors", *s.DeprecationErrors)
+	}
+	return dst
+}
@@ -7,6 +7,7 @@
 package session // import "go.mongodb.org/mongo-driver/x/mongo/driver/session"
 
 import (
+	"context"
 	"errors"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/address"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/description"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/uuid"
 )
@@ -40,6 +42,25 @@ var ErrCommitAfterAbort = errors.New("cannot call commitTransaction after callin
 // ErrUnackWCUnsupported is returned if an unacknowledged write concern is supported for a transaciton.
 var ErrUnackWCUnsupported = errors.New("transactions do not support unacknowledged write concerns")
 
+// LoadBalancedTransactionConnection represents a connection that is pinned to a transaction because the deployment
+// is behind a load balancer. Its methods are a copy of those of driver.PinnedConnection, which cannot be used here
+// without causing an import cycle.
+type LoadBalancedTransactionConnection interface {
+	// Functions copied over from driver.Connection.
+	WriteWireMessage(context.Context, []byte) error
+	ReadWireMessage(ctx context.Context, dst []byte) ([]byte, error)
+	Description() description.Server
+	Close() error
+	ID() string
+	Address() address.Address
+
+	// Functions copied over from driver.PinnedConnection.
+	PinToCursor() error
+	PinToTransaction() error
+	UnpinFromCursor() error
+	UnpinFromTransaction() error
+}
+
 // Type describes the type of the session
 type Type uint8
 
@@ -93,6 +114,10 @@ type Client struct {
 	state         state
 	PinnedServer  *description.Server
 	RecoveryToken bson.Raw
+
+	// PinnedConnection is the connection the current transaction is pinned to. It is only set when the deployment is
+	// behind a load balancer.
+	PinnedConnection LoadBalancedTransactionConnection
 }
 
 func getClusterTime(clusterTime bson.Raw) (uint32, uint32) {
@@ -227,6 +252,25 @@ func (c *Client) ClearPinnedServer() {
 	}
 }
 
+// ClearPinnedResources sets the PinnedServer to nil and unpins the PinnedConnection, if there is one, returning it to
+// its pool.
+func (c *Client) ClearPinnedResources() error {
+	if c == nil {
+		return nil
+	}
+
+	c.PinnedServer = nil
+	if c.PinnedConnection == nil {
+		return nil
+	}
+	conn := c.PinnedConnection
+	c.PinnedConnection = nil
+	if err := conn.UnpinFromTransaction(); err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
 // EndSession ends the session.
 func (c *Client) EndSession() {
 	if c.Terminated {
@@ -234,6 +278,7 @@ func (c *Client) EndSession() {
 	}
 
 	c.Terminated = true
+	_ = c.ClearPinnedResources()
 	c.pool.ReturnSession(c.Server)
 
 	return
@@ -309,8 +354,7 @@ func (c *Client) StartTransaction(opts *TransactionOptions) error {
 	}
 
 	c.state = Starting
-	c.PinnedServer = nil
-	return nil
+	return c.ClearPinnedResources()
 }
 
 // CheckCommitTransaction checks to see if allowed to commit transaction and returns
@@ -397,6 +441,6 @@ func (c *Client) clearTransactionOpts() {
 	c.CurrentWc = nil
 	c.CurrentRp = nil
 	c.CurrentRc = nil
-	c.PinnedServer = nil
 	c.RecoveryToken = nil
+	_ = c.ClearPinnedResources()
 }
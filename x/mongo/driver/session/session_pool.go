@@ -26,7 +26,10 @@ type Pool struct {
 	head     *Node
 	tail     *Node
 	timeout  uint32
-	mutex    sync.Mutex // mutex to protect list and sessionTimeout
+	// loadBalanced is true if the deployment is behind a load balancer. Servers behind a load balancer are not
+	// monitored, so the pool never learns the session timeout and sessions are never considered expired.
+	loadBalanced bool
+	mutex        sync.Mutex // mutex to protect list and sessionTimeout
 
 	checkedOut int // number of sessions checked out of pool
 }
@@ -55,11 +58,20 @@ func (p *Pool) updateTimeout() {
 	select {
 	case newDesc := <-p.descChan:
 		p.timeout = newDesc.SessionTimeoutMinutes
+		p.loadBalanced = newDesc.Kind == description.LoadBalanced
 	default:
 		// no new description waiting
 	}
 }
 
+// assumes caller has mutex to protect the pool
+func (p *Pool) sessionExpired(ss *Server) bool {
+	if p.loadBalanced {
+		return false
+	}
+	return ss.expired(p.timeout)
+}
+
 // GetSession retrieves an unexpired session from the pool.
 func (p *Pool) GetSession() (*Server, error) {
 	p.mutex.Lock() // prevent changing the linked list while seeing if sessions have expired
@@ -73,7 +85,7 @@ func (p *Pool) GetSession() (*Server, error) {
 	p.updateTimeout()
 	for p.head != nil {
 		// pull session from head of queue and return if it is valid for at least 1 more minute
-		if p.head.expired(p.timeout) {
+		if p.sessionExpired(p.head.Server) {
 			p.head = p.head.next
 			continue
 		}
@@ -112,7 +124,7 @@ func (p *Pool) ReturnSession(ss *Server) {
 	p.updateTimeout()
 	// check sessions at end of queue for expired
 	// stop checking after hitting the first valid session
-	for p.tail != nil && p.tail.expired(p.timeout) {
+	for p.tail != nil && p.sessionExpired(p.tail.Server) {
 		if p.tail.prev != nil {
 			p.tail.prev.next = nil
 		}
@@ -120,7 +132,7 @@ func (p *Pool) ReturnSession(ss *Server) {
 	}
 
 	// session expired
-	if ss.expired(p.timeout) {
+	if p.sessionExpired(ss) {
 		return
 	}
 
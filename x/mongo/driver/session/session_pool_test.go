@@ -68,4 +68,24 @@ func TestSessionPool(t *testing.T) {
 			t.Errorf("Expired sessions not removed!")
 		}
 	})
+
+	t.Run("TestLoadBalancedReused", func(t *testing.T) {
+		descChan := make(chan description.Topology, 1)
+		p := NewPool(descChan)
+		// Load balanced topologies never report a session timeout.
+		descChan <- description.Topology{Kind: description.LoadBalanced}
+
+		first, err := p.GetSession()
+		testhelpers.RequireNil(t, err, "error getting session %s", err)
+		firstID := first.SessionID
+
+		p.ReturnSession(first)
+
+		sess, err := p.GetSession()
+		testhelpers.RequireNil(t, err, "error getting session %s", err)
+
+		if !sess.SessionID.Equal(firstID) {
+			t.Errorf("session not reused. got %s expected %s", sess.SessionID, firstID)
+		}
+	})
 }
@@ -30,6 +30,7 @@ type poolOptions struct {
 	MinPoolSize        int32 `json:"minPoolSize"`
 	MaxIdleTimeMS      int32 `json:"maxIdleTimeMS"`
 	WaitQueueTimeoutMS int32 `json:"waitQueueTimeoutMS"`
+	MaxConnecting      int32 `json:"maxConnecting"`
 }
 
 type cmapTestFile struct {
@@ -110,6 +111,12 @@ func runCMAPTest(t *testing.T, testFileName string) {
 		WithConnectionPoolMaxIdleTime(func(duration time.Duration) time.Duration {
 			return time.Duration(test.PoolOptions.MaxIdleTimeMS) * time.Millisecond
 		}),
+		WithMaxConnecting(func(u uint64) uint64 {
+			if test.PoolOptions.MaxConnecting > 0 {
+				return uint64(test.PoolOptions.MaxConnecting)
+			}
+			return u
+		}),
 		WithConnectionPoolMonitor(func(monitor *event.PoolMonitor) *event.PoolMonitor {
 			return &event.PoolMonitor{func(event *event.PoolEvent) { testInfo.originalEventChan <- event }}
 		}))
@@ -18,10 +18,13 @@ import (
 	"sync/atomic"
 	"time"
 
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/internal/tlsutil"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/address"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/description"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/ocsp"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/wiremessage"
 )
 
@@ -47,10 +50,15 @@ type connection struct {
 	connectErr       error
 	config           *connectionConfig
 
+	// streaming related fields. These are only used by the server monitor's dedicated connection.
+	canStream          bool
+	currentlyStreaming bool
+
 	// pool related fields
-	pool       *pool
-	poolID     uint64
-	generation uint64
+	pool              *pool
+	poolID            uint64
+	generation        uint64
+	serviceGeneration uint64 // only set for connections to a server behind a load balancer
 }
 
 // newConnection handles the creation of a connection. It does not connect the connection.
@@ -90,6 +98,15 @@ func (c *connection) connect(ctx context.Context) {
 		return
 	}
 	defer close(c.connectDone)
+	defer func() {
+		if c.connectErr == nil && c.pool != nil && c.pool.monitor != nil {
+			c.pool.monitor.Event(&event.PoolEvent{
+				Type:         event.ConnectionReady,
+				Address:      c.pool.address.String(),
+				ConnectionID: c.poolID,
+			})
+		}
+	}()
 
 	var err error
 	c.nc, err = c.config.dialer.DialContext(ctx, c.addr.Network(), c.addr.String())
@@ -104,7 +121,7 @@ func (c *connection) connect(ctx context.Context) {
 
 		// store the result of configureTLS in a separate variable than c.nc to avoid overwriting c.nc with nil in
 		// error cases.
-		tlsNc, err := configureTLS(ctx, c.nc, c.addr, tlsConfig)
+		tlsNc, err := configureTLS(ctx, c.nc, c.addr, tlsConfig, c.ocspOptions())
 		if err != nil {
 			if c.nc != nil {
 				_ = c.nc.Close()
@@ -138,6 +155,9 @@ func (c *connection) connect(ctx context.Context) {
 		return
 	}
 
+	if c.desc.ServiceID != nil && c.pool != nil {
+		c.serviceGeneration = c.pool.serviceGeneration(*c.desc.ServiceID)
+	}
 	if c.config.descCallback != nil {
 		c.config.descCallback(c.desc)
 	}
@@ -318,6 +338,7 @@ func (c *connection) bumpIdleDeadline() {
 type initConnection struct{ *connection }
 
 var _ driver.Connection = initConnection{}
+var _ driver.StreamerConnection = initConnection{}
 
 func (c initConnection) Description() description.Server {
 	if c.connection == nil {
@@ -340,6 +361,15 @@ func (c initConnection) WriteWireMessage(ctx context.Context, wm []byte) error {
 func (c initConnection) ReadWireMessage(ctx context.Context, dst []byte) ([]byte, error) {
 	return c.readWireMessage(ctx, dst)
 }
+func (c initConnection) SetStreaming(streaming bool) {
+	c.currentlyStreaming = streaming
+}
+func (c initConnection) CurrentlyStreaming() bool {
+	return c.currentlyStreaming
+}
+func (c initConnection) SupportsStreaming() bool {
+	return c.canStream
+}
 
 // Connection implements the driver.Connection interface to allow reading and writing wire
 // messages and the driver.Expirable interface to allow expiring.
@@ -347,11 +377,16 @@ type Connection struct {
 	*connection
 	s *Server
 
+	// pinCount is the number of cursors and transactions the connection is pinned to. The connection is not returned
+	// to the pool while it is pinned.
+	pinCount int
+
 	mu sync.RWMutex
 }
 
 var _ driver.Connection = (*Connection)(nil)
 var _ driver.Expirable = (*Connection)(nil)
+var _ driver.PinnedConnection = (*Connection)(nil)
 
 // WriteWireMessage handles writing a wire message to the underlying connection.
 func (c *Connection) WriteWireMessage(ctx context.Context, wm []byte) error {
@@ -418,11 +453,11 @@ func (c *Connection) Description() description.Server {
 }
 
 // Close returns this connection to the connection pool. This method may not closeConnection the underlying
-// socket.
+// socket. Close is a no-op while the connection is pinned to a cursor or transaction.
 func (c *Connection) Close() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	if c.connection == nil {
+	if c.connection == nil || c.pinCount > 0 {
 		return nil
 	}
 	if c.s != nil {
@@ -448,6 +483,52 @@ func (c *Connection) Expire() error {
 	return err
 }
 
+// PinToCursor pins this connection to a cursor. The connection is not returned to the pool until it has been unpinned
+// by every cursor and transaction it is pinned to.
+func (c *Connection) PinToCursor() error {
+	return c.pin()
+}
+
+// PinToTransaction pins this connection to a transaction. The connection is not returned to the pool until it has
+// been unpinned by every cursor and transaction it is pinned to.
+func (c *Connection) PinToTransaction() error {
+	return c.pin()
+}
+
+// UnpinFromCursor unpins this connection from a cursor.
+func (c *Connection) UnpinFromCursor() error {
+	return c.unpin("cursor")
+}
+
+// UnpinFromTransaction unpins this connection from a transaction.
+func (c *Connection) UnpinFromTransaction() error {
+	return c.unpin("transaction")
+}
+
+func (c *Connection) pin() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.connection == nil {
+		return ErrConnectionClosed
+	}
+	c.pinCount++
+	return nil
+}
+
+func (c *Connection) unpin(reason string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.connection == nil {
+		// The connection has already been expired, so there is nothing to unpin.
+		return nil
+	}
+	if c.pinCount == 0 {
+		return fmt.Errorf("cannot unpin a connection from a %s because it is not pinned", reason)
+	}
+	c.pinCount--
+	return nil
+}
+
 // Alive returns if the connection is still alive.
 func (c *Connection) Alive() bool {
 	return c.connection != nil
@@ -486,18 +567,31 @@ func (c *Connection) LocalAddress() address.Address {
 var notMasterCodes = []int32{10107, 13435}
 var recoveringCodes = []int32{11600, 11602, 13436, 189, 91}
 
-func configureTLS(ctx context.Context, nc net.Conn, addr address.Address, config *tls.Config) (net.Conn, error) {
-	if !config.InsecureSkipVerify {
-		hostname := addr.String()
-		colonPos := strings.LastIndex(hostname, ":")
-		if colonPos == -1 {
-			colonPos = len(hostname)
-		}
+// ocspOptions returns the options used to check the revocation status of the server's certificate after the TLS
+// handshake, or nil if revocation checking is disabled.
+func (c *connection) ocspOptions() *ocsp.VerifyOptions {
+	if c.config.disableCertRevocationCheck {
+		return nil
+	}
+	return &ocsp.VerifyOptions{
+		Cache:                   c.config.ocspCache,
+		DisableEndpointChecking: c.config.disableOCSPEndpointCheck,
+	}
+}
+
+func configureTLS(ctx context.Context, nc net.Conn, addr address.Address, config *tls.Config,
+	ocspOpts *ocsp.VerifyOptions) (net.Conn, error) {
 
-		hostname = hostname[:colonPos]
-		config.ServerName = hostname
+	// The server name is used for SNI even if certificate verification is disabled.
+	hostname := addr.String()
+	colonPos := strings.LastIndex(hostname, ":")
+	if colonPos == -1 {
+		colonPos = len(hostname)
 	}
 
+	hostname = hostname[:colonPos]
+	config.ServerName = hostname
+
 	client := tls.Client(nc, config)
 
 	errChan := make(chan error, 1)
@@ -513,5 +607,17 @@ func configureTLS(ctx context.Context, nc net.Conn, addr address.Address, config
 	case <-ctx.Done():
 		return nil, errors.New("server connection cancelled/timeout during TLS handshake")
 	}
+
+	// Revocation checking is skipped if certificate verification is disabled because the verified certificate chain
+	// is needed to validate OCSP responses.
+	if ocspOpts != nil {
+		connState := client.ConnectionState()
+		connState.VerifiedChains = tlsutil.VerifiedChains(config, connState)
+		if len(connState.VerifiedChains) > 0 {
+			if err := ocsp.Verify(ctx, connState, ocspOpts); err != nil {
+				return nil, err
+			}
+		}
+	}
 	return client, nil
 }
@@ -9,6 +9,7 @@ import (
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/description"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/ocsp"
 )
 
 // Dialer is used to make network connections.
@@ -50,6 +51,10 @@ type connectionConfig struct {
 	zlibLevel      *int
 	zstdLevel      *int
 	descCallback   func(description.Server)
+
+	ocspCache                  ocsp.Cache
+	disableOCSPEndpointCheck   bool
+	disableCertRevocationCheck bool
 }
 
 func newConnectionConfig(opts ...ConnectionOption) (*connectionConfig, error) {
@@ -167,6 +172,32 @@ func WithTLSConfig(fn func(*tls.Config) *tls.Config) ConnectionOption {
 	}
 }
 
+// WithOCSPCache specifies the cache used to store OCSP responses for TLS connections.
+func WithOCSPCache(fn func(ocsp.Cache) ocsp.Cache) ConnectionOption {
+	return func(c *connectionConfig) error {
+		c.ocspCache = fn(c.ocspCache)
+		return nil
+	}
+}
+
+// WithDisableOCSPEndpointCheck specifies whether or not the driver should reach out to OCSP responders to check the
+// revocation status of a server certificate if the server did not staple an OCSP response.
+func WithDisableOCSPEndpointCheck(fn func(bool) bool) ConnectionOption {
+	return func(c *connectionConfig) error {
+		c.disableOCSPEndpointCheck = fn(c.disableOCSPEndpointCheck)
+		return nil
+	}
+}
+
+// WithDisableCertificateRevocationCheck specifies whether or not the driver should check the revocation status of
+// server certificates using OCSP.
+func WithDisableCertificateRevocationCheck(fn func(bool) bool) ConnectionOption {
+	return func(c *connectionConfig) error {
+		c.disableCertRevocationCheck = fn(c.disableCertRevocationCheck)
+		return nil
+	}
+}
+
 // WithMonitor configures a event for command monitoring.
 func WithMonitor(fn func(*event.CommandMonitor) *event.CommandMonitor) ConnectionOption {
 	return func(c *connectionConfig) error {
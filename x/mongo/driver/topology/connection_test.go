@@ -8,16 +8,26 @@ package topology
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
+	"math/big"
 	"net"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/address"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/description"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/ocsp"
+	xocsp "golang.org/x/crypto/ocsp"
 )
 
 type netErr struct {
@@ -536,3 +546,97 @@ func (d *dialer) lenclosed() int {
 	defer d.Unlock()
 	return len(d.closed)
 }
+
+func TestConfigureTLSOCSP(t *testing.T) {
+	caKey, caCert := newTestCertificate(t, nil, nil)
+	leafKey, leafCert := newTestCertificate(t, caCert, caKey)
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+
+	newStaple := func(status int) []byte {
+		res, err := xocsp.CreateResponse(caCert, caCert, xocsp.Response{
+			Status:       status,
+			SerialNumber: leafCert.SerialNumber,
+			ThisUpdate:   time.Now().Add(-time.Minute),
+			NextUpdate:   time.Now().Add(time.Hour),
+		}, caKey)
+		assert.Nil(t, err, "CreateResponse error: %v", err)
+		return res
+	}
+
+	testCases := []struct {
+		name      string
+		staple    []byte
+		insecure  bool
+		ocspOpts  *ocsp.VerifyOptions
+		expectErr bool
+	}{
+		{"good staple", newStaple(xocsp.Good), false, &ocsp.VerifyOptions{}, false},
+		{"revoked staple", newStaple(xocsp.Revoked), false, &ocsp.VerifyOptions{}, true},
+		{"revoked staple with revocation checking disabled", newStaple(xocsp.Revoked), false, nil, false},
+		{"revoked staple with certificate verification disabled", newStaple(xocsp.Revoked), true, &ocsp.VerifyOptions{}, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			serverConn, clientConn := net.Pipe()
+			defer func() { _ = clientConn.Close() }()
+
+			serverCfg := &tls.Config{
+				Certificates: []tls.Certificate{{
+					Certificate: [][]byte{leafCert.Raw},
+					PrivateKey:  leafKey,
+					OCSPStaple:  tc.staple,
+				}},
+			}
+			go func() {
+				server := tls.Server(serverConn, serverCfg)
+				_ = server.Handshake()
+				_ = server.Close()
+			}()
+
+			clientCfg := &tls.Config{RootCAs: roots, InsecureSkipVerify: tc.insecure}
+			addr := address.Address("localhost:27017")
+			nc, err := configureTLS(context.Background(), clientConn, addr, clientCfg, tc.ocspOpts)
+			if tc.expectErr {
+				_, ok := err.(*ocsp.Error)
+				assert.True(t, ok, "expected error of type %T, got %v (type %T)", &ocsp.Error{}, err, err)
+				return
+			}
+			assert.Nil(t, err, "configureTLS error: %v", err)
+			assert.NotNil(t, nc, "expected connection, got nil")
+		})
+	}
+}
+
+// newTestCertificate creates a certificate for localhost signed by the given issuer. If the issuer is nil, a
+// self-signed CA certificate is created.
+func newTestCertificate(t *testing.T, issuer *x509.Certificate, issuerKey *ecdsa.PrivateKey) (*ecdsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err, "GenerateKey error: %v", err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	if issuer == nil {
+		template.SerialNumber = big.NewInt(1)
+		template.Subject = pkix.Name{CommonName: "test CA"}
+		template.KeyUsage |= x509.KeyUsageCertSign
+		template.BasicConstraintsValid = true
+		template.IsCA = true
+		issuer, issuerKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, issuerKey)
+	assert.Nil(t, err, "CreateCertificate error: %v", err)
+	cert, err := x509.ParseCertificate(der)
+	assert.Nil(t, err, "ParseCertificate error: %v", err)
+	return key, cert
+}
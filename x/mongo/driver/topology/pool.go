@@ -12,6 +12,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/address"
 )
@@ -35,18 +36,24 @@ var ErrWaitQueueTimeout = PoolError("timed out while checking out a connection f
 // PoolError is an error returned from a Pool method.
 type PoolError string
 
-// maintainInterval is the interval at which the background routine to close stale connections will be run.
+// maintainInterval is the interval at which the background routine to close stale connections and to re-establish
+// minPoolSize connections will be run.
 var maintainInterval = time.Minute
 
 func (pe PoolError) Error() string { return string(pe) }
 
+// defaultMaxConnecting is the default maximum number of connections a pool will establish concurrently.
+const defaultMaxConnecting = 2
+
 // poolConfig contains all aspects of the pool that can be configured
 type poolConfig struct {
-	Address     address.Address
-	MinPoolSize uint64
-	MaxPoolSize uint64 // MaxPoolSize is not used because handling the max number of connections in the pool is handled in server. This is only used for command monitoring
-	MaxIdleTime time.Duration
-	PoolMonitor *event.PoolMonitor
+	Address          address.Address
+	MinPoolSize      uint64
+	MaxPoolSize      uint64 // MaxPoolSize is not used because handling the max number of connections in the pool is handled in server. This is only used for command monitoring
+	MaxConnecting    uint64
+	MaxIdleTime      time.Duration
+	WaitQueueTimeout time.Duration // WaitQueueTimeout is enforced by server. This is only used for command monitoring
+	PoolMonitor      *event.PoolMonitor
 }
 
 // checkOutResult is all the values that can be returned from a checkOut
@@ -56,6 +63,13 @@ type checkOutResult struct {
 	reason string
 }
 
+// wantConn is a checkout request waiting in the pool's wait queue. The request is satisfied either by handing it a
+// connection that was checked in or by granting it permission to establish a new connection.
+type wantConn struct {
+	ready chan struct{} // closed when the request is satisfied
+	conn  *connection   // the connection that was handed off, or nil if permission to connect was granted
+}
+
 // pool is a wrapper of resource pool that follows the CMAP spec for connection pools
 type pool struct {
 	address    address.Address
@@ -64,10 +78,31 @@ type pool struct {
 	generation uint64        // must be accessed using atomic package
 	monitor    *event.PoolMonitor
 
+	// serviceGenerations holds a generation for each serviceId seen behind a load balancer, so that the connections
+	// to a single service can be cleared without affecting the others. It is guarded by serviceLock.
+	serviceGenerations map[primitive.ObjectID]uint64
+	serviceLock        sync.Mutex
+
 	connected int32 // Must be accessed using the sync/atomic package.
 	nextid    uint64
 	opened    map[uint64]*connection // opened holds all of the currently open connections.
 	sync.Mutex
+
+	// minSize is the number of connections the background maintenance routine keeps open. maintainReady wakes the
+	// routine up before its next scheduled run, e.g. after the pool has been cleared.
+	minSize          uint64
+	maintainInterval time.Duration
+	maintainReady    chan struct{}
+	cancelMaintain   context.CancelFunc
+	maintainDone     sync.WaitGroup
+
+	// maxConnecting limits the number of connections being established at once. Checkout requests that need a new
+	// connection while the limit is reached wait in waitQueue in FIFO order. connecting and waitQueue are guarded by
+	// queueLock.
+	maxConnecting uint64
+	connecting    uint64
+	waitQueue     []*wantConn
+	queueLock     sync.Mutex
 }
 
 // connectionExpiredFunc checks if a given connection is stale and should be removed from the resource pool
@@ -123,18 +158,6 @@ func connectionCloseFunc(v interface{}) {
 	}()
 }
 
-// connectionInitFunc returns an init function for the resource pool that will make new connections for this pool
-func (p *pool) connectionInitFunc() interface{} {
-	c, _, err := p.makeNewConnection(context.Background())
-	if err != nil {
-		return nil
-	}
-
-	go c.connect(context.Background())
-
-	return c
-}
-
 // newPool creates a new pool that will hold size number of idle connections. It will use the
 // provided options when creating connections.
 func newPool(config poolConfig, connOpts ...ConnectionOption) (*pool, error) {
@@ -143,21 +166,28 @@ func newPool(config poolConfig, connOpts ...ConnectionOption) (*pool, error) {
 		opts = append(opts, WithIdleTimeout(func(_ time.Duration) time.Duration { return config.MaxIdleTime }))
 	}
 
-	pool := &pool{
-		address:   config.Address,
-		monitor:   config.PoolMonitor,
-		connected: disconnected,
-		opened:    make(map[uint64]*connection),
-		opts:      opts,
+	maxConnecting := config.MaxConnecting
+	if maxConnecting == 0 {
+		maxConnecting = defaultMaxConnecting
 	}
 
-	// we do not pass in config.MaxPoolSize because we manage the max size at this level rather than the resource pool level
+	pool := &pool{
+		address:          config.Address,
+		monitor:          config.PoolMonitor,
+		connected:        disconnected,
+		opened:           make(map[uint64]*connection),
+		opts:             opts,
+		maxConnecting:    maxConnecting,
+		minSize:          config.MinPoolSize,
+		maintainInterval: maintainInterval,
+		maintainReady:    make(chan struct{}, 1),
+	}
+
+	// we do not pass in config.MaxPoolSize or config.MinPoolSize because the max size is managed by the server and
+	// the min size is maintained by the pool's background routine rather than the resource pool
 	rpc := resourcePoolConfig{
-		MinSize:          config.MinPoolSize,
-		MaintainInterval: maintainInterval,
-		ExpiredFn:        connectionExpiredFunc,
-		CloseFn:          connectionCloseFunc,
-		InitFn:           pool.connectionInitFunc,
+		ExpiredFn: connectionExpiredFunc,
+		CloseFn:   connectionCloseFunc,
 	}
 
 	if pool.monitor != nil {
@@ -165,8 +195,9 @@ func newPool(config poolConfig, connOpts ...ConnectionOption) (*pool, error) {
 			Type: event.PoolCreated,
 			PoolOptions: &event.MonitorPoolOptions{
 				MaxPoolSize:        config.MaxPoolSize,
-				MinPoolSize:        rpc.MinSize,
-				WaitQueueTimeoutMS: uint64(config.MaxIdleTime) / uint64(time.Millisecond),
+				MinPoolSize:        config.MinPoolSize,
+				MaxConnecting:      maxConnecting,
+				WaitQueueTimeoutMS: uint64(config.WaitQueueTimeout) / uint64(time.Millisecond),
 			},
 			Address: pool.address.String(),
 		})
@@ -184,9 +215,23 @@ func newPool(config poolConfig, connOpts ...ConnectionOption) (*pool, error) {
 // drain drains the pool by increasing the generation ID.
 func (p *pool) drain() { atomic.AddUint64(&p.generation, 1) }
 
-// stale checks if a given connection's generation is below the generation of the pool
+// stale checks if a given connection's generation is below the generation of the pool or, for connections to a
+// server behind a load balancer, below the generation of the connection's service.
 func (p *pool) stale(c *connection) bool {
-	return c == nil || c.generation < atomic.LoadUint64(&p.generation)
+	if c == nil || c.generation < atomic.LoadUint64(&p.generation) {
+		return true
+	}
+	if c.desc.ServiceID != nil {
+		return c.serviceGeneration < p.serviceGeneration(*c.desc.ServiceID)
+	}
+	return false
+}
+
+// serviceGeneration returns the current generation of the given service.
+func (p *pool) serviceGeneration(serviceID primitive.ObjectID) uint64 {
+	p.serviceLock.Lock()
+	defer p.serviceLock.Unlock()
+	return p.serviceGenerations[serviceID]
 }
 
 // connect puts the pool into the connected state, allowing it to be used and will allow items to begin being processed from the wait queue
@@ -194,7 +239,11 @@ func (p *pool) connect() error {
 	if !atomic.CompareAndSwapInt32(&p.connected, disconnected, connected) {
 		return ErrPoolConnected
 	}
-	p.conns.initialize()
+
+	var ctx context.Context
+	ctx, p.cancelMaintain = context.WithCancel(context.Background())
+	p.maintainDone.Add(1)
+	go p.maintain(ctx)
 	return nil
 }
 
@@ -208,7 +257,11 @@ func (p *pool) disconnect(ctx context.Context) error {
 		ctx = context.Background()
 	}
 
-	p.conns.Close()
+	// Stop the background routine before clearing the idle connections so it cannot establish new ones.
+	p.cancelMaintain()
+	p.maintainDone.Wait()
+
+	p.conns.Clear()
 	atomic.AddUint64(&p.generation, 1)
 
 	var err error
@@ -308,7 +361,10 @@ func (p *pool) makeNewConnection(ctx context.Context) (*connection, string, erro
 
 }
 
-// Checkout returns a connection from the pool
+// Checkout returns a connection from the pool. An idle connection is used if one is available. Otherwise, a new
+// connection is established, subject to the pool's maxConnecting limit. Requests that cannot establish a connection
+// because of that limit wait in a FIFO queue until either a connection is checked in or another request finishes
+// establishing its connection.
 func (p *pool) get(ctx context.Context) (*connection, error) {
 
 	if ctx == nil {
@@ -326,35 +382,35 @@ func (p *pool) get(ctx context.Context) (*connection, error) {
 		return nil, ErrPoolDisconnected
 	}
 
-	connVal := p.conns.Get()
-	if c, ok := connVal.(*connection); ok && connVal != nil {
-		// call connect if not connected
-		if atomic.LoadInt32(&c.connected) == initialized {
-			c.connect(ctx)
-		}
-
-		err := c.wait()
+	c := p.getIdle()
+	if c == nil {
+		var err error
+		c, err = p.waitForConnectionOrPermit(ctx)
 		if err != nil {
 			if p.monitor != nil {
 				p.monitor.Event(&event.PoolEvent{
 					Type:    event.GetFailed,
 					Address: p.address.String(),
-					Reason:  event.ReasonConnectionErrored,
+					Reason:  event.ReasonTimedOut,
 				})
 			}
 			return nil, err
 		}
 
-		if p.monitor != nil {
-			p.monitor.Event(&event.PoolEvent{
-				Type:         event.GetSucceeded,
-				Address:      p.address.String(),
-				ConnectionID: c.poolID,
-			})
+		// If no connection was handed off, this request is now allowed to establish a new connection. A connection
+		// may have become idle while waiting, so check again before doing so.
+		if c == nil {
+			if c = p.getIdle(); c != nil {
+				p.releasePermit()
+			}
 		}
-		return c, nil
+	}
+	if c != nil {
+		return p.checkOutExisting(ctx, c)
 	}
 
+	defer p.releasePermit()
+
 	select {
 	case <-ctx.Done():
 		if p.monitor != nil {
@@ -383,11 +439,12 @@ func (p *pool) get(ctx context.Context) (*connection, error) {
 		// wait for conn to be connected
 		err = c.wait()
 		if err != nil {
+			p.closeFailedConnection(c)
 			if p.monitor != nil {
 				p.monitor.Event(&event.PoolEvent{
 					Type:    event.GetFailed,
 					Address: p.address.String(),
-					Reason:  reason,
+					Reason:  event.ReasonConnectionErrored,
 				})
 			}
 			return nil, err
@@ -404,6 +461,134 @@ func (p *pool) get(ctx context.Context) (*connection, error) {
 	}
 }
 
+// getIdle returns an idle connection from the pool or nil if there are none.
+func (p *pool) getIdle() *connection {
+	c, ok := p.conns.Get().(*connection)
+	if !ok {
+		return nil
+	}
+	return c
+}
+
+// checkOutExisting finishes checking out a connection that was idle in the pool or handed off by put.
+func (p *pool) checkOutExisting(ctx context.Context, c *connection) (*connection, error) {
+	// call connect if not connected
+	if atomic.LoadInt32(&c.connected) == initialized {
+		c.connect(ctx)
+	}
+
+	err := c.wait()
+	if err != nil {
+		p.closeFailedConnection(c)
+		if p.monitor != nil {
+			p.monitor.Event(&event.PoolEvent{
+				Type:    event.GetFailed,
+				Address: p.address.String(),
+				Reason:  event.ReasonConnectionErrored,
+			})
+		}
+		return nil, err
+	}
+
+	if p.monitor != nil {
+		p.monitor.Event(&event.PoolEvent{
+			Type:         event.GetSucceeded,
+			Address:      p.address.String(),
+			ConnectionID: c.poolID,
+		})
+	}
+	return c, nil
+}
+
+// waitForConnectionOrPermit blocks until the caller may establish a new connection or until a checked in connection
+// is handed to it. In the first case, the returned connection is nil and the caller must call releasePermit once it
+// has finished establishing its connection. ErrWaitQueueTimeout is returned if ctx expires first.
+func (p *pool) waitForConnectionOrPermit(ctx context.Context) (*connection, error) {
+	p.queueLock.Lock()
+	// Only skip the queue if no one else is waiting so requests are served in the order they arrive.
+	if len(p.waitQueue) == 0 && p.connecting < p.maxConnecting {
+		p.connecting++
+		p.queueLock.Unlock()
+		return nil, nil
+	}
+
+	w := &wantConn{ready: make(chan struct{})}
+	p.waitQueue = append(p.waitQueue, w)
+	p.queueLock.Unlock()
+
+	select {
+	case <-w.ready:
+		return w.conn, nil
+	case <-ctx.Done():
+	}
+
+	p.queueLock.Lock()
+	defer p.queueLock.Unlock()
+	select {
+	case <-w.ready:
+		// The request was satisfied concurrently with the context expiring, so use the result.
+		return w.conn, nil
+	default:
+	}
+	for i, queued := range p.waitQueue {
+		if queued == w {
+			p.waitQueue = append(p.waitQueue[:i], p.waitQueue[i+1:]...)
+			break
+		}
+	}
+	return nil, ErrWaitQueueTimeout
+}
+
+// tryAcquirePermit grants permission to establish a connection if that can be done without waiting or jumping ahead
+// of requests in the wait queue. The caller must call releasePermit once it has finished establishing its connection.
+func (p *pool) tryAcquirePermit() bool {
+	p.queueLock.Lock()
+	defer p.queueLock.Unlock()
+
+	if len(p.waitQueue) > 0 || p.connecting >= p.maxConnecting {
+		return false
+	}
+	p.connecting++
+	return true
+}
+
+// releasePermit gives up permission to establish a connection. The permission is passed on to the request at the
+// front of the wait queue if there is one.
+func (p *pool) releasePermit() {
+	p.queueLock.Lock()
+	defer p.queueLock.Unlock()
+
+	if len(p.waitQueue) > 0 {
+		w := p.waitQueue[0]
+		p.waitQueue = p.waitQueue[1:]
+		close(w.ready)
+		return
+	}
+	p.connecting--
+}
+
+// handOff gives a checked in connection to the request at the front of the wait queue. It returns false if there
+// are no waiting requests, in which case the connection should be returned to the idle pool instead.
+func (p *pool) handOff(c *connection) bool {
+	p.queueLock.Lock()
+	defer p.queueLock.Unlock()
+
+	if len(p.waitQueue) == 0 {
+		return false
+	}
+	if connectionExpiredFunc(c) {
+		// Waiting requests will be granted permission to connect once an in-progress connection is established.
+		connectionCloseFunc(c)
+		return true
+	}
+
+	w := p.waitQueue[0]
+	p.waitQueue = p.waitQueue[1:]
+	w.conn = c
+	close(w.ready)
+	return true
+}
+
 // closeConnection closes a connection, not the pool itself. This method will actually closeConnection the connection,
 // making it unusable, to instead return the connection to the pool, use put.
 func (p *pool) closeConnection(c *connection) error {
@@ -428,6 +613,20 @@ func (p *pool) closeConnection(c *connection) error {
 	return nil
 }
 
+// closeFailedConnection removes a connection that could not be established from the pool so it no longer counts
+// towards the pool's size.
+func (p *pool) closeFailedConnection(c *connection) {
+	if p.monitor != nil {
+		p.monitor.Event(&event.PoolEvent{
+			Type:         event.ConnectionClosed,
+			Address:      p.address.String(),
+			ConnectionID: c.poolID,
+			Reason:       event.ReasonConnectionErrored,
+		})
+	}
+	_ = p.closeConnection(c) // The connection already failed, ignore the error from closing it.
+}
+
 // put returns a connection to this pool. If the pool is connected, the connection is not
 // stale, and there is space in the cache, the connection is returned to the cache.
 func (p *pool) put(c *connection) error {
@@ -453,6 +652,9 @@ func (p *pool) put(c *connection) error {
 		return ErrWrongPool
 	}
 
+	if p.handOff(c) {
+		return nil
+	}
 	_ = p.conns.Put(c)
 
 	return nil
@@ -468,5 +670,101 @@ func (p *pool) clear() {
 	}
 
 	p.drain()
-	p.conns.Maintain()
+	p.conns.Prune()
+	p.wakeMaintain()
+}
+
+// clearService clears the connections to a single service behind a load balancer by incrementing the generation of
+// that service. Connections to other services are unaffected.
+func (p *pool) clearService(serviceID primitive.ObjectID) {
+	if p.monitor != nil {
+		p.monitor.Event(&event.PoolEvent{
+			Type:      event.PoolCleared,
+			Address:   p.address.String(),
+			ServiceID: &serviceID,
+		})
+	}
+
+	p.serviceLock.Lock()
+	if p.serviceGenerations == nil {
+		p.serviceGenerations = make(map[primitive.ObjectID]uint64)
+	}
+	p.serviceGenerations[serviceID]++
+	p.serviceLock.Unlock()
+
+	p.conns.Prune()
+	p.wakeMaintain()
+}
+
+// wakeMaintain wakes up the background routine so minPoolSize connections are re-established right away.
+func (p *pool) wakeMaintain() {
+	select {
+	case p.maintainReady <- struct{}{}:
+	default:
+	}
+}
+
+// maintain is the pool's background maintenance routine. Every maintainInterval, and whenever it is woken up through
+// maintainReady, it closes idle and perished connections and establishes new connections until the pool holds at
+// least minSize connections of the current generation. It returns once ctx is cancelled by disconnect.
+func (p *pool) maintain(ctx context.Context) {
+	defer p.maintainDone.Done()
+
+	ticker := time.NewTicker(p.maintainInterval)
+	defer ticker.Stop()
+
+	for {
+		p.conns.Prune()
+		p.ensureMinSize(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-p.maintainReady:
+		}
+	}
+}
+
+// ensureMinSize establishes connections one at a time until the pool holds minSize connections that are not stale.
+// Background connections are subject to the maxConnecting limit but never wait for it so they do not delay checkout
+// requests; any shortfall is made up on a later run.
+func (p *pool) ensureMinSize(ctx context.Context) {
+	for p.currentSize() < p.minSize {
+		if ctx.Err() != nil || !p.tryAcquirePermit() {
+			return
+		}
+
+		c, _, err := p.makeNewConnection(ctx)
+		if err != nil {
+			p.releasePermit()
+			return
+		}
+
+		c.connect(ctx)
+		err = c.wait()
+		p.releasePermit()
+		if err != nil {
+			p.closeFailedConnection(c)
+			return
+		}
+
+		if !p.handOff(c) {
+			_ = p.conns.Put(c)
+		}
+	}
+}
+
+// currentSize returns the number of open connections, both idle and checked out, that belong to the current
+// generation of the pool and, for connections to a server behind a load balancer, of their service.
+func (p *pool) currentSize() uint64 {
+	p.Lock()
+	defer p.Unlock()
+	var size uint64
+	for _, c := range p.opened {
+		if !p.stale(c) {
+			size++
+		}
+	}
+	return size
 }
@@ -319,6 +319,31 @@ func TestPool(t *testing.T) {
 				t.Errorf("Should return error from calling New. got %v; want %v", got, want)
 			}
 		})
+		t.Run("removes connections that fail to connect", func(t *testing.T) {
+			var dialer DialerFunc = func(context.Context, string, string) (net.Conn, error) {
+				return nil, errors.New("dial error")
+			}
+			pc := poolConfig{
+				Address: address.Address(""),
+			}
+			p, err := newPool(pc, WithDialer(func(Dialer) Dialer { return dialer }))
+			noerr(t, err)
+			err = p.connect()
+			noerr(t, err)
+			defer func() { _ = p.disconnect(context.Background()) }()
+
+			for i := 0; i < 3; i++ {
+				if _, err := p.get(context.Background()); err == nil {
+					t.Fatal("expected an error from get but got nil")
+				}
+			}
+			if opened := len(p.opened); opened != 0 {
+				t.Errorf("failed connections should be removed from the pool. got %d opened; want %d", opened, 0)
+			}
+			if size := p.currentSize(); size != 0 {
+				t.Errorf("unexpected pool size. got %d; want %d", size, 0)
+			}
+		})
 		t.Run("adds connection to inflight pool", func(t *testing.T) {
 			cleanup := make(chan struct{})
 			addr := bootstrapConnections(t, 1, func(nc net.Conn) {
@@ -555,6 +580,247 @@ func TestPool(t *testing.T) {
 			noerr(t, err)
 		})
 	})
+	t.Run("wait queue", func(t *testing.T) {
+		t.Run("limits the number of connections being established", func(t *testing.T) {
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 4, func(nc net.Conn) {
+				<-cleanup
+				_ = nc.Close()
+			})
+			d := newBlockingDialer(&net.Dialer{})
+			pc := poolConfig{
+				Address:       address.Address(addr.String()),
+				MaxConnecting: 2,
+			}
+			p, err := newPool(pc, WithDialer(func(Dialer) Dialer { return d }))
+			noerr(t, err)
+			err = p.connect()
+			noerr(t, err)
+
+			errs := make(chan error, 4)
+			for i := 0; i < 4; i++ {
+				go func() {
+					_, err := p.get(context.Background())
+					errs <- err
+				}()
+			}
+			time.Sleep(100 * time.Millisecond)
+			if dialing := atomic.LoadInt32(&d.dialing); dialing != 2 {
+				t.Errorf("unexpected number of connections being established. got %d; want %d", dialing, 2)
+			}
+			close(d.unblock)
+			for i := 0; i < 4; i++ {
+				noerr(t, <-errs)
+			}
+		})
+		t.Run("hands checked in connections to waiting requests in order", func(t *testing.T) {
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 2, func(nc net.Conn) {
+				<-cleanup
+				_ = nc.Close()
+			})
+			d := newBlockingDialer(&net.Dialer{})
+			pc := poolConfig{
+				Address:       address.Address(addr.String()),
+				MaxConnecting: 1,
+			}
+			p, err := newPool(pc, WithDialer(func(Dialer) Dialer { return d }))
+			noerr(t, err)
+			err = p.connect()
+			noerr(t, err)
+
+			close(d.unblock)
+			c, err := p.get(context.Background())
+			noerr(t, err)
+
+			// Hold the only permit so that waiting requests can only be served by a checked in connection.
+			_, err = p.waitForConnectionOrPermit(context.Background())
+			noerr(t, err)
+
+			order := make(chan int, 2)
+			for i := 0; i < 2; i++ {
+				go func(i int) {
+					got, err := p.get(context.Background())
+					noerr(t, err)
+					order <- i
+					_ = p.put(got)
+				}(i)
+				time.Sleep(50 * time.Millisecond)
+			}
+			err = p.put(c)
+			noerr(t, err)
+			for want := 0; want < 2; want++ {
+				if got := <-order; got != want {
+					t.Errorf("requests were not served in order. got %d; want %d", got, want)
+				}
+			}
+			p.releasePermit()
+			if d.lenopened() != 1 {
+				t.Errorf("unexpected number of connections opened. got %d; want %d", d.lenopened(), 1)
+			}
+		})
+		t.Run("times out", func(t *testing.T) {
+			d := newBlockingDialer(&net.Dialer{})
+			defer close(d.unblock)
+			pc := poolConfig{
+				Address:       address.Address("localhost:27017"),
+				MaxConnecting: 1,
+			}
+			p, err := newPool(pc, WithDialer(func(Dialer) Dialer { return d }))
+			noerr(t, err)
+			err = p.connect()
+			noerr(t, err)
+
+			_, err = p.waitForConnectionOrPermit(context.Background())
+			noerr(t, err)
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			_, err = p.get(ctx)
+			if err != ErrWaitQueueTimeout {
+				t.Errorf("unexpected error. got %v; want %v", err, ErrWaitQueueTimeout)
+			}
+			if len(p.waitQueue) != 0 {
+				t.Errorf("expected timed out request to leave the wait queue. got %d waiting", len(p.waitQueue))
+			}
+		})
+	})
+	t.Run("maintenance", func(t *testing.T) {
+		t.Run("establishes minPoolSize connections", func(t *testing.T) {
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 3, func(nc net.Conn) {
+				<-cleanup
+				_ = nc.Close()
+			})
+			d := newdialer(&net.Dialer{})
+			pc := poolConfig{
+				Address:     address.Address(addr.String()),
+				MinPoolSize: 3,
+			}
+			p, err := newPool(pc, WithDialer(func(Dialer) Dialer { return d }))
+			noerr(t, err)
+			err = p.connect()
+			noerr(t, err)
+			defer func() { _ = p.disconnect(context.Background()) }()
+
+			waitUntil(t, func() bool { return atomic.LoadUint64(&p.conns.size) == 3 })
+			if d.lenopened() != 3 {
+				t.Errorf("unexpected number of connections opened. got %d; want %d", d.lenopened(), 3)
+			}
+		})
+		t.Run("re-establishes minPoolSize connections after clear", func(t *testing.T) {
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 4, func(nc net.Conn) {
+				<-cleanup
+				_ = nc.Close()
+			})
+			d := newdialer(&net.Dialer{})
+			pc := poolConfig{
+				Address:     address.Address(addr.String()),
+				MinPoolSize: 2,
+			}
+			p, err := newPool(pc, WithDialer(func(Dialer) Dialer { return d }))
+			noerr(t, err)
+			err = p.connect()
+			noerr(t, err)
+			defer func() { _ = p.disconnect(context.Background()) }()
+
+			waitUntil(t, func() bool { return d.lenopened() == 2 })
+			p.clear()
+			waitUntil(t, func() bool { return d.lenopened() == 4 && d.lenclosed() == 2 })
+			if size := p.currentSize(); size != 2 {
+				t.Errorf("unexpected number of connections in the current generation. got %d; want %d", size, 2)
+			}
+		})
+		t.Run("closes idle connections", func(t *testing.T) {
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 1, func(nc net.Conn) {
+				<-cleanup
+				_ = nc.Close()
+			})
+			d := newdialer(&net.Dialer{})
+			pc := poolConfig{
+				Address:     address.Address(addr.String()),
+				MaxIdleTime: 10 * time.Millisecond,
+			}
+			maintainInterval = 10 * time.Millisecond
+			p, err := newPool(pc, WithDialer(func(Dialer) Dialer { return d }))
+			maintainInterval = time.Minute
+			noerr(t, err)
+			err = p.connect()
+			noerr(t, err)
+			defer func() { _ = p.disconnect(context.Background()) }()
+
+			c, err := p.get(context.Background())
+			noerr(t, err)
+			err = p.put(c)
+			noerr(t, err)
+			waitUntil(t, func() bool { return d.lenclosed() == 1 })
+			if size := atomic.LoadUint64(&p.conns.size); size != 0 {
+				t.Errorf("expected idle connection to be removed from the pool. got %d idle connections", size)
+			}
+		})
+		t.Run("stops on disconnect", func(t *testing.T) {
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 1, func(nc net.Conn) {
+				<-cleanup
+				_ = nc.Close()
+			})
+			d := newdialer(&net.Dialer{})
+			pc := poolConfig{
+				Address:     address.Address(addr.String()),
+				MinPoolSize: 1,
+			}
+			p, err := newPool(pc, WithDialer(func(Dialer) Dialer { return d }))
+			noerr(t, err)
+			err = p.connect()
+			noerr(t, err)
+
+			waitUntil(t, func() bool { return d.lenopened() == 1 })
+			err = p.disconnect(context.Background())
+			noerr(t, err)
+			p.clear()
+			time.Sleep(50 * time.Millisecond)
+			if d.lenopened() != 1 {
+				t.Errorf("expected no connections to be established after disconnect. got %d; want %d", d.lenopened(), 1)
+			}
+		})
+	})
+}
+
+// waitUntil polls cond until it returns true, failing the test if that does not happen within a few seconds.
+func waitUntil(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+type blockingDialer struct {
+	*dialer
+	dialing int32
+	unblock chan struct{}
+}
+
+func newBlockingDialer(d Dialer) *blockingDialer {
+	return &blockingDialer{dialer: newdialer(d), unblock: make(chan struct{})}
+}
+
+func (d *blockingDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	atomic.AddInt32(&d.dialing, 1)
+	defer atomic.AddInt32(&d.dialing, -1)
+	<-d.unblock
+	return d.dialer.DialContext(ctx, network, address)
 }
 
 type sleepDialer struct {
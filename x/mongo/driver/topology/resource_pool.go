@@ -10,7 +10,6 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
-	"time"
 )
 
 // expiredFunc is the function type used for testing whether or not resources in a resourcePool have stale. It should
@@ -21,16 +20,9 @@ type expiredFunc func(interface{}) bool
 // asynchronously
 type closeFunc func(interface{})
 
-// initFunc is the function used to add a resource to the resource pool to maintain minimum size. It returns a new
-// resource each time it is called.
-type initFunc func() interface{}
-
 type resourcePoolConfig struct {
-	MinSize          uint64
-	MaintainInterval time.Duration
-	ExpiredFn        expiredFunc
-	CloseFn          closeFunc
-	InitFn           initFunc
+	ExpiredFn expiredFunc
+	CloseFn   closeFunc
 }
 
 // setup sets defaults in the rpc and checks that the given values are valid
@@ -41,9 +33,6 @@ func (rpc *resourcePoolConfig) setup() error {
 	if rpc.CloseFn == nil {
 		return fmt.Errorf("an CloseFn is required to create a resource pool")
 	}
-	if rpc.MaintainInterval == time.Duration(0) {
-		return fmt.Errorf("unable to have MaintainInterval time of %v", rpc.MaintainInterval)
-	}
 	return nil
 }
 
@@ -53,53 +42,33 @@ type resourcePoolElement struct {
 	value      interface{}
 }
 
-// resourcePool is a concurrent resource pool
+// resourcePool is a concurrent resource pool. It does not create resources or prune itself in the background; the
+// owner of the pool is responsible for both.
 type resourcePool struct {
-	start, end       *resourcePoolElement
-	size, minSize    uint64
-	expiredFn        expiredFunc
-	closeFn          closeFunc
-	initFn           initFunc
-	maintainTimer    *time.Timer
-	maintainInterval time.Duration
+	start, end *resourcePoolElement
+	size       uint64
+	expiredFn  expiredFunc
+	closeFn    closeFunc
 
 	sync.Mutex
 }
 
-// NewResourcePool creates a new resourcePool instance that is capped to maxSize resources.
-// If maxSize is 0, the pool size will be unbounded.
+// newResourcePool creates a new, empty resourcePool instance.
 func newResourcePool(config resourcePoolConfig) (*resourcePool, error) {
 	err := (&config).setup()
 	if err != nil {
 		return nil, err
 	}
 	rp := &resourcePool{
-		minSize:          config.MinSize,
-		expiredFn:        config.ExpiredFn,
-		closeFn:          config.CloseFn,
-		initFn:           config.InitFn,
-		maintainInterval: config.MaintainInterval,
+		expiredFn: config.ExpiredFn,
+		closeFn:   config.CloseFn,
 	}
 
 	return rp, nil
 }
 
-func (rp *resourcePool) initialize() {
-	rp.Lock()
-	rp.maintainTimer = time.AfterFunc(rp.maintainInterval, rp.Maintain)
-	rp.Unlock()
-
-	rp.Maintain()
-}
-
 // add will add a new rpe to the pool, requires that the resource pool is locked
 func (rp *resourcePool) add(e *resourcePoolElement) {
-	if e == nil {
-		e = &resourcePoolElement{
-			value: rp.initFn(),
-		}
-	}
-
 	e.next = rp.start
 	if rp.start != nil {
 		rp.start.prev = e
@@ -161,8 +130,8 @@ func (rp *resourcePool) remove(e *resourcePoolElement) {
 	atomicSubtract1Uint64(&rp.size)
 }
 
-// Maintain puts the pool back into a state of having a correct number of resources if possible and removes all stale resources
-func (rp *resourcePool) Maintain() {
+// Prune removes and closes all stale resources in the pool.
+func (rp *resourcePool) Prune() {
 	rp.Lock()
 	defer rp.Unlock()
 	for curr := rp.end; curr != nil; curr = curr.prev {
@@ -171,26 +140,6 @@ func (rp *resourcePool) Maintain() {
 			rp.closeFn(curr.value)
 		}
 	}
-
-	for atomic.LoadUint64(&rp.size) < rp.minSize {
-		rp.add(nil)
-	}
-
-	// reset the timer for the background cleanup routine
-	if rp.maintainTimer == nil {
-		rp.maintainTimer = time.AfterFunc(rp.maintainInterval, rp.Maintain)
-	}
-	if !rp.maintainTimer.Stop() {
-		rp.maintainTimer = time.AfterFunc(rp.maintainInterval, rp.Maintain)
-		return
-	}
-	rp.maintainTimer.Reset(rp.maintainInterval)
-}
-
-// Close clears the pool and stops the background maintenance of the pool
-func (rp *resourcePool) Close() {
-	rp.Clear()
-	_ = rp.maintainTimer.Stop()
 }
 
 // Clear closes all resources in the pool
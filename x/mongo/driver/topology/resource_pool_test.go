@@ -10,7 +10,6 @@ import (
 	"reflect"
 	"sync/atomic"
 	"testing"
-	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
@@ -72,20 +71,18 @@ func (ec *expiredCounter) getCloseCalled() int32 {
 	return atomic.LoadInt32(&ec.closeCalled)
 }
 
-func initPool(t *testing.T, minSize uint64, expFn expiredFunc, closeFn closeFunc, initFn initFunc, pruneInterval time.Duration) *resourcePool {
+func initPool(t *testing.T, size int, expFn expiredFunc, closeFn closeFunc) *resourcePool {
 	t.Helper()
 
 	rpc := resourcePoolConfig{
-		MinSize:          minSize,
-		MaintainInterval: pruneInterval,
-		ExpiredFn:        expFn,
-		CloseFn:          closeFn,
-		InitFn:           initFn,
+		ExpiredFn: expFn,
+		CloseFn:   closeFn,
 	}
 	rp, err := newResourcePool(rpc)
 	assert.Nil(t, err, "error creating new resource pool: %v", err)
-	rp.initialize()
-	rp.maintainTimer.Reset(rp.maintainInterval)
+	for i := 0; i < size; i++ {
+		rp.add(&resourcePoolElement{value: initRsrc()})
+	}
 	return rp
 }
 
@@ -98,8 +95,7 @@ func TestResourcePool(t *testing.T) {
 	t.Run("get", func(t *testing.T) {
 		t.Run("remove stale resources", func(t *testing.T) {
 			ec := newExpiredCounter(5)
-			rp := initPool(t, 1, ec.expired, ec.close, initRsrc, time.Minute)
-			rp.maintainTimer.Stop()
+			rp := initPool(t, 1, ec.expired, ec.close)
 
 			got := rp.Get()
 			assert.Nil(t, got, "expected nil, got %v", got)
@@ -111,8 +107,7 @@ func TestResourcePool(t *testing.T) {
 			assert.Equal(t, int32(1), closeCalled, "expected close to be called 1 time, got %v", closeCalled)
 		})
 		t.Run("recycle resources", func(t *testing.T) {
-			rp := initPool(t, 1, neverExpired, closeRsrc, initRsrc, time.Minute)
-			rp.maintainTimer.Stop()
+			rp := initPool(t, 1, neverExpired, closeRsrc)
 			for i := 0; i < 5; i++ {
 				got := rp.Get()
 				assert.NotNil(t, got, "expected resource, got nil")
@@ -125,7 +120,7 @@ func TestResourcePool(t *testing.T) {
 	})
 	t.Run("Put", func(t *testing.T) {
 		t.Run("returned resources are returned to front of pool", func(t *testing.T) {
-			rp := initPool(t, 0, neverExpired, closeRsrc, initRsrc, time.Minute)
+			rp := initPool(t, 0, neverExpired, closeRsrc)
 			ret := &rsrc{}
 			assert.True(t, rp.Put(ret), "expected Put to return true, got false")
 			assert.Equal(t, uint64(1), rp.size, "expected size 1, got %v", rp.size)
@@ -134,7 +129,7 @@ func TestResourcePool(t *testing.T) {
 			assert.Equal(t, ret, headVal, "expected resource %v at head of pool, got %v", ret, headVal)
 		})
 		t.Run("stale resource not returned", func(t *testing.T) {
-			rp := initPool(t, 1, alwaysExpired, closeRsrc, initRsrc, time.Minute)
+			rp := initPool(t, 1, alwaysExpired, closeRsrc)
 			ret := &rsrc{}
 			assert.False(t, rp.Put(ret), "expected Put to return false, got true")
 		})
@@ -142,13 +137,13 @@ func TestResourcePool(t *testing.T) {
 	t.Run("Prune", func(t *testing.T) {
 		t.Run("removes all stale resources", func(t *testing.T) {
 			ec := newExpiredCounter(3)
-			rp := initPool(t, 0, ec.expired, ec.close, initRsrc, time.Minute)
+			rp := initPool(t, 0, ec.expired, ec.close)
 			for i := 0; i < 5; i++ {
 				ret := &rsrc{}
 				_ = rp.Put(ret)
 			}
 
-			rp.Maintain()
+			rp.Prune()
 			assert.Equal(t, uint64(2), rp.size, "expected size 2, got %v", rp.size)
 
 			expiredCalled := ec.getExpiredCalled()
@@ -157,31 +152,4 @@ func TestResourcePool(t *testing.T) {
 			assert.Equal(t, int32(3), closeCalled, "expected close to be called 3 times, got %v", closeCalled)
 		})
 	})
-	t.Run("Background cleanup", func(t *testing.T) {
-		t.Run("runs once every interval", func(t *testing.T) {
-			ec := newExpiredCounter(3)
-			dur := 100 * time.Millisecond
-			rp := initPool(t, 0, neverExpired, ec.close, initRsrc, dur)
-			rp.maintainTimer.Stop()
-
-			for i := 0; i < 5; i++ {
-				ret := &rsrc{}
-				_ = rp.Put(ret)
-			}
-
-			rp.expiredFn = ec.expired
-			rp.maintainTimer.Reset(dur)
-
-			select {
-			case <-ec.closeChan:
-			case <-time.After(5 * time.Second):
-				t.Fatalf("value was not read on closeChan after 5 seconds")
-			}
-
-			expiredCalled := ec.getExpiredCalled()
-			assert.Equal(t, int32(5), expiredCalled, "expected expire to be called 5 times, got %v", expiredCalled)
-			closeCalled := ec.getCloseCalled()
-			assert.Equal(t, int32(3), closeCalled, "expected close to be called 3 times, got %v", closeCalled)
-		})
-	})
 }
@@ -16,6 +16,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/address"
@@ -84,15 +85,25 @@ type Server struct {
 	address         address.Address
 	connectionstate int32
 
+	// topologyID is the ID of the Topology this server belongs to. It is only used when publishing SDAM events.
+	topologyID primitive.ObjectID
+
 	// connection related fields
 	pool *pool
 	sem  *semaphore.Weighted
 
 	// goroutine management fields
-	done          chan struct{}
-	checkNow      chan struct{}
-	disconnecting chan struct{}
-	closewg       sync.WaitGroup
+	done     chan struct{}
+	checkNow chan struct{}
+	closewg  sync.WaitGroup
+
+	// heartbeat related fields. heartbeatCtx is cancelled when the server is disconnected, which stops any in-progress
+	// heartbeat and the RTT monitor. conn is the dedicated monitoring connection and is protected by heartbeatLock.
+	heartbeatCtx       context.Context
+	heartbeatCtxCancel context.CancelFunc
+	heartbeatLock      sync.Mutex
+	conn               *connection
+	rttLock            sync.Mutex
 
 	// description related fields
 	desc                   atomic.Value // holds a description.Server
@@ -140,24 +151,32 @@ func NewServer(addr address.Address, opts ...ServerOption) (*Server, error) {
 
 		sem: semaphore.NewWeighted(int64(maxConns)),
 
-		done:          make(chan struct{}),
-		checkNow:      make(chan struct{}, 1),
-		disconnecting: make(chan struct{}),
+		done:     make(chan struct{}),
+		checkNow: make(chan struct{}, 1),
 
 		subscribers: make(map[uint64]chan description.Server),
 	}
-	s.desc.Store(description.Server{Addr: addr})
+	s.desc.Store(s.initialDescription())
+	s.heartbeatCtx, s.heartbeatCtxCancel = context.WithCancel(context.Background())
 
-	callback := func(desc description.Server) { s.updateDescription(desc, false) }
+	connectionOpts := cfg.connectionOpts
+	if !cfg.loadBalanced {
+		// Connections to a server behind a load balancer may go to different services, so their handshake
+		// responses do not describe the server and must not be used to update its description.
+		callback := func(desc description.Server) { s.updateDescription(desc, false) }
+		connectionOpts = withServerDescriptionCallback(callback, connectionOpts...)
+	}
 	pc := poolConfig{
-		Address:     addr,
-		MinPoolSize: cfg.minConns,
-		MaxPoolSize: cfg.maxConns,
-		MaxIdleTime: cfg.connectionPoolMaxIdleTime,
-		PoolMonitor: cfg.poolMonitor,
+		Address:          addr,
+		MinPoolSize:      cfg.minConns,
+		MaxPoolSize:      cfg.maxConns,
+		MaxConnecting:    cfg.maxConnecting,
+		MaxIdleTime:      cfg.connectionPoolMaxIdleTime,
+		WaitQueueTimeout: cfg.waitQueueTimeout,
+		PoolMonitor:      cfg.poolMonitor,
 	}
 
-	s.pool, err = newPool(pc, withServerDescriptionCallback(callback, cfg.connectionOpts...)...)
+	s.pool, err = newPool(pc, connectionOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -170,13 +189,29 @@ func (s *Server) Connect(updateCallback func(description.Server)) error {
 	if !atomic.CompareAndSwapInt32(&s.connectionstate, disconnected, connected) {
 		return ErrServerConnected
 	}
-	s.desc.Store(description.Server{Addr: s.address})
+	s.desc.Store(s.initialDescription())
 	s.updateTopologyCallback.Store(updateCallback)
-	go s.update()
-	s.closewg.Add(1)
+	if s.heartbeatCtx.Err() != nil {
+		// The server was previously disconnected, so the heartbeat context has already been cancelled.
+		s.heartbeatCtx, s.heartbeatCtxCancel = context.WithCancel(context.Background())
+	}
+	s.publishServerOpeningEvent()
+	if !s.cfg.loadBalanced {
+		go s.update()
+		s.closewg.Add(1)
+	}
 	return s.pool.connect()
 }
 
+// initialDescription returns the description of the server before it has been checked. Servers behind a load
+// balancer are never checked, so they are described as a LoadBalancer from the start.
+func (s *Server) initialDescription() description.Server {
+	if s.cfg.loadBalanced {
+		return description.Server{Addr: s.address, Kind: description.LoadBalancer}
+	}
+	return description.Server{Addr: s.address}
+}
+
 // Disconnect closes sockets to the server referenced by this Server.
 // Subscriptions to this Server will be closed. Disconnect will shutdown
 // any monitoring goroutines, closeConnection the idle connection pool, and will
@@ -193,15 +228,21 @@ func (s *Server) Disconnect(ctx context.Context) error {
 
 	s.updateTopologyCallback.Store((func(description.Server))(nil))
 
+	// Stop any in-progress heartbeat. An awaitable isMaster can block for up to heartbeatInterval, so the monitoring
+	// connection is closed to interrupt it.
+	s.heartbeatCtxCancel()
+	s.cancelCheck()
+
 	// For every call to Connect there must be at least 1 goroutine that is
-	// waiting on the done channel.
-	select {
-	case <-ctx.Done():
-		// signal a disconnect and still wait for receiver of done
-		// to finish.
-		close(s.disconnecting)
-		s.done <- struct{}{}
-	case s.done <- struct{}{}:
+	// waiting on the done channel. Servers behind a load balancer are not monitored, so there is none.
+	if !s.cfg.loadBalanced {
+		select {
+		case <-ctx.Done():
+			// signal a disconnect without waiting for the receiver of done to be ready, so the pool is closed
+			// before the context's deadline passes.
+			go func() { s.done <- struct{}{} }()
+		case s.done <- struct{}{}:
+		}
 	}
 	err := s.pool.disconnect(ctx)
 	if err != nil {
@@ -210,16 +251,19 @@ func (s *Server) Disconnect(ctx context.Context) error {
 
 	s.closewg.Wait()
 	atomic.StoreInt32(&s.connectionstate, disconnected)
+	s.publishServerClosedEvent()
 
 	return nil
 }
 
-// Connection gets a connection to the server.
+// Connection gets a connection to the server. Requests wait in FIFO order if the server's maximum pool size has been
+// reached. If a wait queue timeout is configured, it limits the total time spent checking out the connection,
+// including the time spent establishing a new connection.
 func (s *Server) Connection(ctx context.Context) (driver.Connection, error) {
 
 	if s.pool.monitor != nil {
 		s.pool.monitor.Event(&event.PoolEvent{
-			Type:    "ConnectionCheckOutStarted",
+			Type:    event.GetStarted,
 			Address: s.pool.address.String(),
 		})
 	}
@@ -228,13 +272,19 @@ func (s *Server) Connection(ctx context.Context) (driver.Connection, error) {
 		return nil, ErrServerClosed
 	}
 
+	if s.cfg.waitQueueTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.cfg.waitQueueTimeout)
+		defer cancel()
+	}
+
 	err := s.sem.Acquire(ctx, 1)
 	if err != nil {
 		if s.pool.monitor != nil {
 			s.pool.monitor.Event(&event.PoolEvent{
-				Type:    "ConnectionCheckOutFailed",
+				Type:    event.GetFailed,
 				Address: s.pool.address.String(),
-				Reason:  "timeout",
+				Reason:  event.ReasonTimedOut,
 			})
 		}
 		return nil, ErrWaitQueueTimeout
@@ -249,7 +299,11 @@ func (s *Server) Connection(ctx context.Context) (driver.Connection, error) {
 		}
 
 		// Since the only kind of ConnectionError we receive from pool.Get will be an initialization
-		// error, we should set the description.Server appropriately.
+		// error, we should set the description.Server appropriately. The description of a server
+		// behind a load balancer never changes.
+		if s.cfg.loadBalanced {
+			return nil, err
+		}
 		desc := description.Server{
 			Kind:      description.Unknown,
 			LastError: wrappedConnErr,
@@ -316,7 +370,12 @@ func (s *Server) RequestImmediateCheck() {
 }
 
 // ProcessError handles SDAM error handling and implements driver.ErrorProcessor.
-func (s *Server) ProcessError(err error) {
+func (s *Server) ProcessError(err error, conn driver.Connection) {
+	if s.cfg.loadBalanced {
+		s.processLoadBalancedError(err, conn)
+		return
+	}
+
 	// Invalidate server description if not master or node recovering error occurs.
 	// These errors can be reported as a command error or a write concern error.
 	if cerr, ok := err.(driver.Error); ok && (cerr.NodeIsRecovering() || cerr.NotMaster()) {
@@ -367,6 +426,40 @@ func (s *Server) ProcessError(err error) {
 	s.pool.clear()
 }
 
+// processLoadBalancedError handles errors for a server behind a load balancer. The server's description is never
+// changed. State change errors and network errors clear the pool, but only the connections to the service that conn
+// is connected to.
+func (s *Server) processLoadBalancedError(err error, conn driver.Connection) {
+	if conn == nil {
+		return
+	}
+	serviceID := conn.Description().ServiceID
+	if serviceID == nil {
+		return
+	}
+
+	if cerr, ok := err.(driver.Error); ok && (cerr.NodeIsRecovering() || cerr.NotMaster()) {
+		s.pool.clearService(*serviceID)
+		return
+	}
+	if wcerr, ok := err.(driver.WriteConcernError); ok && (wcerr.NodeIsRecovering() || wcerr.NotMaster()) {
+		s.pool.clearService(*serviceID)
+		return
+	}
+
+	wrappedConnErr := unwrapConnectionError(err)
+	if wrappedConnErr == nil {
+		return
+	}
+	if netErr, ok := wrappedConnErr.(net.Error); ok && netErr.Timeout() {
+		return
+	}
+	if wrappedConnErr == context.Canceled || wrappedConnErr == context.DeadlineExceeded {
+		return
+	}
+	s.pool.clearService(*serviceID)
+}
+
 // update handles performing heartbeats and updating any subscribers of the
 // newest description.Server retrieved.
 func (s *Server) update() {
@@ -391,6 +484,7 @@ func (s *Server) update() {
 
 	var conn *connection
 	var desc description.Server
+	var rttMonitorStarted bool
 
 	desc, conn = s.heartbeat(nil)
 	s.updateDescription(desc, true)
@@ -417,14 +511,30 @@ func (s *Server) update() {
 		default:
 		}
 
-		select {
-		case <-heartbeatTicker.C:
-		case <-checkNow:
-		case <-done:
-			closeServer()
-			return
+		// If the server supports streaming or we're already streaming, we want to move to the next check immediately
+		// instead of waiting for the next heartbeat. The awaitable isMaster blocks on the server side until the
+		// server's state changes or maxAwaitTimeMS elapses.
+		streaming := isStreamable(desc) || isStreaming(conn)
+		if streaming && !rttMonitorStarted {
+			// The duration of an awaitable isMaster does not reflect the network latency, so RTT is measured on a
+			// separate connection.
+			rttMonitorStarted = true
+			s.closewg.Add(1)
+			go s.monitorRTT()
 		}
 
+		if !streaming {
+			select {
+			case <-heartbeatTicker.C:
+			case <-checkNow:
+			case <-done:
+				closeServer()
+				return
+			}
+		}
+
+		// Checks are never started more often than minHeartbeatInterval, which also limits how quickly a streaming
+		// check that fails immediately is retried.
 		select {
 		case <-rateLimiter.C:
 		case <-done:
@@ -446,7 +556,11 @@ func (s *Server) updateDescription(desc description.Server, initial bool) {
 		//  ¯\_(ツ)_/¯
 		_ = recover()
 	}()
+	prev := s.Description()
 	s.desc.Store(desc)
+	if !prev.Equal(desc) {
+		s.publishServerDescriptionChangedEvent(prev, desc)
+	}
 
 	callback, ok := s.updateTopologyCallback.Load().(func(description.Server))
 	if ok && callback != nil {
@@ -476,25 +590,26 @@ func (s *Server) updateDescription(desc description.Server, initial bool) {
 }
 
 // heartbeat sends a heartbeat to the server using the given connection. The connection can be nil.
+//
+// If the server supports streaming, the heartbeat is an awaitable isMaster that blocks on the server until the
+// server's topologyVersion changes or maxAwaitTimeMS elapses. The first awaitable isMaster is sent with the
+// exhaustAllowed flag, so subsequent heartbeats read the next streamed reply from the connection without sending a
+// command.
 func (s *Server) heartbeat(conn *connection) (description.Server, *connection) {
 	const maxRetry = 2
 	var saved error
 	var desc description.Server
 	var set bool
 	var err error
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(s.heartbeatCtx)
 	defer cancel()
-	go func() {
-		select {
-		case <-ctx.Done():
-		case <-s.disconnecting:
-			cancel()
-		}
-	}()
 
 	for i := 1; i <= maxRetry; i++ {
 		var now time.Time
 		var descPtr *description.Server
+		var connID string
+		var start time.Time
+		var awaited bool
 
 		if conn != nil && conn.expired() {
 			if conn.nc != nil {
@@ -514,7 +629,8 @@ func (s *Server) heartbeat(conn *connection) (description.Server, *connection) {
 			// one because need to make sure we don't do auth.
 			opts = append(opts, WithHandshaker(func(h Handshaker) Handshaker {
 				now = time.Now()
-				return operation.NewIsMaster().AppName(s.cfg.appname).Compressors(s.cfg.compressionOpts)
+				return operation.NewIsMaster().AppName(s.cfg.appname).Compressors(s.cfg.compressionOpts).
+					ServerAPI(s.cfg.serverAPI)
 			}))
 
 			// Override any command monitors specified in options with nil to avoid monitoring heartbeats.
@@ -524,6 +640,13 @@ func (s *Server) heartbeat(conn *connection) (description.Server, *connection) {
 
 			conn, err = newConnection(ctx, s.address, opts...)
 
+			s.heartbeatLock.Lock()
+			s.conn = conn
+			s.heartbeatLock.Unlock()
+
+			connID = conn.id
+			s.publishServerHeartbeatStartedEvent(connID, false)
+			start = time.Now()
 			conn.connect(ctx)
 
 			err = conn.wait()
@@ -534,12 +657,36 @@ func (s *Server) heartbeat(conn *connection) (description.Server, *connection) {
 
 		// do a heartbeat because a new connection wasn't created so a handshake was not performed
 		if descPtr == nil && err == nil {
-			now = time.Now()
+			previous := s.Description()
 			op := operation.
 				NewIsMaster().
 				ClusterClock(s.cfg.clock).
+				ServerAPI(s.cfg.serverAPI).
 				Deployment(driver.SingleConnectionDeployment{initConnection{conn}})
-			err = op.Execute(ctx)
+
+			// If the server supports streaming, send an awaitable isMaster. The read timeout for the connection is
+			// extended by the heartbeat interval because the server can wait that long before replying. A connection
+			// that is already streaming must keep reading replies even if the description was since reset.
+			streamable := isStreamable(previous)
+			if streamable || conn.currentlyStreaming {
+				awaited = true
+				conn.canStream = true
+				conn.readTimeout = s.cfg.heartbeatTimeout + s.cfg.heartbeatInterval
+			}
+			if streamable {
+				op = op.TopologyVersion(previous.TopologyVersion).
+					MaxAwaitTimeMS(int64(s.cfg.heartbeatInterval / time.Millisecond))
+			}
+
+			connID = conn.id
+			s.publishServerHeartbeatStartedEvent(connID, awaited)
+			now = time.Now()
+			start = now
+			if conn.currentlyStreaming {
+				err = op.StreamResponse(ctx, initConnection{conn})
+			} else {
+				err = op.Execute(ctx)
+			}
 			if err == nil {
 				tmpDesc := op.Result(s.address)
 				descPtr = &tmpDesc
@@ -552,8 +699,13 @@ func (s *Server) heartbeat(conn *connection) (description.Server, *connection) {
 
 		// we do a retry if the server is connected, if succeed return new server desc (see below)
 		if err != nil {
+			s.publishServerHeartbeatFailedEvent(connID, time.Since(start), err, awaited)
 			saved = err
 			conn = nil
+			// Don't retry if the heartbeat was interrupted because the server is being disconnected.
+			if ctx.Err() != nil {
+				break
+			}
 			if wrappedConnErr := unwrapConnectionError(err); wrappedConnErr != nil {
 				s.pool.drain()
 				// If the server is not connected, give up and exit loop
@@ -565,10 +717,17 @@ func (s *Server) heartbeat(conn *connection) (description.Server, *connection) {
 		}
 
 		desc = *descPtr
-		delay := time.Since(now)
-		desc = desc.SetAverageRTT(s.updateAverageRTT(delay))
+		if awaited {
+			// The duration of an awaitable isMaster includes the time the server waited, so the RTT is measured
+			// separately by the RTT monitor.
+			desc = desc.SetAverageRTT(s.currentAverageRTT())
+		} else {
+			delay := time.Since(now)
+			desc = desc.SetAverageRTT(s.updateAverageRTT(delay))
+		}
 		desc.HeartbeatInterval = s.cfg.heartbeatInterval
 		set = true
+		s.publishServerHeartbeatSucceededEvent(connID, time.Since(start), desc, awaited)
 
 		break
 	}
@@ -581,12 +740,115 @@ func (s *Server) heartbeat(conn *connection) (description.Server, *connection) {
 		}
 	}
 
+	s.heartbeatLock.Lock()
+	s.conn = conn
+	s.heartbeatLock.Unlock()
+
 	return desc, conn
 }
 
+// cancelCheck closes the dedicated monitoring connection to interrupt an in-progress heartbeat.
+func (s *Server) cancelCheck() {
+	s.heartbeatLock.Lock()
+	conn := s.conn
+	s.heartbeatLock.Unlock()
+
+	if conn == nil || conn.nc == nil {
+		return
+	}
+	_ = conn.nc.Close()
+}
+
+// monitorRTT periodically runs an isMaster on a dedicated connection to measure the round trip time to the server.
+// This is only used when the server is monitored with awaitable isMaster commands. It returns when the server is
+// disconnected.
+func (s *Server) monitorRTT() {
+	defer s.closewg.Done()
+
+	ticker := time.NewTicker(s.cfg.heartbeatInterval)
+	defer ticker.Stop()
+
+	var conn *connection
+	defer func() {
+		if conn != nil && conn.nc != nil {
+			_ = conn.nc.Close()
+		}
+	}()
+
+	for {
+		conn = s.measureRTT(conn)
+
+		select {
+		case <-ticker.C:
+		case <-s.heartbeatCtx.Done():
+			return
+		}
+	}
+}
+
+// measureRTT runs a single isMaster against the server and adds its duration to the average RTT. If conn is nil or
+// has expired, a new connection is created and the duration of its handshake is used instead. The connection to use
+// for the next measurement is returned and will be nil if an error occurred.
+func (s *Server) measureRTT(conn *connection) *connection {
+	ctx := s.heartbeatCtx
+
+	if conn != nil && conn.expired() {
+		if conn.nc != nil {
+			_ = conn.nc.Close()
+		}
+		conn = nil
+	}
+
+	var now time.Time
+	if conn == nil {
+		opts := []ConnectionOption{
+			WithConnectTimeout(func(time.Duration) time.Duration { return s.cfg.heartbeatTimeout }),
+			WithReadTimeout(func(time.Duration) time.Duration { return s.cfg.heartbeatTimeout }),
+			WithWriteTimeout(func(time.Duration) time.Duration { return s.cfg.heartbeatTimeout }),
+		}
+		opts = append(opts, s.cfg.connectionOpts...)
+		opts = append(opts, WithHandshaker(func(h Handshaker) Handshaker {
+			now = time.Now()
+			return operation.NewIsMaster().AppName(s.cfg.appname).Compressors(s.cfg.compressionOpts).
+				ServerAPI(s.cfg.serverAPI)
+		}))
+		opts = append(opts, WithMonitor(func(*event.CommandMonitor) *event.CommandMonitor {
+			return nil
+		}))
+
+		var err error
+		conn, err = newConnection(ctx, s.address, opts...)
+		if err != nil {
+			return nil
+		}
+		conn.connect(ctx)
+		if err = conn.wait(); err != nil {
+			return nil
+		}
+	} else {
+		op := operation.
+			NewIsMaster().
+			ClusterClock(s.cfg.clock).
+			ServerAPI(s.cfg.serverAPI).
+			Deployment(driver.SingleConnectionDeployment{initConnection{conn}})
+		now = time.Now()
+		if err := op.Execute(ctx); err != nil {
+			_ = conn.close()
+			return nil
+		}
+	}
+
+	s.updateAverageRTT(time.Since(now))
+	return conn
+}
+
 func (s *Server) updateAverageRTT(delay time.Duration) time.Duration {
+	s.rttLock.Lock()
+	defer s.rttLock.Unlock()
+
 	if !s.averageRTTSet {
 		s.averageRTT = delay
+		s.averageRTTSet = true
 	} else {
 		alpha := 0.2
 		s.averageRTT = time.Duration(alpha*float64(delay) + (1-alpha)*float64(s.averageRTT))
@@ -594,6 +856,27 @@ func (s *Server) updateAverageRTT(delay time.Duration) time.Duration {
 	return s.averageRTT
 }
 
+func (s *Server) currentAverageRTT() time.Duration {
+	s.rttLock.Lock()
+	defer s.rttLock.Unlock()
+
+	if !s.averageRTTSet {
+		return description.UnsetRTT
+	}
+	return s.averageRTT
+}
+
+// isStreamable returns whether or not the server described by desc can be monitored using awaitable isMaster
+// commands. Only servers that report a topologyVersion, i.e. MongoDB 4.4+, support this.
+func isStreamable(desc description.Server) bool {
+	return desc.Kind != description.Unknown && desc.TopologyVersion != nil
+}
+
+// isStreaming returns whether or not conn is currently receiving streamed isMaster replies.
+func isStreaming(conn *connection) bool {
+	return conn != nil && conn.currentlyStreaming
+}
+
 // String implements the Stringer interface.
 func (s *Server) String() string {
 	desc := s.Description()
@@ -641,6 +924,86 @@ func (ss *ServerSubscription) Unsubscribe() error {
 	return nil
 }
 
+// publishes a ServerDescriptionChangedEvent to indicate the server description has changed
+func (s *Server) publishServerDescriptionChangedEvent(prev description.Server, current description.Server) {
+	serverDescriptionChanged := &event.ServerDescriptionChangedEvent{
+		Address:             s.address,
+		TopologyID:          s.topologyID,
+		PreviousDescription: prev,
+		NewDescription:      current,
+	}
+
+	if s.cfg.serverMonitor != nil && s.cfg.serverMonitor.ServerDescriptionChanged != nil {
+		s.cfg.serverMonitor.ServerDescriptionChanged(serverDescriptionChanged)
+	}
+}
+
+// publishes a ServerOpeningEvent to indicate the server is being initialized
+func (s *Server) publishServerOpeningEvent() {
+	serverOpening := &event.ServerOpeningEvent{
+		Address:    s.address,
+		TopologyID: s.topologyID,
+	}
+
+	if s.cfg.serverMonitor != nil && s.cfg.serverMonitor.ServerOpening != nil {
+		s.cfg.serverMonitor.ServerOpening(serverOpening)
+	}
+}
+
+// publishes a ServerClosedEvent to indicate the server has been closed
+func (s *Server) publishServerClosedEvent() {
+	serverClosed := &event.ServerClosedEvent{
+		Address:    s.address,
+		TopologyID: s.topologyID,
+	}
+
+	if s.cfg.serverMonitor != nil && s.cfg.serverMonitor.ServerClosed != nil {
+		s.cfg.serverMonitor.ServerClosed(serverClosed)
+	}
+}
+
+// publishes a ServerHeartbeatStartedEvent to indicate an isMaster command has started
+func (s *Server) publishServerHeartbeatStartedEvent(connectionID string, await bool) {
+	serverHeartbeatStarted := &event.ServerHeartbeatStartedEvent{
+		ConnectionID: connectionID,
+		Awaited:      await,
+	}
+
+	if s.cfg.serverMonitor != nil && s.cfg.serverMonitor.ServerHeartbeatStarted != nil {
+		s.cfg.serverMonitor.ServerHeartbeatStarted(serverHeartbeatStarted)
+	}
+}
+
+// publishes a ServerHeartbeatSucceededEvent to indicate isMaster has succeeded
+func (s *Server) publishServerHeartbeatSucceededEvent(connectionID string, duration time.Duration,
+	desc description.Server, await bool) {
+
+	serverHeartbeatSucceeded := &event.ServerHeartbeatSucceededEvent{
+		DurationNanos: duration.Nanoseconds(),
+		Reply:         desc,
+		ConnectionID:  connectionID,
+		Awaited:       await,
+	}
+
+	if s.cfg.serverMonitor != nil && s.cfg.serverMonitor.ServerHeartbeatSucceeded != nil {
+		s.cfg.serverMonitor.ServerHeartbeatSucceeded(serverHeartbeatSucceeded)
+	}
+}
+
+// publishes a ServerHeartbeatFailedEvent to indicate isMaster has failed
+func (s *Server) publishServerHeartbeatFailedEvent(connectionID string, duration time.Duration, err error, await bool) {
+	serverHeartbeatFailed := &event.ServerHeartbeatFailedEvent{
+		DurationNanos: duration.Nanoseconds(),
+		Failure:       err,
+		ConnectionID:  connectionID,
+		Awaited:       await,
+	}
+
+	if s.cfg.serverMonitor != nil && s.cfg.serverMonitor.ServerHeartbeatFailed != nil {
+		s.cfg.serverMonitor.ServerHeartbeatFailed(serverHeartbeatFailed)
+	}
+}
+
 // unwrapConnectionError returns the connection error wrapped by err, or nil if err does not wrap a connection error.
 func unwrapConnectionError(err error) error {
 	connErr, ok := err.(ConnectionError)
@@ -12,6 +12,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
 )
 
@@ -26,9 +27,14 @@ type serverConfig struct {
 	heartbeatTimeout          time.Duration
 	maxConns                  uint64
 	minConns                  uint64
+	maxConnecting             uint64
+	waitQueueTimeout          time.Duration
 	poolMonitor               *event.PoolMonitor
+	serverMonitor             *event.ServerMonitor
 	connectionPoolMaxIdleTime time.Duration
 	registry                  *bsoncodec.Registry
+	loadBalanced              bool
+	serverAPI                 *driver.ServerAPIOptions
 }
 
 func newServerConfig(opts ...ServerOption) (*serverConfig, error) {
@@ -112,6 +118,24 @@ func WithMinConnections(fn func(uint64) uint64) ServerOption {
 	}
 }
 
+// WithMaxConnecting configures the maximum number of connections that can be established to a given server at the
+// same time. If maxConnecting is 0, the default of 2 is used.
+func WithMaxConnecting(fn func(uint64) uint64) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.maxConnecting = fn(cfg.maxConnecting)
+		return nil
+	}
+}
+
+// WithWaitQueueTimeout configures the maximum amount of time a request can wait to check out a connection to a given
+// server. If waitQueueTimeout is 0, requests wait until their context expires.
+func WithWaitQueueTimeout(fn func(time.Duration) time.Duration) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.waitQueueTimeout = fn(cfg.waitQueueTimeout)
+		return nil
+	}
+}
+
 // WithConnectionPoolMaxIdleTime configures the maximum time that a connection can remain idle in the connection pool
 // before being removed. If connectionPoolMaxIdleTime is 0, then no idle time is set and connections will not be removed
 // because of their age
@@ -130,6 +154,14 @@ func WithConnectionPoolMonitor(fn func(*event.PoolMonitor) *event.PoolMonitor) S
 	}
 }
 
+// WithServerMonitor configures the monitor for all SDAM events for a server
+func WithServerMonitor(fn func(*event.ServerMonitor) *event.ServerMonitor) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.serverMonitor = fn(cfg.serverMonitor)
+		return nil
+	}
+}
+
 // WithClock configures the ClusterClock for the server to use.
 func WithClock(fn func(clock *session.ClusterClock) *session.ClusterClock) ServerOption {
 	return func(cfg *serverConfig) error {
@@ -146,3 +178,20 @@ func WithRegistry(fn func(*bsoncodec.Registry) *bsoncodec.Registry) ServerOption
 		return nil
 	}
 }
+
+// WithServerLoadBalanced specifies whether or not the server is behind a load balancer. Servers behind a load balancer
+// are not monitored and their connections' errors only affect connections to the same service.
+func WithServerLoadBalanced(fn func(bool) bool) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.loadBalanced = fn(cfg.loadBalanced)
+		return nil
+	}
+}
+
+// WithServerAPI configures the server API version sent with the commands used to monitor the server.
+func WithServerAPI(fn func(*driver.ServerAPIOptions) *driver.ServerAPIOptions) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.serverAPI = fn(cfg.serverAPI)
+		return nil
+	}
+}
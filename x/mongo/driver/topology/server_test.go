@@ -17,6 +17,8 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/address"
@@ -206,7 +208,7 @@ func TestServer(t *testing.T) {
 		s.pool.connected = connected
 
 		wce := driver.WriteConcernError{"", 10107, "not master", []byte{}}
-		s.ProcessError(wce)
+		s.ProcessError(wce, nil)
 
 		// should set ServerDescription to Unknown
 		resultDesc := s.Description()
@@ -230,7 +232,7 @@ func TestServer(t *testing.T) {
 		s.pool.connected = connected
 
 		wce := driver.WriteConcernError{}
-		s.ProcessError(&wce)
+		s.ProcessError(&wce, nil)
 
 		// should not be a LastError
 		require.Nil(t, s.Description().LastError)
@@ -240,6 +242,111 @@ func TestServer(t *testing.T) {
 			t.Errorf("Expected pool to not be drained. got %d; want %d", s.pool.generation, 0)
 		}
 	})
+	t.Run("load balanced", func(t *testing.T) {
+		serviceIDs := []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID()}
+		var handshakes int32
+		var clearedEvents []*event.PoolEvent
+		var eventsLock sync.Mutex
+
+		s, err := NewServer(
+			address.Address("localhost"),
+			WithServerLoadBalanced(func(bool) bool { return true }),
+			WithConnectionPoolMonitor(func(*event.PoolMonitor) *event.PoolMonitor {
+				return &event.PoolMonitor{
+					Event: func(evt *event.PoolEvent) {
+						if evt.Type != event.PoolCleared {
+							return
+						}
+						eventsLock.Lock()
+						clearedEvents = append(clearedEvents, evt)
+						eventsLock.Unlock()
+					},
+				}
+			}),
+			WithConnectionOptions(func(connOpts ...ConnectionOption) []ConnectionOption {
+				return append(connOpts,
+					WithHandshaker(func(Handshaker) Handshaker {
+						return &testHandshaker{
+							getDescription: func(_ context.Context, addr address.Address, _ driver.Connection) (description.Server, error) {
+								// Each connection is routed to a different service behind the load balancer.
+								idx := atomic.AddInt32(&handshakes, 1) - 1
+								serviceID := serviceIDs[int(idx)%len(serviceIDs)]
+								return description.Server{Addr: addr, Kind: description.Mongos, ServiceID: &serviceID}, nil
+							},
+						}
+					}),
+					WithDialer(func(Dialer) Dialer {
+						return DialerFunc(func(context.Context, string, string) (net.Conn, error) {
+							return &net.TCPConn{}, nil
+						})
+					}),
+				)
+			}),
+		)
+		require.NoError(t, err)
+		require.NoError(t, s.Connect(nil))
+
+		lbDesc := description.Server{Addr: address.Address("localhost"), Kind: description.LoadBalancer}
+		require.Equal(t, lbDesc, s.Description())
+
+		conn1, err := s.Connection(context.Background())
+		require.NoError(t, err)
+		conn2, err := s.Connection(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, serviceIDs[0], *conn1.Description().ServiceID)
+		require.Equal(t, serviceIDs[1], *conn2.Description().ServiceID)
+
+		// The handshake responses must not be used to update the server's description.
+		require.Equal(t, lbDesc, s.Description())
+
+		// A state change error does not affect the server's description, but it clears the connections to the service
+		// of the connection it occurred on.
+		s.ProcessError(driver.Error{Code: 10107, Message: "not master"}, conn1)
+		require.Equal(t, lbDesc, s.Description())
+		require.True(t, s.pool.stale(conn1.(*Connection).connection), "expected connection to the failed service to be stale")
+		require.False(t, s.pool.stale(conn2.(*Connection).connection), "expected connection to another service not to be stale")
+
+		// A network error also only clears the connections to the service of the connection it occurred on.
+		conn3, err := s.Connection(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, serviceIDs[0], *conn3.Description().ServiceID)
+		require.False(t, s.pool.stale(conn3.(*Connection).connection), "expected new connection not to be stale")
+		netErr := driver.Error{Labels: []string{driver.NetworkError}, Wrapped: &net.AddrError{}}
+		s.ProcessError(netErr, conn3)
+		require.Equal(t, lbDesc, s.Description())
+		require.True(t, s.pool.stale(conn3.(*Connection).connection), "expected connection to the failed service to be stale")
+		require.False(t, s.pool.stale(conn2.(*Connection).connection), "expected connection to another service not to be stale")
+		require.Equal(t, uint64(0), atomic.LoadUint64(&s.pool.generation))
+
+		// Connections to a cleared service do not count towards the pool's minimum size.
+		require.Equal(t, uint64(1), s.pool.currentSize())
+
+		eventsLock.Lock()
+		require.Equal(t, 2, len(clearedEvents))
+		require.Equal(t, serviceIDs[0], *clearedEvents[0].ServiceID)
+		require.Equal(t, serviceIDs[0], *clearedEvents[1].ServiceID)
+		eventsLock.Unlock()
+
+		// Closing a pinned connection is a no-op until every resource has unpinned it.
+		pinned := conn2.(*Connection)
+		require.NoError(t, pinned.PinToCursor())
+		require.NoError(t, pinned.PinToTransaction())
+		require.NoError(t, pinned.Close())
+		require.NoError(t, pinned.UnpinFromCursor())
+		require.NoError(t, pinned.Close())
+		require.True(t, pinned.Alive(), "expected the connection to stay checked out while it is pinned")
+		require.NoError(t, pinned.UnpinFromTransaction())
+		require.Error(t, pinned.UnpinFromTransaction())
+		require.NoError(t, pinned.Close())
+		require.False(t, pinned.Alive(), "expected the connection to be returned to the pool")
+		require.NoError(t, conn1.Close())
+		require.NoError(t, conn3.Close())
+
+		// There is no monitoring routine, so disconnecting must not wait for one.
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		require.NoError(t, s.Disconnect(ctx))
+	})
 	t.Run("update topology", func(t *testing.T) {
 		var updated atomic.Value // bool
 		updated.Store(false)
@@ -290,6 +397,115 @@ func TestServer(t *testing.T) {
 			t.Fatal("client metadata not expected in heartbeat but found")
 		}
 	})
+	t.Run("heartbeat monitoring", func(t *testing.T) {
+		var started, succeeded, failed int
+		monitor := &event.ServerMonitor{
+			ServerHeartbeatStarted:   func(*event.ServerHeartbeatStartedEvent) { started++ },
+			ServerHeartbeatSucceeded: func(*event.ServerHeartbeatSucceededEvent) { succeeded++ },
+			ServerHeartbeatFailed:    func(*event.ServerHeartbeatFailedEvent) { failed++ },
+		}
+		dialer := &channelNetConnDialer{}
+		serverOpts := []ServerOption{
+			WithConnectionOptions(func(connOpts ...ConnectionOption) []ConnectionOption {
+				return append(connOpts, WithDialer(func(Dialer) Dialer { return dialer }))
+			}),
+			WithServerMonitor(func(*event.ServerMonitor) *event.ServerMonitor { return monitor }),
+		}
+
+		s, err := NewServer(address.Address("localhost:27017"), serverOpts...)
+		require.Nil(t, err, "error from NewServer: %v", err)
+
+		_, conn := s.heartbeat(nil)
+		require.NotNil(t, conn, "no connection dialed")
+		require.Equal(t, 1, started, "expected 1 started event, got %v", started)
+		require.Equal(t, 1, succeeded, "expected 1 succeeded event, got %v", succeeded)
+		require.Equal(t, 0, failed, "expected 0 failed events, got %v", failed)
+	})
+	t.Run("server description changed monitoring", func(t *testing.T) {
+		var events []*event.ServerDescriptionChangedEvent
+		monitor := &event.ServerMonitor{
+			ServerDescriptionChanged: func(e *event.ServerDescriptionChangedEvent) { events = append(events, e) },
+		}
+		s, err := NewServer(address.Address("localhost"),
+			WithServerMonitor(func(*event.ServerMonitor) *event.ServerMonitor { return monitor }))
+		require.Nil(t, err, "error from NewServer: %v", err)
+
+		newDesc := description.Server{Addr: s.address, CanonicalAddr: s.address, Kind: description.Standalone}
+		s.updateDescription(newDesc, true)
+		s.updateDescription(newDesc.SetAverageRTT(time.Second), true)
+		require.Equal(t, 1, len(events), "expected 1 event, got %v", len(events))
+		require.Equal(t, description.Standalone, events[0].NewDescription.Kind,
+			"expected new kind %v, got %v", description.Standalone, events[0].NewDescription.Kind)
+	})
+	t.Run("streaming heartbeat", func(t *testing.T) {
+		processID := primitive.NewObjectID()
+		cnc := &drivertest.ChannelNetConn{
+			Written:  make(chan []byte, 1),
+			ReadResp: make(chan []byte, 2),
+		}
+		err := cnc.AddResponse(drivertest.MakeReply(makeStreamableIsMasterDoc(processID, 0)))
+		require.Nil(t, err, "error adding response: %v", err)
+
+		var awaitedEvents int
+		monitor := &event.ServerMonitor{
+			ServerHeartbeatSucceeded: func(e *event.ServerHeartbeatSucceededEvent) {
+				if e.Awaited {
+					awaitedEvents++
+				}
+			},
+		}
+		s, err := NewServer(address.Address("localhost:27017"),
+			WithConnectionOptions(func(connOpts ...ConnectionOption) []ConnectionOption {
+				return append(connOpts, WithDialer(func(Dialer) Dialer {
+					return DialerFunc(func(context.Context, string, string) (net.Conn, error) {
+						return cnc, nil
+					})
+				}))
+			}),
+			WithServerMonitor(func(*event.ServerMonitor) *event.ServerMonitor { return monitor }),
+		)
+		require.Nil(t, err, "error from NewServer: %v", err)
+
+		// The first heartbeat performs the handshake and learns the server's topologyVersion.
+		desc, conn := s.heartbeat(nil)
+		require.NotNil(t, conn, "no connection dialed")
+		_ = cnc.GetWrittenMessage()
+		require.True(t, isStreamable(desc), "expected description to be streamable")
+		s.updateDescription(desc, true)
+
+		// The second heartbeat is an awaitable isMaster. The server replies with moreToCome set.
+		err = cnc.AddResponse(makeMsgReply(makeStreamableIsMasterDoc(processID, 1), true))
+		require.Nil(t, err, "error adding response: %v", err)
+		desc, conn = s.heartbeat(conn)
+		require.NotNil(t, conn, "expected connection to be kept")
+		require.True(t, conn.currentlyStreaming, "expected connection to be streaming")
+		require.Equal(t, int64(1), desc.TopologyVersion.Counter,
+			"expected topologyVersion counter 1, got %v", desc.TopologyVersion.Counter)
+
+		wm := cnc.GetWrittenMessage()
+		flags, cmd := readMsgFlagsAndCommand(t, wm)
+		require.Equal(t, wiremessage.ExhaustAllowed, flags&wiremessage.ExhaustAllowed, "expected exhaustAllowed flag to be set")
+		_, err = cmd.LookupErr("topologyVersion")
+		require.Nil(t, err, "expected topologyVersion in awaitable isMaster")
+		_, err = cmd.LookupErr("maxAwaitTimeMS")
+		require.Nil(t, err, "expected maxAwaitTimeMS in awaitable isMaster")
+		s.updateDescription(desc, false)
+
+		// The third heartbeat reads the next streamed reply without sending a command.
+		err = cnc.AddResponse(makeMsgReply(makeStreamableIsMasterDoc(processID, 2), false))
+		require.Nil(t, err, "error adding response: %v", err)
+		desc, conn = s.heartbeat(conn)
+		require.NotNil(t, conn, "expected connection to be kept")
+		require.False(t, conn.currentlyStreaming, "expected connection to stop streaming")
+		require.Equal(t, int64(2), desc.TopologyVersion.Counter,
+			"expected topologyVersion counter 2, got %v", desc.TopologyVersion.Counter)
+		select {
+		case <-cnc.Written:
+			t.Fatal("expected no command to be written for a streamed reply")
+		default:
+		}
+		require.Equal(t, 2, awaitedEvents, "expected 2 awaited heartbeat events, got %v", awaitedEvents)
+	})
 	t.Run("WithServerAppName", func(t *testing.T) {
 		name := "test"
 
@@ -300,6 +516,52 @@ func TestServer(t *testing.T) {
 	})
 }
 
+func makeStreamableIsMasterDoc(processID primitive.ObjectID, counter int64) bsoncore.Document {
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+	doc = bsoncore.AppendInt32Element(doc, "ok", 1)
+	doc = bsoncore.AppendBooleanElement(doc, "ismaster", true)
+	doc = bsoncore.AppendInt32Element(doc, "maxWireVersion", 9)
+	tvIdx, doc := bsoncore.AppendDocumentElementStart(doc, "topologyVersion")
+	doc = bsoncore.AppendObjectIDElement(doc, "processId", processID)
+	doc = bsoncore.AppendInt64Element(doc, "counter", counter)
+	doc, _ = bsoncore.AppendDocumentEnd(doc, tvIdx)
+	doc, _ = bsoncore.AppendDocumentEnd(doc, idx)
+	return doc
+}
+
+func makeMsgReply(doc bsoncore.Document, moreToCome bool) []byte {
+	var flags wiremessage.MsgFlag
+	if moreToCome {
+		flags = wiremessage.MoreToCome
+	}
+	idx, dst := wiremessage.AppendHeaderStart(nil, 10, 9, wiremessage.OpMsg)
+	dst = wiremessage.AppendMsgFlags(dst, flags)
+	dst = wiremessage.AppendMsgSectionType(dst, wiremessage.SingleDocument)
+	dst = append(dst, doc...)
+	return bsoncore.UpdateLength(dst, idx, int32(len(dst[idx:])))
+}
+
+func readMsgFlagsAndCommand(t *testing.T, wm []byte) (wiremessage.MsgFlag, bsoncore.Document) {
+	var ok bool
+	_, _, _, _, wm, ok = wiremessage.ReadHeader(wm)
+	if !ok {
+		t.Fatal("could not read header")
+	}
+	flags, wm, ok := wiremessage.ReadMsgFlags(wm)
+	if !ok {
+		t.Fatal("could not read flags")
+	}
+	_, wm, ok = wiremessage.ReadMsgSectionType(wm)
+	if !ok {
+		t.Fatal("could not read section type")
+	}
+	cmd, _, ok := wiremessage.ReadMsgSectionSingleDocument(wm)
+	if !ok {
+		t.Fatal("could not read command document")
+	}
+	return flags, cmd
+}
+
 func includesMetadata(t *testing.T, wm []byte) bool {
 	var ok bool
 	_, _, _, _, wm, ok = wiremessage.ReadHeader(wm)
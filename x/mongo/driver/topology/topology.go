@@ -21,6 +21,8 @@ import (
 
 	"fmt"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/address"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/description"
@@ -50,6 +52,7 @@ type MonitorMode uint8
 const (
 	AutomaticMode MonitorMode = iota
 	SingleMode
+	LoadBalancedMode
 )
 
 // Topology represents a MongoDB deployment.
@@ -58,6 +61,10 @@ type Topology struct {
 
 	cfg *config
 
+	// id is a unique identifier for this Topology that is included in SDAM events.
+	id            primitive.ObjectID
+	serverMonitor *event.ServerMonitor
+
 	desc atomic.Value // holds a description.Topology
 
 	dnsResolver *dns.Resolver
@@ -119,9 +126,15 @@ func New(opts ...Option) (*Topology, error) {
 		subscribers:       make(map[uint64]chan description.Topology),
 		servers:           make(map[address.Address]*Server),
 		dnsResolver:       dns.DefaultResolver,
+		id:                primitive.NewObjectID(),
 	}
 	t.desc.Store(description.Topology{})
 
+	// The server monitor is configured as a server option, but topology-level events are published by the Topology.
+	if serverCfg, err := newServerConfig(cfg.serverOpts...); err == nil {
+		t.serverMonitor = serverCfg.serverMonitor
+	}
+
 	if cfg.replicaSetName != "" {
 		t.fsm.SetName = cfg.replicaSetName
 		t.fsm.Kind = description.ReplicaSetNoPrimary
@@ -131,6 +144,13 @@ func New(opts ...Option) (*Topology, error) {
 		t.fsm.Kind = description.Single
 	}
 
+	if cfg.mode == LoadBalancedMode {
+		// A load balancer hides the deployment behind it, so the servers are not monitored and the topology
+		// description never changes.
+		t.fsm.Kind = description.LoadBalanced
+		cfg.serverOpts = append(cfg.serverOpts, WithServerLoadBalanced(func(bool) bool { return true }))
+	}
+
 	return t, nil
 }
 
@@ -142,19 +162,38 @@ func (t *Topology) Connect() error {
 	}
 
 	t.desc.Store(description.Topology{})
+	t.publishTopologyOpeningEvent()
+
 	var err error
 	t.serversLock.Lock()
 	for _, a := range t.cfg.seedList {
 		addr := address.Address(a).Canonicalize()
-		t.fsm.Servers = append(t.fsm.Servers, description.Server{Addr: addr})
+		desc := description.Server{Addr: addr}
+		if t.cfg.mode == LoadBalancedMode {
+			desc.Kind = description.LoadBalancer
+		}
+		t.fsm.Servers = append(t.fsm.Servers, desc)
+	}
+
+	newDesc := description.Topology{
+		Kind:                  t.fsm.Kind,
+		Servers:               t.fsm.Servers,
+		SessionTimeoutMinutes: t.fsm.SessionTimeoutMinutes,
+	}
+	t.desc.Store(newDesc)
+	t.publishTopologyDescriptionChangedEvent(description.Topology{}, newDesc)
+
+	for _, a := range t.cfg.seedList {
+		addr := address.Address(a).Canonicalize()
 		err = t.addServer(addr)
 		if err != nil {
+			t.serversLock.Unlock()
 			return err
 		}
 	}
 	t.serversLock.Unlock()
 
-	if srvPollingRequired(t.cfg.cs.Original) {
+	if t.pollingRequired() {
 		go t.pollSRVRecords()
 		t.pollingwg.Add(1)
 	}
@@ -192,7 +231,7 @@ func (t *Topology) Disconnect(ctx context.Context) error {
 	t.subscriptionsClosed = true
 	t.subLock.Unlock()
 
-	if srvPollingRequired(t.cfg.cs.Original) {
+	if t.pollingRequired() {
 		t.pollingDone <- struct{}{}
 		t.pollingwg.Wait()
 	}
@@ -200,6 +239,7 @@ func (t *Topology) Disconnect(ctx context.Context) error {
 	t.desc.Store(description.Topology{})
 
 	atomic.StoreInt32(&t.connectionstate, disconnected)
+	t.publishTopologyClosedEvent()
 	return nil
 }
 
@@ -207,6 +247,12 @@ func srvPollingRequired(connstr string) bool {
 	return strings.HasPrefix(connstr, "mongodb+srv://")
 }
 
+// pollingRequired returns true if the topology's SRV record must be polled. The hosts behind a load balancer are
+// not visible to the driver, so SRV records are not polled in load balanced mode.
+func (t *Topology) pollingRequired() bool {
+	return srvPollingRequired(t.cfg.cs.Original) && t.cfg.mode != LoadBalancedMode
+}
+
 // Description returns a description of the topology.
 func (t *Topology) Description() description.Topology {
 	td, ok := t.desc.Load().(description.Topology)
@@ -282,9 +328,14 @@ func (t *Topology) RequestImmediateCheck() {
 	t.serversLock.Unlock()
 }
 
-// SupportsSessions returns true if the topology supports sessions.
+// SupportsSessions returns true if the topology supports sessions. A load balanced topology always supports
+// sessions because load balancing requires a server version that supports them.
 func (t *Topology) SupportsSessions() bool {
-	return t.Description().SessionTimeoutMinutes != 0 && t.Description().Kind != description.Single
+	desc := t.Description()
+	if desc.Kind == description.LoadBalanced {
+		return true
+	}
+	return desc.SessionTimeoutMinutes != 0 && desc.Kind != description.Single
 }
 
 // SupportsRetryWrites returns true if the topology supports retryable writes, which it does if it supports sessions.
@@ -579,7 +630,11 @@ func (t *Topology) processSRVResults(parsedHosts []string) bool {
 		Servers:               t.fsm.Servers,
 		SessionTimeoutMinutes: t.fsm.SessionTimeoutMinutes,
 	}
+	prev := t.Description()
 	t.desc.Store(newDesc)
+	if !prev.Equal(newDesc) {
+		t.publishTopologyDescriptionChangedEvent(prev, newDesc)
+	}
 
 	t.subLock.Lock()
 	for _, ch := range t.subscribers {
@@ -631,6 +686,9 @@ func (t *Topology) apply(ctx context.Context, desc description.Server) {
 	}
 
 	t.desc.Store(current)
+	if !prev.Equal(current) {
+		t.publishTopologyDescriptionChangedEvent(prev, current)
+	}
 
 	t.subLock.Lock()
 	for _, ch := range t.subscribers {
@@ -653,7 +711,13 @@ func (t *Topology) addServer(addr address.Address) error {
 	topoFunc := func(desc description.Server) {
 		t.apply(context.TODO(), desc)
 	}
-	svr, err := ConnectServer(addr, topoFunc, t.cfg.serverOpts...)
+	svr, err := NewServer(addr, t.cfg.serverOpts...)
+	if err != nil {
+		return err
+	}
+	svr.topologyID = t.id
+
+	err = svr.Connect(topoFunc)
 	if err != nil {
 		return err
 	}
@@ -675,3 +739,38 @@ func (t *Topology) String() string {
 	}
 	return fmt.Sprintf("Type: %s, Servers: [%s]", desc.Kind, serversStr)
 }
+
+// publishes a TopologyDescriptionChangedEvent to indicate the topology description has changed
+func (t *Topology) publishTopologyDescriptionChangedEvent(prev description.Topology, current description.Topology) {
+	topologyDescriptionChanged := &event.TopologyDescriptionChangedEvent{
+		PreviousDescription: prev,
+		NewDescription:      current,
+		TopologyID:          t.id,
+	}
+
+	if t.serverMonitor != nil && t.serverMonitor.TopologyDescriptionChanged != nil {
+		t.serverMonitor.TopologyDescriptionChanged(topologyDescriptionChanged)
+	}
+}
+
+// publishes a TopologyOpeningEvent to indicate the topology is being initialized
+func (t *Topology) publishTopologyOpeningEvent() {
+	topologyOpening := &event.TopologyOpeningEvent{
+		TopologyID: t.id,
+	}
+
+	if t.serverMonitor != nil && t.serverMonitor.TopologyOpening != nil {
+		t.serverMonitor.TopologyOpening(topologyOpening)
+	}
+}
+
+// publishes a TopologyClosedEvent to indicate the topology has been closed
+func (t *Topology) publishTopologyClosedEvent() {
+	topologyClosed := &event.TopologyClosedEvent{
+		TopologyID: t.id,
+	}
+
+	if t.serverMonitor != nil && t.serverMonitor.TopologyClosed != nil {
+		t.serverMonitor.TopologyClosed(topologyClosed)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"crypto/tls"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/pem"
 	"errors"
 	"fmt"
@@ -17,9 +18,11 @@ import (
 	"strings"
 	"time"
 
+	"go.mongodb.org/mongo-driver/internal/tlsutil"
 	"go.mongodb.org/mongo-driver/x/mongo/driver"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/auth"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/ocsp"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
 )
 
@@ -72,6 +75,9 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 		case connstring.SingleConnect:
 			c.mode = SingleMode
 		}
+		if cs.LoadBalanced {
+			c.mode = LoadBalancedMode
+		}
 
 		c.seedList = cs.Hosts
 
@@ -96,6 +102,10 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 			connOpts = append(connOpts, WithIdleTimeout(func(time.Duration) time.Duration { return cs.MaxConnIdleTime }))
 		}
 
+		if cs.MaxConnectingSet {
+			c.serverOpts = append(c.serverOpts, WithMaxConnecting(func(uint64) uint64 { return cs.MaxConnecting }))
+		}
+
 		if cs.MaxPoolSizeSet {
 			c.serverOpts = append(c.serverOpts, WithMaxConnections(func(uint64) uint64 { return cs.MaxPoolSize }))
 		}
@@ -104,6 +114,12 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 			c.serverOpts = append(c.serverOpts, WithMinConnections(func(u uint64) uint64 { return cs.MinPoolSize }))
 		}
 
+		if cs.WaitQueueTimeoutSet {
+			c.serverOpts = append(c.serverOpts, WithWaitQueueTimeout(func(time.Duration) time.Duration {
+				return cs.WaitQueueTimeout
+			}))
+		}
+
 		if cs.ReplicaSet != "" {
 			c.replicaSetName = cs.ReplicaSet
 		}
@@ -119,16 +135,22 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 				}
 			}
 
-			if cs.SSLInsecure {
+			if cs.SSLInsecure || cs.SSLAllowInvalidCertificates {
 				tlsConfig.InsecureSkipVerify = true
 			}
 
-			if cs.SSLClientCertificateKeyFileSet {
+			if cs.SSLClientCertificateKeyFileSet || cs.SSLCertificateFileSet {
 				var keyPasswd string
 				if cs.SSLClientCertificateKeyPasswordSet && cs.SSLClientCertificateKeyPassword != nil {
 					keyPasswd = cs.SSLClientCertificateKeyPassword()
 				}
-				s, err := addClientCertFromFile(tlsConfig, cs.SSLClientCertificateKeyFile, keyPasswd)
+				var s string
+				var err error
+				if cs.SSLClientCertificateKeyFileSet {
+					s, err = addClientCertFromFile(tlsConfig, cs.SSLClientCertificateKeyFile, keyPasswd)
+				} else {
+					s, err = addClientCertFromSeparateFiles(tlsConfig, cs.SSLCertificateFile, cs.SSLPrivateKeyFile, keyPasswd)
+				}
 				if err != nil {
 					return err
 				}
@@ -148,7 +170,30 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 				x509Username = b.String()
 			}
 
+			var crls []*pkix.CertificateList
+			if cs.SSLCRLFileSet {
+				var err error
+				crls, err = tlsutil.LoadCRLFile(cs.SSLCRLFile)
+				if err != nil {
+					return err
+				}
+			}
+			tlsutil.ConfigureVerification(tlsConfig, cs.SSLAllowInvalidHostnames, crls)
+
 			connOpts = append(connOpts, WithTLSConfig(func(*tls.Config) *tls.Config { return tlsConfig }))
+
+			ocspCache := ocsp.NewCache()
+			connOpts = append(connOpts, WithOCSPCache(func(ocsp.Cache) ocsp.Cache { return ocspCache }))
+			if cs.SSLDisableOCSPEndpointCheckSet {
+				connOpts = append(connOpts, WithDisableOCSPEndpointCheck(func(bool) bool {
+					return cs.SSLDisableOCSPEndpointCheck
+				}))
+			}
+			if cs.SSLDisableRevocationCheckSet {
+				connOpts = append(connOpts, WithDisableCertificateRevocationCheck(func(bool) bool {
+					return cs.SSLDisableRevocationCheck
+				}))
+			}
 		}
 
 		if cs.Username != "" || cs.AuthMechanism == auth.MongoDBX509 || cs.AuthMechanism == auth.GSSAPI {
@@ -186,6 +231,7 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 					AppName:       cs.AppName,
 					Authenticator: authenticator,
 					Compressors:   cs.Compressors,
+					LoadBalanced:  cs.LoadBalanced,
 				}
 				if cs.AuthMechanism == "" {
 					// Required for SASL mechanism negotiation during handshake
@@ -196,7 +242,7 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 		} else {
 			// We need to add a non-auth Handshaker to the connection options
 			connOpts = append(connOpts, WithHandshaker(func(h driver.Handshaker) driver.Handshaker {
-				return operation.NewIsMaster().AppName(cs.AppName).Compressors(cs.Compressors)
+				return operation.NewIsMaster().AppName(cs.AppName).Compressors(cs.Compressors).LoadBalanced(cs.LoadBalanced)
 			}))
 		}
 
@@ -338,6 +384,31 @@ func addClientCertFromFile(cfg *tls.Config, clientFile, keyPasswd string) (strin
 		return "", err
 	}
 
+	return addClientCertFromBytes(cfg, data, keyPasswd)
+}
+
+// addClientCertFromSeparateFiles adds a client certificate to the configuration given paths to the
+// files containing the certificate and the private key and returns the certificate's subject name.
+func addClientCertFromSeparateFiles(cfg *tls.Config, certFile, keyFile, keyPasswd string) (string, error) {
+	certData, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return "", err
+	}
+	keyData, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return "", err
+	}
+
+	data := make([]byte, 0, len(certData)+len(keyData)+1)
+	data = append(data, certData...)
+	data = append(data, '\n')
+	data = append(data, keyData...)
+	return addClientCertFromBytes(cfg, data, keyPasswd)
+}
+
+// addClientCertFromBytes adds a client certificate to the configuration given the PEM encoded
+// certificate and private key and returns the certificate's subject name.
+func addClientCertFromBytes(cfg *tls.Config, data []byte, keyPasswd string) (string, error) {
 	var currentBlock *pem.Block
 	var certBlock, certDecodedBlock, keyBlock []byte
 
@@ -354,7 +425,18 @@ func addClientCertFromFile(cfg *tls.Config, clientFile, keyPasswd string) (strin
 			certDecodedBlock = currentBlock.Bytes
 			start += len(certBlock)
 		} else if strings.HasSuffix(currentBlock.Type, "PRIVATE KEY") {
-			if keyPasswd != "" && x509.IsEncryptedPEMBlock(currentBlock) {
+			if currentBlock.Type == "ENCRYPTED PRIVATE KEY" {
+				if keyPasswd == "" {
+					return "", errors.New("a password is required to decrypt an ENCRYPTED PRIVATE KEY")
+				}
+				buf, err := tlsutil.DecryptPKCS8PrivateKey(currentBlock.Bytes, []byte(keyPasswd))
+				if err != nil {
+					return "", err
+				}
+
+				keyBlock = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: buf})
+				start = len(data) - len(remaining)
+			} else if keyPasswd != "" && x509.IsEncryptedPEMBlock(currentBlock) {
 				var encoded bytes.Buffer
 				buf, err := x509.DecryptPEMBlock(currentBlock, []byte(keyPasswd))
 				if err != nil {
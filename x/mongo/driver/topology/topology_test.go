@@ -289,7 +289,7 @@ func TestServerSelection(t *testing.T) {
 		serv, err := topo.FindServer(desc.Servers[0])
 		noerr(t, err)
 		atomic.StoreInt32(&serv.connectionstate, connected)
-		serv.ProcessError(driver.Error{Message: "not master"})
+		serv.ProcessError(driver.Error{Message: "not master"}, nil)
 
 		resp := make(chan []description.Server)
 
@@ -514,3 +514,34 @@ func TestTopology_String_Race(t *testing.T) {
 	<-ch
 	<-ch
 }
+
+func TestLoadBalancedTopology(t *testing.T) {
+	connStr := connstring.ConnString{
+		Hosts:           []string{"localhost:27017"},
+		LoadBalanced:    true,
+		LoadBalancedSet: true,
+	}
+	topo, err := New(WithConnString(func(connstring.ConnString) connstring.ConnString { return connStr }))
+	noerr(t, err)
+	noerr(t, topo.Connect())
+	defer func() {
+		_ = topo.Disconnect(context.Background())
+	}()
+
+	desc := topo.Description()
+	assert.Equal(t, description.LoadBalanced, desc.Kind, "expected topology kind %v, got %v", description.LoadBalanced, desc.Kind)
+	assert.Equal(t, 1, len(desc.Servers), "expected 1 server, got %d", len(desc.Servers))
+	assert.Equal(t, description.LoadBalancer, desc.Servers[0].Kind,
+		"expected server kind %v, got %v", description.LoadBalancer, desc.Servers[0].Kind)
+	assert.True(t, topo.SupportsSessions(), "expected a load balanced topology to support sessions")
+
+	// The server is never checked, so it can be selected right away.
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	srvr, err := topo.SelectServer(ctx, description.WriteSelector())
+	noerr(t, err)
+	selected := srvr.(*SelectedServer)
+	assert.Equal(t, address.Address("localhost:27017"), selected.address,
+		"expected server localhost:27017, got %v", selected.address)
+	assert.Equal(t, description.LoadBalanced, selected.Kind, "expected topology kind %v, got %v", description.LoadBalanced, selected.Kind)
+}